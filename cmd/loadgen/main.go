@@ -0,0 +1,175 @@
+// loadgen replays synthetic Telegram updates against a running BotForge
+// webhook endpoint to measure throughput of the Manager.ServeHTTP path.
+//
+// Example, hammering two bots with 500 simulated users for 30 seconds:
+//
+//	go run ./cmd/loadgen -url http://localhost:8080 \
+//	    -tokens 111:AAA,222:BBB -users 500 -concurrency 16 -duration 30s
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// update mirrors the fields of a Telegram update the webhook path reads
+type update struct {
+	ID      int      `json:"update_id"`
+	Message *message `json:"message"`
+}
+
+type message struct {
+	ID       int    `json:"message_id"`
+	Sender   *user  `json:"from"`
+	Chat     *chat  `json:"chat"`
+	Text     string `json:"text"`
+	Unixtime int64  `json:"date"`
+}
+
+type user struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username"`
+}
+
+type chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// counters aggregates results across workers
+type counters struct {
+	sent      int64
+	failed    int64
+	non200    int64
+	latencyNs int64
+	maxNs     int64
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080", "Base URL of the BotForge server")
+	tokenList := flag.String("tokens", "", "Comma-separated bot tokens to target (required)")
+	users := flag.Int("users", 100, "Number of simulated users")
+	concurrency := flag.Int("concurrency", 8, "Number of concurrent workers")
+	duration := flag.Duration("duration", 10*time.Second, "How long to run")
+	flag.Parse()
+
+	tokens := splitTokens(*tokenList)
+	if len(tokens) == 0 {
+		log.Fatal("At least one bot token is required (-tokens)")
+	}
+
+	// Pre-marshal one update body per simulated user
+	bodies := make([][]byte, *users)
+	for i := range bodies {
+		body, err := json.Marshal(syntheticUpdate(i))
+		if err != nil {
+			log.Fatalf("Failed to marshal update: %v", err)
+		}
+		bodies[i] = body
+	}
+
+	log.Printf("Replaying updates: %d bot(s), %d user(s), %d worker(s), %s",
+		len(tokens), *users, *concurrency, *duration)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	deadline := time.Now().Add(*duration)
+
+	var stats counters
+	var wg sync.WaitGroup
+	for w := 0; w < *concurrency; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			runWorker(client, *baseURL, tokens, bodies, deadline, &stats, seed)
+		}(int64(w))
+	}
+	wg.Wait()
+
+	report(&stats, *duration)
+}
+
+// runWorker posts updates round-robin across bots until the deadline
+func runWorker(client *http.Client, baseURL string, tokens []string, bodies [][]byte, deadline time.Time, stats *counters, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+
+	for time.Now().Before(deadline) {
+		token := tokens[rng.Intn(len(tokens))]
+		body := bodies[rng.Intn(len(bodies))]
+
+		start := time.Now()
+		resp, err := client.Post(baseURL+"/webhook/"+token, "application/json", bytes.NewReader(body))
+		elapsed := time.Since(start).Nanoseconds()
+
+		atomic.AddInt64(&stats.sent, 1)
+		atomic.AddInt64(&stats.latencyNs, elapsed)
+		for {
+			max := atomic.LoadInt64(&stats.maxNs)
+			if elapsed <= max || atomic.CompareAndSwapInt64(&stats.maxNs, max, elapsed) {
+				break
+			}
+		}
+
+		if err != nil {
+			atomic.AddInt64(&stats.failed, 1)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			atomic.AddInt64(&stats.non200, 1)
+		}
+		resp.Body.Close()
+	}
+}
+
+// syntheticUpdate builds a plausible private-chat text message from user i
+func syntheticUpdate(i int) update {
+	userID := int64(1_000_000 + i)
+	return update{
+		ID: i + 1,
+		Message: &message{
+			ID:       i + 1,
+			Sender:   &user{ID: userID, FirstName: "Load", Username: fmt.Sprintf("loaduser%d", i)},
+			Chat:     &chat{ID: userID, Type: "private"},
+			Text:     fmt.Sprintf("load test message %d", i),
+			Unixtime: time.Now().Unix(),
+		},
+	}
+}
+
+// splitTokens parses the comma-separated -tokens flag
+func splitTokens(s string) []string {
+	var tokens []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// report prints the final throughput and latency summary
+func report(stats *counters, duration time.Duration) {
+	sent := atomic.LoadInt64(&stats.sent)
+	if sent == 0 {
+		log.Println("No requests sent")
+		return
+	}
+
+	avg := time.Duration(atomic.LoadInt64(&stats.latencyNs) / sent)
+	fmt.Println("\n==================== Load Test Results ====================")
+	fmt.Printf("Requests sent:     %d\n", sent)
+	fmt.Printf("Throughput:        %.1f req/s\n", float64(sent)/duration.Seconds())
+	fmt.Printf("Transport errors:  %d\n", atomic.LoadInt64(&stats.failed))
+	fmt.Printf("Non-200 responses: %d\n", atomic.LoadInt64(&stats.non200))
+	fmt.Printf("Avg latency:       %s\n", avg)
+	fmt.Printf("Max latency:       %s\n", time.Duration(atomic.LoadInt64(&stats.maxNs)))
+}
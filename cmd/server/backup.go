@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Amr-9/botforge/internal/database"
+)
+
+// ==================== Backup / Restore Commands ====================
+
+// runBackup serializes one bot's rows across all tables to a JSON file.
+// The file contains the plaintext bot token, so it is written with 0600
+// permissions and must be handled as a secret.
+func runBackup(args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	botID := fs.Int64("bot-id", 0, "ID of the bot to back up")
+	out := fs.String("out", "", "Output file path")
+	fs.Parse(args)
+
+	if *botID == 0 {
+		log.Fatal("-bot-id is required")
+	}
+	if *out == "" {
+		log.Fatal("-out is required")
+	}
+
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	backup, err := repo.BackupBot(context.Background(), *botID)
+	if err != nil {
+		log.Fatalf("Backup failed: %v", err)
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode backup: %v", err)
+	}
+	if err := os.WriteFile(*out, data, 0600); err != nil {
+		log.Fatalf("Failed to write backup file: %v", err)
+	}
+
+	log.Printf("Backed up bot %d (@%s) to %s", backup.Bot.ID, backup.Bot.Username, *out)
+}
+
+// runRestore loads a backup file and inserts it as a new bot, with the
+// token re-encrypted under the current BOT_ENCRYPTION_KEY. The restored
+// bot is picked up on the next serve start.
+func runRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := fs.String("in", "", "Backup file path")
+	fs.Parse(args)
+
+	if *in == "" {
+		log.Fatal("-in is required")
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatalf("Failed to read backup file: %v", err)
+	}
+
+	var backup database.BotBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		log.Fatalf("Failed to parse backup file: %v", err)
+	}
+
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	newBotID, err := repo.RestoreBotBackup(context.Background(), &backup)
+	if err != nil {
+		log.Fatalf("Restore failed (no rows were written): %v", err)
+	}
+
+	log.Printf("Restored bot @%s as ID %d. It will start with the next serve.", backup.Bot.Username, newBotID)
+}
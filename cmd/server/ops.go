@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/config"
+	"github.com/Amr-9/botforge/internal/database"
+)
+
+// ==================== Operational Commands ====================
+//
+// These subcommands cover the routine maintenance tasks that used to
+// require ad-hoc SQL. They share the server's .env configuration.
+
+// openDatabase connects to the configured backend: MySQL normally, the
+// embedded in-memory SQLite database in dev mode without a DB_HOST
+func openDatabase(cfg *config.Config) (*database.MySQL, error) {
+	if cfg.UseSQLite() {
+		return database.NewSQLite()
+	}
+	return database.NewMySQLWithPrefix(cfg.GetDSN(), cfg.DBTablePrefix)
+}
+
+// openRepo loads the configuration and connects to the database.
+// The caller must Close the returned MySQL handle.
+func openRepo() (*config.Config, *database.MySQL, *database.Repository) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	mysql, err := openDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	return cfg, mysql, database.NewRepository(mysql, cfg.EncryptionKey)
+}
+
+// runMigrate applies schema migrations and exits. Migrations also run on
+// every serve start; this command exists for deploy pipelines that want
+// to migrate before rolling out.
+func runMigrate() {
+	_, mysql, _ := openRepo() // NewMySQL runs migrations on connect
+	defer mysql.Close()
+
+	log.Println("Migrations applied successfully")
+}
+
+// runRekey re-encrypts all stored bot tokens with a new encryption key.
+// After it succeeds, update BOT_ENCRYPTION_KEY before the next serve.
+func runRekey(args []string) {
+	fs := flag.NewFlagSet("rekey", flag.ExitOnError)
+	newKey := fs.String("new-key", "", "New 32-byte encryption key")
+	fs.Parse(args)
+
+	if len(*newKey) != 32 {
+		log.Fatalf("-new-key must be exactly 32 bytes, got %d", len(*newKey))
+	}
+
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	rekeyed, err := repo.RekeyBotTokens(context.Background(), *newKey)
+	if err != nil {
+		log.Fatalf("Rekey failed (no tokens were changed): %v", err)
+	}
+
+	log.Printf("Re-encrypted %d bot tokens. Update BOT_ENCRYPTION_KEY to the new key before restarting.", rekeyed)
+}
+
+// botExport is the JSON shape written by the export command
+type botExport struct {
+	ID          int64     `json:"id"`
+	Username    string    `json:"username"`
+	Token       string    `json:"token"` // masked
+	OwnerChatID int64     `json:"owner_chat_id"`
+	IsActive    bool      `json:"is_active"`
+	Users       int64     `json:"users"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// runExport dumps all registered bots as JSON to stdout
+func runExport() {
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	ctx := context.Background()
+	bots, err := repo.GetAllBots(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load bots: %v", err)
+	}
+
+	exports := make([]botExport, 0, len(bots))
+	for _, b := range bots {
+		users, err := repo.GetUniqueUserCount(ctx, b.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to count users for bot %d: %v", b.ID, err)
+		}
+		exports = append(exports, botExport{
+			ID:          b.ID,
+			Username:    b.Username,
+			Token:       maskToken(b.Token),
+			OwnerChatID: b.OwnerChatID,
+			IsActive:    b.IsActive,
+			Users:       users,
+			CreatedAt:   b.CreatedAt,
+		})
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(exports); err != nil {
+		log.Fatalf("Failed to encode export: %v", err)
+	}
+}
+
+// runPurge permanently removes bots that were soft-deleted long enough ago
+func runPurge(args []string) {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", 30, "Purge bots soft-deleted more than this many days ago")
+	fs.Parse(args)
+
+	if *days < 1 {
+		log.Fatal("-days must be at least 1")
+	}
+
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	purged, err := repo.PurgeDeletedBots(context.Background(), *days)
+	if err != nil {
+		log.Fatalf("Purge failed: %v", err)
+	}
+
+	log.Printf("Permanently removed %d bots soft-deleted more than %d days ago", purged, *days)
+}
+
+// runStats prints global usage statistics
+func runStats() {
+	_, mysql, repo := openRepo()
+	defer mysql.Close()
+
+	ctx := context.Background()
+
+	bots, err := repo.GetAllBots(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load bots: %v", err)
+	}
+	active := 0
+	for _, b := range bots {
+		if b.IsActive {
+			active++
+		}
+	}
+
+	deleted, _ := repo.GetDeletedBotsCount(ctx)
+	owners, _ := repo.GetUniqueOwnerCount(ctx)
+	users, _ := repo.GetGlobalUniqueUserCount(ctx)
+	messages, _ := repo.GetGlobalTotalMessageCount(ctx)
+	activeToday, _ := repo.GetGlobalActiveUserCount(ctx, time.Now().AddDate(0, 0, -1))
+	banned, _ := repo.GetGlobalBannedUserCount(ctx)
+
+	fmt.Println("==================== BotForge Stats ====================")
+	fmt.Printf("Bots:               %d total, %d active, %d deleted\n", len(bots), active, deleted)
+	fmt.Printf("Owners:             %d\n", owners)
+	fmt.Printf("Users:              %d (%d active in last 24h)\n", users, activeToday)
+	fmt.Printf("Messages:           %d\n", messages)
+	fmt.Printf("Banned users:       %d\n", banned)
+}
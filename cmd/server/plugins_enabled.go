@@ -0,0 +1,11 @@
+package main
+
+// Plugins are enabled at compile time by blank-importing their packages
+// here. Each plugin package calls plugin.Register from its init
+// function; nothing else is needed.
+//
+// Example:
+//
+//	import _ "github.com/Amr-9/botforge/plugins/weather"
+//
+// No plugins are enabled in the default build.
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/api"
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/config"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/events"
+	"github.com/Amr-9/botforge/internal/factory"
+	"github.com/Amr-9/botforge/internal/features"
+	"github.com/Amr-9/botforge/internal/metrics"
+	"github.com/Amr-9/botforge/internal/plugin"
+	"github.com/Amr-9/botforge/internal/recovery"
+	"github.com/Amr-9/botforge/internal/rpc"
+	"github.com/Amr-9/botforge/internal/scheduler"
+	"github.com/Amr-9/botforge/internal/spam"
+	"github.com/Amr-9/botforge/internal/tgapi"
+	"gopkg.in/telebot.v3"
+)
+
+// runServe boots the full webhook server: factory bot, child bots,
+// scheduler and HTTP listener. This is the default subcommand.
+func runServe() {
+	log.Println("Starting Bot Factory (Webhook Mode)...")
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	log.Println(cfg.Summary())
+
+	// Chaos mode: wrap the shared transport so every outgoing Telegram
+	// API call (telebot and tgapi alike) goes through fault injection
+	if cfg.ChaosEnabled() {
+		log.Printf("CHAOS MODE ENABLED: injecting Telegram API faults (429=%g 5xx=%g timeout=%g) — never run this in production",
+			cfg.ChaosRate429, cfg.ChaosRate5xx, cfg.ChaosRateTimeout)
+		http.DefaultTransport = tgapi.NewChaosTransport(http.DefaultTransport, cfg.ChaosRate429, cfg.ChaosRate5xx, cfg.ChaosRateTimeout)
+	}
+
+	// Connect to the database (in dev mode without a DB_HOST this is the
+	// embedded in-memory SQLite backend, so no MySQL instance is needed)
+	mysql, err := openDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer mysql.Close()
+
+	// Create repository
+	repo := database.NewRepository(mysql, cfg.EncryptionKey)
+
+	// In dev mode run an embedded in-memory cache so no Redis instance
+	// is needed locally. State is lost on restart, which is fine for dev.
+	var redisCache cache.Cache
+	if cfg.DevMode {
+		redisCache, err = cache.NewMemory(cfg.MessageTTL)
+		if err != nil {
+			log.Fatalf("Failed to start embedded cache: %v", err)
+		}
+		log.Println("Dev mode: using embedded in-memory cache")
+	} else {
+		redisCache, err = cache.NewRedis(
+			cfg.RedisAddr,
+			cfg.RedisPassword,
+			cfg.RedisDB,
+			cfg.MessageTTL,
+		)
+		if err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+	}
+	defer redisCache.Close()
+
+	// Create bot manager with Webhook support
+	manager := bot.NewManager(repo, redisCache, cfg.WebhookURL)
+
+	// Compile-time plugins: run their migrations now, then have the
+	// manager attach their handlers to every child bot it starts
+	for _, p := range plugin.All() {
+		if mig, ok := p.(plugin.Migrator); ok {
+			if err := mysql.RunPluginMigrations(p.Name(), mig.Migrations()); err != nil {
+				log.Fatalf("Failed to migrate plugin: %v", err)
+			}
+		}
+		log.Printf("Plugin enabled: %s", p.Name())
+	}
+	manager.SetPlugins(repo, plugin.All())
+
+	// Event bus: NATS when configured, otherwise in-process. Metrics
+	// consume every event; further consumers subscribe the same way.
+	var bus events.Bus
+	if cfg.NATSURL != "" {
+		bus, err = events.NewNATSBus(cfg.NATSURL)
+		if err != nil {
+			log.Fatalf("Failed to connect event bus: %v", err)
+		}
+		log.Printf("Event bus: NATS at %s", cfg.NATSURL)
+	} else {
+		bus = events.NewInProcBus()
+	}
+	defer bus.Close()
+	bus.Subscribe("", func(e events.Event) { metrics.CountEvent(e.Name) })
+	manager.SetEventBus(bus)
+
+	// Spam scoring: external model when configured, embedded heuristic
+	// otherwise. Bots only use it once their owner sets a threshold.
+	if cfg.SpamClassifierURL != "" {
+		manager.SetSpamClassifier(spam.NewHTTPClassifier(cfg.SpamClassifierURL))
+		log.Printf("Spam classifier: HTTP endpoint at %s", cfg.SpamClassifierURL)
+	} else {
+		manager.SetSpamClassifier(spam.NewHeuristicClassifier())
+	}
+
+	// Create scheduler service
+	schedulerService := scheduler.NewScheduler(repo, manager, 1*time.Minute)
+
+	// Load optional white-label branding overrides for the factory bots
+	brandings := map[string]factory.Branding{}
+	if cfg.BrandingFile != "" {
+		brandings, err = factory.LoadBrandingFile(cfg.BrandingFile)
+		if err != nil {
+			log.Fatalf("Failed to load branding file: %v", err)
+		}
+		log.Printf("Loaded branding overrides for %d factory bot(s)", len(brandings))
+	}
+
+	// Create all configured Factory Bots (primary plus any extra brands),
+	// each with its own admin, all sharing the same backend
+	factoryAccounts := cfg.Factories()
+	factories := make([]*factory.Factory, 0, len(factoryAccounts))
+	for _, account := range factoryAccounts {
+		factorySettings := telebot.Settings{
+			Token:  account.Token,
+			Poller: &bot.ManualPoller{}, // Use ManualPoller to avoid port binding
+		}
+
+		factoryBot, err := telebot.NewBot(factorySettings)
+		if err != nil {
+			log.Fatalf("Failed to create factory bot %s: %v", maskToken(account.Token), err)
+		}
+
+		// Set Factory Webhook
+		factoryPublicURL := fmt.Sprintf("%s/webhook/%s", cfg.WebhookURL, account.Token)
+		webhook := &telebot.Webhook{
+			Endpoint: &telebot.WebhookEndpoint{PublicURL: factoryPublicURL},
+		}
+		if err := factoryBot.SetWebhook(webhook); err != nil {
+			log.Fatalf("Failed to set factory webhook for %s: %v", maskToken(account.Token), err)
+		}
+
+		// Create Factory Logic
+		branding := factory.BrandingFor(brandings, account.Token)
+		f, err := factory.NewFactoryWithBranding(factoryBot, repo, redisCache, manager, account.AdminID, branding)
+		if err != nil {
+			log.Fatalf("Failed to create factory logic for %s: %v", maskToken(account.Token), err)
+		}
+		factories = append(factories, f)
+	}
+
+	// Create shared panic recovery handler
+	panicHandler := recovery.DefaultHandler
+
+	// HTTP Server Routing with panic recovery middleware
+	http.Handle("/webhook/", recovery.HTTPMiddleware(manager, panicHandler))
+
+	// Management REST API (see api/openapi.yaml), authenticated by API
+	// keys. The whole surface sits behind a feature flag so it can be
+	// switched off at runtime without a restart.
+	flags := features.New(redisCache)
+	apiServer := api.NewServer(repo, manager)
+	http.Handle("/api/v1/", recovery.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !flags.EnabledGlobally(r.Context(), features.WebDashboard) {
+			http.Error(w, "API disabled", http.StatusServiceUnavailable)
+			return
+		}
+		apiServer.ServeHTTP(w, r)
+	}), panicHandler))
+
+	// Click-counting redirects for tracked broadcast link buttons
+	http.Handle("/c/", recovery.HTTPMiddleware(http.HandlerFunc(manager.HandleLinkClick), panicHandler))
+
+	// Owner-created short links
+	http.Handle("/l/", recovery.HTTPMiddleware(http.HandlerFunc(manager.HandleShortLink), panicHandler))
+
+	// Internal shard gRPC API so sibling instances can reach bots owned here
+	var shardServer *rpc.Server
+	if cfg.ShardRPCAddr != "" {
+		shardServer = rpc.NewServer(repo, manager)
+		if err := shardServer.Start(cfg.ShardRPCAddr); err != nil {
+			log.Fatalf("Failed to start shard API on %s: %v", cfg.ShardRPCAddr, err)
+		}
+	}
+
+	// Handler latency metrics in Prometheus text format
+	http.Handle("/metrics", metrics.Handler())
+
+	// Start HTTP Server
+	server := &http.Server{
+		Addr:    ":" + cfg.ServerPort,
+		Handler: nil, // DefaultServeMux
+	}
+
+	// Start HTTP server with panic recovery
+	// Use SafeGoWithRestartAndReset - only restart on panic, not on normal return
+	recovery.SafeGoWithRestartAndReset(
+		func() {
+			log.Printf("Server listening on port %s...", cfg.ServerPort)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				// Panic on critical HTTP error to trigger restart
+				panic(fmt.Errorf("HTTP server critical error: %v", err))
+			}
+		},
+		map[string]string{"type": "http_server"},
+		panicHandler,
+		recovery.NewRestartPolicy(5, 1*time.Second, 30*time.Second),
+		30*time.Second, // Reset retry counter if server runs for 30s successfully
+		func() {
+			log.Fatalf("[CRITICAL] HTTP server exhausted restart retries")
+		},
+	)
+
+	// Load and start all active bots (Set Webhook for them)
+	ctx := context.Background()
+	activeBots, err := repo.GetActiveBots(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load active bots: %v", err)
+	} else {
+		log.Printf("Loading %d active bots...", len(activeBots))
+		for _, b := range activeBots {
+			if err := manager.StartBot(b.Token, b.OwnerChatID, b.ID); err != nil {
+				log.Printf("Failed to start bot %s: %v", maskToken(b.Token), err)
+			}
+		}
+		log.Printf("Started %d child bots successfully", manager.GetRunningCount())
+	}
+
+	// Tell owners about broadcasts the previous process left unfinished
+	manager.NotifyInterruptedBroadcasts()
+
+	// Register Factory Bots into Manager so ServeHTTP routes their webhook updates
+	for i, f := range factories {
+		manager.RegisterExistingBot(factoryAccounts[i].Token, f.GetBot())
+	}
+
+	// Route abuse report alerts to the admin through the primary factory bot
+	primaryFactory := factories[0]
+	primaryFactory.StartAbandonmentPolicy()
+	manager.SetAdminNotifier(func(text string) {
+		if _, err := primaryFactory.GetBot().Send(&telebot.Chat{ID: cfg.AdminID}, text, telebot.ModeHTML); err != nil {
+			log.Printf("Failed to notify admin: %v", err)
+		}
+	})
+
+	// Start scheduler service
+	schedulerService.Start()
+	log.Println("Scheduler service started")
+
+	// Start warm/cold tiering so inactive bots release their resources
+	manager.StartTiering()
+
+	// Poll webhook health for bots that stop receiving updates
+	manager.StartHealthChecks()
+
+	// Enforce per-bot retention on stored message content
+	manager.StartContentRetention()
+
+	// Handle graceful shutdown
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	log.Println("Shutting down gracefully...")
+
+	// Stop scheduler service
+	schedulerService.Stop()
+	manager.StopTiering()
+	manager.StopHealthChecks()
+	manager.StopContentRetention()
+	if shardServer != nil {
+		shardServer.Stop()
+	}
+
+	// Shutdown HTTP server
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(ctx)
+
+	// Remove Webhooks
+	manager.StopAll()
+	for _, f := range factories {
+		f.Stop() // This currently stops the bot instance
+	}
+
+	log.Println("Shutdown complete")
+}
@@ -0,0 +1,299 @@
+// Package api serves the REST management API described by api/openapi.yaml.
+// Requests authenticate with a Bearer API key created from the factory bot;
+// every key is scoped to one bot and a set of permissions.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// Server handles /api/v1/ requests
+type Server struct {
+	repo    *database.Repository
+	manager *bot.Manager
+}
+
+// NewServer creates the management API server
+func NewServer(repo *database.Repository, manager *bot.Manager) *Server {
+	return &Server{
+		repo:    repo,
+		manager: manager,
+	}
+}
+
+// botStats mirrors the BotStats schema in the OpenAPI spec
+type botStats struct {
+	BotID          int64 `json:"bot_id"`
+	Running        bool  `json:"running"`
+	ReachableUsers int64 `json:"reachable_users"`
+	BlockedUsers   int64 `json:"blocked_users"`
+}
+
+// sendMessageRequest mirrors the SendMessageRequest schema
+type sendMessageRequest struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// autoReplyView mirrors the AutoReply schema
+type autoReplyView struct {
+	ID          int64  `json:"id"`
+	Trigger     string `json:"trigger"`
+	Response    string `json:"response"`
+	TriggerType string `json:"trigger_type"`
+	MatchType   string `json:"match_type,omitempty"`
+}
+
+// createAutoReplyRequest mirrors the CreateAutoReplyRequest schema
+type createAutoReplyRequest struct {
+	Trigger     string `json:"trigger"`
+	Response    string `json:"response"`
+	TriggerType string `json:"trigger_type"`
+	MatchType   string `json:"match_type"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode API response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// ServeHTTP routes /api/v1/bots/{botID}/... requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	key, ok := s.authenticate(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	// Expected: bots/{botID}/{resource}[/{id}]
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/"), "/")
+	if len(parts) < 3 || parts[0] != "bots" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	botID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid bot ID")
+		return
+	}
+
+	// Keys are scoped to exactly one bot
+	if key.BotID != botID {
+		writeError(w, http.StatusForbidden, "key is not scoped to this bot")
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[2] == "stats" && r.Method == http.MethodGet:
+		s.handleStats(ctx, w, key, botID)
+	case len(parts) == 3 && parts[2] == "messages" && r.Method == http.MethodPost:
+		s.handleSendMessage(ctx, w, r, key, botID)
+	case len(parts) == 3 && parts[2] == "replies" && r.Method == http.MethodGet:
+		s.handleListReplies(ctx, w, r, key, botID)
+	case len(parts) == 3 && parts[2] == "replies" && r.Method == http.MethodPost:
+		s.handleCreateReply(ctx, w, r, key, botID)
+	case len(parts) == 4 && parts[2] == "replies" && r.Method == http.MethodDelete:
+		s.handleDeleteReply(ctx, w, key, botID, parts[3])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// authenticate resolves the Bearer key; a failed lookup ends the request
+func (s *Server) authenticate(ctx context.Context, w http.ResponseWriter, r *http.Request) (*models.APIKey, bool) {
+	auth := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok || token == "" {
+		writeError(w, http.StatusUnauthorized, "missing API key")
+		return nil, false
+	}
+
+	key, err := s.repo.LookupAPIKey(ctx, token)
+	if err != nil {
+		log.Printf("API key lookup failed: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+	if key == nil {
+		writeError(w, http.StatusUnauthorized, "unknown API key")
+		return nil, false
+	}
+	return key, true
+}
+
+// requirePermission ends the request if the key lacks the permission
+func requirePermission(w http.ResponseWriter, key *models.APIKey, perm string) bool {
+	if !key.HasPermission(perm) {
+		writeError(w, http.StatusForbidden, "key lacks the "+perm+" permission")
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleStats(ctx context.Context, w http.ResponseWriter, key *models.APIKey, botID int64) {
+	if !requirePermission(w, key, models.APIPermReadStats) {
+		return
+	}
+
+	reachable, err := s.repo.GetReachableUserCount(ctx, botID)
+	if err != nil {
+		log.Printf("Failed to get reachable user count for bot %d: %v", botID, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	blocked, err := s.repo.GetBlockedUserCount(ctx, botID)
+	if err != nil {
+		log.Printf("Failed to get blocked user count for bot %d: %v", botID, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	running := false
+	if _, _, err := s.manager.GetBotByID(botID); err == nil {
+		running = true
+	}
+
+	writeJSON(w, http.StatusOK, botStats{
+		BotID:          botID,
+		Running:        running,
+		ReachableUsers: reachable,
+		BlockedUsers:   blocked,
+	})
+}
+
+func (s *Server) handleSendMessage(ctx context.Context, w http.ResponseWriter, r *http.Request, key *models.APIKey, botID int64) {
+	if !requirePermission(w, key, models.APIPermSendMessages) {
+		return
+	}
+
+	var req sendMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChatID == 0 || req.Text == "" {
+		writeError(w, http.StatusBadRequest, "chat_id and text are required")
+		return
+	}
+
+	childBot, _, err := s.manager.GetBotByID(botID)
+	if err != nil {
+		writeError(w, http.StatusConflict, "bot is not running")
+		return
+	}
+
+	if _, err := childBot.Send(&telebot.Chat{ID: req.ChatID}, req.Text); err != nil {
+		if recordErr := s.repo.RecordDelivery(ctx, botID, req.ChatID, models.DeliverySourceAPI, models.DeliveryStatusError, ""); recordErr != nil {
+			log.Printf("Failed to record delivery: %v", recordErr)
+		}
+		log.Printf("API send to %d via bot %d failed: %v", req.ChatID, botID, err)
+		writeError(w, http.StatusBadGateway, "failed to deliver message")
+		return
+	}
+
+	if recordErr := s.repo.RecordDelivery(ctx, botID, req.ChatID, models.DeliverySourceAPI, models.DeliveryStatusDelivered, ""); recordErr != nil {
+		log.Printf("Failed to record delivery: %v", recordErr)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]bool{"delivered": true})
+}
+
+func (s *Server) handleListReplies(ctx context.Context, w http.ResponseWriter, r *http.Request, key *models.APIKey, botID int64) {
+	if !requirePermission(w, key, models.APIPermManageReplies) {
+		return
+	}
+
+	triggerType := r.URL.Query().Get("type")
+	if triggerType == "" {
+		triggerType = "keyword"
+	}
+	if triggerType != "keyword" && triggerType != "command" {
+		writeError(w, http.StatusBadRequest, "type must be keyword or command")
+		return
+	}
+
+	replies, err := s.repo.GetAutoReplies(ctx, botID, triggerType)
+	if err != nil {
+		log.Printf("Failed to list auto-replies for bot %d: %v", botID, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	views := make([]autoReplyView, 0, len(replies))
+	for _, reply := range replies {
+		views = append(views, autoReplyView{
+			ID:          reply.ID,
+			Trigger:     reply.TriggerWord,
+			Response:    reply.Response,
+			TriggerType: reply.TriggerType,
+			MatchType:   reply.MatchType,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (s *Server) handleCreateReply(ctx context.Context, w http.ResponseWriter, r *http.Request, key *models.APIKey, botID int64) {
+	if !requirePermission(w, key, models.APIPermManageReplies) {
+		return
+	}
+
+	var req createAutoReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Trigger == "" || req.Response == "" {
+		writeError(w, http.StatusBadRequest, "trigger and response are required")
+		return
+	}
+	if req.TriggerType == "" {
+		req.TriggerType = "keyword"
+	}
+	if req.MatchType == "" {
+		req.MatchType = "exact"
+	}
+
+	// Only plain text replies can be created over the API; media replies
+	// need a Telegram file ID and are managed from the child bot.
+	err := s.repo.CreateAutoReply(ctx, botID, req.Trigger, req.Response, models.MessageTypeText, "", "", req.TriggerType, req.MatchType)
+	if err != nil {
+		log.Printf("Failed to create auto-reply for bot %d: %v", botID, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleDeleteReply(ctx context.Context, w http.ResponseWriter, key *models.APIKey, botID int64, replyRef string) {
+	if !requirePermission(w, key, models.APIPermManageReplies) {
+		return
+	}
+
+	replyID, err := strconv.ParseInt(replyRef, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid reply ID")
+		return
+	}
+
+	if err := s.repo.DeleteAutoReply(ctx, botID, replyID, key.OwnerChatID); err != nil {
+		log.Printf("Failed to delete auto-reply %d for bot %d: %v", replyID, botID, err)
+		writeError(w, http.StatusNotFound, "reply not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
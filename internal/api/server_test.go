@@ -0,0 +1,154 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/api"
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/pkg/client"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+// ==================== Management API Tests ====================
+
+// newTestServer wires the API server to a mocked repository
+func newTestServer(t *testing.T) (*httptest.Server, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+	manager := bot.NewManager(repo, nil, "")
+
+	ts := httptest.NewServer(api.NewServer(repo, manager))
+	t.Cleanup(ts.Close)
+
+	return ts, mock
+}
+
+// expectKeyLookup mocks a successful API key resolution with the given
+// bot scope and permissions, including the last-used stamp.
+func expectKeyLookup(mock sqlmock.Sqlmock, botID int64, permissions string) {
+	rows := sqlmock.NewRows([]string{"id", "owner_chat_id", "bot_id", "label", "permissions", "last_used_at", "created_at"}).
+		AddRow(int64(1), int64(999), botID, "Test key", permissions, nil, time.Now())
+	mock.ExpectQuery("SELECT id, owner_chat_id, bot_id, label, permissions, last_used_at, created_at").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(rows)
+	mock.ExpectExec("UPDATE api_keys SET last_used_at").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestAPI_MissingKeyIsUnauthorized(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/api/v1/bots/1/stats")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAPI_KeyScopedToOtherBotIsForbidden(t *testing.T) {
+	ts, mock := newTestServer(t)
+	expectKeyLookup(mock, 2, "read_stats")
+
+	c := client.New(ts.URL, "bfk_test")
+	_, err := c.GetBotStats(context.Background(), 1)
+
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestAPI_MissingPermissionIsForbidden(t *testing.T) {
+	ts, mock := newTestServer(t)
+	expectKeyLookup(mock, 1, "read_stats")
+
+	c := client.New(ts.URL, "bfk_test")
+	err := c.SendMessage(context.Background(), 1, 555, "hello")
+
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestAPI_GetBotStats(t *testing.T) {
+	ts, mock := newTestServer(t)
+	expectKeyLookup(mock, 1, "read_stats")
+
+	mock.ExpectQuery("SELECT COUNT").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(42)))
+	mock.ExpectQuery("SELECT COUNT").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	c := client.New(ts.URL, "bfk_test")
+	stats, err := c.GetBotStats(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBotStats failed: %v", err)
+	}
+
+	if stats.BotID != 1 {
+		t.Errorf("Expected bot ID 1, got %d", stats.BotID)
+	}
+	if stats.ReachableUsers != 42 {
+		t.Errorf("Expected 42 reachable users, got %d", stats.ReachableUsers)
+	}
+	if stats.BlockedUsers != 3 {
+		t.Errorf("Expected 3 blocked users, got %d", stats.BlockedUsers)
+	}
+	if stats.Running {
+		t.Error("Expected bot to be reported as not running")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestAPI_ListAutoReplies(t *testing.T) {
+	ts, mock := newTestServer(t)
+	expectKeyLookup(mock, 1, "read_stats,manage_replies")
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "trigger_word", "response", "trigger_type", "match_type"}).
+		AddRow(int64(7), int64(1), "hello", "Hi there!", "keyword", "contains")
+	mock.ExpectQuery("SELECT (.+) FROM auto_replies").
+		WillReturnRows(rows)
+
+	c := client.New(ts.URL, "bfk_test")
+	replies, err := c.ListAutoReplies(context.Background(), 1, "keyword")
+	if err != nil {
+		t.Fatalf("ListAutoReplies failed: %v", err)
+	}
+
+	if len(replies) != 1 {
+		t.Fatalf("Expected 1 reply, got %d", len(replies))
+	}
+	if replies[0].Trigger != "hello" {
+		t.Errorf("Expected trigger 'hello', got %q", replies[0].Trigger)
+	}
+}
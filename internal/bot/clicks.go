@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Broadcast Link Click Tracking ====================
+
+// Broadcasts whose message carries URL buttons get those buttons
+// rewritten through /c/ on this server, so each click is counted per
+// button before the browser is redirected to the real target. The
+// broadcast report can then show click-through rates.
+
+// HandleLinkClick serves /c/<broadcastID>/<button>: counts the click and
+// 302s to the stored target. Unknown or expired links get a 404 - the
+// endpoint never redirects anywhere it did not record itself.
+func (m *Manager) HandleLinkClick(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/c/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	button, err := strconv.Atoi(parts[1])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	target, err := m.cache.GetBroadcastLink(ctx, parts[0], button)
+	if err != nil || target == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := m.cache.IncrementBroadcastClick(ctx, parts[0], button); err != nil {
+		log.Printf("Failed to count link click: %v", err)
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// trackedBroadcastMarkup rewrites the URL buttons of a broadcast message
+// through the click redirect endpoint. Returns nil when the message has
+// no URL buttons, no public URL is configured to serve the redirects, or
+// the targets could not be stored - the broadcast then goes out untracked.
+func (m *Manager) trackedBroadcastMarkup(ctx context.Context, msg *telebot.Message) (*telebot.ReplyMarkup, string) {
+	if msg == nil || msg.ReplyMarkup == nil || m.webhookURL == "" {
+		return nil, ""
+	}
+
+	broadcastID := newBroadcastID()
+	if broadcastID == "" {
+		return nil, ""
+	}
+
+	var urls []string
+	rows := make([][]telebot.InlineButton, len(msg.ReplyMarkup.InlineKeyboard))
+	for i, row := range msg.ReplyMarkup.InlineKeyboard {
+		rows[i] = make([]telebot.InlineButton, len(row))
+		copy(rows[i], row)
+		for j := range rows[i] {
+			if rows[i][j].URL == "" {
+				continue
+			}
+			urls = append(urls, rows[i][j].URL)
+			rows[i][j].URL = fmt.Sprintf("%s/c/%s/%d", m.webhookURL, broadcastID, len(urls)-1)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, ""
+	}
+
+	if err := m.cache.SetBroadcastLinks(ctx, broadcastID, urls); err != nil {
+		log.Printf("Failed to store broadcast links: %v", err)
+		return nil, ""
+	}
+
+	markup := *msg.ReplyMarkup
+	markup.InlineKeyboard = rows
+	return &markup, broadcastID
+}
+
+// newBroadcastID returns a random identifier for one tracked broadcast,
+// or "" if the system randomness source fails
+func newBroadcastID() string {
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		log.Printf("Failed to generate broadcast ID: %v", err)
+		return ""
+	}
+	return hex.EncodeToString(raw)
+}
+
+// handleBroadcastClicks shows click counts and the click-through rate
+// for a tracked broadcast. Callback data is "<broadcastID>:<delivered>".
+func (m *Manager) handleBroadcastClicks(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		parts := strings.SplitN(c.Data(), ":", 2)
+		if len(parts) != 2 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid data"})
+		}
+		broadcastID := parts[0]
+		delivered, _ := strconv.Atoi(parts[1])
+
+		ctx := context.Background()
+		clicks, err := m.cache.GetBroadcastClicks(ctx, broadcastID)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to load click stats", ShowAlert: true})
+		}
+
+		msg := "🔗 <b>Link Clicks</b>\n\n"
+		var total int64
+		if len(clicks) == 0 {
+			msg += "No clicks yet.\n"
+		} else {
+			idxs := make([]int, 0, len(clicks))
+			for i := range clicks {
+				idxs = append(idxs, i)
+			}
+			sort.Ints(idxs)
+			for _, i := range idxs {
+				msg += fmt.Sprintf("• Button %d: <b>%d</b>\n", i+1, clicks[i])
+				total += clicks[i]
+			}
+		}
+		if delivered > 0 {
+			msg += fmt.Sprintf("\n<b>CTR:</b> %.1f%% (%d clicks / %d delivered)",
+				float64(total)/float64(delivered)*100, total, delivered)
+		}
+
+		c.Respond(&telebot.CallbackResponse{})
+		return c.Send(msg, telebot.ModeHTML)
+	}
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/telebot.v3"
+)
+
+func TestTrackedBroadcastMarkup_RewritesURLButtons(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+	ctx := context.Background()
+
+	msg := &telebot.Message{
+		ReplyMarkup: &telebot.ReplyMarkup{
+			InlineKeyboard: [][]telebot.InlineButton{
+				{{Text: "Docs", URL: "https://example.org/docs"}},
+				{{Text: "Vote", Unique: "vote", Data: "yes"}},
+			},
+		},
+	}
+
+	markup, broadcastID := m.trackedBroadcastMarkup(ctx, msg)
+	if markup == nil || broadcastID == "" {
+		t.Fatal("Expected tracked markup for a message with URL buttons")
+	}
+
+	rewritten := markup.InlineKeyboard[0][0].URL
+	if !strings.HasPrefix(rewritten, "https://example.com/c/"+broadcastID+"/") {
+		t.Errorf("URL button not routed through redirect endpoint: %q", rewritten)
+	}
+	if markup.InlineKeyboard[1][0].Data != "yes" {
+		t.Error("Non-URL button should be left untouched")
+	}
+	if msg.ReplyMarkup.InlineKeyboard[0][0].URL != "https://example.org/docs" {
+		t.Error("Original markup should not be modified")
+	}
+
+	// The stored target round-trips through the redirect handler
+	req := httptest.NewRequest(http.MethodGet, "/c/"+broadcastID+"/0", nil)
+	rr := httptest.NewRecorder()
+	m.HandleLinkClick(rr, req)
+
+	if rr.Code != http.StatusFound {
+		t.Fatalf("Expected 302, got %d", rr.Code)
+	}
+	if loc := rr.Header().Get("Location"); loc != "https://example.org/docs" {
+		t.Errorf("Expected redirect to original target, got %q", loc)
+	}
+
+	clicks, err := m.cache.GetBroadcastClicks(ctx, broadcastID)
+	if err != nil {
+		t.Fatalf("GetBroadcastClicks failed: %v", err)
+	}
+	if clicks[0] != 1 {
+		t.Errorf("Expected 1 recorded click, got %d", clicks[0])
+	}
+}
+
+func TestTrackedBroadcastMarkup_NoURLButtons(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+
+	msg := &telebot.Message{
+		ReplyMarkup: &telebot.ReplyMarkup{
+			InlineKeyboard: [][]telebot.InlineButton{
+				{{Text: "Vote", Unique: "vote", Data: "yes"}},
+			},
+		},
+	}
+
+	if markup, _ := m.trackedBroadcastMarkup(context.Background(), msg); markup != nil {
+		t.Error("Expected no tracking for a message without URL buttons")
+	}
+	if markup, _ := m.trackedBroadcastMarkup(context.Background(), nil); markup != nil {
+		t.Error("Expected no tracking for a nil message")
+	}
+}
+
+func TestHandleLinkClick_UnknownBroadcast(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/c/deadbeef/0", nil)
+	rr := httptest.NewRecorder()
+	m.HandleLinkClick(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown broadcast, got %d", rr.Code)
+	}
+}
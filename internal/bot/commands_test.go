@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		caption  string
+		want     string
+	}{
+		{"plain text", "Opening hours and location", "", "Opening hours and location"},
+		{"first line only", "Our prices:\n- Basic: $5\n- Pro: $10", "", "Our prices:"},
+		{"falls back to caption", "", "Price list attached", "Price list attached"},
+		{"empty falls back to default", "", "", "Custom command"},
+		{"too short falls back to default", "ok", "", "Custom command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := commandDescription(tt.response, tt.caption)
+			if got != tt.want {
+				t.Errorf("commandDescription(%q, %q) = %q, want %q", tt.response, tt.caption, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandDescription_TruncatesLongText(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	got := commandDescription(long, "")
+	if len(got) != 64 {
+		t.Errorf("Expected 64 chars, got %d", len(got))
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("Expected truncated text to end with ellipsis, got %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package bot
+
+import "testing"
+
+// ==================== Content Filter Tests ====================
+
+func TestContainsProfanity_Match(t *testing.T) {
+	cases := []string{
+		"fuck this",
+		"what the SHIT",
+		"you bitch!",
+		"eres una puta",
+		"so ein arschloch",
+	}
+	for _, text := range cases {
+		if !containsProfanity(text) {
+			t.Errorf("Expected %q to match the profanity pack", text)
+		}
+	}
+}
+
+func TestContainsProfanity_NoMatch(t *testing.T) {
+	cases := []string{
+		"",
+		"hello there",
+		"the dickensian novel", // Not a standalone word match
+		"scunthorpe problem",
+	}
+	for _, text := range cases {
+		if containsProfanity(text) {
+			t.Errorf("Expected %q not to match the profanity pack", text)
+		}
+	}
+}
+
+func TestContainsLink_Match(t *testing.T) {
+	cases := []string{
+		"visit https://example.com now",
+		"http://spam.io",
+		"check www.shop.example",
+		"join t.me/somechannel",
+		"https://telegram.me/joinchat/abc",
+	}
+	for _, text := range cases {
+		if !containsLink(text) {
+			t.Errorf("Expected %q to match the link filter", text)
+		}
+	}
+}
+
+func TestContainsLink_NoMatch(t *testing.T) {
+	cases := []string{
+		"",
+		"hello, how do I order?",
+		"my username is @someone",
+	}
+	for _, text := range cases {
+		if containsLink(text) {
+			t.Errorf("Expected %q not to match the link filter", text)
+		}
+	}
+}
+
+func TestNextFilterAction_Cycle(t *testing.T) {
+	steps := map[string]string{
+		FilterActionOff:  FilterActionDrop,
+		FilterActionDrop: FilterActionWarn,
+		FilterActionWarn: FilterActionFlag,
+		FilterActionFlag: FilterActionOff,
+	}
+	for current, expected := range steps {
+		if got := nextFilterAction(current); got != expected {
+			t.Errorf("nextFilterAction(%q) = %q, expected %q", current, got, expected)
+		}
+	}
+}
+
+func TestNextFilterAction_UnknownDefaultsToOff(t *testing.T) {
+	if got := nextFilterAction("bogus"); got != FilterActionOff {
+		t.Errorf("Expected unknown action to reset to off, got %q", got)
+	}
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Flow Framework ====================
+
+// The flow framework replaces the ad-hoc string state machines that used
+// to live in per-feature process* functions. A flow is a named set of
+// steps keyed by the user state stored in Redis; each incoming owner
+// message is routed to the step that owns the current state. Steps
+// validate their input, collect a typed draft serialized as JSON into the
+// per-user flow hash, and either advance to another step or finish.
+// Abandoned conversations expire with the flow hash TTL, so no explicit
+// timeout bookkeeping is needed.
+
+// flowStep is one step of a multi-step conversation
+type flowStep struct {
+	// validate inspects the incoming message and returns a user-facing
+	// warning; the handler only runs when the warning is empty
+	validate func(c telebot.Context) string
+	// handle processes the validated input
+	handle func(ctx context.Context, c telebot.Context, fs *flowSession) error
+}
+
+// flow groups the steps of one conversation. Steps are keyed by the user
+// state string, so callback handlers can still move a conversation along
+// with plain state writes where needed.
+type flow struct {
+	name  string
+	entry string // state written when the flow starts
+	steps map[string]flowStep
+}
+
+// flowSession gives a step access to the manager and one user's draft data
+type flowSession struct {
+	m      *Manager
+	bot    *telebot.Bot
+	token  string
+	userID int64
+}
+
+// botID resolves the session's bot ID
+func (fs *flowSession) botID() int64 {
+	fs.m.mu.RLock()
+	defer fs.m.mu.RUnlock()
+	return fs.m.botIDs[fs.token]
+}
+
+// advance moves the conversation to another step
+func (fs *flowSession) advance(ctx context.Context, state string) error {
+	return fs.m.cache.SetUserState(ctx, fs.token, fs.userID, state)
+}
+
+// saveDraft persists the flow's typed draft struct
+func (fs *flowSession) saveDraft(ctx context.Context, draft interface{}) error {
+	return fs.m.cache.SetFlowDraft(ctx, fs.token, fs.userID, draft)
+}
+
+// loadDraft loads the draft; false means it expired or was never saved
+func (fs *flowSession) loadDraft(ctx context.Context, draft interface{}) (bool, error) {
+	return fs.m.cache.GetFlowDraft(ctx, fs.token, fs.userID, draft)
+}
+
+// finish ends the conversation, clearing the state and draft
+func (fs *flowSession) finish(ctx context.Context) {
+	fs.m.cache.ClearUserState(ctx, fs.token, fs.userID)
+	fs.m.cache.ClearFlowDraft(ctx, fs.token, fs.userID)
+}
+
+// requireText builds a validator that rejects messages without text
+func requireText(warn string) func(c telebot.Context) string {
+	return func(c telebot.Context) string {
+		if strings.TrimSpace(c.Text()) == "" {
+			return warn
+		}
+		return ""
+	}
+}
+
+// registerFlows builds the flow registry and the state -> step index
+func (m *Manager) registerFlows() {
+	m.flows = make(map[string]flow)
+	m.flowSteps = make(map[string]flowStep)
+
+	for _, f := range []flow{
+		m.addAutoReplyFlow(),
+		m.addCustomCommandFlow(),
+		m.scheduleMessageFlow(),
+		m.addForcedChannelFlow(),
+		m.forcedSubMessageFlow(),
+	} {
+		m.flows[f.name] = f
+		for state, step := range f.steps {
+			if _, exists := m.flowSteps[state]; exists {
+				log.Printf("[Flow] Duplicate state %q registered by flow %q", state, f.name)
+			}
+			m.flowSteps[state] = step
+		}
+	}
+}
+
+// startFlow puts the user at the named flow's entry step
+func (m *Manager) startFlow(ctx context.Context, token string, userID int64, name string) error {
+	f, ok := m.flows[name]
+	if !ok {
+		return fmt.Errorf("unknown flow: %s", name)
+	}
+	return m.cache.SetUserState(ctx, token, userID, f.entry)
+}
+
+// cancelFlow abandons the user's conversation, if any
+func (m *Manager) cancelFlow(ctx context.Context, token string, userID int64) {
+	m.cache.ClearUserState(ctx, token, userID)
+	m.cache.ClearFlowDraft(ctx, token, userID)
+}
+
+// dispatchFlowState routes a message to the step owning the user's current
+// state. Returns false when no flow owns the state.
+func (m *Manager) dispatchFlowState(ctx context.Context, c telebot.Context, bot *telebot.Bot, token string, state string) (bool, error) {
+	step, ok := m.flowSteps[state]
+	if !ok {
+		return false, nil
+	}
+
+	if step.validate != nil {
+		if warn := step.validate(c); warn != "" {
+			return true, c.Reply(warn)
+		}
+	}
+
+	fs := &flowSession{m: m, bot: bot, token: token, userID: c.Sender().ID}
+	return true, step.handle(ctx, c, fs)
+}
@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"testing"
+
+	"gopkg.in/telebot.v3"
+)
+
+// textContext is a stub telebot.Context that only implements Text
+type textContext struct {
+	telebot.Context
+	text string
+}
+
+func (t textContext) Text() string { return t.text }
+
+func TestRequireText(t *testing.T) {
+	validate := requireText("warn")
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"text passes", "hello", ""},
+		{"empty rejected", "", "warn"},
+		{"whitespace rejected", "   ", "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate(textContext{text: tt.text}); got != tt.want {
+				t.Errorf("requireText()(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -8,15 +8,28 @@ import (
 	"time"
 
 	"gopkg.in/telebot.v3"
+
+	"github.com/Amr-9/botforge/internal/cache"
 )
 
 // handleChildStart handles the /start command for child bots
 func (m *Manager) handleChildStart(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
 	return func(c telebot.Context) error {
 		sender := c.Sender()
+		ctx := context.Background()
 
 		// If owner, show admin menu
 		if sender.ID == ownerChat.ID {
+			// /start doubles as the escape hatch from a stuck flow:
+			// drop any half-finished owner state so a stale prompt
+			// cannot swallow the next message
+			if err := m.cache.ClearFlowScope(ctx, cache.FlowScopeOwner, token, sender.ID); err != nil {
+				log.Printf("Failed to clear owner flow state: %v", err)
+			}
+			if err := m.cache.ClearBroadcastMode(ctx, token, sender.ID); err != nil {
+				log.Printf("Failed to clear broadcast mode: %v", err)
+			}
+
 			menu := &telebot.ReplyMarkup{}
 			btnStats := menu.Data("📊 Statistics", "child_stats")
 			btnBroadcast := menu.Data("📢 Broadcast", "child_broadcast")
@@ -28,10 +41,13 @@ func (m *Manager) handleChildStart(bot *telebot.Bot, token string, ownerChat *te
 				menu.Row(btnSchedule),
 				menu.Row(btnSettings),
 			)
-			return c.Reply("🤖 <b>Bot Admin Panel</b>\n\nSelect an option:", menu, telebot.ModeHTML)
+			return c.Reply(m.adminPanelText(ctx), menu, telebot.ModeHTML)
 		}
 
-		ctx := context.Background()
+		// Same stale-state cleanup for the user-flow namespace
+		if err := m.cache.ClearFlowScope(ctx, cache.FlowScopeUser, token, sender.ID); err != nil {
+			log.Printf("Failed to clear user flow state: %v", err)
+		}
 
 		// Check if user is banned - silently ignore
 		m.mu.RLock()
@@ -79,11 +95,35 @@ func (m *Manager) handleChildStart(bot *telebot.Bot, token string, ownerChat *te
 			}
 		}
 
-		// Send welcome message to user
+		// Append the privacy notice, if the owner configured one
+		if notice := m.privacyNotice(ctx, token, botID); notice != "" {
+			welcomeMsg += "\n\n" + notice
+		}
+
+		// Send welcome message to user, with the quick-reply keyboard if
+		// the owner has pinned any buttons
+		if kb := m.quickReplyKeyboard(ctx, token, botID); kb != nil {
+			return c.Send(welcomeMsg, kb, telebot.ModeMarkdown)
+		}
 		return c.Send(welcomeMsg, telebot.ModeMarkdown)
 	}
 }
 
+// adminPanelText renders the admin panel header, topped by the
+// factory-wide announcement while one is published
+func (m *Manager) adminPanelText(ctx context.Context) string {
+	const header = "🤖 <b>Bot Admin Panel</b>\n\nSelect an option:"
+
+	announcement, err := m.cache.GetAnnouncement(ctx)
+	if err != nil {
+		log.Printf("Failed to get announcement: %v", err)
+	}
+	if announcement == "" {
+		return header
+	}
+	return "📣 " + announcement + "\n\n" + header
+}
+
 // handleChildMainMenu shows the main admin menu (Edit mode for callbacks)
 func (m *Manager) handleChildMainMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
 	return func(c telebot.Context) error {
@@ -102,7 +142,7 @@ func (m *Manager) handleChildMainMenu(bot *telebot.Bot, token string, ownerChat
 			menu.Row(btnSchedule),
 			menu.Row(btnSettings),
 		)
-		return c.Edit("🤖 <b>Bot Admin Panel</b>\n\nSelect an option:", menu, telebot.ModeHTML)
+		return c.Edit(m.adminPanelText(context.Background()), menu, telebot.ModeHTML)
 	}
 }
 
@@ -126,6 +166,9 @@ func (m *Manager) handleChildSettings(bot *telebot.Bot, token string, ownerChat
 		commandCount, _ := m.repo.GetAutoReplyCount(ctx, botID, "command")
 		autoReplyTotal := keywordCount + commandCount
 
+		// Get routing rule count
+		routingRuleCount, _ := m.repo.GetRoutingRuleCount(ctx, botID)
+
 		// Get forced subscription info
 		forcedChannelCount, _ := m.repo.GetForcedChannelCount(ctx, botID)
 		botModel, _ := m.repo.GetBotByToken(ctx, token)
@@ -134,26 +177,115 @@ func (m *Manager) handleChildSettings(bot *telebot.Bot, token string, ownerChat
 			forcedSubStatus = "ON"
 		}
 
-		// Get sent confirmation status
-		sentConfirmStatus := "ON"
-		if botModel != nil && !botModel.ShowSentConfirmation {
-			sentConfirmStatus = "OFF"
+		// Get confirmation emoji for display
+		confirmEmoji := "👍"
+		if botModel != nil && botModel.ConfirmationEmoji != "" {
+			confirmEmoji = botModel.ConfirmationEmoji
+		}
+
+		// Get digest status for display
+		digestStatus := "OFF"
+		if botModel != nil && botModel.DigestIntervalMinutes > 0 {
+			digestStatus = fmt.Sprintf("%dm", botModel.DigestIntervalMinutes)
+		}
+
+		// Get relay status for display
+		relayStatus := "OFF"
+		if botModel != nil && botModel.RelayChannelID != 0 {
+			relayStatus = "ON"
+		}
+
+		// Get archive status for display
+		archiveStatus := "OFF"
+		if botModel != nil && botModel.ArchiveChatID != 0 {
+			archiveStatus = "ON"
+		}
+
+		// Get media policy status for display
+		mediaPolicyStatus := "OFF"
+		if botModel != nil && (botModel.BlockedMediaTypes != "" || botModel.MaxFileSizeMB > 0) {
+			mediaPolicyStatus = "ON"
+		}
+
+		// Get dedupe status for display
+		dedupeStatus := "OFF"
+		if botModel != nil && botModel.DedupeWindowMinutes > 0 {
+			dedupeStatus = fmt.Sprintf("%dm", botModel.DedupeWindowMinutes)
+		}
+
+		// Get SLA status for display
+		slaStatus := "OFF"
+		if botModel != nil && botModel.SLAMinutes > 0 {
+			slaStatus = fmt.Sprintf("%dm", botModel.SLAMinutes)
+		}
+
+		// Get content filter status for display
+		filterStatus := "OFF"
+		if botModel != nil && (isFilterEnabled(botModel.FilterProfanityAction) || isFilterEnabled(botModel.FilterLinksAction)) {
+			filterStatus = "ON"
 		}
 
 		menu := &telebot.ReplyMarkup{}
 		btnSetStartMsg := menu.Data("📝 Set Start Message", "set_start_msg")
+		btnPrivacyNotice := menu.Data("🔏 Privacy Notice", "set_privacy_notice")
 		btnAutoReplies := menu.Data(fmt.Sprintf("🤖 Auto-Replies (%d)", autoReplyTotal), "auto_replies_menu")
 		btnForcedSub := menu.Data(fmt.Sprintf("🔐 Forced Sub [%s] (%d)", forcedSubStatus, forcedChannelCount), "forced_sub_menu")
 		btnBannedUsers := menu.Data(fmt.Sprintf("🚫 Banned Users (%d)", bannedCount), "banned_list")
-		btnSentConfirm := menu.Data(fmt.Sprintf("✅ Sent Confirmation [%s]", sentConfirmStatus), "toggle_sent_confirm")
+		btnSentConfirm := settingButton(menu, boolSettingByKey("toggle_sent_confirm"), botModel)
+		btnConfirmEmoji := menu.Data(fmt.Sprintf("%s Confirmation Emoji", confirmEmoji), "set_confirm_emoji")
+		btnConfirmMode := choiceButton(menu, choiceSettingByKey("toggle_confirm_mode"), botModel)
+		btnDigest := menu.Data(fmt.Sprintf("📬 Digest [%s]", digestStatus), "set_digest_interval")
+		btnUrgentWords := menu.Data("🚨 Urgent Keywords", "set_digest_keywords")
+		btnDedupe := menu.Data(fmt.Sprintf("♻️ Dedupe [%s]", dedupeStatus), "set_dedupe_window")
+		btnSLA := menu.Data(fmt.Sprintf("⏱ SLA Alerts [%s]", slaStatus), "set_sla_minutes")
+		btnSurvey := settingButton(menu, boolSettingByKey("toggle_survey"), botModel)
+		btnMenuButton := settingButton(menu, boolSettingByKey("toggle_menu_button"), botModel)
+		btnRouting := menu.Data(fmt.Sprintf("🔀 Keyword Routing (%d)", routingRuleCount), "routing_menu")
+		btnShortLinks := menu.Data("🔗 Short Links", "short_links_menu")
+		btnMediaPolicy := menu.Data(fmt.Sprintf("🛡 Media Policy [%s]", mediaPolicyStatus), "media_policy_menu")
+		btnFilters := menu.Data(fmt.Sprintf("🧹 Content Filters [%s]", filterStatus), "filters_menu")
+		btnSpam := choiceButton(menu, choiceSettingByKey("toggle_spam"), botModel)
+		btnContentLog := settingButton(menu, boolSettingByKey("toggle_content_log"), botModel)
+		btnRetention := choiceButton(menu, choiceSettingByKey("toggle_retention"), botModel)
+		btnMsgTTL := choiceButton(menu, choiceSettingByKey("toggle_msg_ttl"), botModel)
+		btnLocale := choiceButton(menu, choiceSettingByKey("toggle_locale"), botModel)
+		btnTimezone := choiceButton(menu, choiceSettingByKey("toggle_timezone"), botModel)
+		btnStatsDigest := choiceButton(menu, choiceSettingByKey("toggle_stats_digest"), botModel)
+		// Get reaction workflow status for display
+		reactionsStatus := "OFF"
+		if botModel != nil && botModel.ReactionActions != "" {
+			reactionsStatus = "ON"
+		}
+
+		btnWhitelist := settingButton(menu, boolSettingByKey("toggle_whitelist"), botModel)
+		btnWhitelistUsers := menu.Data("🗂 Whitelist", "whitelist_menu")
+		btnReactions := menu.Data(fmt.Sprintf("⚡ Reactions [%s]", reactionsStatus), "reactions_menu")
+		btnRelay := menu.Data(fmt.Sprintf("📡 Channel Relay [%s]", relayStatus), "set_relay_channel")
+		btnArchive := menu.Data(fmt.Sprintf("🗄 Archive Chat [%s]", archiveStatus), "set_archive_chat")
 		btnBack := menu.Data("« Back to Menu", "child_main_menu")
 
 		menu.Inline(
 			menu.Row(btnSetStartMsg),
+			menu.Row(btnPrivacyNotice),
 			menu.Row(btnAutoReplies),
 			menu.Row(btnForcedSub),
 			menu.Row(btnBannedUsers),
 			menu.Row(btnSentConfirm),
+			menu.Row(btnConfirmEmoji, btnConfirmMode),
+			menu.Row(btnDigest, btnUrgentWords),
+			menu.Row(btnDedupe, btnSLA),
+			menu.Row(btnSurvey, btnMenuButton),
+			menu.Row(btnRouting),
+			menu.Row(btnShortLinks),
+			menu.Row(btnMediaPolicy),
+			menu.Row(btnFilters, btnSpam),
+			menu.Row(btnContentLog, btnRetention),
+			menu.Row(btnMsgTTL),
+			menu.Row(btnLocale, btnTimezone),
+			menu.Row(btnStatsDigest),
+			menu.Row(btnWhitelist, btnWhitelistUsers),
+			menu.Row(btnReactions),
+			menu.Row(btnRelay, btnArchive),
 			menu.Row(btnBack),
 		)
 
@@ -210,6 +342,107 @@ _Italic_, *Bold*, [Link](http://example.com)`, currentMsg)
 	}
 }
 
+// handleSetConfirmEmojiBtn initiates state to change the confirmation emoji
+func (m *Manager) handleSetConfirmEmojiBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_confirm_emoji"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		currentEmoji := "👍"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.ConfirmationEmoji != "" {
+			currentEmoji = currentBot.ConfirmationEmoji
+		}
+
+		msg := fmt.Sprintf(`😀 <b>Set Confirmation Emoji</b>
+
+<b>Current Emoji:</b> %s
+
+Send the emoji to react with when your reply is delivered.
+⚠️ Telegram only supports its standard reaction emojis (👍 ❤️ 🔥 ✅ 👌 ...)`, currentEmoji)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleSetDigestIntervalBtn initiates state to change the digest interval
+func (m *Manager) handleSetDigestIntervalBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_digest_interval"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "OFF"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.DigestIntervalMinutes > 0 {
+			current = fmt.Sprintf("every %d minutes", currentBot.DigestIntervalMinutes)
+		}
+
+		msg := fmt.Sprintf(`📬 <b>Message Digest</b>
+
+<b>Current:</b> %s
+
+Instead of forwarding every message, your bot can collect them and send you a summary every N minutes.
+
+Send the interval in minutes (5-1440), or <code>0</code> to turn digest mode off.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleSetDigestKeywordsBtn initiates state to change the urgent keywords
+func (m *Manager) handleSetDigestKeywordsBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_digest_keywords"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "<i>(None)</i>"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.DigestUrgentKeywords != "" {
+			current = currentBot.DigestUrgentKeywords
+		}
+
+		msg := fmt.Sprintf(`🚨 <b>Urgent Keywords</b>
+
+<b>Current:</b> %s
+
+Messages containing any of these words skip the digest and are forwarded immediately.
+
+Send a comma-separated list (e.g. <code>urgent, refund, help</code>), or <code>-</code> to clear.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
 // handleChildStats shows bot statistics to the owner
 func (m *Manager) handleChildStats(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
 	return func(c telebot.Context) error {
@@ -228,12 +461,26 @@ func (m *Manager) handleChildStats(bot *telebot.Bot, token string, ownerChat *te
 		activeUsers24h, _ := m.repo.GetActiveUserCount(ctx, botID, timeNow().AddDate(0, 0, -1))
 		newUsersToday, _ := m.repo.GetNewUserCount(ctx, botID, todayStart())
 		bannedCount, _ := m.repo.GetBannedUserCount(ctx, botID)
+		reachableUsers, _ := m.repo.GetReachableUserCount(ctx, botID)
+		blockedUsers, _ := m.repo.GetBlockedUserCount(ctx, botID)
 
 		// Get message statistics
 		totalMessages, _ := m.repo.GetTotalMessageCount(ctx, botID)
 		messagesToday, _ := m.repo.GetMessageCountSince(ctx, botID, todayStart())
 		messagesWeek, _ := m.repo.GetMessageCountSince(ctx, botID, timeNow().AddDate(0, 0, -7))
 
+		// Get response time statistics (last 7 days)
+		responseTimes, _ := m.repo.GetFirstResponseTimes(ctx, botID, timeNow().AddDate(0, 0, -7))
+		unansweredCount, _ := m.repo.GetUnansweredCount(ctx, botID, timeNow().AddDate(0, 0, -7))
+		avgResponse, medianResponse := responseTimeSummary(responseTimes)
+
+		// Get satisfaction statistics
+		avgRating, ratingCount, _ := m.repo.GetRatingStats(ctx, botID)
+		satisfaction := "—"
+		if ratingCount > 0 {
+			satisfaction = fmt.Sprintf("%.1f ⭐ (%d ratings)", avgRating, ratingCount)
+		}
+
 		// Get configuration counts
 		keywordCount, _ := m.repo.GetAutoReplyCount(ctx, botID, "keyword")
 		commandCount, _ := m.repo.GetAutoReplyCount(ctx, botID, "command")
@@ -246,6 +493,8 @@ func (m *Manager) handleChildStats(bot *telebot.Bot, token string, ownerChat *te
 ├ Active (24h): %d
 ├ Active (7d): %d
 ├ New today: %d
+├ Reachable: %d
+├ Blocked bot: %d
 └ Banned: %d
 
 <b>📨 Messages</b>
@@ -253,19 +502,31 @@ func (m *Manager) handleChildStats(bot *telebot.Bot, token string, ownerChat *te
 ├ Today: %d
 └ This week: %d
 
+<b>⏱ Response Time (7d)</b>
+├ Average: %s
+├ Median: %s
+└ Unanswered: %d
+
+<b>⭐ Satisfaction</b>
+└ %s
+
 <b>⚙️ Configuration</b>
 ├ Auto-replies: %d
 ├ Commands: %d
 └ Forced channels: %d`,
-			totalUsers, activeUsers24h, activeUsers7d, newUsersToday, bannedCount,
+			totalUsers, activeUsers24h, activeUsers7d, newUsersToday, reachableUsers, blockedUsers, bannedCount,
 			totalMessages, messagesToday, messagesWeek,
+			formatResponseDuration(avgResponse), formatResponseDuration(medianResponse), unansweredCount,
+			satisfaction,
 			keywordCount, commandCount, forcedChannelCount)
 
 		menu := &telebot.ReplyMarkup{}
 		btnRefresh := menu.Data("🔄 Refresh", "child_stats")
+		btnReset := menu.Data("🗑 Reset Statistics", "reset_stats")
 		btnBack := menu.Data("« Back to Menu", "child_main_menu")
 		menu.Inline(
 			menu.Row(btnRefresh),
+			menu.Row(btnReset),
 			menu.Row(btnBack),
 		)
 
@@ -281,42 +542,3 @@ func todayStart() time.Time {
 	now := timeNow()
 	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 }
-
-// handleToggleSentConfirmation toggles the "Message sent successfully" notification
-func (m *Manager) handleToggleSentConfirmation(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
-	return func(c telebot.Context) error {
-		if c.Sender().ID != ownerChat.ID {
-			return nil
-		}
-
-		ctx := context.Background()
-		m.mu.RLock()
-		botID := m.botIDs[token]
-		m.mu.RUnlock()
-
-		// Get current setting
-		botModel, err := m.repo.GetBotByToken(ctx, token)
-		if err != nil || botModel == nil {
-			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to get settings!", ShowAlert: true})
-		}
-
-		// Toggle the setting
-		newValue := !botModel.ShowSentConfirmation
-		if err := m.repo.UpdateBotShowSentConfirmation(ctx, botID, newValue); err != nil {
-			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to update setting!", ShowAlert: true})
-		}
-
-		// Update cache immediately for better performance
-		m.cache.SetShowSentConfirmation(ctx, token, newValue)
-
-		status := "ON"
-		if !newValue {
-			status = "OFF"
-		}
-
-		c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("✅ Sent confirmation is now %s", status)})
-
-		// Refresh settings menu
-		return m.handleChildSettings(bot, token, ownerChat)(c)
-	}
-}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Archive Chat Functions ====================
+
+// archiveMessage forwards a message to the bot's archive chat, if one is
+// configured. Failures are logged but never interrupt normal delivery.
+func (m *Manager) archiveMessage(ctx context.Context, bot *telebot.Bot, token string, botID int64, msg *telebot.Message) {
+	archiveChatID := m.archiveChat(ctx, token, botID)
+	if archiveChatID == 0 || msg == nil {
+		return
+	}
+
+	if _, err := bot.Forward(&telebot.Chat{ID: archiveChatID}, msg); err != nil {
+		log.Printf("Failed to archive message %d to chat %d: %v", msg.ID, archiveChatID, err)
+	}
+}
+
+// archiveChat returns the configured archive chat ID (0 = off),
+// cache-first with a DB fallback
+func (m *Manager) archiveChat(ctx context.Context, token string, botID int64) int64 {
+	chatID, cacheHit, err := m.cache.GetArchiveChat(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return chatID
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetArchiveChat(ctx, token, botModel.ArchiveChatID)
+	return botModel.ArchiveChatID
+}
+
+// handleSetArchiveChatBtn initiates the archive chat configuration flow
+func (m *Manager) handleSetArchiveChatBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_archive_chat"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "<i>(None)</i>"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.ArchiveChatID != 0 {
+			current = fmt.Sprintf("<code>%d</code>", currentBot.ArchiveChatID)
+		}
+
+		msg := fmt.Sprintf(`🗄 <b>Archive Chat</b>
+
+<b>Current Chat:</b> %s
+
+Every inbound user message and every reply you send is copied to the archive chat for record-keeping.
+
+<b>Step 1:</b> Add the bot to the archive group or channel
+<b>Step 2:</b> Forward any message from that chat, or send its ID
+
+Send <code>0</code> to turn archiving off.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// processSetArchiveChat processes the chat input for the archive setting
+func (m *Manager) processSetArchiveChat(ctx context.Context, c telebot.Context, bot *telebot.Bot, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	msg := c.Message()
+	text := strings.TrimSpace(c.Text())
+
+	// Disable archiving
+	if text == "0" {
+		if err := m.repo.UpdateBotArchiveChat(ctx, botID, 0); err != nil {
+			return c.Reply("❌ Failed to update archive chat.")
+		}
+		m.cache.SetArchiveChat(ctx, token, 0)
+		m.cache.ClearUserState(ctx, token, c.Sender().ID)
+		return c.Reply("✅ <b>Archiving Disabled!</b>", telebot.ModeHTML)
+	}
+
+	var chatID int64
+	var chatTitle string
+
+	// Method 1: Forwarded message from the archive chat
+	if msg.OriginalChat != nil && msg.OriginalChat.Type != telebot.ChatPrivate {
+		chatID = msg.OriginalChat.ID
+		chatTitle = msg.OriginalChat.Title
+	}
+
+	// Method 2: Numeric chat ID
+	if chatID == 0 && text != "" {
+		if numID, err := strconv.ParseInt(text, 10, 64); err == nil {
+			if chat, err := bot.ChatByID(numID); err == nil && chat.Type != telebot.ChatPrivate {
+				chatID = chat.ID
+				chatTitle = chat.Title
+			}
+		}
+	}
+
+	if chatID == 0 {
+		return c.Reply("⚠️ Chat not found. Forward a message from the archive chat (with the bot as member), or send <code>0</code> to disable.", telebot.ModeHTML)
+	}
+
+	if err := m.repo.UpdateBotArchiveChat(ctx, botID, chatID); err != nil {
+		log.Printf("Failed to update archive chat: %v", err)
+		return c.Reply("❌ Failed to update archive chat.")
+	}
+
+	m.cache.SetArchiveChat(ctx, token, chatID)
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if chatTitle == "" {
+		chatTitle = strconv.FormatInt(chatID, 10)
+	}
+	return c.Reply(fmt.Sprintf("✅ <b>Archiving Enabled!</b>\n\nAll conversations will be copied to <b>%s</b>.", chatTitle), telebot.ModeHTML)
+}
@@ -2,11 +2,13 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/database"
 	"github.com/Amr-9/botforge/internal/models"
 	"gopkg.in/telebot.v3"
 )
@@ -46,12 +48,14 @@ func (m *Manager) handleAutoRepliesMenu(bot *telebot.Bot, token string, ownerCha
 		btnListKeywords := menu.Data(fmt.Sprintf("📋 Auto-Replies (%d)", keywordCount), "list_auto_replies")
 		btnListCommands := menu.Data(fmt.Sprintf("📋 Commands (%d)", commandCount), "list_custom_cmds")
 		btnToggleForward := menu.Data(forwardBtnText, "toggle_forward_replies")
+		btnQuickReplies := menu.Data("⌨️ Quick-Reply Keyboard", "quick_reply_list")
 		btnBack := menu.Data("« Back", "child_settings")
 
 		menu.Inline(
 			menu.Row(btnAddKeyword, btnAddCommand),
 			menu.Row(btnListKeywords),
 			menu.Row(btnListCommands),
+			menu.Row(btnQuickReplies),
 			menu.Row(btnToggleForward),
 			menu.Row(btnBack),
 		)
@@ -124,7 +128,7 @@ func (m *Manager) handleAddAutoReply(bot *telebot.Bot, token string, ownerChat *
 		}
 
 		ctx := context.Background()
-		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_auto_reply_trigger"); err != nil {
+		if err := m.startFlow(ctx, token, c.Sender().ID, "add_auto_reply"); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "An error occurred!", ShowAlert: true})
 		}
 
@@ -152,7 +156,7 @@ func (m *Manager) handleAddCustomCommand(bot *telebot.Bot, token string, ownerCh
 		}
 
 		ctx := context.Background()
-		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_custom_cmd_name"); err != nil {
+		if err := m.startFlow(ctx, token, c.Sender().ID, "add_custom_cmd"); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "An error occurred!", ShowAlert: true})
 		}
 
@@ -321,62 +325,124 @@ func (m *Manager) handleDeleteAutoReply(bot *telebot.Bot, token string, ownerCha
 			return c.Respond(&telebot.CallbackResponse{Text: "Invalid data", ShowAlert: true})
 		}
 
-		// Get the reply first to know its type (for cache invalidation)
-		reply, err := m.repo.GetAutoReplyByID(ctx, replyID)
-		if err != nil {
+		// Get the reply first to know its type (for cache invalidation).
+		// The lookup is scoped to this bot, so a forged ID cannot touch
+		// another bot's replies.
+		reply, err := m.repo.GetAutoReplyByID(ctx, botID, replyID)
+		if err != nil || reply == nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Reply not found", ShowAlert: true})
 		}
 
 		// Delete from DB
-		if err := m.repo.DeleteAutoReply(ctx, botID, replyID); err != nil {
+		if err := m.repo.DeleteAutoReply(ctx, botID, replyID, ownerChat.ID); err != nil {
 			log.Printf("Error deleting auto-reply: %v", err)
 			return c.Respond(&telebot.CallbackResponse{Text: "Error deleting", ShowAlert: true})
 		}
 
 		// Invalidate cache
 		m.cache.DeleteAutoReply(ctx, token, reply.TriggerWord, reply.TriggerType)
+		if reply.IsQuickReply {
+			m.cache.InvalidateQuickReplies(ctx, token)
+		}
 
 		c.Respond(&telebot.CallbackResponse{Text: "✅ Deleted successfully"})
 
 		// Reload the appropriate list
 		if reply.TriggerType == "command" {
+			// Drop the command from Telegram's command lists
+			go m.syncBotCommands(context.Background(), bot, token, ownerChat, botID)
 			return m.handleListCustomCommands(bot, token, ownerChat)(c)
 		}
 		return m.handleListAutoReplies(bot, token, ownerChat)(c)
 	}
 }
 
-// processAutoReplyState handles the multi-step flow for adding auto-replies
-func (m *Manager) processAutoReplyState(ctx context.Context, c telebot.Context, token string, state string) (bool, error) {
-	sender := c.Sender()
-	text := strings.TrimSpace(c.Text())
+// autoReplyDraft is the typed draft collected while adding a keyword
+// auto-reply or custom command
+type autoReplyDraft struct {
+	Trigger string `json:"trigger,omitempty"`
+	Command string `json:"command,omitempty"`
+}
 
-	m.mu.RLock()
-	botID := m.botIDs[token]
-	m.mu.RUnlock()
+// extractResponseContent determines the stored message type and content
+// for an auto-reply response. ok is false for unsupported message types.
+func extractResponseContent(msg *telebot.Message, text string) (msgType, responseText, fileID, caption string, ok bool) {
+	msgType = models.MessageTypeText
+	responseText = text
+	ok = true
+
+	switch {
+	case msg.Photo != nil:
+		msgType, fileID, caption, responseText = models.MessageTypePhoto, msg.Photo.FileID, msg.Caption, ""
+	case msg.Video != nil:
+		msgType, fileID, caption, responseText = models.MessageTypeVideo, msg.Video.FileID, msg.Caption, ""
+	case msg.Audio != nil:
+		msgType, fileID, caption, responseText = models.MessageTypeAudio, msg.Audio.FileID, msg.Caption, ""
+	case msg.Voice != nil:
+		msgType, fileID, caption, responseText = models.MessageTypeVoice, msg.Voice.FileID, msg.Caption, ""
+	case msg.Document != nil:
+		msgType, fileID, caption, responseText = models.MessageTypeDocument, msg.Document.FileID, msg.Caption, ""
+	case msg.Animation != nil:
+		msgType, fileID, caption, responseText = models.MessageTypeAnimation, msg.Animation.FileID, msg.Caption, ""
+	case msg.VideoNote != nil:
+		msgType, fileID, responseText = models.MessageTypeVideoNote, msg.VideoNote.FileID, ""
+	case msg.Sticker != nil:
+		msgType, fileID, responseText = models.MessageTypeSticker, msg.Sticker.FileID, ""
+	case responseText == "":
+		ok = false
+	}
+	return
+}
 
-	switch state {
-	case "add_auto_reply_trigger":
-		// Store trigger word temporarily and ask for response
-		if text == "" {
-			return true, c.Reply("⚠️ Please send a text message.")
-		}
+// addAutoReplyFlow defines the two-step keyword auto-reply conversation
+func (m *Manager) addAutoReplyFlow() flow {
+	return flow{
+		name:  "add_auto_reply",
+		entry: "add_auto_reply_trigger",
+		steps: map[string]flowStep{
+			"add_auto_reply_trigger": {
+				validate: requireText("⚠️ Please send a text message."),
+				handle:   m.stepAutoReplyTrigger,
+			},
+			"add_auto_reply_response": {handle: m.stepAutoReplyResponse},
+		},
+	}
+}
 
-		// Check if trigger already exists
-		existing, _ := m.repo.GetAutoReplyByTrigger(ctx, botID, text, "keyword")
-		if existing != nil {
-			return true, c.Reply("⚠️ This keyword already exists. Send a different one:")
-		}
+// addCustomCommandFlow defines the two-step custom command conversation
+func (m *Manager) addCustomCommandFlow() flow {
+	return flow{
+		name:  "add_custom_cmd",
+		entry: "add_custom_cmd_name",
+		steps: map[string]flowStep{
+			"add_custom_cmd_name": {
+				validate: requireText("⚠️ Please send the command name."),
+				handle:   m.stepCustomCommandName,
+			},
+			"add_custom_cmd_response": {handle: m.stepCustomCommandResponse},
+		},
+	}
+}
 
-		// Store trigger temporarily
-		m.cache.SetTempData(ctx, token, sender.ID, "trigger", text)
-		m.cache.SetUserState(ctx, token, sender.ID, "add_auto_reply_response")
+// stepAutoReplyTrigger stores the trigger keyword and asks for the response
+func (m *Manager) stepAutoReplyTrigger(ctx context.Context, c telebot.Context, fs *flowSession) error {
+	text := strings.TrimSpace(c.Text())
 
-		menu := &telebot.ReplyMarkup{}
-		btnCancel := menu.Data("❌ Cancel", "auto_replies_menu")
-		menu.Inline(menu.Row(btnCancel))
+	// Check if trigger already exists
+	existing, _ := m.repo.GetAutoReplyByTrigger(ctx, fs.botID(), text, "keyword")
+	if existing != nil {
+		return c.Reply("⚠️ This keyword already exists. Send a different one:")
+	}
+
+	// Store trigger in the draft
+	fs.saveDraft(ctx, &autoReplyDraft{Trigger: text})
+	fs.advance(ctx, "add_auto_reply_response")
 
-		return true, c.Send(fmt.Sprintf(`✅ Keyword: <code>%s</code>
+	menu := &telebot.ReplyMarkup{}
+	btnCancel := menu.Data("❌ Cancel", "auto_replies_menu")
+	menu.Inline(menu.Row(btnCancel))
+
+	return c.Send(fmt.Sprintf(`✅ Keyword: <code>%s</code>
 
 Now send the auto-reply response.
 
@@ -385,128 +451,86 @@ You can send:
 • Photo, Video, Audio, Voice
 • Document, GIF, Sticker
 • Video note (circle video)`, text), menu, telebot.ModeHTML)
+}
 
-	case "add_auto_reply_response":
-		// Determine message type and extract content
-		msgType := models.MessageTypeText
-		responseText := text
-		fileID := ""
-		caption := ""
-
-		msg := c.Message()
-		if msg.Photo != nil {
-			msgType = models.MessageTypePhoto
-			fileID = msg.Photo.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Video != nil {
-			msgType = models.MessageTypeVideo
-			fileID = msg.Video.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Audio != nil {
-			msgType = models.MessageTypeAudio
-			fileID = msg.Audio.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Voice != nil {
-			msgType = models.MessageTypeVoice
-			fileID = msg.Voice.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Document != nil {
-			msgType = models.MessageTypeDocument
-			fileID = msg.Document.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Animation != nil {
-			msgType = models.MessageTypeAnimation
-			fileID = msg.Animation.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.VideoNote != nil {
-			msgType = models.MessageTypeVideoNote
-			fileID = msg.VideoNote.FileID
-			responseText = ""
-		} else if msg.Sticker != nil {
-			msgType = models.MessageTypeSticker
-			fileID = msg.Sticker.FileID
-			responseText = ""
-		} else if responseText == "" {
-			return true, c.Reply("⚠️ Please send a text message or media (photo, video, audio, voice, document, animation, video note, or sticker).")
-		}
-
-		// Get trigger from temp storage
-		trigger, _ := m.cache.GetTempData(ctx, token, sender.ID, "trigger")
-		if trigger == "" {
-			m.cache.ClearUserState(ctx, token, sender.ID)
-			return true, c.Reply("⚠️ Session expired. Please try again.")
-		}
-
-		// Save to DB with media fields
-		err := m.repo.CreateAutoReply(ctx, botID, trigger, responseText, msgType, fileID, caption, "keyword", "contains")
-		if err != nil {
-			log.Printf("Error creating auto-reply: %v", err)
-			return true, c.Reply("❌ Error saving.")
-		}
-
-		// Cache with media info
-		cacheData := &cache.AutoReplyCache{
-			Response:    responseText,
-			MessageType: msgType,
-			FileID:      fileID,
-			Caption:     caption,
-		}
-		m.cache.SetAutoReplyWithMedia(ctx, token, trigger, cacheData, "keyword")
-
-		// Clear state
-		m.cache.ClearUserState(ctx, token, sender.ID)
-		m.cache.ClearTempData(ctx, token, sender.ID, "trigger")
-
-		// Build confirmation message
-		var confirmMsg string
-		if msgType == models.MessageTypeText {
-			confirmMsg = fmt.Sprintf("✅ <b>Auto-reply added!</b>\n\n🔑 Keyword: <code>%s</code>\n💬 Response: %s", trigger, responseText)
-		} else {
-			confirmMsg = fmt.Sprintf("✅ <b>Auto-reply added!</b>\n\n🔑 Keyword: <code>%s</code>\n📎 Type: %s", trigger, msgType)
-			if caption != "" {
-				confirmMsg += fmt.Sprintf("\n📝 Caption: %s", caption)
-			}
-		}
+// stepAutoReplyResponse saves the completed keyword auto-reply
+func (m *Manager) stepAutoReplyResponse(ctx context.Context, c telebot.Context, fs *flowSession) error {
+	msgType, responseText, fileID, caption, ok := extractResponseContent(c.Message(), strings.TrimSpace(c.Text()))
+	if !ok {
+		return c.Reply("⚠️ Please send a text message or media (photo, video, audio, voice, document, animation, video note, or sticker).")
+	}
 
-		return true, c.Reply(confirmMsg, telebot.ModeHTML)
+	// Get trigger from the draft
+	var draft autoReplyDraft
+	if found, _ := fs.loadDraft(ctx, &draft); !found || draft.Trigger == "" {
+		fs.finish(ctx)
+		return c.Reply("⚠️ Session expired. Please try again.")
+	}
+	trigger := draft.Trigger
 
-	case "add_custom_cmd_name":
-		if text == "" {
-			return true, c.Reply("⚠️ Please send the command name.")
+	// Save to DB with media fields
+	err := m.repo.CreateAutoReply(ctx, fs.botID(), trigger, responseText, msgType, fileID, caption, "keyword", "contains")
+	if err != nil {
+		log.Printf("Error creating auto-reply: %v", err)
+		if errors.Is(err, database.ErrUnavailable) {
+			return c.Reply("❌ Service temporarily unavailable. Please try again.")
 		}
+		return c.Reply("❌ Error saving.")
+	}
 
-		// Clean command name (remove / if present)
-		cmdName := strings.TrimPrefix(text, "/")
-		cmdName = strings.ToLower(cmdName)
+	// Cache with media info
+	cacheData := &cache.AutoReplyCache{
+		Response:    responseText,
+		MessageType: msgType,
+		FileID:      fileID,
+		Caption:     caption,
+	}
+	m.cache.SetAutoReplyWithMedia(ctx, fs.token, trigger, cacheData, "keyword")
 
-		// Validate command name (alphanumeric only)
-		for _, r := range cmdName {
-			if !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_') {
-				return true, c.Reply("⚠️ Command name must contain only English letters, numbers, and underscores.")
-			}
+	fs.finish(ctx)
+
+	// Build confirmation message
+	var confirmMsg string
+	if msgType == models.MessageTypeText {
+		confirmMsg = fmt.Sprintf("✅ <b>Auto-reply added!</b>\n\n🔑 Keyword: <code>%s</code>\n💬 Response: %s", trigger, responseText)
+	} else {
+		confirmMsg = fmt.Sprintf("✅ <b>Auto-reply added!</b>\n\n🔑 Keyword: <code>%s</code>\n📎 Type: %s", trigger, msgType)
+		if caption != "" {
+			confirmMsg += fmt.Sprintf("\n📝 Caption: %s", caption)
 		}
+	}
 
-		// Check if command already exists
-		existing, _ := m.repo.GetAutoReplyByTrigger(ctx, botID, cmdName, "command")
-		if existing != nil {
-			return true, c.Reply("⚠️ This command already exists. Send a different one:")
+	return c.Reply(confirmMsg, telebot.ModeHTML)
+}
+
+// stepCustomCommandName validates and stores the command name
+func (m *Manager) stepCustomCommandName(ctx context.Context, c telebot.Context, fs *flowSession) error {
+	// Clean command name (remove / if present)
+	cmdName := strings.TrimPrefix(strings.TrimSpace(c.Text()), "/")
+	cmdName = strings.ToLower(cmdName)
+
+	// Validate command name (alphanumeric only)
+	for _, r := range cmdName {
+		if !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_') {
+			return c.Reply("⚠️ Command name must contain only English letters, numbers, and underscores.")
 		}
+	}
 
-		// Store command name temporarily
-		m.cache.SetTempData(ctx, token, sender.ID, "command", cmdName)
-		m.cache.SetUserState(ctx, token, sender.ID, "add_custom_cmd_response")
+	// Check if command already exists
+	existing, _ := m.repo.GetAutoReplyByTrigger(ctx, fs.botID(), cmdName, "command")
+	if existing != nil {
+		return c.Reply("⚠️ This command already exists. Send a different one:")
+	}
 
-		menu := &telebot.ReplyMarkup{}
-		btnCancel := menu.Data("❌ Cancel", "auto_replies_menu")
-		menu.Inline(menu.Row(btnCancel))
+	// Store command name in the draft
+	fs.saveDraft(ctx, &autoReplyDraft{Command: cmdName})
+	fs.advance(ctx, "add_custom_cmd_response")
 
-		return true, c.Send(fmt.Sprintf(`✅ Command: <code>/%s</code>
+	menu := &telebot.ReplyMarkup{}
+	btnCancel := menu.Data("❌ Cancel", "auto_replies_menu")
+	menu.Inline(menu.Row(btnCancel))
+
+	return c.Send(fmt.Sprintf(`✅ Command: <code>/%s</code>
 
 Now send the response for this command.
 
@@ -515,99 +539,57 @@ You can send:
 • Photo, Video, Audio, Voice
 • Document, GIF, Sticker
 • Video note (circle video)`, cmdName), menu, telebot.ModeHTML)
+}
 
-	case "add_custom_cmd_response":
-		// Determine message type and extract content
-		msgType := models.MessageTypeText
-		responseText := text
-		fileID := ""
-		caption := ""
-
-		msg := c.Message()
-		if msg.Photo != nil {
-			msgType = models.MessageTypePhoto
-			fileID = msg.Photo.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Video != nil {
-			msgType = models.MessageTypeVideo
-			fileID = msg.Video.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Audio != nil {
-			msgType = models.MessageTypeAudio
-			fileID = msg.Audio.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Voice != nil {
-			msgType = models.MessageTypeVoice
-			fileID = msg.Voice.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Document != nil {
-			msgType = models.MessageTypeDocument
-			fileID = msg.Document.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.Animation != nil {
-			msgType = models.MessageTypeAnimation
-			fileID = msg.Animation.FileID
-			caption = msg.Caption
-			responseText = ""
-		} else if msg.VideoNote != nil {
-			msgType = models.MessageTypeVideoNote
-			fileID = msg.VideoNote.FileID
-			responseText = ""
-		} else if msg.Sticker != nil {
-			msgType = models.MessageTypeSticker
-			fileID = msg.Sticker.FileID
-			responseText = ""
-		} else if responseText == "" {
-			return true, c.Reply("⚠️ Please send a text message or media (photo, video, audio, voice, document, animation, video note, or sticker).")
-		}
-
-		// Get command from temp storage
-		cmdName, _ := m.cache.GetTempData(ctx, token, sender.ID, "command")
-		if cmdName == "" {
-			m.cache.ClearUserState(ctx, token, sender.ID)
-			return true, c.Reply("⚠️ Session expired. Please try again.")
-		}
-
-		// Save to DB with media fields
-		err := m.repo.CreateAutoReply(ctx, botID, cmdName, responseText, msgType, fileID, caption, "command", "exact")
-		if err != nil {
-			log.Printf("Error creating custom command: %v", err)
-			return true, c.Reply("❌ Error saving.")
-		}
-
-		// Cache with media info
-		cacheData := &cache.AutoReplyCache{
-			Response:    responseText,
-			MessageType: msgType,
-			FileID:      fileID,
-			Caption:     caption,
-		}
-		m.cache.SetAutoReplyWithMedia(ctx, token, cmdName, cacheData, "command")
-
-		// Clear state
-		m.cache.ClearUserState(ctx, token, sender.ID)
-		m.cache.ClearTempData(ctx, token, sender.ID, "command")
-
-		// Build confirmation message
-		var confirmMsg string
-		if msgType == models.MessageTypeText {
-			confirmMsg = fmt.Sprintf("✅ <b>Custom command added!</b>\n\n🔑 Command: <code>/%s</code>\n💬 Response: %s", cmdName, responseText)
-		} else {
-			confirmMsg = fmt.Sprintf("✅ <b>Custom command added!</b>\n\n🔑 Command: <code>/%s</code>\n📎 Type: %s", cmdName, msgType)
-			if caption != "" {
-				confirmMsg += fmt.Sprintf("\n📝 Caption: %s", caption)
-			}
-		}
+// stepCustomCommandResponse saves the completed custom command
+func (m *Manager) stepCustomCommandResponse(ctx context.Context, c telebot.Context, fs *flowSession) error {
+	msgType, responseText, fileID, caption, ok := extractResponseContent(c.Message(), strings.TrimSpace(c.Text()))
+	if !ok {
+		return c.Reply("⚠️ Please send a text message or media (photo, video, audio, voice, document, animation, video note, or sticker).")
+	}
 
-		return true, c.Reply(confirmMsg, telebot.ModeHTML)
+	// Get command from the draft
+	var draft autoReplyDraft
+	if found, _ := fs.loadDraft(ctx, &draft); !found || draft.Command == "" {
+		fs.finish(ctx)
+		return c.Reply("⚠️ Session expired. Please try again.")
+	}
+	cmdName := draft.Command
+	botID := fs.botID()
+
+	// Save to DB with media fields
+	err := m.repo.CreateAutoReply(ctx, botID, cmdName, responseText, msgType, fileID, caption, "command", "exact")
+	if err != nil {
+		log.Printf("Error creating custom command: %v", err)
+		return c.Reply("❌ Error saving.")
+	}
+
+	// Cache with media info
+	cacheData := &cache.AutoReplyCache{
+		Response:    responseText,
+		MessageType: msgType,
+		FileID:      fileID,
+		Caption:     caption,
+	}
+	m.cache.SetAutoReplyWithMedia(ctx, fs.token, cmdName, cacheData, "command")
+
+	fs.finish(ctx)
+
+	// Push the new command to Telegram's command lists
+	go m.syncBotCommands(context.Background(), c.Bot(), fs.token, &telebot.Chat{ID: fs.userID}, botID)
+
+	// Build confirmation message
+	var confirmMsg string
+	if msgType == models.MessageTypeText {
+		confirmMsg = fmt.Sprintf("✅ <b>Custom command added!</b>\n\n🔑 Command: <code>/%s</code>\n💬 Response: %s", cmdName, responseText)
+	} else {
+		confirmMsg = fmt.Sprintf("✅ <b>Custom command added!</b>\n\n🔑 Command: <code>/%s</code>\n📎 Type: %s", cmdName, msgType)
+		if caption != "" {
+			confirmMsg += fmt.Sprintf("\n📝 Caption: %s", caption)
+		}
 	}
 
-	return false, nil
+	return c.Reply(confirmMsg, telebot.ModeHTML)
 }
 
 // checkAutoReply checks if a message matches any auto-reply triggers (exact match only)
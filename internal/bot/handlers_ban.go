@@ -2,10 +2,12 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/Amr-9/botforge/internal/database"
 	"gopkg.in/telebot.v3"
 )
 
@@ -29,6 +31,9 @@ func (m *Manager) handleBanCommand(ctx context.Context, c telebot.Context, bot *
 	// Ban the user
 	if err := m.repo.BanUser(ctx, botID, userChatID, c.Sender().ID); err != nil {
 		log.Printf("Error banning user: %v", err)
+		if errors.Is(err, database.ErrUnavailable) {
+			return c.Reply("Service temporarily unavailable. Please try again.")
+		}
 		return c.Reply("Failed to ban user.")
 	}
 
@@ -74,17 +79,13 @@ func (m *Manager) handleBannedUsersList(bot *telebot.Bot, token string, ownerCha
 		botID := m.botIDs[token]
 		m.mu.RUnlock()
 
-		// Get page from callback data (default: 0)
-		page := 0
+		// Get the keyset cursor from callback data (empty = first page)
+		var cursor database.Cursor
 		if c.Callback() != nil && c.Callback().Data != "" {
-			// Check if it's just a page number (not an unban action)
-			if p, err := strconv.Atoi(c.Callback().Data); err == nil {
-				page = p
-			}
+			cursor = parseListCursor(c.Callback().Data)
 		}
 
 		pageSize := 5
-		offset := page * pageSize
 
 		// Get banned users count
 		count, err := m.repo.GetBannedUserCount(ctx, botID)
@@ -99,13 +100,18 @@ func (m *Manager) handleBannedUsersList(bot *telebot.Bot, token string, ownerCha
 			return c.Edit("🚫 <b>Banned Users</b>\n\nNo users are currently banned.", menu, telebot.ModeHTML)
 		}
 
-		// Get banned users
-		bannedUsers, err := m.repo.GetBannedUsers(ctx, botID, pageSize, offset)
+		// Fetch one extra row to know whether a next page exists
+		bannedUsers, err := m.repo.GetBannedUsersPage(ctx, botID, cursor, pageSize+1)
 		if err != nil {
 			return c.Edit("Failed to retrieve banned users.")
 		}
+		hasMore := len(bannedUsers) > pageSize
+		if hasMore {
+			bannedUsers = bannedUsers[:pageSize]
+		}
 
 		// Build message
+		clock := m.ownerClock(ctx, token, botID)
 		msg := fmt.Sprintf("🚫 <b>Banned Users</b> (%d total)\n\n", count)
 
 		menu := &telebot.ReplyMarkup{}
@@ -126,20 +132,22 @@ func (m *Manager) handleBannedUsersList(bot *telebot.Bot, token string, ownerCha
 				}
 			}
 			msg += fmt.Sprintf("%d. %s\n   🆔 <code>%d</code>\n   📅 %s\n\n",
-				offset+i+1, name, ban.UserChatID, ban.CreatedAt.Format("2006-01-02 15:04"))
+				i+1, name, ban.UserChatID, clock.DateTime(ban.CreatedAt))
 
 			// Add unban button for each user
 			btnUnban := menu.Data(fmt.Sprintf("Unban %d", ban.UserChatID), "unban_user", strconv.FormatInt(ban.UserChatID, 10))
 			rows = append(rows, menu.Row(btnUnban))
 		}
 
-		// Pagination buttons
+		// Pagination buttons. Keyset cursors only walk forward, so the
+		// way back is a jump to the first page.
 		var navRow []telebot.Btn
-		if page > 0 {
-			navRow = append(navRow, menu.Data("« Prev", "banned_list", strconv.Itoa(page-1)))
+		if !cursor.IsZero() {
+			navRow = append(navRow, menu.Data("« First", "banned_list"))
 		}
-		if int64(offset+pageSize) < count {
-			navRow = append(navRow, menu.Data("Next »", "banned_list", strconv.Itoa(page+1)))
+		if hasMore {
+			last := bannedUsers[len(bannedUsers)-1]
+			navRow = append(navRow, menu.Data("Next »", "banned_list", formatListCursor(last.CreatedAt, last.ID)))
 		}
 		if len(navRow) > 0 {
 			rows = append(rows, menu.Row(navRow...))
@@ -174,7 +182,7 @@ func (m *Manager) handleUnbanUser(bot *telebot.Bot, token string, ownerChat *tel
 		}
 
 		// Unban the user
-		if err := m.repo.UnbanUser(ctx, botID, userChatID); err != nil {
+		if err := m.repo.UnbanUser(ctx, botID, userChatID, ownerChat.ID); err != nil {
 			log.Printf("Error unbanning user: %v", err)
 			return c.Respond(&telebot.CallbackResponse{Text: "Failed to unban user", ShowAlert: true})
 		}
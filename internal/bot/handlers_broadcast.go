@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
+	"strconv"
 	"time"
 
+	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/events"
+	"github.com/Amr-9/botforge/internal/models"
 	"gopkg.in/telebot.v3"
 )
 
@@ -23,8 +26,9 @@ func (m *Manager) handleChildBroadcast(bot *telebot.Bot, token string, ownerChat
 		}
 
 		menu := &telebot.ReplyMarkup{}
+		btnHistory := menu.Data("📜 Broadcast History", "broadcast_history")
 		btnCancel := menu.Data("❌ Cancel Broadcast", "cancel_broadcast")
-		menu.Inline(menu.Row(btnCancel))
+		menu.Inline(menu.Row(btnHistory), menu.Row(btnCancel))
 
 		return c.Edit("📢 <b>Broadcast Mode</b>\n\nSend the message you want to broadcast to all users.\nYou can send text, photos, videos, etc.", menu, telebot.ModeHTML)
 	}
@@ -49,7 +53,7 @@ func (m *Manager) handleCancelBroadcast(bot *telebot.Bot, token string) telebot.
 			menu.Row(btnSettings),
 		)
 
-		return c.Edit("🤖 <b>Bot Admin Panel</b>\n\nSelect an option:", menu, telebot.ModeHTML)
+		return c.Edit(m.adminPanelText(ctx), menu, telebot.ModeHTML)
 	}
 }
 
@@ -89,6 +93,10 @@ func (m *Manager) handleConfirmBroadcast(bot *telebot.Bot, token string, ownerCh
 		m.cache.ClearPendingBroadcast(ctx, token, c.Sender().ID)
 		m.cache.ClearBroadcastMode(ctx, token, c.Sender().ID)
 
+		// The confirmation prompt replied to the broadcast message, so
+		// its URL buttons (if any) are available here for click tracking
+		trackedMarkup, broadcastID := m.trackedBroadcastMarkup(ctx, c.Message().ReplyTo)
+
 		c.Edit("⏳ Starting broadcast. This may take a while...")
 
 		m.mu.RLock()
@@ -100,33 +108,91 @@ func (m *Manager) handleConfirmBroadcast(bot *telebot.Bot, token string, ownerCh
 			return c.Respond(&telebot.CallbackResponse{Text: "Failed to retrieve user list", ShowAlert: true})
 		}
 
+		// Broadcast throughput and audience follow the owner's plan tier
+		plan, err := m.repo.GetOwnerPlan(ctx, c.Sender().ID)
+		if err != nil {
+			log.Printf("Failed to get owner plan: %v", err)
+			plan = models.PlanFree
+		}
+		limits := models.BroadcastLimitsFor(plan)
+		if limits.MaxAudience > 0 && len(userIDs) > limits.MaxAudience {
+			return c.Send(fmt.Sprintf(`❌ <b>Audience Too Large</b>
+
+The free plan can broadcast to at most %d users and this bot has %d. Upgrade to the paid plan to reach everyone.`,
+				limits.MaxAudience, len(userIDs)), telebot.ModeHTML)
+		}
+
 		// Get the original message to broadcast
 		originalMsg := &telebot.Message{ID: msgID, Chat: ownerChat}
 
+		// Persist job state so a deploy mid-broadcast can tell the owner
+		// where it stopped (see NotifyInterruptedBroadcasts)
+		progress := &cache.BroadcastProgress{BotToken: token, OwnerChatID: c.Sender().ID, Total: len(userIDs)}
+		if err := m.cache.SetBroadcastProgress(ctx, progress); err != nil {
+			log.Printf("Failed to persist broadcast progress: %v", err)
+		}
+
 		success := 0
 		blocked := 0
 		failed := 0
+		attempted := 0
 
 		for _, userID := range userIDs {
 			if userID == c.Sender().ID {
 				continue
 			}
 
-			userChat := &telebot.Chat{ID: userID}
-			_, err := bot.Copy(userChat, originalMsg)
-			if err != nil {
-				if strings.Contains(err.Error(), "blocked") || strings.Contains(err.Error(), "Forbidden") {
-					blocked++
-				} else {
-					failed++
-					log.Printf("Failed to broadcast to %d: %v", userID, err)
+			attempted++
+			if attempted%broadcastProgressEvery == 0 {
+				progress.Sent = attempted
+				if err := m.cache.SetBroadcastProgress(ctx, progress); err != nil {
+					log.Printf("Failed to persist broadcast progress: %v", err)
 				}
-			} else {
+			}
+
+			userChat := &telebot.Chat{ID: userID}
+			var copyOpts []interface{}
+			if trackedMarkup != nil {
+				copyOpts = append(copyOpts, trackedMarkup)
+			}
+			_, err := bot.Copy(userChat, originalMsg, copyOpts...)
+
+			status, errorCode := deliveryStatusFor(err)
+			if recordErr := m.repo.RecordDelivery(ctx, botID, userID, models.DeliverySourceBroadcast, status, errorCode); recordErr != nil {
+				log.Printf("Failed to record broadcast delivery for %d: %v", userID, recordErr)
+			}
+
+			switch status {
+			case models.DeliveryStatusDelivered:
 				success++
+			case models.DeliveryStatusBlocked:
+				blocked++
+				if markErr := m.repo.MarkUserUnreachable(ctx, botID, userID); markErr != nil {
+					log.Printf("Failed to mark user %d unreachable: %v", userID, markErr)
+				}
+			default:
+				failed++
+				log.Printf("Failed to broadcast to %d: %v", userID, err)
 			}
 
-			// Rate limiting: 40ms delay between messages (max ~25 msg/sec)
-			time.Sleep(40 * time.Millisecond)
+			// Rate limiting according to the owner's plan tier
+			time.Sleep(limits.MessageInterval)
+		}
+
+		if err := m.cache.ClearBroadcastProgress(ctx, token); err != nil {
+			log.Printf("Failed to clear broadcast progress: %v", err)
+		}
+
+		if err := m.repo.CreateBroadcast(ctx, &models.Broadcast{
+			BotID:          botID,
+			ComposerChatID: c.Sender().ID,
+			MessageID:      msgID,
+			Audience:       len(userIDs),
+			SuccessCount:   success,
+			BlockedCount:   blocked,
+			FailedCount:    failed,
+		}); err != nil {
+			log.Printf("Failed to record broadcast history: %v", err)
 		}
 
 		report := fmt.Sprintf(`📢 <b>Broadcast Report</b>
@@ -137,17 +203,34 @@ func (m *Manager) handleConfirmBroadcast(bot *telebot.Bot, token string, ownerCh
 👥 <b>Total Attempted:</b> %d`,
 			success, blocked, failed, len(userIDs))
 
+		m.publishEvent(events.Event{
+			Name:        events.BroadcastCompleted,
+			BotID:       botID,
+			OwnerChatID: ownerChat.ID,
+			Fields: map[string]string{
+				"success": strconv.Itoa(success),
+				"blocked": strconv.Itoa(blocked),
+				"failed":  strconv.Itoa(failed),
+			},
+		})
+
 		menu := &telebot.ReplyMarkup{}
 		btnStats := menu.Data("📊 Statistics", "child_stats")
 		btnBroadcast := menu.Data("📢 Broadcast", "child_broadcast")
 		btnSchedule := menu.Data("📅 Schedule Message", "schedule_menu")
 		btnSettings := menu.Data("⚙️ Settings", "child_settings")
-		menu.Inline(
+		rows := []telebot.Row{
 			menu.Row(btnStats),
 			menu.Row(btnBroadcast),
 			menu.Row(btnSchedule),
 			menu.Row(btnSettings),
-		)
+		}
+		if broadcastID != "" {
+			report += "\n🔗 <b>Links:</b> tracked — check clicks below"
+			btnClicks := menu.Data("🔗 Link Clicks", "broadcast_clicks", fmt.Sprintf("%s:%d", broadcastID, success))
+			rows = append([]telebot.Row{menu.Row(btnClicks)}, rows...)
+		}
+		menu.Inline(rows...)
 
 		return c.Send(report, menu, telebot.ModeHTML)
 	}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+
+	"github.com/Amr-9/botforge/internal/cache"
+)
+
+// handleCancelCommand clears every pending owner-side state - broadcast
+// mode, the schedule wizard, flow-framework flows and settings text
+// inputs - and tells the owner what was dropped. This is the escape
+// hatch for "the bot is stuck waiting for input".
+func (m *Manager) handleCancelCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		cancelled := m.cancelOwnerStates(ctx, token, c.Sender().ID)
+
+		if len(cancelled) == 0 {
+			return c.Reply("Nothing to cancel — no input was pending.")
+		}
+		return c.Reply("✅ <b>Cancelled</b> "+strings.Join(cancelled, ", ")+".", telebot.ModeHTML)
+	}
+}
+
+// cancelOwnerStates clears every active owner state and returns a label
+// for each one that was actually set
+func (m *Manager) cancelOwnerStates(ctx context.Context, token string, ownerID int64) []string {
+	var cancelled []string
+
+	if isBroadcast, err := m.cache.GetBroadcastMode(ctx, token, ownerID); err != nil {
+		log.Printf("Failed to check broadcast mode: %v", err)
+	} else if isBroadcast {
+		if err := m.cache.ClearBroadcastMode(ctx, token, ownerID); err != nil {
+			log.Printf("Failed to clear broadcast mode: %v", err)
+		} else {
+			cancelled = append(cancelled, "broadcast mode")
+		}
+	}
+
+	state, err := m.cache.GetUserState(ctx, token, ownerID)
+	if err != nil {
+		log.Printf("Failed to get owner state: %v", err)
+	}
+	scheduleState, err := m.cache.GetScheduleState(ctx, token, ownerID)
+	if err != nil {
+		log.Printf("Failed to get schedule state: %v", err)
+	}
+
+	if state == "" && scheduleState == "" {
+		return cancelled
+	}
+
+	// The whole owner flow hash goes at once, so a half-finished wizard
+	// cannot leave orphaned draft fields behind
+	if err := m.cache.ClearFlowScope(ctx, cache.FlowScopeOwner, token, ownerID); err != nil {
+		log.Printf("Failed to clear owner flow state: %v", err)
+		return cancelled
+	}
+
+	label := stateLabel(state)
+	if state != "" {
+		cancelled = append(cancelled, label)
+	}
+	if scheduleState != "" && label != "the schedule wizard" {
+		cancelled = append(cancelled, "the schedule wizard")
+	}
+	return cancelled
+}
+
+// stateLabel names an owner flow state for the /cancel confirmation
+func stateLabel(state string) string {
+	switch {
+	case strings.HasPrefix(state, "add_auto_reply"):
+		return "the add-reply flow"
+	case strings.HasPrefix(state, "add_custom_cmd"):
+		return "the add-command flow"
+	case state == "add_routing_rule":
+		return "the routing rule input"
+	case state == "add_short_link":
+		return "the short link input"
+	case state == "add_whitelist_user":
+		return "the whitelist user input"
+	case strings.HasPrefix(state, "schedule_"):
+		return "the schedule wizard"
+	case strings.HasPrefix(state, "set_"):
+		return "a settings input"
+	default:
+		return "a pending input"
+	}
+}
@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Command Scope Functions ====================
+
+// syncBotCommands publishes the bot's command lists to Telegram using
+// command scopes: regular users see /start plus the owner's custom
+// commands, while the owner's private chat additionally gets the admin
+// commands. Called on startup and whenever custom commands or the menu
+// button setting change.
+func (m *Manager) syncBotCommands(ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat, botID int64) {
+	userCommands := []telebot.Command{
+		{Text: "start", Description: "Start the bot"},
+		{Text: "help", Description: "How to use this bot"},
+	}
+
+	customCommands, err := m.repo.GetAutoReplies(ctx, botID, "command")
+	if err != nil {
+		log.Printf("Failed to load custom commands for sync (bot %d): %v", botID, err)
+	}
+	for _, cmd := range customCommands {
+		userCommands = append(userCommands, telebot.Command{
+			Text:        cmd.TriggerWord,
+			Description: commandDescription(cmd.Response, cmd.Caption),
+		})
+	}
+
+	if m.menuButtonEnabled(ctx, token, botID) {
+		if err := bot.SetCommands(userCommands, telebot.CommandScope{Type: telebot.CommandScopeAllPrivateChats}); err != nil {
+			log.Printf("Failed to set user commands (bot %d): %v", botID, err)
+		}
+		if err := setDefaultMenuButton(bot, telebot.MenuButtonCommands); err != nil {
+			log.Printf("Failed to set menu button (bot %d): %v", botID, err)
+		}
+	} else {
+		// Hide the command list (and with it the menu button) from users
+		if err := bot.DeleteCommands(telebot.CommandScope{Type: telebot.CommandScopeAllPrivateChats}); err != nil {
+			log.Printf("Failed to delete user commands (bot %d): %v", botID, err)
+		}
+		if err := setDefaultMenuButton(bot, telebot.MenuButtonDefault); err != nil {
+			log.Printf("Failed to reset menu button (bot %d): %v", botID, err)
+		}
+	}
+
+	// The owner always sees the full list in their own chat, regardless
+	// of the user-facing menu button setting
+	ownerCommands := append([]telebot.Command{
+		{Text: "start", Description: "Open the admin panel"},
+		{Text: "cancel", Description: "Cancel pending input"},
+	}, userCommands[1:]...)
+	ownerScope := telebot.CommandScope{Type: telebot.CommandScopeChat, ChatID: ownerChat.ID}
+	if err := bot.SetCommands(ownerCommands, ownerScope); err != nil {
+		log.Printf("Failed to set owner commands (bot %d): %v", botID, err)
+	}
+}
+
+// setDefaultMenuButton changes the bot's default menu button for all
+// private chats. telebot's SetMenuButton requires a concrete chat, so
+// this calls setChatMenuButton directly without a chat_id.
+func setDefaultMenuButton(bot *telebot.Bot, buttonType telebot.MenuButtonType) error {
+	params := map[string]interface{}{
+		"menu_button": telebot.MenuButton{Type: buttonType},
+	}
+	_, err := bot.Raw("setChatMenuButton", params)
+	return err
+}
+
+// commandDescription derives a Telegram command description (3-256 chars)
+// from an auto-reply's response text or media caption
+func commandDescription(response, caption string) string {
+	desc := strings.TrimSpace(response)
+	if desc == "" {
+		desc = strings.TrimSpace(caption)
+	}
+	if idx := strings.IndexByte(desc, '\n'); idx >= 0 {
+		desc = desc[:idx]
+	}
+	if len(desc) > 64 {
+		desc = desc[:61] + "..."
+	}
+	if len(desc) < 3 {
+		desc = "Custom command"
+	}
+	return desc
+}
+
+// menuButtonEnabled returns whether the commands menu button is shown to
+// users - cache first, DB fallback
+func (m *Manager) menuButtonEnabled(ctx context.Context, token string, botID int64) bool {
+	enabled, cacheHit, err := m.cache.GetMenuButtonEnabled(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting menu button setting: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - fallback to DB
+	botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+	if err != nil || botModel == nil {
+		return true
+	}
+
+	// Cache for next time
+	m.cache.SetMenuButtonEnabled(ctx, token, botModel.MenuButtonEnabled)
+	return botModel.MenuButtonEnabled
+}
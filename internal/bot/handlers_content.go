@@ -0,0 +1,272 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Message Content Logging ====================
+
+// Opt-in storage of incoming message text, encrypted at rest with a
+// per-bot derived key. Owners toggle it in Settings, pick a retention
+// window there, browse stored messages with /history and can purge
+// everything at any time. Bots that never opt in store nothing.
+
+const (
+	// historyPageSize is how many stored messages /history shows
+	historyPageSize = 20
+
+	// contentRetentionInterval is how often expired content is purged
+	contentRetentionInterval = 12 * time.Hour
+
+	// searchResultLimit is how many matches /search sends; each match
+	// is its own message replying to the original forward, so Telegram's
+	// reply quote doubles as the jump link into the conversation
+	searchResultLimit = 5
+)
+
+// contentLoggingEnabled reports whether message content logging is on,
+// cache-first with a DB fallback
+func (m *Manager) contentLoggingEnabled(ctx context.Context, token string, botID int64) bool {
+	enabled, cacheHit, err := m.cache.GetContentLogging(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return false
+	}
+	m.cache.SetContentLogging(ctx, token, botModel.ContentLoggingEnabled)
+	return botModel.ContentLoggingEnabled
+}
+
+// maybeSaveContent stores a forwarded message's text if the owner opted
+// into content logging. Only text is stored, never media.
+func (m *Manager) maybeSaveContent(ctx context.Context, token string, botID, userChatID int64, adminMsgID int, text string) {
+	if text == "" || !m.contentLoggingEnabled(ctx, token, botID) {
+		return
+	}
+	if err := m.repo.SaveMessageContent(ctx, botID, userChatID, adminMsgID, text); err != nil {
+		log.Printf("Failed to save message content for bot %d: %v", botID, err)
+	}
+}
+
+// handleHistoryCommand shows the owner the most recent stored messages,
+// decrypted, with the purge entry point underneath
+func (m *Manager) handleHistoryCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel == nil || !botModel.ContentLoggingEnabled {
+			return c.Reply("📜 Content logging is off. Enable it in ⚙️ Settings to keep a message history.")
+		}
+
+		messages, err := m.repo.GetMessageContents(ctx, botID, historyPageSize)
+		if err != nil {
+			log.Printf("Failed to load message history for bot %d: %v", botID, err)
+			return c.Reply("❌ Failed to load message history.")
+		}
+
+		total, _ := m.repo.GetMessageContentCount(ctx, botID)
+
+		clock := m.ownerClock(ctx, token, botID)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("📜 <b>Message History</b> (%d stored, kept %d days)\n\n", total, botModel.ContentRetentionDays))
+		if len(messages) == 0 {
+			sb.WriteString("<i>No messages stored yet.</i>")
+		} else {
+			for _, msg := range messages {
+				sb.WriteString(fmt.Sprintf("• %s <code>%d</code>: %s\n",
+					clock.DateTime(msg.CreatedAt), msg.UserChatID, html.EscapeString(contentSnippet(msg.Content))))
+			}
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnPurge := menu.Data("🧨 Purge History", "purge_content")
+		menu.Inline(menu.Row(btnPurge))
+
+		return c.Reply(sb.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// handleSearchCommand searches the owner's stored messages:
+// /search <text>
+//
+// Stored content is encrypted at rest, so matching happens in the
+// repository after decryption rather than in MySQL.
+func (m *Manager) handleSearchCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		search := strings.TrimSpace(c.Message().Payload)
+		if search == "" {
+			return c.Reply("Usage: /search <text>")
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		if !m.contentLoggingEnabled(ctx, token, botID) {
+			return c.Reply("📜 Content logging is off. Enable it in ⚙️ Settings to make messages searchable.")
+		}
+
+		matches, err := m.repo.SearchMessageContents(ctx, botID, search, searchResultLimit)
+		if err != nil {
+			log.Printf("Failed to search message contents for bot %d: %v", botID, err)
+			return c.Reply("❌ Search failed.")
+		}
+
+		if len(matches) == 0 {
+			return c.Reply(fmt.Sprintf("🔍 No stored messages match <b>%s</b>.", html.EscapeString(search)), telebot.ModeHTML)
+		}
+
+		if err := c.Reply(fmt.Sprintf("🔍 <b>%d match(es) for %s</b> (newest first):", len(matches), html.EscapeString(search)), telebot.ModeHTML); err != nil {
+			return err
+		}
+
+		// One message per match, quoting the original forward so the
+		// owner can jump straight into the conversation
+		clock := m.ownerClock(ctx, token, botID)
+		for _, match := range matches {
+			result := fmt.Sprintf("• %s <code>%d</code>: %s",
+				clock.DateTime(match.CreatedAt), match.UserChatID, html.EscapeString(contentSnippet(match.Content)))
+			opts := &telebot.SendOptions{
+				ReplyTo:           &telebot.Message{ID: match.AdminMsgID, Chat: ownerChat},
+				AllowWithoutReply: true, // the original forward may be gone
+				ParseMode:         telebot.ModeHTML,
+			}
+			if _, err := bot.Send(ownerChat, result, opts); err != nil {
+				log.Printf("Failed to send search result for bot %d: %v", botID, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// contentSnippet shortens a stored message for the history list
+func contentSnippet(text string) string {
+	runes := []rune(text)
+	if len(runes) > 80 {
+		return string(runes[:77]) + "..."
+	}
+	return text
+}
+
+// handlePurgeContentBtn shows the confirmation prompt before deleting
+// all stored message content
+func (m *Manager) handlePurgeContentBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("⚠️ Yes, purge everything", "purge_content_execute")
+		btnCancel := menu.Data("❌ Cancel", "child_settings")
+		menu.Inline(menu.Row(btnConfirm), menu.Row(btnCancel))
+
+		msg := `🧨 <b>Purge Message History</b>
+
+This will permanently delete every stored message for this bot. Logging stays on unless you also turn it off in Settings.
+
+<b>This cannot be undone.</b>`
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handlePurgeContentExecute deletes all stored content after confirmation
+func (m *Manager) handlePurgeContentExecute(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		deleted, err := m.repo.PurgeMessageContents(ctx, botID)
+		if err != nil {
+			log.Printf("Failed to purge message contents for bot %d: %v", botID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to purge history!", ShowAlert: true})
+		}
+
+		if err := m.repo.RecordAudit(ctx, botID, c.Sender().ID, models.AuditActionPurgeContent, ""); err != nil {
+			log.Printf("Failed to record audit log: %v", err)
+		}
+		log.Printf("Purged %d stored messages for bot %d (requested by %d)", deleted, botID, c.Sender().ID)
+
+		c.Respond(&telebot.CallbackResponse{Text: "✅ History purged"})
+		return c.Edit(fmt.Sprintf("🧨 <b>Message History Purged</b>\n\nDeleted %d stored messages.", deleted), telebot.ModeHTML)
+	}
+}
+
+// ==================== Retention Loop ====================
+
+// StartContentRetention launches the background loop that deletes stored
+// content older than each bot's retention window
+func (m *Manager) StartContentRetention() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.retentionCancel = cancel
+	m.mu.Unlock()
+	go m.runContentRetentionLoop(ctx)
+}
+
+// StopContentRetention stops the retention loop
+func (m *Manager) StopContentRetention() {
+	m.mu.Lock()
+	cancel := m.retentionCancel
+	m.retentionCancel = nil
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func (m *Manager) runContentRetentionLoop(ctx context.Context) {
+	ticker := time.NewTicker(contentRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := m.repo.PurgeExpiredMessageContents(ctx)
+			if err != nil {
+				log.Printf("Content retention purge failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Content retention: purged %d expired messages", deleted)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
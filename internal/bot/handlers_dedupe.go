@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Dedupe Handlers ====================
+
+// handleSetDedupeWindowBtn initiates state to change the dedupe window
+func (m *Manager) handleSetDedupeWindowBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_dedupe_window"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "OFF"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.DedupeWindowMinutes > 0 {
+			current = fmt.Sprintf("%d minutes", currentBot.DedupeWindowMinutes)
+		}
+
+		msg := fmt.Sprintf(`♻️ <b>Duplicate Suppression</b>
+
+<b>Current:</b> %s
+
+When a user resends exactly the same text within the window, the repeat is accepted but not forwarded to you again.
+
+Send the window in minutes (1-1440), or <code>0</code> to turn it off.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// dedupeWindow returns the dedupe window in minutes (0 = off),
+// cache-first with a DB fallback
+func (m *Manager) dedupeWindow(ctx context.Context, token string, botID int64) int {
+	minutes, cacheHit, err := m.cache.GetDedupeWindow(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return minutes
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetDedupeWindow(ctx, token, botModel.DedupeWindowMinutes)
+	return botModel.DedupeWindowMinutes
+}
+
+// isDuplicateMessage reports whether this text repeats the user's previous
+// message inside the configured window. Errors count as not duplicate so a
+// cache outage never drops messages.
+func (m *Manager) isDuplicateMessage(ctx context.Context, token string, botID, userChatID int64, text string) bool {
+	if text == "" {
+		return false
+	}
+
+	window := m.dedupeWindow(ctx, token, botID)
+	if window <= 0 {
+		return false
+	}
+
+	duplicate, err := m.cache.IsDuplicateMessage(ctx, token, userChatID, text, time.Duration(window)*time.Minute)
+	if err != nil {
+		log.Printf("Error checking duplicate message: %v", err)
+		return false
+	}
+	return duplicate
+}
@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// How often the digest loop wakes up to check whether a digest is due.
+const digestTickInterval = 1 * time.Minute
+
+// Most users listed as expand buttons in a single digest summary.
+const digestMaxUsers = 10
+
+// ==================== Digest Functions ====================
+
+// runDigestLoop periodically flushes queued user messages as a summary to
+// the owner. The interval is re-read every tick so setting changes apply
+// without a restart. Runs until ctx is cancelled by StopBot.
+func (m *Manager) runDigestLoop(ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat, botID int64) {
+	ticker := time.NewTicker(digestTickInterval)
+	defer ticker.Stop()
+
+	lastFlush := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			interval := m.digestInterval(ctx, token, botID)
+			if interval <= 0 {
+				lastFlush = time.Now()
+				continue
+			}
+			if time.Since(lastFlush) < time.Duration(interval)*time.Minute {
+				continue
+			}
+			m.flushDigest(ctx, bot, token, ownerChat)
+			lastFlush = time.Now()
+		}
+	}
+}
+
+// flushDigest sends the "N new messages from M users" summary with a button
+// per conversation, then resets the counter
+func (m *Manager) flushDigest(ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat) {
+	count, users, err := m.cache.GetDigestState(ctx, token)
+	if err != nil {
+		log.Printf("Failed to read digest state: %v", err)
+		return
+	}
+	if count == 0 || len(users) == 0 {
+		return
+	}
+
+	menu := &telebot.ReplyMarkup{}
+	var rows []telebot.Row
+	for i, userChatID := range users {
+		if i >= digestMaxUsers {
+			break
+		}
+		pending, err := m.cache.CountDigestMessages(ctx, token, userChatID)
+		if err != nil || pending == 0 {
+			continue
+		}
+		label := fmt.Sprintf("👤 %d (%d)", userChatID, pending)
+		btn := menu.Data(label, "digest_expand", strconv.FormatInt(userChatID, 10))
+		rows = append(rows, menu.Row(btn))
+	}
+	if len(rows) == 0 {
+		m.cache.ResetDigestCount(ctx, token)
+		return
+	}
+	menu.Inline(rows...)
+
+	summary := fmt.Sprintf("📬 <b>%d new message(s) from %d user(s)</b>\n\nTap a conversation to read it:", count, len(users))
+	if _, err := bot.Send(ownerChat, summary, menu, telebot.ModeHTML); err != nil {
+		log.Printf("Failed to send digest summary: %v", err)
+		return
+	}
+
+	if err := m.cache.ResetDigestCount(ctx, token); err != nil {
+		log.Printf("Failed to reset digest count: %v", err)
+	}
+}
+
+// handleDigestExpand forwards a user's queued messages when the owner taps
+// their button in a digest summary
+func (m *Manager) handleDigestExpand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid conversation!", ShowAlert: true})
+		}
+
+		msgIDs, err := m.cache.PopDigestMessages(ctx, token, userChatID)
+		if err != nil {
+			log.Printf("Failed to pop digest messages for user %d: %v", userChatID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to load messages!", ShowAlert: true})
+		}
+		if len(msgIDs) == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "No pending messages."})
+		}
+
+		forwarded := 0
+		var lastSent *telebot.Message
+		for _, msgID := range msgIDs {
+			stored := telebot.StoredMessage{
+				MessageID: strconv.Itoa(msgID),
+				ChatID:    userChatID,
+			}
+			sent, err := bot.Forward(ownerChat, stored)
+			if err != nil {
+				log.Printf("Failed to forward digest message %d from user %d: %v", msgID, userChatID, err)
+				continue
+			}
+			m.linkAdminMessage(ctx, token, botID, sent.ID, userChatID)
+			forwarded++
+			lastSent = sent
+		}
+
+		// Attach quick actions to the last message of the batch so the
+		// owner can act on the conversation, same as live forwarding
+		if lastSent != nil {
+			if _, err := bot.EditReplyMarkup(lastSent, quickActionsMenu()); err != nil {
+				log.Printf("Failed to attach quick actions to message %d: %v", lastSent.ID, err)
+			}
+		}
+
+		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Showing %d message(s)", forwarded)})
+	}
+}
+
+// shouldDigest reports whether a user message should be queued for the next
+// digest instead of being forwarded right away
+func (m *Manager) shouldDigest(ctx context.Context, token string, botID int64, text string) bool {
+	interval := m.digestInterval(ctx, token, botID)
+	if interval <= 0 {
+		return false
+	}
+	return !m.isUrgentMessage(ctx, token, botID, text)
+}
+
+// digestInterval returns the digest interval in minutes (0 = off),
+// cache-first with a DB fallback
+func (m *Manager) digestInterval(ctx context.Context, token string, botID int64) int {
+	minutes, cacheHit, err := m.cache.GetDigestInterval(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return minutes
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetDigestInterval(ctx, token, botModel.DigestIntervalMinutes)
+	return botModel.DigestIntervalMinutes
+}
+
+// isUrgentMessage checks the text against the owner's urgent keywords,
+// which always bypass digest mode
+func (m *Manager) isUrgentMessage(ctx context.Context, token string, botID int64, text string) bool {
+	if text == "" {
+		return false
+	}
+
+	keywords, cacheHit, err := m.cache.GetDigestKeywords(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if !cacheHit {
+		// Cache miss - load from DB and cache it
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel == nil {
+			return false
+		}
+		keywords = botModel.DigestUrgentKeywords
+		m.cache.SetDigestKeywords(ctx, token, keywords)
+	}
+	if keywords == "" {
+		return false
+	}
+
+	lowerText := strings.ToLower(text)
+	for _, keyword := range strings.Split(keywords, ",") {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && strings.Contains(lowerText, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Content Filter Handlers ====================
+
+// Filter actions, stored per bot and per filter pack
+const (
+	FilterActionOff  = "off"  // Filter disabled
+	FilterActionDrop = "drop" // Silently drop the message
+	FilterActionWarn = "warn" // Drop and warn the sender
+	FilterActionFlag = "flag" // Forward, but mark the message as flagged
+)
+
+// filterActionCycle is the order the settings button steps through
+var filterActionCycle = []string{FilterActionOff, FilterActionDrop, FilterActionWarn, FilterActionFlag}
+
+// contentFilter is one stage of the inbound filter pipeline. Built-in packs
+// and custom keyword filters share this shape.
+type contentFilter struct {
+	name    string
+	matches func(text string) bool
+}
+
+// profanityWords is the built-in multi-language profanity pack, matched on
+// word boundaries after lowercasing
+var profanityWords = map[string]bool{
+	// English
+	"fuck": true, "shit": true, "bitch": true, "asshole": true, "bastard": true,
+	"dick": true, "cunt": true, "slut": true, "whore": true,
+	// Spanish
+	"puta": true, "mierda": true, "cabron": true, "pendejo": true,
+	// German
+	"scheisse": true, "arschloch": true, "fotze": true,
+	// Russian (transliterated)
+	"suka": true, "blyat": true, "pizdec": true,
+}
+
+// wordSplitter breaks a message into candidate words for the profanity pack
+var wordSplitter = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// linkPattern matches URLs and Telegram invite links
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|telegram\.me/|joinchat)`)
+
+// containsProfanity reports whether any word of the text is in the built-in
+// profanity pack
+func containsProfanity(text string) bool {
+	for _, word := range wordSplitter.Split(strings.ToLower(text), -1) {
+		if profanityWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsLink reports whether the text carries a URL or invite link
+func containsLink(text string) bool {
+	return linkPattern.MatchString(text)
+}
+
+// checkContentFilters runs the inbound text through the enabled filter
+// packs and returns the configured action of the first match, together
+// with the filter's name. Returns ("off", "") when nothing matches.
+// Uses the cache-first pattern with a DB fallback.
+func (m *Manager) checkContentFilters(ctx context.Context, token string, botID int64, text string) (string, string) {
+	if text == "" {
+		return FilterActionOff, ""
+	}
+
+	profanityAction, profanityHit, err := m.cache.GetFilterAction(ctx, token, "profanity")
+	if err != nil {
+		log.Printf("Cache error getting profanity filter action: %v", err)
+	}
+	linksAction, linksHit, err := m.cache.GetFilterAction(ctx, token, "links")
+	if err != nil {
+		log.Printf("Cache error getting links filter action: %v", err)
+	}
+
+	if !profanityHit || !linksHit {
+		// Fallback to DB and backfill the cache
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel != nil {
+			profanityAction = botModel.FilterProfanityAction
+			linksAction = botModel.FilterLinksAction
+			m.cache.SetFilterAction(ctx, token, "profanity", profanityAction)
+			m.cache.SetFilterAction(ctx, token, "links", linksAction)
+		}
+	}
+
+	pipeline := []struct {
+		filter contentFilter
+		action string
+	}{
+		{contentFilter{name: "profanity", matches: containsProfanity}, profanityAction},
+		{contentFilter{name: "links", matches: containsLink}, linksAction},
+	}
+
+	for _, stage := range pipeline {
+		if stage.action == "" || stage.action == FilterActionOff {
+			continue
+		}
+		if stage.filter.matches(text) {
+			return stage.action, stage.filter.name
+		}
+	}
+
+	return FilterActionOff, ""
+}
+
+// handleFiltersMenu shows the content filter settings
+func (m *Manager) handleFiltersMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		profanityAction := FilterActionOff
+		linksAction := FilterActionOff
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel != nil {
+			if botModel.FilterProfanityAction != "" {
+				profanityAction = botModel.FilterProfanityAction
+			}
+			if botModel.FilterLinksAction != "" {
+				linksAction = botModel.FilterLinksAction
+			}
+		}
+
+		msg := `🧹 <b>Content Filters</b>
+
+Filter what users may send before it reaches you.
+
+<b>Actions:</b>
+• <b>drop</b> — silently discard the message
+• <b>warn</b> — discard and warn the sender
+• <b>flag</b> — forward it marked as flagged
+
+Tap a filter to cycle its action:`
+
+		menu := &telebot.ReplyMarkup{}
+		btnProfanity := menu.Data(fmt.Sprintf("🤬 Profanity [%s]", strings.ToUpper(profanityAction)), "cycle_filter", "profanity")
+		btnLinks := menu.Data(fmt.Sprintf("🔗 Links & Invites [%s]", strings.ToUpper(linksAction)), "cycle_filter", "links")
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		menu.Inline(
+			menu.Row(btnProfanity),
+			menu.Row(btnLinks),
+			menu.Row(btnBack),
+		)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleCycleFilter advances the named filter to its next action
+func (m *Manager) handleCycleFilter(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		filterName := c.Callback().Data
+		if filterName != "profanity" && filterName != "links" {
+			return c.Respond(&telebot.CallbackResponse{Text: "Unknown filter!", ShowAlert: true})
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		current := FilterActionOff
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel != nil {
+			if filterName == "profanity" && botModel.FilterProfanityAction != "" {
+				current = botModel.FilterProfanityAction
+			}
+			if filterName == "links" && botModel.FilterLinksAction != "" {
+				current = botModel.FilterLinksAction
+			}
+		}
+
+		next := nextFilterAction(current)
+		if err := m.repo.UpdateBotFilterAction(ctx, botID, filterName, next); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to update!", ShowAlert: true})
+		}
+
+		// Cache the new action so enforcement picks it up immediately
+		if err := m.cache.SetFilterAction(ctx, token, filterName, next); err != nil {
+			log.Printf("Failed to cache filter action: %v", err)
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Filter set to %s", strings.ToUpper(next))})
+		return m.handleFiltersMenu(bot, token, ownerChat)(c)
+	}
+}
+
+// isFilterEnabled reports whether a stored action actually filters anything
+func isFilterEnabled(action string) bool {
+	return action != "" && action != FilterActionOff
+}
+
+// nextFilterAction returns the action following current in the cycle
+func nextFilterAction(current string) string {
+	for i, action := range filterActionCycle {
+		if action == current {
+			return filterActionCycle[(i+1)%len(filterActionCycle)]
+		}
+	}
+	return FilterActionOff
+}
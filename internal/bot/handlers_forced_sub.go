@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Amr-9/botforge/internal/models"
 	"gopkg.in/telebot.v3"
@@ -23,7 +24,7 @@ func (m *Manager) checkForcedSubscription(ctx context.Context, c telebot.Context
 	}
 
 	if !cacheHit {
-		botModel, err := m.repo.GetBotByToken(ctx, token)
+		botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
 		if err != nil {
 			log.Printf("Error getting bot for forced sub check: %v", err)
 			return true, nil, "", nil // Allow on error
@@ -43,6 +44,24 @@ func (m *Manager) checkForcedSubscription(ctx context.Context, c telebot.Context
 		return true, nil, "", nil
 	}
 
+	// VIPs and testers on the exemption list bypass the check entirely
+	if exempt, err := m.repo.IsForcedSubExempt(ctx, botID, userID); err != nil {
+		log.Printf("Error checking forced sub exemption: %v", err)
+	} else if exempt {
+		return true, nil, "", nil
+	}
+
+	// Grace period: the user's first N messages are allowed before
+	// enforcement kicks in
+	if grace := m.forcedSubGraceCount(ctx, token, botID); grace > 0 {
+		count, err := m.repo.GetUserMessageCount(ctx, botID, userID)
+		if err != nil {
+			log.Printf("Error counting messages for grace period: %v", err)
+		} else if count < int64(grace) {
+			return true, nil, "", nil
+		}
+	}
+
 	// Get required channels from DB
 	channels, err := m.repo.GetForcedChannels(ctx, botID)
 	if err != nil {
@@ -82,14 +101,14 @@ func (m *Manager) checkForcedSubscription(ctx context.Context, c telebot.Context
 	}
 
 	// Build blocked message with join buttons
-	menu, blockedMsg := m.buildSubscriptionRequiredMessage(ctx, token, notSubscribed)
+	menu, blockedMsg := m.buildSubscriptionRequiredMessage(ctx, botID, notSubscribed)
 	return false, menu, blockedMsg, nil
 }
 
 // buildSubscriptionRequiredMessage creates the message and buttons for non-subscribers
-func (m *Manager) buildSubscriptionRequiredMessage(ctx context.Context, token string, channels []models.ForcedChannel) (*telebot.ReplyMarkup, string) {
+func (m *Manager) buildSubscriptionRequiredMessage(ctx context.Context, botID int64, channels []models.ForcedChannel) (*telebot.ReplyMarkup, string) {
 	// Get custom message if set
-	botModel, _ := m.repo.GetBotByToken(ctx, token)
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
 	customMsg := ""
 	if botModel != nil && botModel.ForcedSubMessage != "" {
 		customMsg = botModel.ForcedSubMessage
@@ -190,6 +209,13 @@ func (m *Manager) handleForcedSubMenu(bot *telebot.Bot, token string, ownerChat
 					title = fmt.Sprintf("Channel %d", ch.ChannelID)
 				}
 				msgBuilder.WriteString(fmt.Sprintf("%s 📺 %s\n", prefix, title))
+				if growth := m.channelGrowthLine(ctx, botID, ch.ChannelID); growth != "" {
+					cont := "│"
+					if i == len(channels)-1 {
+						cont = " "
+					}
+					msgBuilder.WriteString(fmt.Sprintf("%s    %s\n", cont, growth))
+				}
 			}
 		} else {
 			msgBuilder.WriteString("<i>No channels configured</i>\n")
@@ -208,6 +234,13 @@ func (m *Manager) handleForcedSubMenu(bot *telebot.Bot, token string, ownerChat
 		btnAddChannel := menu.Data("➕ Add Channel", "add_forced_channel")
 		btnListChannels := menu.Data(fmt.Sprintf("📋 Manage Channels (%d)", len(channels)), "list_forced_channels")
 		btnSetMessage := menu.Data("📝 Set Custom Message", "set_forced_sub_msg")
+
+		graceLabel := "🎟 Grace Period: Off"
+		if botModel != nil && botModel.ForcedSubGraceCount > 0 {
+			graceLabel = fmt.Sprintf("🎟 Grace Period: %d msgs", botModel.ForcedSubGraceCount)
+		}
+		btnGrace := menu.Data(graceLabel, "set_fsub_grace")
+		btnExempt := menu.Data("⭐ Exempt Users", "fsub_exemptions")
 		btnBack := menu.Data("« Back to Settings", "back_to_settings")
 
 		menu.Inline(
@@ -215,6 +248,8 @@ func (m *Manager) handleForcedSubMenu(bot *telebot.Bot, token string, ownerChat
 			menu.Row(btnAddChannel),
 			menu.Row(btnListChannels),
 			menu.Row(btnSetMessage),
+			menu.Row(btnGrace),
+			menu.Row(btnExempt),
 			menu.Row(btnBack),
 		)
 
@@ -253,6 +288,9 @@ func (m *Manager) handleToggleForcedSub(bot *telebot.Bot, token string, ownerCha
 		// Invalidate cache
 		m.cache.InvalidateForcedSubEnabled(ctx, token)
 
+		// Update allowed_updates to match the new feature set
+		m.refreshWebhook(ctx, bot, token, botID)
+
 		// Show feedback
 		msg := "Forced subscription disabled"
 		if newState {
@@ -273,7 +311,7 @@ func (m *Manager) handleAddForcedChannel(bot *telebot.Bot, token string, ownerCh
 		}
 
 		ctx := context.Background()
-		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_forced_channel"); err != nil {
+		if err := m.startFlow(ctx, token, c.Sender().ID, "add_forced_channel"); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
 		}
 
@@ -432,12 +470,22 @@ func (m *Manager) processAddForcedChannel(ctx context.Context, c telebot.Context
 		return c.Reply("⚠️ This channel is already in the list.")
 	}
 
-	// For private channels, try to get invite link
+	// For private channels, create a dedicated invite link owned by this
+	// bot so the join button always works and joins can be attributed
 	if channelUsername == "" {
-		// Try to get or create invite link
-		chat, err := bot.ChatByID(channelID)
-		if err == nil && chat.InviteLink != "" {
-			inviteLink = chat.InviteLink
+		link, err := bot.CreateInviteLink(&telebot.Chat{ID: channelID}, &telebot.ChatInviteLink{
+			Name: fmt.Sprintf("via @%s", bot.Me.Username),
+		})
+		if err == nil && link != nil {
+			inviteLink = link.InviteLink
+		} else {
+			// Bot may lack the "invite users" admin right - fall back to
+			// the channel's primary invite link if one is exposed
+			log.Printf("CreateInviteLink failed for channel %d: %v", channelID, err)
+			chat, err := bot.ChatByID(channelID)
+			if err == nil && chat.InviteLink != "" {
+				inviteLink = chat.InviteLink
+			}
 		}
 	}
 
@@ -541,7 +589,7 @@ func (m *Manager) handleRemoveForcedChannel(bot *telebot.Bot, token string, owne
 		m.mu.RUnlock()
 
 		// Delete from DB
-		if err := m.repo.DeleteForcedChannel(ctx, botID, channelID); err != nil {
+		if err := m.repo.DeleteForcedChannel(ctx, botID, channelID, ownerChat.ID); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Error removing channel", ShowAlert: true})
 		}
 
@@ -563,7 +611,7 @@ func (m *Manager) handleSetForcedSubMsg(bot *telebot.Bot, token string, ownerCha
 		}
 
 		ctx := context.Background()
-		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_forced_sub_message"); err != nil {
+		if err := m.startFlow(ctx, token, c.Sender().ID, "set_forced_sub_message"); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
 		}
 
@@ -671,7 +719,7 @@ func (m *Manager) handleCheckSubscription(bot *telebot.Bot, token string, ownerC
 			c.Respond(&telebot.CallbackResponse{Text: "✅ Subscription verified! You can now use the bot.", ShowAlert: true})
 
 			// Show welcome message
-			botModel, _ := m.repo.GetBotByToken(ctx, token)
+			botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
 			welcomeMsg := "👋 Welcome! You can now send me your message."
 			if botModel != nil && botModel.StartMessage != "" {
 				welcomeMsg = botModel.StartMessage
@@ -685,13 +733,294 @@ func (m *Manager) handleCheckSubscription(bot *telebot.Bot, token string, ownerC
 	}
 }
 
-// processForcedSubState processes multi-step flow states for forced subscription
-func (m *Manager) processForcedSubState(ctx context.Context, c telebot.Context, bot *telebot.Bot, token string, state string) (bool, error) {
-	switch state {
-	case "add_forced_channel":
-		return true, m.processAddForcedChannel(ctx, c, bot, token)
-	case "set_forced_sub_message":
-		return true, m.processSetForcedSubMessage(ctx, c, token)
+// addForcedChannelFlow wraps the single-step add-channel conversation
+func (m *Manager) addForcedChannelFlow() flow {
+	return flow{
+		name:  "add_forced_channel",
+		entry: "add_forced_channel",
+		steps: map[string]flowStep{
+			"add_forced_channel": {
+				handle: func(ctx context.Context, c telebot.Context, fs *flowSession) error {
+					return m.processAddForcedChannel(ctx, c, fs.bot, fs.token)
+				},
+			},
+		},
+	}
+}
+
+// forcedSubMessageFlow wraps the single-step custom message conversation
+func (m *Manager) forcedSubMessageFlow() flow {
+	return flow{
+		name:  "set_forced_sub_message",
+		entry: "set_forced_sub_message",
+		steps: map[string]flowStep{
+			"set_forced_sub_message": {
+				handle: func(ctx context.Context, c telebot.Context, fs *flowSession) error {
+					return m.processSetForcedSubMessage(ctx, c, fs.token)
+				},
+			},
+		},
+	}
+}
+
+// forcedSubGraceCount returns how many free messages a user may send
+// before forced subscription is enforced (0 = enforce immediately),
+// cache-first with a DB fallback
+func (m *Manager) forcedSubGraceCount(ctx context.Context, token string, botID int64) int {
+	count, cacheHit, err := m.cache.GetForcedSubGraceCount(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return count
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetForcedSubGraceCount(ctx, token, botModel.ForcedSubGraceCount)
+	return botModel.ForcedSubGraceCount
+}
+
+// handleSetFsubGraceBtn initiates state to change the grace period
+func (m *Manager) handleSetFsubGraceBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_fsub_grace"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "forced_sub_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := 0
+		if botModel, err := m.repo.GetBotByToken(ctx, token); err == nil && botModel != nil {
+			current = botModel.ForcedSubGraceCount
+		}
+
+		msg := fmt.Sprintf(`🎟 <b>Grace Period</b>
+
+<b>Current:</b> %d free messages
+
+New users may send this many messages before the subscription check kicks in, so they can try the bot first.
+
+Send a number between 0 and 100 (<code>0</code> = enforce immediately).`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// processSetFsubGrace stores the grace period sent by the owner
+func (m *Manager) processSetFsubGrace(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	count, err := strconv.Atoi(strings.TrimSpace(c.Text()))
+	if err != nil || count < 0 || count > 100 {
+		return c.Reply("⚠️ Please send a number between 0 and 100.")
+	}
+
+	if err := m.repo.UpdateForcedSubGraceCount(ctx, botID, count); err != nil {
+		return c.Reply("❌ Failed to update grace period.")
+	}
+
+	// Cache the new value so enforcement picks it up immediately
+	m.cache.SetForcedSubGraceCount(ctx, token, count)
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if count == 0 {
+		return c.Reply("✅ <b>Grace Period Disabled!</b>\n\nSubscription is enforced from the first message.", telebot.ModeHTML)
+	}
+	return c.Reply(fmt.Sprintf("✅ <b>Grace Period Updated!</b>\n\nUsers may send %d messages before subscription is enforced.", count), telebot.ModeHTML)
+}
+
+// handleFsubExemptions lists exempted users and initiates state to edit
+// the list
+func (m *Manager) handleFsubExemptions(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_fsub_exempt"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		exemptions, err := m.repo.GetForcedSubExemptions(ctx, botID)
+		if err != nil {
+			log.Printf("Failed to get forced sub exemptions: %v", err)
+		}
+
+		var msgBuilder strings.Builder
+		msgBuilder.WriteString("⭐ <b>Exempt Users</b>\n\n")
+		msgBuilder.WriteString("Exempted users (VIPs, testers) are never asked to subscribe.\n\n")
+
+		if len(exemptions) > 0 {
+			msgBuilder.WriteString(fmt.Sprintf("<b>Exempted (%d):</b>\n", len(exemptions)))
+			for i, ex := range exemptions {
+				prefix := "├"
+				if i == len(exemptions)-1 {
+					prefix = "└"
+				}
+				msgBuilder.WriteString(fmt.Sprintf("%s <code>%d</code>\n", prefix, ex.UserChatID))
+			}
+			msgBuilder.WriteString("\n")
+		} else {
+			msgBuilder.WriteString("<i>No exempted users</i>\n\n")
+		}
+
+		msgBuilder.WriteString("Send a user ID to add it to the list, or the ID of an exempted user to remove it.")
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "forced_sub_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		return c.Edit(msgBuilder.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// processSetFsubExempt toggles the exemption of the user ID sent by the
+// owner
+func (m *Manager) processSetFsubExempt(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Text()), 10, 64)
+	if err != nil || userChatID <= 0 {
+		return c.Reply("⚠️ Please send a numeric user ID.")
+	}
+
+	exempt, err := m.repo.IsForcedSubExempt(ctx, botID, userChatID)
+	if err != nil {
+		return c.Reply("❌ Failed to update exemption list.")
+	}
+
+	if exempt {
+		if err := m.repo.RemoveForcedSubExemption(ctx, botID, userChatID); err != nil {
+			return c.Reply("❌ Failed to update exemption list.")
+		}
+		// Drop the verification cache so the check applies again
+		m.cache.ClearUserSubVerified(ctx, token, userChatID)
+		m.cache.ClearUserState(ctx, token, c.Sender().ID)
+		return c.Reply(fmt.Sprintf("✅ <b>Exemption Removed!</b>\n\nUser <code>%d</code> is subject to the subscription check again.", userChatID), telebot.ModeHTML)
+	}
+
+	if err := m.repo.AddForcedSubExemption(ctx, botID, userChatID); err != nil {
+		return c.Reply("❌ Failed to update exemption list.")
+	}
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+	return c.Reply(fmt.Sprintf("✅ <b>User Exempted!</b>\n\nUser <code>%d</code> will never be asked to subscribe.", userChatID), telebot.ModeHTML)
+}
+
+// ==================== Channel Growth Tracking ====================
+
+// How often the member counts of forced channels are sampled.
+const channelStatsTickInterval = 6 * time.Hour
+
+// How many samples the growth sparkline in the menu is drawn from.
+const channelStatsSparkSamples = 12
+
+// runChannelStatsLoop periodically records the member count of each forced
+// channel so owners can see how their bot drives channel growth. One sample
+// is taken right after startup, then every tick. Runs until ctx is
+// cancelled by StopBot.
+func (m *Manager) runChannelStatsLoop(ctx context.Context, bot *telebot.Bot, token string, botID int64) {
+	ticker := time.NewTicker(channelStatsTickInterval)
+	defer ticker.Stop()
+
+	m.recordChannelMemberCounts(ctx, bot, botID)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.recordChannelMemberCounts(ctx, bot, botID)
+		}
+	}
+}
+
+// recordChannelMemberCounts samples the member count of every forced
+// channel via getChatMemberCount
+func (m *Manager) recordChannelMemberCounts(ctx context.Context, bot *telebot.Bot, botID int64) {
+	channels, err := m.repo.GetForcedChannels(ctx, botID)
+	if err != nil {
+		log.Printf("Failed to get channels for member count sampling: %v", err)
+		return
+	}
+
+	for _, channel := range channels {
+		count, err := bot.Len(&telebot.Chat{ID: channel.ChannelID})
+		if err != nil {
+			// Bot might not be admin anymore - log and skip this channel
+			log.Printf("Failed to get member count for channel %d: %v", channel.ChannelID, err)
+			continue
+		}
+		if err := m.repo.RecordChannelMemberCount(ctx, botID, channel.ChannelID, count); err != nil {
+			log.Printf("Failed to record member count for channel %d: %v", channel.ChannelID, err)
+		}
+	}
+}
+
+// channelGrowthLine renders a sparkline summary of a channel's recent
+// member counts, or "" when no samples have been recorded yet
+func (m *Manager) channelGrowthLine(ctx context.Context, botID, channelID int64) string {
+	history, err := m.repo.GetChannelMemberHistory(ctx, botID, channelID, channelStatsSparkSamples)
+	if err != nil {
+		log.Printf("Failed to get member history for channel %d: %v", channelID, err)
+		return ""
+	}
+	if len(history) == 0 {
+		return ""
+	}
+
+	counts := make([]int, len(history))
+	for i, sample := range history {
+		counts[i] = sample.MemberCount
+	}
+
+	line := fmt.Sprintf("📈 %s %d members", sparkline(counts), counts[len(counts)-1])
+	if delta := counts[len(counts)-1] - counts[0]; len(counts) > 1 && delta != 0 {
+		line += fmt.Sprintf(" (%+d)", delta)
+	}
+	return line
+}
+
+// sparkline renders values as a row of block characters scaled between the
+// minimum and maximum of the series
+func sparkline(values []int) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > min {
+			idx = (v - min) * (len(blocks) - 1) / (max - min)
+		}
+		sb.WriteRune(blocks[idx])
 	}
-	return false, nil
+	return sb.String()
 }
@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Help Functions ====================
+
+// replyCommandDocs documents the commands the owner can send as a reply
+// to a forwarded user message (dispatched in handleAdminReply)
+var replyCommandDocs = []struct {
+	name string
+	desc string
+}{
+	{"ban", "Ban the user from messaging the bot"},
+	{"info", "Show the user's profile and message history"},
+	{"delete", "Delete the copy delivered to the user"},
+	{"erase", "Permanently erase all of the user's data"},
+}
+
+// handleHelpCommand serves /help with context-aware documentation: owners
+// get the admin command and reply-syntax reference plus their current
+// configuration, users get the commands available to them. Both lists are
+// built from the registered features rather than hardcoded.
+func (m *Manager) handleHelpCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		if c.Sender().ID == ownerChat.ID {
+			return c.Reply(m.buildOwnerHelp(ctx, token, botID), telebot.ModeHTML)
+		}
+		return c.Reply(m.buildUserHelp(ctx, token, botID), telebot.ModeHTML)
+	}
+}
+
+// buildOwnerHelp assembles the owner's command reference from the
+// registered commands, reply commands and the settings registry
+func (m *Manager) buildOwnerHelp(ctx context.Context, token string, botID int64) string {
+	var sb strings.Builder
+	sb.WriteString("📖 <b>Owner Help</b>\n\n")
+
+	sb.WriteString("<b>Commands:</b>\n")
+	sb.WriteString("/start - Open the admin panel\n")
+	sb.WriteString("/help - Show this reference\n")
+	sb.WriteString("/history - Browse stored messages (if content logging is on)\n")
+	sb.WriteString("/search - Search stored messages, e.g. <code>/search refund</code>\n")
+	sb.WriteString("/spam - Review messages held by the spam filter\n")
+	for _, cmd := range m.customCommandDocs(ctx, botID) {
+		sb.WriteString(cmd + "\n")
+	}
+
+	sb.WriteString("\n<b>Reply Commands</b> (send as a reply to a forwarded message):\n")
+	for _, rc := range replyCommandDocs {
+		sb.WriteString(fmt.Sprintf("<code>%s</code> - %s\n", rc.name, rc.desc))
+	}
+	sb.WriteString("\nOr reply with any text or media to answer the user directly.\n")
+
+	// Current configuration, read from the settings registry so new
+	// settings show up here without touching the help text
+	botModel, err := m.repo.GetBotByToken(ctx, token)
+	if err != nil {
+		log.Printf("Failed to load bot for help (bot %d): %v", botID, err)
+	}
+	if botModel != nil {
+		sb.WriteString("\n<b>Current Configuration:</b>\n")
+		for _, s := range boolSettings {
+			status := "❌ Off"
+			if s.value(botModel) {
+				status = "✅ On"
+			}
+			sb.WriteString(fmt.Sprintf("%s: %s\n", s.label, status))
+		}
+		for _, s := range choiceSettings {
+			name := s.names[s.value(botModel)]
+			if name == "" {
+				name = s.names[s.values[0]]
+			}
+			sb.WriteString(fmt.Sprintf("%s: %s\n", s.label, name))
+		}
+		sb.WriteString("\nUse /start → ⚙️ Settings to change these.")
+	}
+
+	return sb.String()
+}
+
+// buildUserHelp assembles the end-user command list, matching what
+// syncBotCommands publishes to Telegram
+func (m *Manager) buildUserHelp(ctx context.Context, token string, botID int64) string {
+	var sb strings.Builder
+	sb.WriteString("📖 <b>Help</b>\n\n")
+	sb.WriteString("Send any message here and it will be forwarded to the operator, who can reply to you through the bot.\n\n")
+
+	sb.WriteString("<b>Commands:</b>\n")
+	sb.WriteString("/start - Start the bot\n")
+	sb.WriteString("/help - Show this reference\n")
+	sb.WriteString("/privacy - How your messages are stored\n")
+	for _, cmd := range m.customCommandDocs(ctx, botID) {
+		sb.WriteString(cmd + "\n")
+	}
+
+	return sb.String()
+}
+
+// customCommandDocs renders the owner's custom commands as help lines
+func (m *Manager) customCommandDocs(ctx context.Context, botID int64) []string {
+	customCommands, err := m.repo.GetAutoReplies(ctx, botID, "command")
+	if err != nil {
+		log.Printf("Failed to load custom commands for help (bot %d): %v", botID, err)
+		return nil
+	}
+
+	var docs []string
+	for _, cmd := range customCommands {
+		if !cmd.IsActive {
+			continue
+		}
+		docs = append(docs, fmt.Sprintf("/%s - %s", cmd.TriggerWord, commandDescription(cmd.Response, cmd.Caption)))
+	}
+	return docs
+}
@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// broadcastHistoryLimit is how many past broadcasts the history shows
+const broadcastHistoryLimit = 10
+
+// handleBroadcastHistory lists the bot's most recent broadcasts with
+// resend and duplicate actions
+func (m *Manager) handleBroadcastHistory(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		broadcasts, err := m.repo.GetBroadcasts(ctx, botID, broadcastHistoryLimit)
+		if err != nil {
+			log.Printf("Error getting broadcast history: %v", err)
+		}
+
+		var msgBuilder strings.Builder
+		msgBuilder.WriteString("📜 <b>Broadcast History</b>\n\n")
+
+		if len(broadcasts) > 0 {
+			for i, b := range broadcasts {
+				prefix := "├"
+				if i == len(broadcasts)-1 {
+					prefix = "└"
+				}
+				msgBuilder.WriteString(fmt.Sprintf("%s #%d — %s · 👥 %d · ✅ %d 🚫 %d ❌ %d\n",
+					prefix, b.ID, b.SentAt.Format("Jan 2 15:04"), b.Audience, b.SuccessCount, b.BlockedCount, b.FailedCount))
+			}
+			msgBuilder.WriteString("\n🔄 resends the original message, 📋 copies it back to you as a new draft.")
+		} else {
+			msgBuilder.WriteString("<i>No broadcasts yet</i>")
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		for _, b := range broadcasts {
+			id := strconv.FormatInt(b.ID, 10)
+			btnResend := menu.Data(fmt.Sprintf("🔄 #%d", b.ID), "resend_broadcast", id)
+			btnDup := menu.Data(fmt.Sprintf("📋 #%d", b.ID), "dup_broadcast", id)
+			rows = append(rows, menu.Row(btnResend, btnDup))
+		}
+		btnBack := menu.Data("« Back", "child_broadcast")
+		rows = append(rows, menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		return c.Edit(msgBuilder.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// handleResendBroadcast puts a past broadcast back into the standard
+// confirmation flow, pointing at the original message
+func (m *Manager) handleResendBroadcast(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		broadcastID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid broadcast!", ShowAlert: true})
+		}
+
+		broadcast, err := m.repo.GetBroadcastByID(ctx, botID, broadcastID)
+		if err != nil || broadcast == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Broadcast not found!", ShowAlert: true})
+		}
+
+		// Reuse the pending-broadcast confirmation so resends get the
+		// same audience checks, pacing and reporting as fresh ones
+		if err := m.cache.SetPendingBroadcast(ctx, token, c.Sender().ID, broadcast.MessageID); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to prepare resend!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("✅ Confirm Send", "confirm_broadcast")
+		btnCancel := menu.Data("❌ Cancel", "cancel_broadcast")
+		menu.Inline(menu.Row(btnConfirm, btnCancel))
+
+		return c.Edit(fmt.Sprintf("⚠️ <b>Confirm Resend</b>\n\nSend broadcast #%d (%s) to all users again?",
+			broadcast.ID, broadcast.SentAt.Format("Jan 2 15:04")), menu, telebot.ModeHTML)
+	}
+}
+
+// handleDuplicateBroadcast copies a past broadcast's message back into
+// the owner's chat as a fresh draft and offers to send that copy
+func (m *Manager) handleDuplicateBroadcast(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		broadcastID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid broadcast!", ShowAlert: true})
+		}
+
+		broadcast, err := m.repo.GetBroadcastByID(ctx, botID, broadcastID)
+		if err != nil || broadcast == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Broadcast not found!", ShowAlert: true})
+		}
+
+		// The copy becomes the draft: Telegram re-renders the content so
+		// the owner sees exactly what would go out
+		draft, err := bot.Copy(ownerChat, &telebot.Message{ID: broadcast.MessageID, Chat: ownerChat})
+		if err != nil {
+			log.Printf("Failed to duplicate broadcast %d: %v", broadcastID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Original message is no longer available!", ShowAlert: true})
+		}
+
+		if err := m.cache.SetPendingBroadcast(ctx, token, c.Sender().ID, draft.ID); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to prepare broadcast!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("✅ Confirm Send", "confirm_broadcast")
+		btnCancel := menu.Data("❌ Cancel", "cancel_broadcast")
+		menu.Inline(menu.Row(btnConfirm, btnCancel))
+
+		_, err = bot.Send(ownerChat, "⚠️ <b>Confirm Broadcast</b>\n\nSend this copy to all users?", menu, telebot.ModeHTML)
+		return err
+	}
+}
@@ -0,0 +1,267 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Media Policy Handlers ====================
+
+// validBlockableTypes are the media types owners may block. Text cannot be
+// blocked; a bot that rejects text is useless as a contact bot.
+var validBlockableTypes = map[string]bool{
+	models.MessageTypePhoto:     true,
+	models.MessageTypeVideo:     true,
+	models.MessageTypeDocument:  true,
+	models.MessageTypeAudio:     true,
+	models.MessageTypeVoice:     true,
+	models.MessageTypeAnimation: true,
+	models.MessageTypeVideoNote: true,
+	models.MessageTypeSticker:   true,
+}
+
+// policyMediaType classifies an inbound message for policy checks.
+// Returns "" for plain text and other unclassified content.
+func policyMediaType(msg *telebot.Message) string {
+	switch {
+	case msg.Photo != nil:
+		return models.MessageTypePhoto
+	case msg.Video != nil:
+		return models.MessageTypeVideo
+	case msg.Document != nil:
+		return models.MessageTypeDocument
+	case msg.Audio != nil:
+		return models.MessageTypeAudio
+	case msg.Voice != nil:
+		return models.MessageTypeVoice
+	case msg.Animation != nil:
+		return models.MessageTypeAnimation
+	case msg.VideoNote != nil:
+		return models.MessageTypeVideoNote
+	case msg.Sticker != nil:
+		return models.MessageTypeSticker
+	}
+	return ""
+}
+
+// handleMediaPolicyMenu shows the current media policy with edit buttons
+func (m *Manager) handleMediaPolicyMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		blockedTypes := ""
+		maxSizeMB := 0
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel != nil {
+			blockedTypes = botModel.BlockedMediaTypes
+			maxSizeMB = botModel.MaxFileSizeMB
+		}
+
+		blockedDisplay := "None"
+		if blockedTypes != "" {
+			blockedDisplay = blockedTypes
+		}
+		sizeDisplay := "Unlimited"
+		if maxSizeMB > 0 {
+			sizeDisplay = fmt.Sprintf("%d MB", maxSizeMB)
+		}
+
+		msg := fmt.Sprintf(`🛡 <b>Media Policy</b>
+
+Restrict what users may send to your bot.
+
+<b>Blocked types:</b> %s
+<b>Max file size:</b> %s`, blockedDisplay, sizeDisplay)
+
+		menu := &telebot.ReplyMarkup{}
+		btnBlocked := menu.Data("🚫 Set Blocked Types", "set_blocked_media")
+		btnMaxSize := menu.Data("📦 Set Max File Size", "set_max_file_size")
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		menu.Inline(
+			menu.Row(btnBlocked),
+			menu.Row(btnMaxSize),
+			menu.Row(btnBack),
+		)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleSetBlockedMediaBtn initiates state to set the blocked media types
+func (m *Manager) handleSetBlockedMediaBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_blocked_media"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		msg := `🚫 <b>Blocked Media Types</b>
+
+Send a comma-separated list of types to block, or - to allow everything.
+
+Valid types:
+<code>photo, video, document, audio, voice, animation, video_note, sticker</code>
+
+Example: <code>sticker, document</code>`
+
+		return c.Edit(msg, telebot.ModeHTML)
+	}
+}
+
+// handleSetMaxFileSizeBtn initiates state to set the max file size
+func (m *Manager) handleSetMaxFileSizeBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_max_file_size"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		msg := `📦 <b>Max File Size</b>
+
+Send the maximum allowed file size in MB (1-2000), or 0 for unlimited.`
+
+		return c.Edit(msg, telebot.ModeHTML)
+	}
+}
+
+// processSetBlockedMedia handles the owner's reply with the blocked types list
+func (m *Manager) processSetBlockedMedia(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	input := strings.TrimSpace(c.Text())
+	if input == "" {
+		return c.Reply("⚠️ Please send a comma-separated list of types, or - to allow everything.")
+	}
+
+	blocked := ""
+	if input != "-" {
+		var types []string
+		for _, part := range strings.Split(input, ",") {
+			mediaType := strings.ToLower(strings.TrimSpace(part))
+			if mediaType == "" {
+				continue
+			}
+			if !validBlockableTypes[mediaType] {
+				return c.Reply(fmt.Sprintf("⚠️ Unknown media type: %s", mediaType))
+			}
+			types = append(types, mediaType)
+		}
+		blocked = strings.Join(types, ",")
+	}
+
+	if err := m.repo.UpdateBotBlockedMediaTypes(ctx, botID, blocked); err != nil {
+		return c.Reply("❌ Failed to update blocked media types.")
+	}
+
+	// Cache the new list so enforcement picks it up immediately
+	if err := m.cache.SetBlockedMediaTypes(ctx, token, blocked); err != nil {
+		log.Printf("Failed to cache blocked media types: %v", err)
+	}
+
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if blocked == "" {
+		return c.Reply("✅ <b>Media Restrictions Cleared!</b>\n\nUsers can send any content again.", telebot.ModeHTML)
+	}
+	return c.Reply(fmt.Sprintf("✅ <b>Blocked Media Types Updated!</b>\n\nUsers can no longer send: %s", blocked), telebot.ModeHTML)
+}
+
+// processSetMaxFileSize handles the owner's reply with the size limit
+func (m *Manager) processSetMaxFileSize(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	sizeMB, convErr := strconv.Atoi(strings.TrimSpace(c.Text()))
+	if convErr != nil || sizeMB < 0 || sizeMB > 2000 {
+		return c.Reply("⚠️ Please send a number of MB between 0 and 2000.")
+	}
+
+	if err := m.repo.UpdateBotMaxFileSize(ctx, botID, sizeMB); err != nil {
+		return c.Reply("❌ Failed to update max file size.")
+	}
+
+	// Cache the new limit so enforcement picks it up immediately
+	if err := m.cache.SetMaxFileSize(ctx, token, sizeMB); err != nil {
+		log.Printf("Failed to cache max file size: %v", err)
+	}
+
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if sizeMB == 0 {
+		return c.Reply("✅ <b>File Size Limit Removed!</b>", telebot.ModeHTML)
+	}
+	return c.Reply(fmt.Sprintf("✅ <b>File Size Limit Updated!</b>\n\nFiles over %d MB will be rejected.", sizeMB), telebot.ModeHTML)
+}
+
+// checkMediaPolicy enforces the bot's media restrictions on an inbound user
+// message. Returns a friendly rejection message, or "" when allowed.
+// Uses the cache-first pattern with a DB fallback.
+func (m *Manager) checkMediaPolicy(ctx context.Context, c telebot.Context, token string, botID int64) string {
+	msg := c.Message()
+	mediaType := policyMediaType(msg)
+	if mediaType == "" {
+		return "" // Plain text is always allowed
+	}
+
+	blocked, blockedHit, err := m.cache.GetBlockedMediaTypes(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting blocked media types: %v", err)
+	}
+	maxSizeMB, sizeHit, err := m.cache.GetMaxFileSize(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting max file size: %v", err)
+	}
+
+	if !blockedHit || !sizeHit {
+		// Fallback to DB and backfill the cache
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+		if botModel != nil {
+			blocked = botModel.BlockedMediaTypes
+			maxSizeMB = botModel.MaxFileSizeMB
+			m.cache.SetBlockedMediaTypes(ctx, token, blocked)
+			m.cache.SetMaxFileSize(ctx, token, maxSizeMB)
+		}
+	}
+
+	if blocked != "" {
+		for _, blockedType := range strings.Split(blocked, ",") {
+			if strings.TrimSpace(blockedType) == mediaType {
+				return fmt.Sprintf("⚠️ Sorry, this bot does not accept %s messages. Please send your message as text.", mediaType)
+			}
+		}
+	}
+
+	if maxSizeMB > 0 {
+		if media := msg.Media(); media != nil && media.MediaFile() != nil {
+			if media.MediaFile().FileSize > int64(maxSizeMB)*1024*1024 {
+				return fmt.Sprintf("⚠️ Sorry, files over %d MB are not accepted by this bot.", maxSizeMB)
+			}
+		}
+	}
+
+	return ""
+}
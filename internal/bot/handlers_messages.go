@@ -3,98 +3,122 @@ package bot
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/events"
+	"github.com/Amr-9/botforge/internal/metrics"
+	"github.com/Amr-9/botforge/internal/models"
 	"gopkg.in/telebot.v3"
 )
 
-// registerChildHandlers sets up message handlers for a child bot
+// deliveryStatusFor classifies a send error into a delivery status and a
+// short error code for the deliveries table
+func deliveryStatusFor(err error) (string, string) {
+	if err == nil {
+		return models.DeliveryStatusDelivered, ""
+	}
+	if strings.Contains(err.Error(), "blocked") || strings.Contains(err.Error(), "Forbidden") {
+		return models.DeliveryStatusBlocked, "blocked_by_user"
+	}
+	return models.DeliveryStatusError, "send_failed"
+}
+
+// timingMiddleware measures the latency of every handler registered
+// after it, keyed by the callback unique, command or update kind
+func timingMiddleware(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		start := time.Now()
+		err := next(c)
+		metrics.ObserveHandler(handlerName(c), time.Since(start))
+		return err
+	}
+}
+
+// handlerName derives the metrics label for an update: the callback
+// unique for buttons, the command for commands, "message" otherwise
+func handlerName(c telebot.Context) string {
+	if cb := c.Callback(); cb != nil {
+		if cb.Unique != "" {
+			return cb.Unique
+		}
+		return "callback"
+	}
+	if text := c.Text(); strings.HasPrefix(text, "/") {
+		name := strings.SplitN(text, " ", 2)[0]
+		if at := strings.IndexByte(name, '@'); at >= 0 {
+			name = name[:at]
+		}
+		if len(name) > 32 {
+			name = name[:32]
+		}
+		return name
+	}
+	return "message"
+}
+
+// registerChildHandlers sets up message handlers for a child bot.
+// The concrete handlers live in the shared tables in handlers_shared.go;
+// each bot instance only registers the bot in the context registry and
+// wires the generic endpoints to the Manager-level dispatchers.
+// Called from StartBot with m.mu held.
 func (m *Manager) registerChildHandlers(bot *telebot.Bot, token string, ownerChatID int64) {
-	ownerChat := &telebot.Chat{ID: ownerChatID}
-
-	// Admin commands (Owner only)
-	bot.Handle("/start", m.handleChildStart(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "child_stats"}, m.handleChildStats(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "child_broadcast"}, m.handleChildBroadcast(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "child_settings"}, m.handleChildSettings(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "set_start_msg"}, m.handleSetStartMsgBtn(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "cancel_broadcast"}, m.handleCancelBroadcast(bot, token))
-	bot.Handle(&telebot.Btn{Unique: "confirm_broadcast"}, m.handleConfirmBroadcast(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "back_to_settings"}, m.handleBackToSettings(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "child_main_menu"}, m.handleChildMainMenu(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "banned_list"}, m.handleBannedUsersList(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "unban_user"}, m.handleUnbanUser(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "toggle_sent_confirm"}, m.handleToggleSentConfirmation(bot, token, ownerChat))
-
-	// Auto-Replies handlers
-	bot.Handle(&telebot.Btn{Unique: "auto_replies_menu"}, m.handleAutoRepliesMenu(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "add_auto_reply"}, m.handleAddAutoReply(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "add_custom_cmd"}, m.handleAddCustomCommand(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "list_auto_replies"}, m.handleListAutoReplies(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "list_custom_cmds"}, m.handleListCustomCommands(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "del_reply"}, m.handleDeleteAutoReply(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "toggle_forward_replies"}, m.handleToggleForwardReplies(bot, token, ownerChat))
-
-	// Forced Subscription handlers
-	bot.Handle(&telebot.Btn{Unique: "forced_sub_menu"}, m.handleForcedSubMenu(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "toggle_forced_sub"}, m.handleToggleForcedSub(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "add_forced_channel"}, m.handleAddForcedChannel(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "list_forced_channels"}, m.handleListForcedChannels(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "del_forced_channel"}, m.handleRemoveForcedChannel(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "set_forced_sub_msg"}, m.handleSetForcedSubMsg(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "clear_forced_sub_msg"}, m.handleClearForcedSubMsg(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "check_subscription"}, m.handleCheckSubscription(bot, token, ownerChat))
-
-	// Schedule handlers
-	bot.Handle(&telebot.Btn{Unique: "schedule_menu"}, m.handleScheduleMenu(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_new"}, m.handleScheduleNewMessage(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_list"}, m.handleListScheduledMessages(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_type_once"}, m.handleScheduleTypeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_type_daily"}, m.handleScheduleTypeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_type_weekly"}, m.handleScheduleTypeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_1h"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_3h"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_6h"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_12h"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_06:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_09:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_12:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_15:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_18:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_daily_21:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_06:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_09:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_12:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_15:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_18:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_time_weekly_21:00"}, m.handleScheduleTimeSelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_0"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_1"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_2"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_3"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_4"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_5"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_day_6"}, m.handleScheduleDaySelection(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_confirm"}, m.handleConfirmSchedule(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_cancel"}, m.handleCancelSchedule(bot, token))
-	bot.Handle(&telebot.Btn{Unique: "schedule_pause"}, m.handlePauseScheduledMessage(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_resume"}, m.handleResumeScheduledMessage(bot, token, ownerChat))
-	bot.Handle(&telebot.Btn{Unique: "schedule_delete"}, m.handleDeleteScheduledMessage(bot, token, ownerChat))
-
-	bot.Handle(telebot.OnText, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnPhoto, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnVideo, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnDocument, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnAudio, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnVoice, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnSticker, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnAnimation, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnVideoNote, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnContact, m.createMessageHandler(bot, token, ownerChat))
-	bot.Handle(telebot.OnLocation, m.createMessageHandler(bot, token, ownerChat))
+	m.childCtxs[bot.Me.ID] = &childContext{
+		token:     token,
+		ownerChat: &telebot.Chat{ID: ownerChatID},
+	}
+
+	// Wrap every handler below with the latency measurement
+	bot.Use(timingMiddleware)
+
+	// Commands and callback buttons, routed via the shared tables
+	bot.Handle(telebot.OnText, m.dispatchText)
+	bot.Handle(telebot.OnCallback, m.dispatchCallback)
+
+	// Media and contact messages
+	for _, endpoint := range messageEndpoints {
+		bot.Handle(endpoint, m.dispatchMessage)
+	}
+
+	// Propagate the owner's edits to the copies delivered to users
+	bot.Handle(telebot.OnEdited, m.dispatchEdited)
+	bot.Handle(telebot.OnMyChatMember, m.dispatchMyChatMember)
+	bot.Handle(telebot.OnChannelPost, m.dispatchChannelPost)
+}
+
+// handleMyChatMember tracks blocks and unblocks in the bot's private chats
+// so reachability stats stay current without waiting for a failed send
+func (m *Manager) handleMyChatMember(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		upd := c.ChatMember()
+		if upd == nil || upd.Chat == nil || upd.NewChatMember == nil {
+			return nil
+		}
+		// Only private conversations signal a user blocking/unblocking the bot
+		if upd.Chat.Type != telebot.ChatPrivate {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		switch upd.NewChatMember.Role {
+		case telebot.Kicked:
+			if err := m.repo.MarkUserUnreachable(ctx, botID, upd.Chat.ID); err != nil {
+				log.Printf("Failed to mark user %d unreachable: %v", upd.Chat.ID, err)
+			}
+		case telebot.Member:
+			if err := m.repo.UpsertBotUser(ctx, botID, upd.Chat.ID); err != nil {
+				log.Printf("Failed to mark user %d reachable: %v", upd.Chat.ID, err)
+			}
+		}
+		return nil
+	}
 }
 
 // createMessageHandler returns a handler function for processing messages
@@ -137,28 +161,205 @@ func (m *Manager) createMessageHandler(bot *telebot.Bot, token string, ownerChat
 				return c.Send(newMsg, telebot.ModeMarkdown)
 			}
 
-			// Handle auto-reply states
-			if strings.HasPrefix(state, "add_auto_reply") || strings.HasPrefix(state, "add_custom_cmd") {
-				handled, err := m.processAutoReplyState(ctx, c, token, state)
-				if handled {
-					return err
+			if state == "set_confirm_emoji" {
+				// Update confirmation emoji
+				m.mu.RLock()
+				botID := m.botIDs[token]
+				m.mu.RUnlock()
+
+				emoji := strings.TrimSpace(c.Text())
+				if emoji == "" || len(emoji) > 16 {
+					return c.Reply("⚠️ Please send a single emoji.")
+				}
+
+				if err := m.repo.UpdateBotConfirmationEmoji(ctx, botID, emoji); err != nil {
+					return c.Reply("❌ Failed to update confirmation emoji.")
+				}
+
+				// Cache the new emoji so replies pick it up immediately
+				if err := m.cache.SetConfirmationEmoji(ctx, token, emoji); err != nil {
+					log.Printf("Failed to cache confirmation emoji: %v", err)
+				}
+
+				// Clear state
+				m.cache.ClearUserState(ctx, token, sender.ID)
+
+				return c.Reply(fmt.Sprintf("✅ <b>Confirmation Emoji Updated!</b>\n\nReplies will now be confirmed with %s", emoji), telebot.ModeHTML)
+			}
+
+			if state == "set_digest_interval" {
+				// Update digest interval
+				m.mu.RLock()
+				botID := m.botIDs[token]
+				m.mu.RUnlock()
+
+				minutes, convErr := strconv.Atoi(strings.TrimSpace(c.Text()))
+				if convErr != nil || minutes < 0 || minutes > 1440 {
+					return c.Reply("⚠️ Please send a number of minutes between 0 and 1440.")
+				}
+				if minutes > 0 && minutes < 5 {
+					return c.Reply("⚠️ The minimum digest interval is 5 minutes.")
+				}
+
+				if err := m.repo.UpdateBotDigestInterval(ctx, botID, minutes); err != nil {
+					return c.Reply("❌ Failed to update digest interval.")
+				}
+
+				// Cache the new interval so the digest loop picks it up
+				if err := m.cache.SetDigestInterval(ctx, token, minutes); err != nil {
+					log.Printf("Failed to cache digest interval: %v", err)
+				}
+
+				// Clear state
+				m.cache.ClearUserState(ctx, token, sender.ID)
+
+				if minutes == 0 {
+					return c.Reply("✅ <b>Digest Mode Disabled!</b>\n\nMessages will be forwarded immediately again.", telebot.ModeHTML)
+				}
+				return c.Reply(fmt.Sprintf("✅ <b>Digest Mode Enabled!</b>\n\nYou will receive a summary every %d minutes.", minutes), telebot.ModeHTML)
+			}
+
+			if state == "set_dedupe_window" {
+				// Update dedupe window
+				m.mu.RLock()
+				botID := m.botIDs[token]
+				m.mu.RUnlock()
+
+				minutes, convErr := strconv.Atoi(strings.TrimSpace(c.Text()))
+				if convErr != nil || minutes < 0 || minutes > 1440 {
+					return c.Reply("⚠️ Please send a number of minutes between 0 and 1440.")
 				}
+
+				if err := m.repo.UpdateBotDedupeWindow(ctx, botID, minutes); err != nil {
+					return c.Reply("❌ Failed to update duplicate suppression.")
+				}
+
+				// Cache the new window so enforcement picks it up
+				if err := m.cache.SetDedupeWindow(ctx, token, minutes); err != nil {
+					log.Printf("Failed to cache dedupe window: %v", err)
+				}
+
+				// Clear state
+				m.cache.ClearUserState(ctx, token, sender.ID)
+
+				if minutes == 0 {
+					return c.Reply("✅ <b>Duplicate Suppression Disabled!</b>", telebot.ModeHTML)
+				}
+				return c.Reply(fmt.Sprintf("✅ <b>Duplicate Suppression Enabled!</b>\n\nIdentical repeats within %d minutes will not be forwarded.", minutes), telebot.ModeHTML)
 			}
 
-			// Handle schedule states
-			if strings.HasPrefix(state, "schedule_") {
-				handled, err := m.processScheduleState(ctx, c, token, state)
-				if handled {
-					return err
+			if state == "set_sla_minutes" {
+				// Update SLA threshold
+				m.mu.RLock()
+				botID := m.botIDs[token]
+				m.mu.RUnlock()
+
+				minutes, convErr := strconv.Atoi(strings.TrimSpace(c.Text()))
+				if convErr != nil || minutes < 0 || minutes > 1440 {
+					return c.Reply("⚠️ Please send a number of minutes between 0 and 1440.")
+				}
+
+				if err := m.repo.UpdateBotSLAMinutes(ctx, botID, minutes); err != nil {
+					return c.Reply("❌ Failed to update SLA threshold.")
+				}
+
+				// Cache the new threshold so the SLA loop picks it up
+				if err := m.cache.SetSLAMinutes(ctx, token, minutes); err != nil {
+					log.Printf("Failed to cache SLA threshold: %v", err)
+				}
+
+				// Clear state
+				m.cache.ClearUserState(ctx, token, sender.ID)
+
+				if minutes == 0 {
+					return c.Reply("✅ <b>SLA Alerts Disabled!</b>", telebot.ModeHTML)
 				}
+				return c.Reply(fmt.Sprintf("✅ <b>SLA Alerts Enabled!</b>\n\nYou will be alerted when a message waits over %d minutes.", minutes), telebot.ModeHTML)
 			}
 
-			// Handle forced subscription states
-			if state == "add_forced_channel" || state == "set_forced_sub_message" {
-				handled, err := m.processForcedSubState(ctx, c, bot, token, state)
-				if handled {
-					return err
+			if state == "set_digest_keywords" {
+				// Update urgent keywords
+				m.mu.RLock()
+				botID := m.botIDs[token]
+				m.mu.RUnlock()
+
+				keywords := strings.TrimSpace(c.Text())
+				if keywords == "" {
+					return c.Reply("⚠️ Please send a comma-separated list of keywords, or - to clear.")
+				}
+				if keywords == "-" {
+					keywords = ""
 				}
+
+				if err := m.repo.UpdateBotDigestKeywords(ctx, botID, keywords); err != nil {
+					return c.Reply("❌ Failed to update urgent keywords.")
+				}
+
+				// Cache the new keywords
+				if err := m.cache.SetDigestKeywords(ctx, token, keywords); err != nil {
+					log.Printf("Failed to cache digest keywords: %v", err)
+				}
+
+				// Clear state
+				m.cache.ClearUserState(ctx, token, sender.ID)
+
+				if keywords == "" {
+					return c.Reply("✅ <b>Urgent Keywords Cleared!</b>", telebot.ModeHTML)
+				}
+				return c.Reply(fmt.Sprintf("✅ <b>Urgent Keywords Updated!</b>\n\nMessages containing: %s will skip the digest.", keywords), telebot.ModeHTML)
+			}
+
+			// Handle multi-step conversations registered with the flow framework
+			if handled, err := m.dispatchFlowState(ctx, c, bot, token, state); handled {
+				return err
+			}
+
+			// Handle routing rule state
+			if state == "add_routing_rule" {
+				return m.processAddRoutingRule(ctx, c, token)
+			}
+
+			// Handle short link state
+			if state == "add_short_link" {
+				return m.processAddShortLink(ctx, c, token)
+			}
+
+			// Handle relay channel state
+			if state == "set_relay_channel" {
+				return m.processSetRelayChannel(ctx, c, bot, token)
+			}
+
+			// Handle archive chat state
+			if state == "set_archive_chat" {
+				return m.processSetArchiveChat(ctx, c, bot, token)
+			}
+
+			if state == "set_privacy_notice" {
+				return m.processSetPrivacyNotice(ctx, c, token)
+			}
+
+			// Handle forced sub grace period and exemption states
+			if state == "set_fsub_grace" {
+				return m.processSetFsubGrace(ctx, c, token)
+			}
+			if state == "set_fsub_exempt" {
+				return m.processSetFsubExempt(ctx, c, token)
+			}
+
+			// Handle media policy states
+			if state == "set_blocked_media" {
+				return m.processSetBlockedMedia(ctx, c, token)
+			}
+			if state == "set_max_file_size" {
+				return m.processSetMaxFileSize(ctx, c, token)
+			}
+
+			// Handle whitelist states
+			if state == "add_whitelist_user" {
+				return m.processAddWhitelistUser(ctx, c, token)
+			}
+			if state == "set_whitelist_msg" {
+				return m.processSetWhitelistMsg(ctx, c, token)
 			}
 
 			return m.handleAdminReply(ctx, c, bot, token)
@@ -177,22 +378,61 @@ func (m *Manager) handleUserMessage(ctx context.Context, c telebot.Context, bot
 	botID := m.botIDs[token]
 	m.mu.RUnlock()
 
-	// Check if user is banned - silently ignore their messages
-	isBanned, err := m.checkUserBanned(ctx, token, botID, sender.ID)
+	// Load all per-user cache flags in one round trip; the zero value
+	// degrades every check below to its DB or API fallback
+	umc, err := m.cache.GetUserMessageContext(ctx, token, sender.ID)
 	if err != nil {
-		log.Printf("Error checking ban status: %v", err)
+		log.Printf("Cache error getting user message context: %v", err)
 	}
-	if isBanned {
-		return nil // Silently ignore banned user messages
+
+	// Check if user is banned - silently ignore their messages
+	if umc.Banned {
+		return nil
+	}
+	if !umc.NotBanned {
+		// Ban status not cached - check the database and backfill
+		isBanned, dbErr := m.repo.IsUserBanned(ctx, botID, sender.ID)
+		if dbErr != nil {
+			log.Printf("Error checking ban status: %v", dbErr)
+		} else if isBanned {
+			m.cache.SetUserBanned(ctx, token, sender.ID)
+			return nil
+		} else {
+			m.cache.CacheNotBanned(ctx, token, sender.ID)
+		}
 	}
 
-	// Check forced subscription
-	isSubscribed, menu, blockedMsg, err := m.checkForcedSubscription(ctx, c, bot, token, botID, sender.ID)
-	if err != nil {
-		log.Printf("Error checking forced subscription: %v", err)
+	// Whitelist-only bots reject everyone who is not pre-approved
+	if rejectMsg := m.checkWhitelist(ctx, token, botID, sender.ID); rejectMsg != "" {
+		return c.Reply(rejectMsg)
 	}
-	if !isSubscribed {
-		return c.Send(blockedMsg, menu, telebot.ModeHTML)
+
+	// Enforce the owner's media policy before doing any forwarding work
+	if rejectMsg := m.checkMediaPolicy(ctx, c, token, botID); rejectMsg != "" {
+		return c.Reply(rejectMsg)
+	}
+
+	// Run the content filter pipeline (profanity, links)
+	filterAction, filterName := m.checkContentFilters(ctx, token, botID, text)
+	switch filterAction {
+	case FilterActionDrop:
+		return nil // Silently discard
+	case FilterActionWarn:
+		return c.Reply("⚠️ Your message was not delivered because it violates this bot's content rules.")
+	}
+
+	// Copy inbound traffic to the archive chat, if configured
+	m.archiveMessage(ctx, bot, token, botID, c.Message())
+
+	// Check forced subscription, unless membership was verified recently
+	if !umc.SubVerified {
+		isSubscribed, menu, blockedMsg, err := m.checkForcedSubscription(ctx, c, bot, token, botID, sender.ID)
+		if err != nil {
+			log.Printf("Error checking forced subscription: %v", err)
+		}
+		if !isSubscribed {
+			return c.Send(blockedMsg, menu, telebot.ModeHTML)
+		}
 	}
 
 	// Check custom commands and auto-replies
@@ -220,7 +460,7 @@ func (m *Manager) handleUserMessage(ctx context.Context, c telebot.Context, bot
 
 		if !cacheHit {
 			// Fallback to DB
-			botModel, _ := m.repo.GetBotByToken(ctx, token)
+			botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
 			if botModel != nil {
 				forwardEnabled = botModel.ForwardAutoReplies
 				// Cache for next time
@@ -233,34 +473,98 @@ func (m *Manager) handleUserMessage(ctx context.Context, c telebot.Context, bot
 		}
 	}
 
-	// Check if session exists
-	hasSession, err := m.cache.HasSession(ctx, token, sender.ID)
-	if err != nil {
-		log.Printf("Error checking session: %v", err)
+	// Muted users are served auto-replies like everyone else (so the
+	// mute stays invisible to them), but nothing reaches the owner
+	if umc.Muted {
+		return nil
 	}
 
-	// If NOT in Redis, check DB
-	if !hasSession {
+	// Optional dedupe: an identical repeat of the user's previous message
+	// is accepted (and already archived) but not forwarded again. It is
+	// still written to message_logs (with no admin-side message to link)
+	// so the message and activity counts include the repeat.
+	if m.isDuplicateMessage(ctx, token, botID, sender.ID, text) {
+		if err := m.repo.SaveMessageLog(ctx, 0, sender.ID, botID); err != nil {
+			log.Printf("Failed to log suppressed duplicate from user %d: %v", sender.ID, err)
+		}
+		return nil
+	}
+
+	// Keyword routing: messages matching a rule go straight to the
+	// configured operator chat instead of the owner
+	if destChatID := m.resolveRouteDestination(ctx, token, botID, text); destChatID != 0 {
+		if _, err := bot.Forward(&telebot.Chat{ID: destChatID}, c.Message()); err != nil {
+			log.Printf("Failed to route message to chat %d: %v", destChatID, err)
+			// Fall through to normal forwarding so the message isn't lost
+		} else {
+			return nil
+		}
+	}
+
+	// Spam scoring: suspected spam is held in the review queue instead
+	// of reaching the inbox; the owner reviews it with /spam
+	if m.shouldDivertSpam(ctx, token, botID, text) {
+		if err := m.cache.AddSpamMessage(ctx, token, sender.ID, c.Message().ID); err != nil {
+			log.Printf("Failed to queue spam message: %v", err)
+			// Fall through to normal forwarding so the message isn't lost
+		} else {
+			return nil
+		}
+	}
+
+	// Digest mode: queue the message for the next summary instead of
+	// forwarding it, unless it matches an urgent keyword
+	if m.shouldDigest(ctx, token, botID, text) {
+		if err := m.cache.AddDigestMessage(ctx, token, sender.ID, c.Message().ID); err != nil {
+			log.Printf("Failed to queue digest message: %v", err)
+			// Fall through to normal forwarding so the message isn't lost
+		} else {
+			return nil
+		}
+	}
+
+	// The header policy decides how long a session lives before the user
+	// info header is sent again ("never" keeps the infinite session)
+	headerPolicy := m.headerPolicy(ctx, token, botID)
+	sessionTTL := sessionTTLForPolicy(headerPolicy)
+
+	// Check if session exists (flag comes from the combined read above)
+	hasSession := umc.HasSession
+
+	// If NOT in Redis, check DB. Only infinite sessions survive a cache
+	// flush this way; expiring policies rely on the Redis TTL alone.
+	if !hasSession && headerPolicy == models.HeaderPolicyNever {
 		hasInteracted, err := m.repo.HasUserInteracted(ctx, botID, sender.ID)
 		if err != nil {
 			log.Printf("Error checking DB interaction: %v", err)
 		} else if hasInteracted {
 			hasSession = true
-			m.cache.SetSession(ctx, token, sender.ID, 0)
+			m.cache.SetSession(ctx, token, sender.ID, sessionTTL)
 		}
 	}
 
-	// If still NO session (truly first time), send Header
+	// If still NO live session (first time, or the old one expired), send Header
 	if !hasSession {
-		userInfo := formatUserInfo(sender)
-		_, err := bot.Send(ownerChat, userInfo, telebot.ModeHTML)
+		header, err := m.sendUserHeader(ctx, bot, ownerChat, token, botID, sender)
 		if err != nil {
 			log.Printf("Failed to send user info: %v", err)
+		} else {
+			// Link the header too so replying to it routes to the user
+			m.linkAdminMessage(ctx, token, botID, header.ID, sender.ID)
 		}
 
-		if err := m.cache.SetSession(ctx, token, sender.ID, 0); err != nil {
+		if err := m.cache.SetSession(ctx, token, sender.ID, sessionTTL); err != nil {
 			log.Printf("Failed to update session: %v", err)
 		}
+
+		// Register the user as reachable on first contact
+		if err := m.repo.UpsertBotUser(ctx, botID, sender.ID); err != nil {
+			log.Printf("Failed to upsert bot user %d: %v", sender.ID, err)
+		}
+	} else if headerPolicy == models.HeaderPolicyGap {
+		// Sliding window: any activity extends the session, so the
+		// header only returns after a real conversation gap
+		m.cache.SetSession(ctx, token, sender.ID, sessionTTL)
 	}
 
 	sent, err := bot.Forward(ownerChat, c.Message())
@@ -270,14 +574,124 @@ func (m *Manager) handleUserMessage(ctx context.Context, c telebot.Context, bot
 	}
 
 	adminMsgID := sent.ID
-	if err := m.repo.SaveMessageLog(ctx, adminMsgID, sender.ID, botID); err != nil {
+	m.linkAdminMessage(ctx, token, botID, adminMsgID, sender.ID)
+	m.maybeSaveContent(ctx, token, botID, sender.ID, adminMsgID, c.Text())
+
+	m.publishEvent(events.Event{
+		Name:        events.MessageForwarded,
+		BotID:       botID,
+		OwnerChatID: ownerChat.ID,
+	})
+
+	// Attach quick actions (Ban/Info/Tag/Mute) under the forwarded message
+	if _, err := bot.EditReplyMarkup(sent, quickActionsMenu()); err != nil {
+		log.Printf("Failed to attach quick actions to message %d: %v", adminMsgID, err)
+	}
+
+	// Mark messages the filter pipeline flagged for review
+	if filterAction == FilterActionFlag {
+		flagNote := fmt.Sprintf("🚩 Flagged by the %s filter", filterName)
+		if _, err := bot.Send(ownerChat, flagNote, &telebot.SendOptions{ReplyTo: sent}); err != nil {
+			log.Printf("Failed to send flag note for message %d: %v", adminMsgID, err)
+		}
+	}
+
+	return nil
+}
+
+// linkAdminMessage records that an admin-side message belongs to a user's
+// conversation, in both Redis and message_logs, so the owner can reply to
+// any of them and still reach the user
+func (m *Manager) linkAdminMessage(ctx context.Context, token string, botID int64, adminMsgID int, userChatID int64) {
+	if err := m.repo.SaveMessageLog(ctx, adminMsgID, userChatID, botID); err != nil {
 		log.Printf("Failed to save message log to MySQL: %v", err)
 	}
 
-	if err := m.cache.SetMessageLink(ctx, token, adminMsgID, sender.ID); err != nil {
+	ttl := time.Duration(m.messageTTLHours(ctx, token, botID)) * time.Hour
+	if err := m.cache.SetMessageLinkWithTTL(ctx, token, adminMsgID, userChatID, ttl); err != nil {
 		log.Printf("Failed to save message link to Redis: %v", err)
 	}
+}
+
+// messageTTLHours returns the bot's reply-routing cache TTL override in
+// hours; 0 means the global MESSAGE_TTL applies
+func (m *Manager) messageTTLHours(ctx context.Context, token string, botID int64) int {
+	hours, cacheHit, err := m.cache.GetMessageTTLHours(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return hours
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetMessageTTLHours(ctx, token, botModel.MessageTTLHours)
+	return botModel.MessageTTLHours
+}
+
+// sendUserInfo replies with profile, ban and delivery details for a user
+func (m *Manager) sendUserInfo(ctx context.Context, c telebot.Context, bot *telebot.Bot, token string, botID, userChatID int64) error {
+	chat, err := bot.ChatByID(userChatID)
+	if err != nil {
+		log.Printf("Failed to get chat info: %v", err)
+		chat = &telebot.Chat{ID: userChatID}
+	}
+
+	firstMsgDate, err := m.repo.GetFirstMessageDate(ctx, botID, userChatID)
+	dateStr := "Unknown"
+	if err == nil && !firstMsgDate.IsZero() {
+		dateStr = m.ownerClock(ctx, token, botID).DateTime(firstMsgDate)
+	}
+
+	// Check ban status
+	isBanned, _ := m.repo.IsUserBanned(ctx, botID, userChatID)
+	banStatus := "No"
+	if isBanned {
+		banStatus = "Yes"
+	}
 
+	// Mute is the softer, temporary sibling of a ban, so it gets its
+	// own line with the time left
+	muteStatus := "No"
+	if muteTTL, err := m.cache.GetMuteTTL(ctx, token, userChatID); err == nil && muteTTL > 0 {
+		muteStatus = fmt.Sprintf("Yes — %s left", muteTTL.Round(time.Minute))
+	}
+
+	// Last delivery outcome
+	deliveryStatus := "—"
+	lastDelivery, _ := m.repo.GetLastDelivery(ctx, botID, userChatID)
+	if lastDelivery != nil {
+		switch lastDelivery.Status {
+		case models.DeliveryStatusDelivered:
+			deliveryStatus = "✅ Delivered"
+		case models.DeliveryStatusBlocked:
+			deliveryStatus = "🚫 Not delivered — user blocked the bot"
+		default:
+			deliveryStatus = "❌ Failed"
+		}
+	}
+
+	infoText := fmt.Sprintf(`👤 <b>From:</b> %s %s
+🔗 <b>Username:</b> @%s
+🆔 <b>ID:</b> <code>%d</code>
+
+📅 <b>First Message:</b> %s
+🚫 <b>Banned:</b> %s
+🔇 <b>Muted:</b> %s
+📬 <b>Last Delivery:</b> %s`,
+		chat.FirstName, chat.LastName, chat.Username, chat.ID, dateStr, banStatus, muteStatus, deliveryStatus)
+
+	sent, err := bot.Reply(c.Message(), infoText, telebot.ModeHTML)
+	if err != nil {
+		return err
+	}
+
+	// Link the info message to the conversation so replying to it works too
+	m.linkAdminMessage(ctx, token, botID, sent.ID, userChatID)
 	return nil
 }
 
@@ -300,79 +714,110 @@ func (m *Manager) handleAdminReply(ctx context.Context, c telebot.Context, bot *
 	}
 
 	replyToID := msg.ReplyTo.ID
-	var userChatID int64
 
-	userChatID, err = m.cache.GetMessageLink(ctx, token, replyToID)
+	// Get command text (lowercase, trimmed)
+	cmdText := strings.ToLower(strings.TrimSpace(msg.Text))
+
+	// DELETE Command: remove a mistakenly sent reply from the user's chat.
+	// Works by replying "delete" to the owner's own delivered message.
+	if cmdText == "delete" || cmdText == "/delete" {
+		return m.handleDeleteReplyCommand(ctx, c, bot, botID, replyToID)
+	}
+
+	// Headers, forwarded messages and info replies are all linked to the
+	// conversation, so replying to any of them resolves the same user
+	userChatID, err := m.resolveConversationUser(ctx, token, botID, replyToID)
 	if err != nil {
-		if cache.IsNil(err) {
-			log.Printf("Cache miss for msg %d, falling back to MySQL", replyToID)
-			userChatID, err = m.repo.GetUserChatID(ctx, replyToID, botID)
-			if err != nil {
-				log.Printf("Failed to get user chat ID from MySQL: %v", err)
-				return c.Reply("Failed to find the original message sender.")
-			}
-		} else {
-			log.Printf("Redis error: %v, falling back to MySQL", err)
-			userChatID, err = m.repo.GetUserChatID(ctx, replyToID, botID)
-			if err != nil {
-				log.Printf("Failed to get user chat ID from MySQL: %v", err)
-				return c.Reply("Failed to find the original message sender.")
-			}
-		}
+		log.Printf("Failed to get user chat ID for msg %d: %v", replyToID, err)
+		return c.Reply("Failed to find the original message sender.")
 	}
 
 	if userChatID == 0 {
+		// Tertiary path: the forwarded copy may still carry visible
+		// forward metadata pointing at the original sender
+		if originID := forwardOriginUser(msg.ReplyTo); originID != 0 {
+			menu := &telebot.ReplyMarkup{}
+			btnSend := menu.Data(fmt.Sprintf("📨 Send by ID %d", originID), "qa_send_id", strconv.FormatInt(originID, 10))
+			menu.Inline(menu.Row(btnSend))
+			return c.Reply(fmt.Sprintf("The message mapping has expired, but the forward metadata points to user <code>%d</code>. Use the button to send your reply there.", originID), menu, telebot.ModeHTML)
+		}
 		return c.Reply("Could not find the original message sender. The message may be too old.")
 	}
 
-	// Get command text (lowercase, trimmed)
-	cmdText := strings.ToLower(strings.TrimSpace(msg.Text))
-
 	// BAN Command: Check if admin sent "ban" or "/ban"
 	if cmdText == "ban" || cmdText == "/ban" {
 		return m.handleBanCommand(ctx, c, bot, token, userChatID)
 	}
 
+	// MUTE/UNMUTE Commands: temporary alternative to banning
+	if cmdText == "mute" || cmdText == "/mute" {
+		if err := m.cache.MuteUser(ctx, token, userChatID, muteDuration); err != nil {
+			log.Printf("Failed to mute user %d: %v", userChatID, err)
+			return c.Reply("❌ Failed to mute the user.")
+		}
+		return c.Reply(fmt.Sprintf("🔇 User <code>%d</code> muted for %s. They still get auto-replies; their messages just won't reach you.", userChatID, muteDuration), telebot.ModeHTML)
+	}
+	if cmdText == "unmute" || cmdText == "/unmute" {
+		if err := m.cache.UnmuteUser(ctx, token, userChatID); err != nil {
+			log.Printf("Failed to unmute user %d: %v", userChatID, err)
+			return c.Reply("❌ Failed to unmute the user.")
+		}
+		return c.Reply(fmt.Sprintf("🔊 User <code>%d</code> unmuted.", userChatID), telebot.ModeHTML)
+	}
+
 	// INFO Command: Check if admin sent "info" (case-insensitive)
 	if cmdText == "info" {
-		chat, err := bot.ChatByID(userChatID)
-		if err != nil {
-			log.Printf("Failed to get chat info: %v", err)
-			chat = &telebot.Chat{ID: userChatID}
-		}
+		return m.sendUserInfo(ctx, c, bot, token, botID, userChatID)
+	}
 
-		firstMsgDate, err := m.repo.GetFirstMessageDate(ctx, botID, userChatID)
-		dateStr := "Unknown"
-		if err == nil && !firstMsgDate.IsZero() {
-			dateStr = firstMsgDate.Format("2006-01-02 15:04:05")
-		}
+	// ERASE Command: wipe all stored data for this user (double-confirmed)
+	if cmdText == "erase" || cmdText == "/erase" {
+		return m.handleEraseUserCommand(ctx, c, userChatID)
+	}
 
-		// Check ban status
-		isBanned, _ := m.repo.IsUserBanned(ctx, botID, userChatID)
-		banStatus := "No"
-		if isBanned {
-			banStatus = "Yes"
-		}
+	// Normal Reply -> Forward to user
+	userChat := &telebot.Chat{ID: userChatID}
 
-		infoText := fmt.Sprintf(`👤 <b>From:</b> %s %s
-🔗 <b>Username:</b> @%s
-🆔 <b>ID:</b> <code>%d</code>
+	// Show a typing indicator so the user sees the reply coming
+	if notifyErr := bot.Notify(userChat, telebot.Typing); notifyErr != nil {
+		log.Printf("Failed to send typing action to user %d: %v", userChatID, notifyErr)
+	}
 
-📅 <b>First Message:</b> %s
-🚫 <b>Banned:</b> %s`,
-			chat.FirstName, chat.LastName, chat.Username, chat.ID, dateStr, banStatus)
+	copied, err := bot.Copy(userChat, msg)
 
-		return c.Reply(infoText, telebot.ModeHTML)
+	// Record the outcome so "info" can show delivery status later
+	status, errorCode := deliveryStatusFor(err)
+	if recordErr := m.repo.RecordDelivery(ctx, botID, userChatID, models.DeliverySourceReply, status, errorCode); recordErr != nil {
+		log.Printf("Failed to record delivery for user %d: %v", userChatID, recordErr)
+	}
+
+	// Copy outbound traffic to the archive chat, if configured
+	if err == nil {
+		m.archiveMessage(ctx, bot, token, botID, msg)
+	}
+
+	// Remember which user-side message this reply became, so later edits
+	// by the owner can be propagated
+	if err == nil && copied != nil {
+		if mapErr := m.repo.SaveReplyMapping(ctx, botID, msg.ID, userChatID, copied.ID); mapErr != nil {
+			log.Printf("Failed to save reply mapping for msg %d: %v", msg.ID, mapErr)
+		}
 	}
 
-	// Normal Reply -> Forward to user
-	userChat := &telebot.Chat{ID: userChatID}
-	_, err = bot.Copy(userChat, msg)
 	if err != nil {
 		log.Printf("Failed to send reply to user %d: %v", userChatID, err)
+		if status == models.DeliveryStatusBlocked {
+			if markErr := m.repo.MarkUserUnreachable(ctx, botID, userChatID); markErr != nil {
+				log.Printf("Failed to mark user %d unreachable: %v", userChatID, markErr)
+			}
+			return c.Reply("Message not delivered — user blocked the bot.")
+		}
 		return c.Reply("Failed to send message to user. They may have blocked the bot.")
 	}
 
+	// Ask the user how satisfied they are, if the survey is enabled
+	m.maybeSendSurvey(ctx, bot, token, botID, userChat)
+
 	// Check if we should show confirmation (use cache for performance)
 	showConfirmation := true // default
 	cachedValue, cacheHit, cacheErr := m.cache.GetShowSentConfirmation(ctx, token)
@@ -384,7 +829,7 @@ func (m *Manager) handleAdminReply(ctx context.Context, c telebot.Context, bot *
 		showConfirmation = cachedValue
 	} else {
 		// Cache miss - load from DB and cache it
-		botModel, _ := m.repo.GetBotByToken(ctx, token)
+		botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
 		if botModel != nil {
 			showConfirmation = botModel.ShowSentConfirmation
 			// Cache the value for future requests
@@ -393,36 +838,276 @@ func (m *Manager) handleAdminReply(ctx context.Context, c telebot.Context, bot *
 	}
 
 	if showConfirmation {
+		if m.confirmationMode(ctx, token, botID) == models.ConfirmationModeText {
+			return c.Reply("✅ Message sent.")
+		}
 		err = bot.React(msg.Chat, msg, telebot.ReactionOptions{
-			Reactions: []telebot.Reaction{{Type: "emoji", Emoji: "👍"}},
+			Reactions: []telebot.Reaction{{Type: "emoji", Emoji: m.confirmationEmoji(ctx, token, botID)}},
 		})
 		if err != nil {
-			log.Printf("⚠️ Reaction Failed: %v", err)
+			// Reactions can be disallowed in some chats - fall back to a
+			// text confirmation so the owner still gets feedback
+			log.Printf("⚠️ Reaction Failed, falling back to text confirmation: %v", err)
+			return c.Reply("✅ Message sent.")
 		}
 	}
 
 	return nil
 }
 
-// formatUserInfo creates a formatted user info header
+// confirmationEmoji returns the emoji used for the sent-confirmation reaction,
+// cache-first with a DB fallback
+func (m *Manager) confirmationEmoji(ctx context.Context, token string, botID int64) string {
+	emoji, cacheHit, err := m.cache.GetConfirmationEmoji(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit && emoji != "" {
+		return emoji
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel != nil && botModel.ConfirmationEmoji != "" {
+		m.cache.SetConfirmationEmoji(ctx, token, botModel.ConfirmationEmoji)
+		return botModel.ConfirmationEmoji
+	}
+	return "👍"
+}
+
+// confirmationMode returns how sent confirmations are shown ("react" or
+// "text"), cache-first with a DB fallback
+// sessionGapTTL is how long a conversation can idle before the "gap"
+// header policy treats the next message as a new conversation
+const sessionGapTTL = 1 * time.Hour
+
+// sessionTTLForPolicy maps a header policy to the session lifetime.
+// Zero means the session never expires.
+func sessionTTLForPolicy(policy string) time.Duration {
+	switch policy {
+	case models.HeaderPolicyGap:
+		return sessionGapTTL
+	case models.HeaderPolicyDaily:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// headerPolicy returns how often the user info header is re-sent,
+// cache-first with a DB fallback
+func (m *Manager) headerPolicy(ctx context.Context, token string, botID int64) string {
+	policy, cacheHit, err := m.cache.GetHeaderPolicy(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit && policy != "" {
+		return policy
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel != nil && botModel.HeaderPolicy != "" {
+		m.cache.SetHeaderPolicy(ctx, token, botModel.HeaderPolicy)
+		return botModel.HeaderPolicy
+	}
+	return models.HeaderPolicyNever
+}
+
+func (m *Manager) confirmationMode(ctx context.Context, token string, botID int64) string {
+	mode, cacheHit, err := m.cache.GetConfirmationMode(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit && mode != "" {
+		return mode
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel != nil && botModel.ConfirmationMode != "" {
+		m.cache.SetConfirmationMode(ctx, token, botModel.ConfirmationMode)
+		return botModel.ConfirmationMode
+	}
+	return models.ConfirmationModeReact
+}
+
+// handleDeleteReplyCommand removes a delivered reply from the user's chat.
+// Telegram only allows deleting bot messages within 48 hours.
+func (m *Manager) handleDeleteReplyCommand(ctx context.Context, c telebot.Context, bot *telebot.Bot, botID int64, replyToID int) error {
+	mapping, err := m.repo.GetReplyMapping(ctx, botID, replyToID)
+	if err != nil {
+		log.Printf("Failed to look up reply mapping for msg %d: %v", replyToID, err)
+		return c.Reply("Failed to look up the message.")
+	}
+	if mapping == nil {
+		return c.Reply("Reply \"delete\" to one of your own delivered messages to remove it from the user's chat.")
+	}
+
+	stored := telebot.StoredMessage{
+		MessageID: strconv.Itoa(mapping.UserMsgID),
+		ChatID:    mapping.UserChatID,
+	}
+	if err := bot.Delete(stored); err != nil {
+		log.Printf("Failed to delete user copy %d for user %d: %v", mapping.UserMsgID, mapping.UserChatID, err)
+		return c.Reply("Could not delete the message. It may be older than 48 hours.")
+	}
+
+	if err := m.repo.DeleteReplyMapping(ctx, botID, replyToID); err != nil {
+		log.Printf("Failed to remove reply mapping for msg %d: %v", replyToID, err)
+	}
+
+	return c.Reply("🗑 Message deleted from the user's chat.")
+}
+
+// handleEditedMessage propagates the owner's edit of a reply to the copy
+// that was delivered to the user
+func (m *Manager) handleEditedMessage(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		msg := c.Message()
+		if c.Sender().ID != ownerChat.ID {
+			return nil // Only owner edits are synced
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		mapping, err := m.repo.GetReplyMapping(ctx, botID, msg.ID)
+		if err != nil {
+			log.Printf("Failed to look up reply mapping for edited msg %d: %v", msg.ID, err)
+			return nil
+		}
+		if mapping == nil {
+			return nil // Not a reply we delivered
+		}
+
+		stored := telebot.StoredMessage{
+			MessageID: strconv.Itoa(mapping.UserMsgID),
+			ChatID:    mapping.UserChatID,
+		}
+
+		switch {
+		case msg.Text != "":
+			_, err = bot.Edit(stored, msg.Text)
+		case msg.Caption != "":
+			_, err = bot.EditCaption(stored, msg.Caption)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			// Editing the copy failed (e.g. media changed) - send a corrected copy
+			log.Printf("Failed to edit user copy for msg %d: %v, sending corrected copy", msg.ID, err)
+			userChat := &telebot.Chat{ID: mapping.UserChatID}
+			copied, copyErr := bot.Copy(userChat, msg)
+			if copyErr != nil {
+				log.Printf("Failed to send corrected copy to user %d: %v", mapping.UserChatID, copyErr)
+				return nil
+			}
+			if mapErr := m.repo.SaveReplyMapping(ctx, botID, msg.ID, mapping.UserChatID, copied.ID); mapErr != nil {
+				log.Printf("Failed to update reply mapping for msg %d: %v", msg.ID, mapErr)
+			}
+		}
+
+		return nil
+	}
+}
+
+// sendUserHeader sends the user info header to the owner. With the rich
+// header enabled it becomes the user's profile photo captioned with
+// counters and a permalink; otherwise it stays the minimal text header.
+func (m *Manager) sendUserHeader(ctx context.Context, bot *telebot.Bot, ownerChat *telebot.Chat, token string, botID int64, sender *telebot.User) (*telebot.Message, error) {
+	if !m.richHeaderEnabled(ctx, token, botID) {
+		return bot.Send(ownerChat, formatUserInfo(sender), telebot.ModeHTML)
+	}
+
+	priorMessages, err := m.repo.GetUserMessageCount(ctx, botID, sender.ID)
+	if err != nil {
+		log.Printf("Failed to count messages for user %d: %v", sender.ID, err)
+	}
+	info := formatUserInfoRich(sender, priorMessages)
+
+	// Caption the user's profile photo when one is available; users
+	// without a photo (or with hidden photos) fall back to text
+	if photos, err := bot.ProfilePhotosOf(sender); err == nil && len(photos) > 0 {
+		photo := photos[0]
+		photo.Caption = info
+		return bot.Send(ownerChat, &photo, telebot.ModeHTML)
+	}
+	return bot.Send(ownerChat, info, telebot.ModeHTML)
+}
+
+// richHeaderEnabled reports whether the extended user info header is on,
+// cache-first with a DB fallback
+func (m *Manager) richHeaderEnabled(ctx context.Context, token string, botID int64) bool {
+	enabled, cacheHit, err := m.cache.GetRichHeaderEnabled(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return false
+	}
+	m.cache.SetRichHeaderEnabled(ctx, token, botModel.RichHeaderEnabled)
+	return botModel.RichHeaderEnabled
+}
+
+// formatUserInfo creates a formatted user info header. Names and
+// usernames are user-controlled, so they are escaped: an unclosed tag
+// in a display name would make Telegram reject the whole HTML message.
 func formatUserInfo(user *telebot.User) string {
 	info := "📩 <b>New Message</b>\n"
 	info += "━━━━━━━━━━━━━━━\n"
 	info += "👤 <b>From:</b> "
 
 	if user.FirstName != "" {
-		info += user.FirstName
+		info += html.EscapeString(user.FirstName)
+	}
+	if user.LastName != "" {
+		info += " " + html.EscapeString(user.LastName)
+	}
+	info += "\n"
+
+	if user.Username != "" {
+		info += "🔗 <b>Username:</b> @" + html.EscapeString(user.Username) + "\n"
+	}
+
+	info += "🆔 <b>ID:</b> <code>" + formatInt64(user.ID) + "</code>\n"
+	info += "━━━━━━━━━━━━━━━"
+
+	return info
+}
+
+// formatUserInfoRich creates the extended user info header with the
+// user's prior message count and a clickable permalink. Like
+// formatUserInfo it escapes the user-controlled fields.
+func formatUserInfoRich(user *telebot.User, priorMessages int64) string {
+	info := "📩 <b>New Message</b>\n"
+	info += "━━━━━━━━━━━━━━━\n"
+	info += "👤 <b>From:</b> "
+
+	if user.FirstName != "" {
+		info += html.EscapeString(user.FirstName)
 	}
 	if user.LastName != "" {
-		info += " " + user.LastName
+		info += " " + html.EscapeString(user.LastName)
 	}
 	info += "\n"
 
 	if user.Username != "" {
-		info += "🔗 <b>Username:</b> @" + user.Username + "\n"
+		info += "🔗 <b>Username:</b> @" + html.EscapeString(user.Username) + "\n"
 	}
 
 	info += "🆔 <b>ID:</b> <code>" + formatInt64(user.ID) + "</code>\n"
+	info += "💬 <b>Prior Messages:</b> " + formatInt64(priorMessages) + "\n"
+	info += fmt.Sprintf(`🏷 <a href="tg://user?id=%d">Open Profile</a>`, user.ID) + "\n"
 	info += "━━━━━━━━━━━━━━━"
 
 	return info
@@ -0,0 +1,281 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Privacy / Erasure Functions ====================
+
+// Owners can wipe a single user's data ("erase" reply command, e.g. for a
+// GDPR request) or reset all of a bot's statistics. Both actions are
+// irreversible, so they require two confirmation taps and are written to
+// the audit log.
+
+// defaultPrivacyNotice is served by /privacy when the owner has not
+// configured a custom notice
+const defaultPrivacyNotice = "🔏 Messages you send here are forwarded to the bot operator and stored so they can reply to you."
+
+// privacyNotice returns the bot's configured privacy notice ("" = off),
+// cache-first with a DB fallback
+func (m *Manager) privacyNotice(ctx context.Context, token string, botID int64) string {
+	notice, cacheHit, err := m.cache.GetPrivacyNotice(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return notice
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return ""
+	}
+	m.cache.SetPrivacyNotice(ctx, token, botModel.PrivacyNotice)
+	return botModel.PrivacyNotice
+}
+
+// handlePrivacyCommand serves /privacy for end users: the owner's custom
+// notice, or a generic retention notice when none is configured
+func (m *Manager) handlePrivacyCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		notice := m.privacyNotice(ctx, token, botID)
+		if notice == "" {
+			notice = defaultPrivacyNotice
+		}
+		return c.Reply(notice)
+	}
+}
+
+// handleSetPrivacyNoticeBtn initiates state to change the privacy notice
+func (m *Manager) handleSetPrivacyNoticeBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_privacy_notice"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "<i>(None)</i>"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.PrivacyNotice != "" {
+			current = strings.ReplaceAll(currentBot.PrivacyNotice, "<", "&lt;")
+			current = strings.ReplaceAll(current, ">", "&gt;")
+		}
+
+		msg := fmt.Sprintf(`🔏 <b>Privacy Notice</b>
+
+<b>Current Notice:</b>
+<pre>%s</pre>
+
+The notice is appended to the welcome message and served by /privacy, telling users their messages are forwarded and stored.
+
+Send the new notice text, or <code>off</code> to remove it.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// processSetPrivacyNotice stores the notice text sent by the owner
+func (m *Manager) processSetPrivacyNotice(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	notice := strings.TrimSpace(c.Text())
+	if notice == "" {
+		return c.Reply("⚠️ Please send a text message.")
+	}
+	if strings.EqualFold(notice, "off") {
+		notice = ""
+	}
+
+	if err := m.repo.UpdateBotPrivacyNotice(ctx, botID, notice); err != nil {
+		return c.Reply("❌ Failed to update privacy notice.")
+	}
+
+	// Cache the new notice so /start and /privacy pick it up immediately
+	m.cache.SetPrivacyNotice(ctx, token, notice)
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if notice == "" {
+		return c.Reply("✅ <b>Privacy Notice Removed!</b>", telebot.ModeHTML)
+	}
+	return c.Reply("✅ <b>Privacy Notice Updated!</b>\n\nIt will be appended to the welcome message and served by /privacy.", telebot.ModeHTML)
+}
+
+// handleEraseUserCommand processes the "erase" reply command: it shows the
+// first of two confirmation prompts before any data is touched
+func (m *Manager) handleEraseUserCommand(ctx context.Context, c telebot.Context, userChatID int64) error {
+	menu := &telebot.ReplyMarkup{}
+	btnConfirm := menu.Data("⚠️ Yes, erase", "erase_user_confirm", strconv.FormatInt(userChatID, 10))
+	btnCancel := menu.Data("❌ Cancel", "erase_cancel")
+	menu.Inline(menu.Row(btnConfirm, btnCancel))
+
+	msg := fmt.Sprintf(`🗑 <b>Erase User Data</b>
+
+This will permanently delete all stored data for user <code>%d</code>:
+messages, replies, delivery records, ban entry and ratings.
+
+<b>This cannot be undone.</b>`, userChatID)
+
+	return c.Reply(msg, menu, telebot.ModeHTML)
+}
+
+// handleEraseUserConfirm shows the second confirmation prompt
+func (m *Manager) handleEraseUserConfirm(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid user!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("🗑 Erase permanently", "erase_user_execute", strconv.FormatInt(userChatID, 10))
+		btnCancel := menu.Data("❌ Cancel", "erase_cancel")
+		menu.Inline(menu.Row(btnConfirm), menu.Row(btnCancel))
+
+		msg := fmt.Sprintf(`🗑 <b>Erase User Data — Final Confirmation</b>
+
+Really delete everything stored for user <code>%d</code>?`, userChatID)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleEraseUserExecute performs the erasure after both confirmations
+func (m *Manager) handleEraseUserExecute(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid user!", ShowAlert: true})
+		}
+
+		if err := m.repo.EraseUserData(ctx, botID, userChatID); err != nil {
+			log.Printf("Failed to erase data for user %d: %v", userChatID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to erase user data!", ShowAlert: true})
+		}
+
+		// Drop every cached trace of the user too
+		if err := m.cache.PurgeUserKeys(ctx, token, userChatID); err != nil {
+			log.Printf("Failed to purge cache keys for user %d: %v", userChatID, err)
+		}
+
+		if err := m.repo.RecordAudit(ctx, botID, c.Sender().ID, models.AuditActionEraseUser, strconv.FormatInt(userChatID, 10)); err != nil {
+			log.Printf("Failed to record audit log: %v", err)
+		}
+		log.Printf("Erased all data for user %d of bot %d (requested by %d)", userChatID, botID, c.Sender().ID)
+
+		c.Respond(&telebot.CallbackResponse{Text: "✅ User data erased"})
+		return c.Edit(fmt.Sprintf("✅ <b>User Data Erased</b>\n\nAll stored data for user <code>%d</code> has been deleted.", userChatID), telebot.ModeHTML)
+	}
+}
+
+// handleEraseCancel aborts either erasure flow without touching data
+func (m *Manager) handleEraseCancel(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+		c.Respond(&telebot.CallbackResponse{Text: "Cancelled"})
+		return c.Edit("❌ Cancelled. No data was deleted.")
+	}
+}
+
+// handleResetStatsBtn shows the first confirmation prompt for a full
+// statistics reset
+func (m *Manager) handleResetStatsBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("⚠️ Yes, reset", "reset_stats_confirm")
+		btnCancel := menu.Data("❌ Cancel", "child_stats")
+		menu.Inline(menu.Row(btnConfirm, btnCancel))
+
+		msg := `🗑 <b>Reset Statistics</b>
+
+This will permanently delete all message logs, reply mappings, delivery records and ratings for this bot. Bans, users and settings are kept.
+
+<b>This cannot be undone.</b>`
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleResetStatsConfirm shows the second confirmation prompt
+func (m *Manager) handleResetStatsConfirm(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnConfirm := menu.Data("🗑 Reset permanently", "reset_stats_execute")
+		btnCancel := menu.Data("❌ Cancel", "child_stats")
+		menu.Inline(menu.Row(btnConfirm), menu.Row(btnCancel))
+
+		return c.Edit("🗑 <b>Reset Statistics — Final Confirmation</b>\n\nReally delete all statistics for this bot?", menu, telebot.ModeHTML)
+	}
+}
+
+// handleResetStatsExecute performs the reset after both confirmations
+func (m *Manager) handleResetStatsExecute(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		if err := m.repo.ResetBotStats(ctx, botID); err != nil {
+			log.Printf("Failed to reset stats for bot %d: %v", botID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to reset statistics!", ShowAlert: true})
+		}
+
+		if err := m.repo.RecordAudit(ctx, botID, c.Sender().ID, models.AuditActionResetStats, ""); err != nil {
+			log.Printf("Failed to record audit log: %v", err)
+		}
+		log.Printf("Reset statistics for bot %d (requested by %d)", botID, c.Sender().ID)
+
+		c.Respond(&telebot.CallbackResponse{Text: "✅ Statistics reset"})
+		return m.handleChildStats(bot, token, ownerChat)(c)
+	}
+}
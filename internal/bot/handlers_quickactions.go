@@ -0,0 +1,208 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// muteDuration is how long a quick-action mute lasts
+const muteDuration = 24 * time.Hour
+
+// quickActionsMenu builds the inline keyboard attached under forwarded
+// user messages
+func quickActionsMenu() *telebot.ReplyMarkup {
+	menu := &telebot.ReplyMarkup{}
+	btnBan := menu.Data("🚫 Ban", "qa_ban")
+	btnInfo := menu.Data("ℹ️ Info", "qa_info")
+	btnTag := menu.Data("🏷 Tag", "qa_tag")
+	btnMute := menu.Data("🔇 Mute 24h", "qa_mute")
+
+	menu.Inline(
+		menu.Row(btnBan, btnInfo),
+		menu.Row(btnTag, btnMute),
+	)
+	return menu
+}
+
+// resolveConversationUser finds the user behind any admin-side message of a
+// conversation, cache-first then message_logs
+func (m *Manager) resolveConversationUser(ctx context.Context, token string, botID int64, adminMsgID int) (int64, error) {
+	userChatID, err := m.cache.GetMessageLink(ctx, token, adminMsgID)
+	if err == nil && userChatID != 0 {
+		return userChatID, nil
+	}
+
+	userChatID, err = m.repo.GetUserChatID(ctx, adminMsgID, botID)
+	if err != nil {
+		return 0, err
+	}
+	return userChatID, nil
+}
+
+// forwardOriginUser extracts the original sender's ID from a forwarded
+// message's metadata; returns 0 when the sender hid their account or the
+// message was not forwarded from a user
+func forwardOriginUser(msg *telebot.Message) int64 {
+	if msg == nil {
+		return 0
+	}
+	if msg.OriginalSender != nil {
+		return msg.OriginalSender.ID
+	}
+	if msg.Origin != nil && msg.Origin.Sender != nil {
+		return msg.Origin.Sender.ID
+	}
+	return 0
+}
+
+// handleQuickSendByID delivers the owner's pending reply to the user the
+// forward metadata pointed at, after the normal mapping expired
+func (m *Manager) handleQuickSendByID(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil || userChatID == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid user ID.", ShowAlert: true})
+		}
+
+		// The notice replies to the owner's pending message, so that is
+		// what gets delivered
+		pending := c.Message().ReplyTo
+		if pending == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "The original reply is gone.", ShowAlert: true})
+		}
+
+		userChat := &telebot.Chat{ID: userChatID}
+		_, err = bot.Copy(userChat, pending)
+
+		status, errorCode := deliveryStatusFor(err)
+		if recordErr := m.repo.RecordDelivery(ctx, botID, userChatID, models.DeliverySourceReply, status, errorCode); recordErr != nil {
+			log.Printf("Failed to record delivery for user %d: %v", userChatID, recordErr)
+		}
+
+		if err != nil {
+			log.Printf("Failed to send reply by ID to user %d: %v", userChatID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to send — the user may have blocked the bot.", ShowAlert: true})
+		}
+
+		// Re-link the conversation so the owner's next replies route normally
+		m.linkAdminMessage(ctx, token, botID, pending.ID, userChatID)
+
+		c.Respond(&telebot.CallbackResponse{Text: "✅ Sent."})
+		return c.Reply(fmt.Sprintf("✅ Delivered to user <code>%d</code>.", userChatID), telebot.ModeHTML)
+	}
+}
+
+// handleQuickBan bans the user behind the tapped message
+func (m *Manager) handleQuickBan(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := m.resolveConversationUser(ctx, token, botID, c.Message().ID)
+		if err != nil || userChatID == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Could not find the sender. The message may be too old.", ShowAlert: true})
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: "Banning user..."})
+		return m.handleBanCommand(ctx, c, bot, token, userChatID)
+	}
+}
+
+// handleQuickInfo shows details for the user behind the tapped message
+func (m *Manager) handleQuickInfo(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := m.resolveConversationUser(ctx, token, botID, c.Message().ID)
+		if err != nil || userChatID == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Could not find the sender. The message may be too old.", ShowAlert: true})
+		}
+
+		c.Respond(&telebot.CallbackResponse{})
+		return m.sendUserInfo(ctx, c, bot, token, botID, userChatID)
+	}
+}
+
+// handleQuickTag replies with a clickable mention of the user so the owner
+// can reference them elsewhere
+func (m *Manager) handleQuickTag(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := m.resolveConversationUser(ctx, token, botID, c.Message().ID)
+		if err != nil || userChatID == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Could not find the sender. The message may be too old.", ShowAlert: true})
+		}
+
+		name := fmt.Sprintf("%d", userChatID)
+		if chat, err := bot.ChatByID(userChatID); err == nil && chat.FirstName != "" {
+			name = chat.FirstName
+		}
+
+		c.Respond(&telebot.CallbackResponse{})
+		return c.Reply(fmt.Sprintf(`🏷 <a href="tg://user?id=%d">%s</a> (<code>%d</code>)`, userChatID, name, userChatID), telebot.ModeHTML)
+	}
+}
+
+// handleQuickMute hides the user's messages for 24 hours without banning them
+func (m *Manager) handleQuickMute(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := m.resolveConversationUser(ctx, token, botID, c.Message().ID)
+		if err != nil || userChatID == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Could not find the sender. The message may be too old.", ShowAlert: true})
+		}
+
+		if err := m.cache.MuteUser(ctx, token, userChatID, muteDuration); err != nil {
+			log.Printf("Failed to mute user %d: %v", userChatID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to mute user.", ShowAlert: true})
+		}
+
+		return c.Respond(&telebot.CallbackResponse{Text: "🔇 User muted for 24 hours."})
+	}
+}
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Quick-Reply Keyboard Handlers ====================
+
+// quickReplyKeyboard builds the persistent reply keyboard shown to end
+// users, or nil if the bot has no quick-reply buttons. Buttons send their
+// label as a plain message, which the normal keyword auto-reply matching
+// then answers.
+func (m *Manager) quickReplyKeyboard(ctx context.Context, token string, botID int64) *telebot.ReplyMarkup {
+	triggers, cacheHit, err := m.cache.GetQuickReplies(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting quick replies: %v", err)
+	}
+
+	if !cacheHit {
+		// Cache miss - fallback to DB
+		triggers, err = m.repo.GetQuickReplyTriggers(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting quick-reply triggers: %v", err)
+			return nil
+		}
+		// Cache for next time
+		m.cache.SetQuickReplies(ctx, token, triggers)
+	}
+
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	menu := &telebot.ReplyMarkup{ResizeKeyboard: true}
+	var rows []telebot.Row
+	for i := 0; i < len(triggers); i += 2 {
+		if i+1 < len(triggers) {
+			rows = append(rows, menu.Row(menu.Text(triggers[i]), menu.Text(triggers[i+1])))
+		} else {
+			rows = append(rows, menu.Row(menu.Text(triggers[i])))
+		}
+	}
+	menu.Reply(rows...)
+	return menu
+}
+
+// handleQuickReplyList shows the keyword auto-replies with a marker for
+// those pinned to the quick-reply keyboard; tapping one toggles it
+func (m *Manager) handleQuickReplyList(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		replies, err := m.repo.GetAutoReplies(ctx, botID, "keyword")
+		if err != nil {
+			log.Printf("Error getting auto-replies: %v", err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Error fetching data", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+
+		if len(replies) == 0 {
+			btnBack := menu.Data("« Back", "auto_replies_menu")
+			menu.Inline(menu.Row(btnBack))
+			return c.Edit("⌨️ <b>Quick-Reply Keyboard</b>\n\n<i>Add a keyword auto-reply first, then pin it here as a keyboard button.</i>", menu, telebot.ModeHTML)
+		}
+
+		var rows []telebot.Row
+		pinnedCount := 0
+		for _, r := range replies {
+			// Truncate long triggers for button display
+			displayTrigger := r.TriggerWord
+			if len(displayTrigger) > 20 {
+				displayTrigger = displayTrigger[:17] + "..."
+			}
+
+			marker := "▫️"
+			if r.IsQuickReply {
+				marker = "📌"
+				pinnedCount++
+			}
+
+			btn := menu.Data(fmt.Sprintf("%s %s", marker, displayTrigger), "toggle_quick_reply", fmt.Sprintf("%d", r.ID))
+			rows = append(rows, menu.Row(btn))
+		}
+
+		btnBack := menu.Data("« Back", "auto_replies_menu")
+		rows = append(rows, menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		msg := fmt.Sprintf(`⌨️ <b>Quick-Reply Keyboard</b> (%d pinned)
+
+Pinned auto-replies appear as a persistent keyboard under the text field for your users. Tapping a button sends its keyword and triggers the auto-reply.
+
+Tap an entry to pin 📌 or unpin ▫️ it:`, pinnedCount)
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleToggleQuickReply pins or unpins one auto-reply on the keyboard
+func (m *Manager) handleToggleQuickReply(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		// Get ID from callback data
+		data := c.Callback().Data
+		var replyID int64
+		if _, err := fmt.Sscanf(data, "%d", &replyID); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid data", ShowAlert: true})
+		}
+
+		// Scoped lookup so a forged ID cannot touch another bot's replies
+		reply, err := m.repo.GetAutoReplyByID(ctx, botID, replyID)
+		if err != nil || reply == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Reply not found", ShowAlert: true})
+		}
+
+		newValue := !reply.IsQuickReply
+		if err := m.repo.SetAutoReplyQuickReply(ctx, botID, replyID, newValue); err != nil {
+			log.Printf("Error updating quick-reply flag: %v", err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Error updating", ShowAlert: true})
+		}
+
+		// Invalidate cached keyboard
+		if err := m.cache.InvalidateQuickReplies(ctx, token); err != nil {
+			log.Printf("Failed to invalidate quick replies cache: %v", err)
+		}
+
+		if newValue {
+			c.Respond(&telebot.CallbackResponse{Text: "📌 Pinned to keyboard"})
+		} else {
+			c.Respond(&telebot.CallbackResponse{Text: "▫️ Removed from keyboard"})
+		}
+
+		// Reload the list to show updated markers
+		return m.handleQuickReplyList(bot, token, ownerChat)(c)
+	}
+}
@@ -0,0 +1,196 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Channel Relay Functions ====================
+
+// handleChannelPost relays new posts from the configured source channel to
+// every bot user, turning the contact bot into a channel mirror
+func (m *Manager) handleChannelPost(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		msg := c.Message()
+		if msg == nil || msg.Chat == nil || msg.Chat.Type != telebot.ChatChannel {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		relayChannelID := m.relayChannel(ctx, token, botID)
+		if relayChannelID == 0 || msg.Chat.ID != relayChannelID {
+			return nil
+		}
+
+		userIDs, err := m.repo.GetAllUserChatIDs(ctx, botID)
+		if err != nil {
+			log.Printf("Failed to get user list for channel relay: %v", err)
+			return nil
+		}
+
+		success := 0
+		blocked := 0
+		failed := 0
+
+		for _, userID := range userIDs {
+			userChat := &telebot.Chat{ID: userID}
+			_, err := bot.Copy(userChat, msg)
+
+			status, errorCode := deliveryStatusFor(err)
+			if recordErr := m.repo.RecordDelivery(ctx, botID, userID, models.DeliverySourceBroadcast, status, errorCode); recordErr != nil {
+				log.Printf("Failed to record relay delivery for %d: %v", userID, recordErr)
+			}
+
+			switch status {
+			case models.DeliveryStatusDelivered:
+				success++
+			case models.DeliveryStatusBlocked:
+				blocked++
+				if markErr := m.repo.MarkUserUnreachable(ctx, botID, userID); markErr != nil {
+					log.Printf("Failed to mark user %d unreachable: %v", userID, markErr)
+				}
+			default:
+				failed++
+				log.Printf("Failed to relay post to %d: %v", userID, err)
+			}
+
+			// Rate limiting: 40ms delay between messages (max ~25 msg/sec)
+			time.Sleep(40 * time.Millisecond)
+		}
+
+		log.Printf("Relayed channel post %d to bot %d users: %d sent, %d blocked, %d failed",
+			msg.ID, botID, success, blocked, failed)
+		return nil
+	}
+}
+
+// handleSetRelayChannelBtn initiates the relay channel configuration flow
+func (m *Manager) handleSetRelayChannelBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_relay_channel"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "<i>(None)</i>"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.RelayChannelID != 0 {
+			current = fmt.Sprintf("<code>%d</code>", currentBot.RelayChannelID)
+		}
+
+		msg := fmt.Sprintf(`📡 <b>Channel Relay</b>
+
+<b>Current Channel:</b> %s
+
+New posts in the source channel are automatically sent to every bot user.
+
+<b>Step 1:</b> Make the bot an <b>admin</b> in the channel
+<b>Step 2:</b> Forward any message from the channel, or send its username
+
+Send <code>0</code> to turn the relay off.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// processSetRelayChannel processes the channel input for the relay setting
+func (m *Manager) processSetRelayChannel(ctx context.Context, c telebot.Context, bot *telebot.Bot, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	msg := c.Message()
+	text := strings.TrimSpace(c.Text())
+
+	// Disable the relay
+	if text == "0" {
+		if err := m.repo.UpdateBotRelayChannel(ctx, botID, 0); err != nil {
+			return c.Reply("❌ Failed to update relay channel.")
+		}
+		m.cache.SetRelayChannel(ctx, token, 0)
+		m.cache.ClearUserState(ctx, token, c.Sender().ID)
+		m.refreshWebhook(ctx, bot, token, botID)
+		return c.Reply("✅ <b>Channel Relay Disabled!</b>", telebot.ModeHTML)
+	}
+
+	var channelID int64
+	var channelTitle string
+
+	// Method 1: Forwarded message from the channel
+	if msg.OriginalChat != nil && msg.OriginalChat.Type == telebot.ChatChannel {
+		channelID = msg.OriginalChat.ID
+		channelTitle = msg.OriginalChat.Title
+	}
+
+	// Method 2: Username or numeric ID
+	if channelID == 0 && text != "" {
+		lookup := strings.TrimPrefix(text, "@")
+		if numID, err := strconv.ParseInt(lookup, 10, 64); err == nil {
+			if chat, err := bot.ChatByID(numID); err == nil && chat.Type == telebot.ChatChannel {
+				channelID = chat.ID
+				channelTitle = chat.Title
+			}
+		} else if chat, err := bot.ChatByUsername(lookup); err == nil && chat.Type == telebot.ChatChannel {
+			channelID = chat.ID
+			channelTitle = chat.Title
+		}
+	}
+
+	if channelID == 0 {
+		return c.Reply("⚠️ Channel not found. Forward a message from the channel (with the bot as admin), or send <code>0</code> to disable.", telebot.ModeHTML)
+	}
+
+	if err := m.repo.UpdateBotRelayChannel(ctx, botID, channelID); err != nil {
+		log.Printf("Failed to update relay channel: %v", err)
+		return c.Reply("❌ Failed to update relay channel.")
+	}
+
+	// Cache the new channel and request channel_post updates
+	m.cache.SetRelayChannel(ctx, token, channelID)
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+	m.refreshWebhook(ctx, bot, token, botID)
+
+	if channelTitle == "" {
+		channelTitle = strconv.FormatInt(channelID, 10)
+	}
+	return c.Reply(fmt.Sprintf("✅ <b>Channel Relay Enabled!</b>\n\nNew posts in <b>%s</b> will be sent to all bot users.", channelTitle), telebot.ModeHTML)
+}
+
+// relayChannel returns the configured relay source channel ID (0 = off),
+// cache-first with a DB fallback
+func (m *Manager) relayChannel(ctx context.Context, token string, botID int64) int64 {
+	channelID, cacheHit, err := m.cache.GetRelayChannel(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return channelID
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetRelayChannel(ctx, token, botModel.RelayChannelID)
+	return botModel.RelayChannelID
+}
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// SetAdminNotifier installs the callback used to alert the factory admin
+// about new abuse reports. The factory bot delivers the message, since
+// child bots cannot reach the admin directly.
+func (m *Manager) SetAdminNotifier(notify func(text string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifyAdmin = notify
+}
+
+// handleReportCommand lets end users report a hosted bot to the factory
+// admin, e.g. "/report spam links"
+func (m *Manager) handleReportCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		// Owners cannot report their own bot
+		if c.Sender().ID == ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		notify := m.notifyAdmin
+		m.mu.RUnlock()
+
+		reason := strings.TrimSpace(c.Message().Payload)
+
+		if err := m.repo.CreateAbuseReport(ctx, botID, c.Sender().ID, reason); err != nil {
+			log.Printf("Failed to create abuse report for bot %d: %v", botID, err)
+			return c.Reply("❌ Failed to submit your report. Please try again later.")
+		}
+
+		if notify != nil {
+			text := fmt.Sprintf("🚨 <b>New Abuse Report</b>\n\n<b>Bot:</b> @%s (ID: %d)", bot.Me.Username, botID)
+			if reason != "" {
+				text += fmt.Sprintf("\n<b>Reason:</b> %s", reason)
+			}
+			notify(text)
+		}
+
+		return c.Reply("✅ Your report has been sent to the moderators. Thank you.")
+	}
+}
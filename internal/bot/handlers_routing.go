@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/cache"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Routing Rule Functions ====================
+
+// handleRoutingMenu shows the keyword routing rules and management buttons
+func (m *Manager) handleRoutingMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		rules, err := m.repo.GetRoutingRules(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting routing rules: %v", err)
+		}
+
+		var msgBuilder strings.Builder
+		msgBuilder.WriteString("🔀 <b>Keyword Routing</b>\n\n")
+		msgBuilder.WriteString("Messages containing a keyword are forwarded to the matching chat instead of you.\n\n")
+
+		if len(rules) > 0 {
+			msgBuilder.WriteString(fmt.Sprintf("<b>Rules (%d):</b>\n", len(rules)))
+			for i, rule := range rules {
+				prefix := "├"
+				if i == len(rules)-1 {
+					prefix = "└"
+				}
+				msgBuilder.WriteString(fmt.Sprintf("%s <code>%s</code> → <code>%d</code>\n", prefix, rule.Keyword, rule.DestChatID))
+			}
+		} else {
+			msgBuilder.WriteString("<i>No rules configured</i>\n")
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		for _, rule := range rules {
+			btnDelete := menu.Data(fmt.Sprintf("🗑 %s", rule.Keyword), "del_routing_rule", strconv.FormatInt(rule.ID, 10))
+			rows = append(rows, menu.Row(btnDelete))
+		}
+		btnAdd := menu.Data("➕ Add Rule", "add_routing_rule")
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		rows = append(rows, menu.Row(btnAdd), menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		return c.Edit(msgBuilder.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// handleAddRoutingRule initiates the add rule flow
+func (m *Manager) handleAddRoutingRule(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_routing_rule"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "routing_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		msg := `➕ <b>Add Routing Rule</b>
+
+Send the keyword and the destination chat ID separated by a space:
+
+<code>refund -1001234567890</code>
+
+<i>Note: The bot must be able to send messages to the destination chat (add it to the group, or have the operator start the bot).</i>`
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleDeleteRoutingRule removes a rule from its button in the routing menu
+func (m *Manager) handleDeleteRoutingRule(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		ruleID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid rule!", ShowAlert: true})
+		}
+
+		if err := m.repo.DeleteRoutingRule(ctx, botID, ruleID, ownerChat.ID); err != nil {
+			log.Printf("Failed to delete routing rule %d: %v", ruleID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to delete rule!", ShowAlert: true})
+		}
+
+		// Invalidate cache so routing picks up the change
+		if err := m.cache.InvalidateRoutingRules(ctx, token); err != nil {
+			log.Printf("Failed to invalidate routing rules cache: %v", err)
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: "Rule deleted"})
+
+		// Refresh menu
+		return m.handleRoutingMenu(bot, token, ownerChat)(c)
+	}
+}
+
+// processAddRoutingRule processes the "keyword chat_id" input
+func (m *Manager) processAddRoutingRule(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	parts := strings.Fields(c.Text())
+	if len(parts) != 2 {
+		return c.Reply("⚠️ Please send the keyword and chat ID separated by a space, e.g. <code>refund -1001234567890</code>", telebot.ModeHTML)
+	}
+
+	keyword := strings.ToLower(parts[0])
+	if len(keyword) > 100 {
+		return c.Reply("⚠️ The keyword must be 100 characters or less.")
+	}
+
+	destChatID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return c.Reply("⚠️ The chat ID must be a number, e.g. <code>-1001234567890</code>", telebot.ModeHTML)
+	}
+
+	if err := m.repo.CreateRoutingRule(ctx, botID, keyword, destChatID); err != nil {
+		log.Printf("Failed to create routing rule: %v", err)
+		return c.Reply("❌ Failed to save routing rule.")
+	}
+
+	// Invalidate cache so routing picks up the change
+	if err := m.cache.InvalidateRoutingRules(ctx, token); err != nil {
+		log.Printf("Failed to invalidate routing rules cache: %v", err)
+	}
+
+	// Clear state
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	return c.Reply(fmt.Sprintf("✅ <b>Routing Rule Added!</b>\n\nMessages containing <code>%s</code> will be forwarded to <code>%d</code>.", keyword, destChatID), telebot.ModeHTML)
+}
+
+// resolveRouteDestination returns the destination chat for a message, or 0
+// when no routing rule matches. Rules are cached as a single entry.
+func (m *Manager) resolveRouteDestination(ctx context.Context, token string, botID int64, text string) int64 {
+	if text == "" {
+		return 0
+	}
+
+	rules, cacheHit, err := m.cache.GetRoutingRules(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if !cacheHit {
+		// Cache miss - load from DB and cache it
+		dbRules, err := m.repo.GetRoutingRules(ctx, botID)
+		if err != nil {
+			log.Printf("Failed to get routing rules: %v", err)
+			return 0
+		}
+		rules = make([]cache.RoutingRuleCache, 0, len(dbRules))
+		for _, rule := range dbRules {
+			rules = append(rules, cache.RoutingRuleCache{Keyword: rule.Keyword, DestChatID: rule.DestChatID})
+		}
+		if err := m.cache.SetRoutingRules(ctx, token, rules); err != nil {
+			log.Printf("Failed to cache routing rules: %v", err)
+		}
+	}
+
+	lowerText := strings.ToLower(text)
+	for _, rule := range rules {
+		if rule.Keyword != "" && strings.Contains(lowerText, rule.Keyword) {
+			return rule.DestChatID
+		}
+	}
+	return 0
+}
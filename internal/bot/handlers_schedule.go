@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Amr-9/botforge/internal/database"
 	"github.com/Amr-9/botforge/internal/models"
 	"gopkg.in/telebot.v3"
 )
@@ -54,7 +55,7 @@ func (m *Manager) handleScheduleNewMessage(bot *telebot.Bot, token string, owner
 		ctx := context.Background()
 
 		// Set state
-		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "schedule_awaiting_message"); err != nil {
+		if err := m.startFlow(ctx, token, c.Sender().ID, "schedule_message"); err != nil {
 			return c.Respond(&telebot.CallbackResponse{
 				Text:      "Failed to start scheduling",
 				ShowAlert: true,
@@ -107,10 +108,10 @@ func (m *Manager) handleScheduleTypeSelection(bot *telebot.Bot, token string, ow
 		switch scheduleType {
 		case models.ScheduleTypeOnce:
 			msg = "⏰ <b>Send Once</b>\n\nSelect when to send:"
-			btn1h := menu.Data("🕐 In 1 Hour", "schedule_time_1h")
-			btn3h := menu.Data("🕐 In 3 Hours", "schedule_time_3h")
-			btn6h := menu.Data("🕐 In 6 Hours", "schedule_time_6h")
-			btn12h := menu.Data("🕐 In 12 Hours", "schedule_time_12h")
+			btn1h := menu.Data("🕐 In 1 Hour", "schedule_time", "1h")
+			btn3h := menu.Data("🕐 In 3 Hours", "schedule_time", "3h")
+			btn6h := menu.Data("🕐 In 6 Hours", "schedule_time", "6h")
+			btn12h := menu.Data("🕐 In 12 Hours", "schedule_time", "12h")
 			btnBack := menu.Data("« Back", "schedule_new")
 			menu.Inline(
 				menu.Row(btn1h, btn3h),
@@ -120,29 +121,24 @@ func (m *Manager) handleScheduleTypeSelection(bot *telebot.Bot, token string, ow
 
 		case models.ScheduleTypeDaily:
 			msg = "📆 <b>Send Daily</b>\n\nSelect time to send every day:"
-			btn6 := menu.Data("🌅 06:00", "schedule_time_daily_06:00")
-			btn9 := menu.Data("🌞 09:00", "schedule_time_daily_09:00")
-			btn12 := menu.Data("🌤️ 12:00", "schedule_time_daily_12:00")
-			btn15 := menu.Data("🌆 15:00", "schedule_time_daily_15:00")
-			btn18 := menu.Data("🌙 18:00", "schedule_time_daily_18:00")
-			btn21 := menu.Data("🌃 21:00", "schedule_time_daily_21:00")
-			btnBack := menu.Data("« Back", "schedule_new")
-			menu.Inline(
-				menu.Row(btn6, btn9),
-				menu.Row(btn12, btn15),
-				menu.Row(btn18, btn21),
-				menu.Row(btnBack),
-			)
+			rows := scheduleTimeRows(menu, "daily")
+			rows = append(rows, menu.Row(menu.Data("« Back", "schedule_new")))
+			menu.Inline(rows...)
 
 		case models.ScheduleTypeWeekly:
+			m.mu.RLock()
+			botID := m.botIDs[token]
+			m.mu.RUnlock()
+			clock := m.ownerClock(ctx, token, botID)
+
 			msg = "📅 <b>Send Weekly</b>\n\nSelect the day:"
-			btnSun := menu.Data("Sunday", "schedule_day_0")
-			btnMon := menu.Data("Monday", "schedule_day_1")
-			btnTue := menu.Data("Tuesday", "schedule_day_2")
-			btnWed := menu.Data("Wednesday", "schedule_day_3")
-			btnThu := menu.Data("Thursday", "schedule_day_4")
-			btnFri := menu.Data("Friday", "schedule_day_5")
-			btnSat := menu.Data("Saturday", "schedule_day_6")
+			btnSun := menu.Data(clock.Weekday(time.Sunday), "schedule_day_0")
+			btnMon := menu.Data(clock.Weekday(time.Monday), "schedule_day_1")
+			btnTue := menu.Data(clock.Weekday(time.Tuesday), "schedule_day_2")
+			btnWed := menu.Data(clock.Weekday(time.Wednesday), "schedule_day_3")
+			btnThu := menu.Data(clock.Weekday(time.Thursday), "schedule_day_4")
+			btnFri := menu.Data(clock.Weekday(time.Friday), "schedule_day_5")
+			btnSat := menu.Data(clock.Weekday(time.Saturday), "schedule_day_6")
 			btnBack := menu.Data("« Back", "schedule_new")
 			menu.Inline(
 				menu.Row(btnSun, btnMon),
@@ -182,23 +178,16 @@ func (m *Manager) handleScheduleDaySelection(bot *telebot.Bot, token string, own
 
 		// Show time selection
 		menu := &telebot.ReplyMarkup{}
-		btn6 := menu.Data("🌅 06:00", "schedule_time_weekly_06:00")
-		btn9 := menu.Data("🌞 09:00", "schedule_time_weekly_09:00")
-		btn12 := menu.Data("🌤️ 12:00", "schedule_time_weekly_12:00")
-		btn15 := menu.Data("🌆 15:00", "schedule_time_weekly_15:00")
-		btn18 := menu.Data("🌙 18:00", "schedule_time_weekly_18:00")
-		btn21 := menu.Data("🌃 21:00", "schedule_time_weekly_21:00")
-		btnBack := menu.Data("« Back", "schedule_type_weekly")
-		menu.Inline(
-			menu.Row(btn6, btn9),
-			menu.Row(btn12, btn15),
-			menu.Row(btn18, btn21),
-			menu.Row(btnBack),
-		)
+		rows := scheduleTimeRows(menu, "weekly")
+		rows = append(rows, menu.Row(menu.Data("« Back", "schedule_type_weekly")))
+		menu.Inline(rows...)
+
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
 
-		dayNames := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
 		dayNum, _ := strconv.Atoi(day)
-		dayName := dayNames[dayNum]
+		dayName := m.ownerClock(ctx, token, botID).Weekday(time.Weekday(dayNum))
 
 		msg := fmt.Sprintf("📅 <b>Send Weekly</b>\n\nDay: <b>%s</b>\n\nSelect time:", dayName)
 		return c.Edit(msg, menu, telebot.ModeHTML)
@@ -217,10 +206,10 @@ func (m *Manager) handleScheduleTimeSelection(bot *telebot.Bot, token string, ow
 
 		ctx := context.Background()
 
-		// Get time data from unique identifier
-		data := strings.TrimPrefix(c.Callback().Unique, "schedule_time_")
+		// Get time data from the callback payload
+		data := strings.TrimSpace(c.Data())
 
-		log.Printf("[Schedule] Time selected: %s (from unique: %s)", data, c.Callback().Unique)
+		log.Printf("[Schedule] Time selected: %s", data)
 
 		var scheduledTime time.Time
 		var timeOfDay string
@@ -280,11 +269,17 @@ func (m *Manager) showScheduleConfirmation(c telebot.Context, ctx context.Contex
 	// Get all data
 	msgType, msgText, _, caption, _ := m.cache.GetScheduleMessageData(ctx, token, adminID)
 	scheduleType, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_type")
+	targetStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_target")
 	scheduleTimeStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_time")
 	dayStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_day")
 
 	scheduledTime, _ := time.Parse("2006-01-02 15:04:05", scheduleTimeStr)
 
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+	clock := m.ownerClock(ctx, token, botID)
+
 	// Build preview
 	preview := "✅ <b>Message Preview:</b>\n"
 	if msgType == models.MessageTypeText {
@@ -304,16 +299,24 @@ func (m *Manager) showScheduleConfirmation(c telebot.Context, ctx context.Contex
 	scheduleInfo := "\n\n<b>Schedule:</b> "
 	switch scheduleType {
 	case models.ScheduleTypeOnce:
-		scheduleInfo += fmt.Sprintf("Once at %s", scheduledTime.Format("2006-01-02 15:04"))
+		scheduleInfo += fmt.Sprintf("Once at %s", clock.DateTime(scheduledTime))
 	case models.ScheduleTypeDaily:
 		scheduleInfo += fmt.Sprintf("Daily at %s", scheduledTime.Format("15:04"))
 	case models.ScheduleTypeWeekly:
-		dayNames := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
 		dayNum, _ := strconv.Atoi(dayStr)
-		scheduleInfo += fmt.Sprintf("Weekly on %s at %s", dayNames[dayNum], scheduledTime.Format("15:04"))
+		scheduleInfo += fmt.Sprintf("Weekly on %s at %s", clock.Weekday(time.Weekday(dayNum)), scheduledTime.Format("15:04"))
+	}
+
+	// Build target info
+	targetInfo := "\n<b>Target:</b> 👥 All Users"
+	if channelID, _ := strconv.ParseInt(targetStr, 10, 64); channelID != 0 {
+		targetInfo = fmt.Sprintf("\n<b>Target:</b> 📣 Channel %d", channelID)
+		if channel, err := m.repo.GetForcedChannel(ctx, botID, channelID); err == nil && channel != nil && channel.ChannelTitle != "" {
+			targetInfo = fmt.Sprintf("\n<b>Target:</b> 📣 %s", channel.ChannelTitle)
+		}
 	}
 
-	msg := preview + scheduleInfo + "\n\n<b>Confirm schedule?</b>"
+	msg := preview + scheduleInfo + targetInfo + "\n\n<b>Confirm schedule?</b>"
 
 	menu := &telebot.ReplyMarkup{}
 	btnConfirm := menu.Data("✅ Confirm & Schedule", "schedule_confirm")
@@ -343,6 +346,7 @@ func (m *Manager) handleConfirmSchedule(bot *telebot.Bot, token string, ownerCha
 		// Get all data
 		msgType, msgText, fileID, caption, _ := m.cache.GetScheduleMessageData(ctx, token, adminID)
 		scheduleType, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_type")
+		targetStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_target")
 		scheduleTimeStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_time")
 		timeOfDay, _ := m.cache.GetTempData(ctx, token, adminID, "time_of_day")
 		dayStr, _ := m.cache.GetTempData(ctx, token, adminID, "schedule_day")
@@ -357,20 +361,23 @@ func (m *Manager) handleConfirmSchedule(bot *telebot.Bot, token string, ownerCha
 			dayOfWeek = &day
 		}
 
+		targetChannelID, _ := strconv.ParseInt(targetStr, 10, 64)
+
 		// Create scheduled message
 		msg := &models.ScheduledMessage{
-			BotID:         botID,
-			OwnerChatID:   adminID,
-			MessageType:   msgType,
-			MessageText:   msgText,
-			FileID:        fileID,
-			Caption:       caption,
-			ScheduleType:  scheduleType,
-			ScheduledTime: scheduledTime,
-			TimeOfDay:     timeOfDay,
-			DayOfWeek:     dayOfWeek,
-			Status:        models.ScheduleStatusPending,
-			NextRunAt:     &nextRunAt,
+			BotID:           botID,
+			OwnerChatID:     adminID,
+			MessageType:     msgType,
+			MessageText:     msgText,
+			FileID:          fileID,
+			Caption:         caption,
+			ScheduleType:    scheduleType,
+			TargetChannelID: targetChannelID,
+			ScheduledTime:   scheduledTime,
+			TimeOfDay:       timeOfDay,
+			DayOfWeek:       dayOfWeek,
+			Status:          models.ScheduleStatusPending,
+			NextRunAt:       &nextRunAt,
 		}
 
 		msgID, err := m.repo.CreateScheduledMessage(ctx, msg)
@@ -396,13 +403,18 @@ func (m *Manager) handleConfirmSchedule(bot *telebot.Bot, token string, ownerCha
 			menu.Row(btnBack),
 		)
 
+		deliveryNote := "Your message will be broadcast to all users at the scheduled time."
+		if targetChannelID != 0 {
+			deliveryNote = "Your message will be posted to the channel at the scheduled time."
+		}
+
 		successMsg := fmt.Sprintf(`✅ <b>Message Scheduled Successfully!</b>
 
 <b>Message ID:</b> #%d
 <b>Type:</b> %s
 <b>Schedule:</b> %s
 
-Your message will be broadcast to all users at the scheduled time.`, msgID, scheduleType, nextRunAt.Format("2006-01-02 15:04"))
+%s`, msgID, scheduleType, m.ownerClock(ctx, token, botID).DateTime(nextRunAt), deliveryNote)
 
 		return c.Edit(successMsg, menu, telebot.ModeHTML)
 	}
@@ -420,7 +432,7 @@ func (m *Manager) handleListScheduledMessages(bot *telebot.Bot, token string, ow
 		botID := m.botIDs[token]
 		m.mu.RUnlock()
 
-		messages, err := m.repo.GetScheduledMessagesByBot(ctx, botID, 20, 0)
+		messages, err := m.repo.GetScheduledMessagesPage(ctx, botID, database.Cursor{}, 20)
 		if err != nil {
 			log.Printf("Failed to get scheduled messages: %v", err)
 			return c.Edit("❌ Failed to load scheduled messages", &telebot.ReplyMarkup{})
@@ -437,6 +449,8 @@ func (m *Manager) handleListScheduledMessages(bot *telebot.Bot, token string, ow
 			return c.Edit("📭 <b>No Scheduled Messages</b>\n\nYou don't have any scheduled messages yet.", menu, telebot.ModeHTML)
 		}
 
+		clock := m.ownerClock(ctx, token, botID)
+
 		msg := fmt.Sprintf("📋 <b>Scheduled Messages (%d active)</b>\n\n", len(messages))
 
 		menu := &telebot.ReplyMarkup{}
@@ -453,12 +467,11 @@ func (m *Manager) handleListScheduledMessages(bot *telebot.Bot, token string, ow
 			var scheduleInfo string
 			switch schedMsg.ScheduleType {
 			case models.ScheduleTypeOnce:
-				scheduleInfo = fmt.Sprintf("Once at %s", schedMsg.ScheduledTime.Format("01-02 15:04"))
+				scheduleInfo = fmt.Sprintf("Once at %s", clock.DateTime(schedMsg.ScheduledTime))
 			case models.ScheduleTypeDaily:
 				scheduleInfo = fmt.Sprintf("Daily at %s", schedMsg.ScheduledTime.Format("15:04"))
 			case models.ScheduleTypeWeekly:
-				dayNames := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
-				scheduleInfo = fmt.Sprintf("Weekly on %s", dayNames[*schedMsg.DayOfWeek])
+				scheduleInfo = fmt.Sprintf("Weekly on %s", clock.Weekday(time.Weekday(*schedMsg.DayOfWeek)))
 			}
 
 			// Build message preview
@@ -497,9 +510,12 @@ func (m *Manager) handleListScheduledMessages(bot *telebot.Bot, token string, ow
 
 			msg += fmt.Sprintf("%d️⃣ %s %s\n", i+1, statusIcon, scheduleInfo)
 			msg += fmt.Sprintf("   %s \"%s\"\n", previewIcon, preview)
+			if schedMsg.TargetChannelID != 0 {
+				msg += fmt.Sprintf("   📣 Posts to channel %d\n", schedMsg.TargetChannelID)
+			}
 			msg += fmt.Sprintf("   Type: %s | Status: %s\n   Next: %s\n\n",
 				schedMsg.MessageType, schedMsg.Status,
-				schedMsg.NextRunAt.Format("2006-01-02 15:04"))
+				clock.DateTime(*schedMsg.NextRunAt))
 
 			// Add action buttons
 			if schedMsg.Status == models.ScheduleStatusPending {
@@ -540,7 +556,7 @@ func (m *Manager) handlePauseScheduledMessage(bot *telebot.Bot, token string, ow
 		botID := m.botIDs[token]
 		m.mu.RUnlock()
 
-		if err := m.repo.PauseScheduledMessage(ctx, msgID, botID); err != nil {
+		if err := m.repo.PauseScheduledMessage(ctx, msgID, botID, ownerChat.ID); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Failed to pause", ShowAlert: true})
 		}
 
@@ -568,7 +584,7 @@ func (m *Manager) handleResumeScheduledMessage(bot *telebot.Bot, token string, o
 		botID := m.botIDs[token]
 		m.mu.RUnlock()
 
-		if err := m.repo.ResumeScheduledMessage(ctx, msgID, botID); err != nil {
+		if err := m.repo.ResumeScheduledMessage(ctx, msgID, botID, ownerChat.ID); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Failed to resume", ShowAlert: true})
 		}
 
@@ -596,7 +612,7 @@ func (m *Manager) handleDeleteScheduledMessage(bot *telebot.Bot, token string, o
 		botID := m.botIDs[token]
 		m.mu.RUnlock()
 
-		if err := m.repo.DeleteScheduledMessage(ctx, msgID, botID); err != nil {
+		if err := m.repo.DeleteScheduledMessage(ctx, msgID, botID, ownerChat.ID); err != nil {
 			return c.Respond(&telebot.CallbackResponse{Text: "Failed to delete", ShowAlert: true})
 		}
 
@@ -610,7 +626,7 @@ func (m *Manager) handleCancelSchedule(bot *telebot.Bot, token string) telebot.H
 	return func(c telebot.Context) error {
 		ctx := context.Background()
 		m.cache.ClearScheduleData(ctx, token, c.Sender().ID)
-		m.cache.ClearUserState(ctx, token, c.Sender().ID)
+		m.cancelFlow(ctx, token, c.Sender().ID)
 
 		c.Respond(&telebot.CallbackResponse{Text: "Cancelled"})
 
@@ -622,69 +638,179 @@ func (m *Manager) handleCancelSchedule(bot *telebot.Bot, token string) telebot.H
 	}
 }
 
-// processScheduleState processes schedule-related states
-func (m *Manager) processScheduleState(ctx context.Context, c telebot.Context, token string, state string) (bool, error) {
-	if state == "schedule_awaiting_message" {
-		msgType := ""
-		text := c.Text()
-		fileID := ""
-		caption := ""
-
-		// Determine message type
-		if c.Message().Photo != nil {
-			msgType = models.MessageTypePhoto
-			fileID = c.Message().Photo.FileID
-			caption = c.Message().Caption
-		} else if c.Message().Video != nil {
-			msgType = models.MessageTypeVideo
-			fileID = c.Message().Video.FileID
-			caption = c.Message().Caption
-		} else if c.Message().Document != nil {
-			msgType = models.MessageTypeDocument
-			fileID = c.Message().Document.FileID
-			caption = c.Message().Caption
-		} else if c.Text() != "" {
-			msgType = models.MessageTypeText
+// scheduleMessageFlow defines the message-collection step of the schedule
+// wizard; the remaining steps are callback-driven and keep their own
+// state writes
+func (m *Manager) scheduleMessageFlow() flow {
+	return flow{
+		name:  "schedule_message",
+		entry: "schedule_awaiting_message",
+		steps: map[string]flowStep{
+			"schedule_awaiting_message": {handle: m.stepScheduleMessage},
+		},
+	}
+}
+
+// stepScheduleMessage captures the message to schedule and asks for a type
+func (m *Manager) stepScheduleMessage(ctx context.Context, c telebot.Context, fs *flowSession) error {
+	msgType := ""
+	text := c.Text()
+	fileID := ""
+	caption := ""
+
+	// Determine message type
+	if c.Message().Photo != nil {
+		msgType = models.MessageTypePhoto
+		fileID = c.Message().Photo.FileID
+		caption = c.Message().Caption
+	} else if c.Message().Video != nil {
+		msgType = models.MessageTypeVideo
+		fileID = c.Message().Video.FileID
+		caption = c.Message().Caption
+	} else if c.Message().Document != nil {
+		msgType = models.MessageTypeDocument
+		fileID = c.Message().Document.FileID
+		caption = c.Message().Caption
+	} else if c.Text() != "" {
+		msgType = models.MessageTypeText
+	} else {
+		return c.Reply("⚠️ Unsupported message type. Please send text, photo, video, or document.")
+	}
+
+	// Validation
+	if msgType == models.MessageTypeText && len(text) > 4096 {
+		return c.Reply("⚠️ Text too long (max 4096 characters)")
+	}
+
+	// Save to Redis
+	m.cache.SetScheduleMessageData(ctx, fs.token, fs.userID, msgType, text, fileID, caption)
+
+	preview := "✅ Message received!\n\n"
+	if msgType == models.MessageTypeText {
+		if len(text) > 50 {
+			preview += fmt.Sprintf("📝 Text: %s...", text[:50])
 		} else {
-			return true, c.Reply("⚠️ Unsupported message type. Please send text, photo, video, or document.")
+			preview += fmt.Sprintf("📝 Text: %s", text)
+		}
+	} else {
+		preview += fmt.Sprintf("📎 Type: %s", msgType)
+	}
+
+	// Offer channel targets only when the bot administers forced-sub
+	// channels; otherwise go straight to the schedule type selection
+	m.mu.RLock()
+	botID := m.botIDs[fs.token]
+	m.mu.RUnlock()
+
+	channels, err := m.repo.GetForcedChannels(ctx, botID)
+	if err != nil {
+		log.Printf("[Schedule] Failed to get channels: %v", err)
+	}
+
+	if len(channels) == 0 {
+		m.cache.SetTempData(ctx, fs.token, fs.userID, "schedule_target", "0")
+		fs.advance(ctx, "schedule_select_type")
+		preview += "\n\n<b>Select schedule type:</b>"
+		return c.Reply(preview, scheduleTypeMenu(), telebot.ModeHTML)
+	}
+
+	fs.advance(ctx, "schedule_select_target")
+
+	menu := &telebot.ReplyMarkup{}
+	btnUsers := menu.Data("👥 All Users", "schedule_target_users")
+	rows := []telebot.Row{menu.Row(btnUsers)}
+	for _, ch := range channels {
+		title := ch.ChannelTitle
+		if title == "" {
+			title = fmt.Sprintf("Channel %d", ch.ChannelID)
+		}
+		btnChannel := menu.Data("📣 "+title, "schedule_target_channel", strconv.FormatInt(ch.ChannelID, 10))
+		rows = append(rows, menu.Row(btnChannel))
+	}
+	btnCancel := menu.Data("❌ Cancel", "schedule_cancel")
+	rows = append(rows, menu.Row(btnCancel))
+	menu.Inline(rows...)
+
+	preview += "\n\n<b>Where should it be sent?</b>"
+	return c.Reply(preview, menu, telebot.ModeHTML)
+}
+
+// scheduleTimePresets are the preset times of day for recurring
+// messages; adding an entry here is all a new slot needs
+var scheduleTimePresets = []struct {
+	label string
+	time  string
+}{
+	{"🌅 06:00", "06:00"},
+	{"🌞 09:00", "09:00"},
+	{"🌤️ 12:00", "12:00"},
+	{"🌆 15:00", "15:00"},
+	{"🌙 18:00", "18:00"},
+	{"🌃 21:00", "21:00"},
+}
+
+// scheduleTimeRows renders the preset times as parameterized
+// schedule_time buttons (payload "<prefix>_<time>"), two per row
+func scheduleTimeRows(menu *telebot.ReplyMarkup, prefix string) []telebot.Row {
+	var rows []telebot.Row
+	var row []telebot.Btn
+	for _, preset := range scheduleTimePresets {
+		row = append(row, menu.Data(preset.label, "schedule_time", prefix+"_"+preset.time))
+		if len(row) == 2 {
+			rows = append(rows, menu.Row(row...))
+			row = nil
 		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, menu.Row(row...))
+	}
+	return rows
+}
+
+// scheduleTypeMenu builds the once/daily/weekly selection keyboard
+func scheduleTypeMenu() *telebot.ReplyMarkup {
+	menu := &telebot.ReplyMarkup{}
+	btnOnce := menu.Data("⏰ Once", "schedule_type_once")
+	btnDaily := menu.Data("📆 Daily", "schedule_type_daily")
+	btnWeekly := menu.Data("📅 Weekly", "schedule_type_weekly")
+	btnCancel := menu.Data("❌ Cancel", "schedule_cancel")
+	menu.Inline(
+		menu.Row(btnOnce),
+		menu.Row(btnDaily, btnWeekly),
+		menu.Row(btnCancel),
+	)
+	return menu
+}
 
-		// Validation
-		if msgType == models.MessageTypeText && len(text) > 4096 {
-			return true, c.Reply("⚠️ Text too long (max 4096 characters)")
+// handleScheduleTargetSelection stores the broadcast target (all users or
+// one of the bot's channels) and moves on to the schedule type selection
+func (m *Manager) handleScheduleTargetSelection(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
 		}
 
-		// Save to Redis
-		m.cache.SetScheduleMessageData(ctx, token, c.Sender().ID, msgType, text, fileID, caption)
-		m.cache.SetUserState(ctx, token, c.Sender().ID, "schedule_select_type")
+		// Acknowledge callback
+		c.Respond()
 
-		// Show type selection
-		menu := &telebot.ReplyMarkup{}
-		btnOnce := menu.Data("⏰ Once", "schedule_type_once")
-		btnDaily := menu.Data("📆 Daily", "schedule_type_daily")
-		btnWeekly := menu.Data("📅 Weekly", "schedule_type_weekly")
-		btnCancel := menu.Data("❌ Cancel", "schedule_cancel")
-		menu.Inline(
-			menu.Row(btnOnce),
-			menu.Row(btnDaily, btnWeekly),
-			menu.Row(btnCancel),
-		)
+		ctx := context.Background()
 
-		preview := "✅ Message received!\n\n"
-		if msgType == models.MessageTypeText {
-			if len(text) > 50 {
-				preview += fmt.Sprintf("📝 Text: %s...", text[:50])
-			} else {
-				preview += fmt.Sprintf("📝 Text: %s", text)
+		target := "0"
+		if c.Callback().Unique == "schedule_target_channel" {
+			channelID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+			if err != nil {
+				return c.Respond(&telebot.CallbackResponse{Text: "Invalid channel!", ShowAlert: true})
 			}
-		} else {
-			preview += fmt.Sprintf("📎 Type: %s", msgType)
+			target = strconv.FormatInt(channelID, 10)
 		}
 
-		preview += "\n\n<b>Select schedule type:</b>"
+		log.Printf("[Schedule] Target selected: %s (from unique: %s)", target, c.Callback().Unique)
 
-		return true, c.Reply(preview, menu, telebot.ModeHTML)
-	}
+		if err := m.cache.SetTempData(ctx, token, c.Sender().ID, "schedule_target", target); err != nil {
+			log.Printf("[Schedule] Error saving target: %v", err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Error", ShowAlert: true})
+		}
 
-	return false, nil
+		return c.Edit("✅ Target saved!\n\n<b>Select schedule type:</b>", scheduleTypeMenu(), telebot.ModeHTML)
+	}
 }
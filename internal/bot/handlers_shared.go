@@ -0,0 +1,332 @@
+package bot
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// This file holds the shared handler tables for child bots. Handlers used
+// to be registered per bot instance as closures capturing token and owner
+// chat, which cost roughly a hundred closures and handler-map entries per
+// bot. Instead, every bot now registers only a handful of generic
+// endpoints pointing at Manager-level dispatchers, which resolve the
+// bot's runtime context from a registry and look the concrete handler up
+// in the tables below. Thousands of bots share one handler table.
+
+// childContext is the per-bot state the shared dispatchers need to serve
+// an update
+type childContext struct {
+	token     string
+	ownerChat *telebot.Chat
+}
+
+// childHandler builds the concrete telebot handler for one endpoint from
+// a bot's runtime context. Entries are method expressions, so the table
+// itself holds no per-bot state; the builder runs per update, which is
+// cheap since the factories only wrap their closure.
+type childHandler func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc
+
+// callbackUniqueRx extracts the button unique and payload from raw
+// callback data, mirroring telebot's own per-unique routing which only
+// runs for handlers registered via telebot.Btn
+var callbackUniqueRx = regexp.MustCompile(`^\f([-\w]+)(\|(.+))?$`)
+
+// childCommandHandlers routes slash commands of child bots
+var childCommandHandlers = map[string]childHandler{
+	"/start":   (*Manager).handleChildStart,
+	"/cancel":  (*Manager).handleCancelCommand,
+	"/report":  (*Manager).handleReportCommand,
+	"/privacy": (*Manager).handlePrivacyCommand,
+	"/help":    (*Manager).handleHelpCommand,
+	"/history": (*Manager).handleHistoryCommand,
+	"/search":  (*Manager).handleSearchCommand,
+	"/spam":    (*Manager).handleSpamCommand,
+}
+
+// childCallbackHandlers routes callback buttons of child bots by unique
+var childCallbackHandlers = buildChildCallbackHandlers()
+
+func buildChildCallbackHandlers() map[string]childHandler {
+	h := map[string]childHandler{
+		// Main menu and broadcast
+		"child_stats":        (*Manager).handleChildStats,
+		"child_broadcast":    (*Manager).handleChildBroadcast,
+		"child_settings":     (*Manager).handleChildSettings,
+		"set_start_msg":      (*Manager).handleSetStartMsgBtn,
+		"set_privacy_notice": (*Manager).handleSetPrivacyNoticeBtn,
+		"cancel_broadcast": func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+			return m.handleCancelBroadcast(bot, token)
+		},
+		"confirm_broadcast": (*Manager).handleConfirmBroadcast,
+		"broadcast_clicks":  (*Manager).handleBroadcastClicks,
+		"broadcast_history": (*Manager).handleBroadcastHistory,
+		"resend_broadcast":  (*Manager).handleResendBroadcast,
+		"dup_broadcast":     (*Manager).handleDuplicateBroadcast,
+		"back_to_settings":  (*Manager).handleBackToSettings,
+		"child_main_menu":   (*Manager).handleChildMainMenu,
+		"banned_list":       (*Manager).handleBannedUsersList,
+		"unban_user":        (*Manager).handleUnbanUser,
+
+		// Privacy tools (user erasure and statistics reset)
+		"erase_user_confirm":  (*Manager).handleEraseUserConfirm,
+		"erase_user_execute":  (*Manager).handleEraseUserExecute,
+		"erase_cancel":        (*Manager).handleEraseCancel,
+		"reset_stats":         (*Manager).handleResetStatsBtn,
+		"reset_stats_confirm": (*Manager).handleResetStatsConfirm,
+		"reset_stats_execute": (*Manager).handleResetStatsExecute,
+
+		// Message content logging (history purge)
+		"purge_content":         (*Manager).handlePurgeContentBtn,
+		"purge_content_execute": (*Manager).handlePurgeContentExecute,
+
+		// Spam review queue and classifier feedback
+		"spam_expand":  (*Manager).handleSpamExpand,
+		"spam_ham":     spamFeedbackHandler(models.SpamVerdictHam),
+		"spam_confirm": spamFeedbackHandler(models.SpamVerdictSpam),
+
+		// Text settings and menus
+		"set_confirm_emoji":   (*Manager).handleSetConfirmEmojiBtn,
+		"set_digest_interval": (*Manager).handleSetDigestIntervalBtn,
+		"set_digest_keywords": (*Manager).handleSetDigestKeywordsBtn,
+		"digest_expand":       (*Manager).handleDigestExpand,
+		"set_dedupe_window":   (*Manager).handleSetDedupeWindowBtn,
+		"set_sla_minutes":     (*Manager).handleSetSLABtn,
+		"rate_conv": func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+			return m.handleRateConversation(bot, token)
+		},
+		"media_policy_menu":  (*Manager).handleMediaPolicyMenu,
+		"set_blocked_media":  (*Manager).handleSetBlockedMediaBtn,
+		"set_max_file_size":  (*Manager).handleSetMaxFileSizeBtn,
+		"filters_menu":       (*Manager).handleFiltersMenu,
+		"cycle_filter":       (*Manager).handleCycleFilter,
+		"routing_menu":       (*Manager).handleRoutingMenu,
+		"add_routing_rule":   (*Manager).handleAddRoutingRule,
+		"del_routing_rule":   (*Manager).handleDeleteRoutingRule,
+		"short_links_menu":   (*Manager).handleShortLinksMenu,
+		"add_short_link":     (*Manager).handleAddShortLink,
+		"del_short_link":     (*Manager).handleDeleteShortLink,
+		"whitelist_menu":     (*Manager).handleWhitelistMenu,
+		"add_whitelist_user": (*Manager).handleAddWhitelistUser,
+		"del_whitelist_user": (*Manager).handleRemoveWhitelistUser,
+		"set_whitelist_msg":  (*Manager).handleSetWhitelistMsgBtn,
+		"reactions_menu":     (*Manager).handleReactionsMenu,
+		"cycle_reaction":     (*Manager).handleCycleReaction,
+
+		// Quick actions under forwarded user messages
+		"qa_ban":     (*Manager).handleQuickBan,
+		"qa_info":    (*Manager).handleQuickInfo,
+		"qa_tag":     (*Manager).handleQuickTag,
+		"qa_mute":    (*Manager).handleQuickMute,
+		"qa_send_id": (*Manager).handleQuickSendByID,
+
+		// Auto-Replies handlers
+		"auto_replies_menu":      (*Manager).handleAutoRepliesMenu,
+		"add_auto_reply":         (*Manager).handleAddAutoReply,
+		"add_custom_cmd":         (*Manager).handleAddCustomCommand,
+		"list_auto_replies":      (*Manager).handleListAutoReplies,
+		"list_custom_cmds":       (*Manager).handleListCustomCommands,
+		"del_reply":              (*Manager).handleDeleteAutoReply,
+		"toggle_forward_replies": (*Manager).handleToggleForwardReplies,
+		"quick_reply_list":       (*Manager).handleQuickReplyList,
+		"toggle_quick_reply":     (*Manager).handleToggleQuickReply,
+
+		// Forced Subscription handlers
+		"forced_sub_menu":      (*Manager).handleForcedSubMenu,
+		"toggle_forced_sub":    (*Manager).handleToggleForcedSub,
+		"add_forced_channel":   (*Manager).handleAddForcedChannel,
+		"list_forced_channels": (*Manager).handleListForcedChannels,
+		"del_forced_channel":   (*Manager).handleRemoveForcedChannel,
+		"set_forced_sub_msg":   (*Manager).handleSetForcedSubMsg,
+		"clear_forced_sub_msg": (*Manager).handleClearForcedSubMsg,
+		"set_fsub_grace":       (*Manager).handleSetFsubGraceBtn,
+		"fsub_exemptions":      (*Manager).handleFsubExemptions,
+		"check_subscription":   (*Manager).handleCheckSubscription,
+
+		// Schedule handlers
+		"schedule_menu":           (*Manager).handleScheduleMenu,
+		"schedule_new":            (*Manager).handleScheduleNewMessage,
+		"schedule_list":           (*Manager).handleListScheduledMessages,
+		"schedule_target_users":   (*Manager).handleScheduleTargetSelection,
+		"schedule_target_channel": (*Manager).handleScheduleTargetSelection,
+		"schedule_type_once":      (*Manager).handleScheduleTypeSelection,
+		"schedule_type_daily":     (*Manager).handleScheduleTypeSelection,
+		"schedule_type_weekly":    (*Manager).handleScheduleTypeSelection,
+		"schedule_time":           (*Manager).handleScheduleTimeSelection,
+		"schedule_confirm":        (*Manager).handleConfirmSchedule,
+		"schedule_cancel": func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+			return m.handleCancelSchedule(bot, token)
+		},
+		"schedule_pause":  (*Manager).handlePauseScheduledMessage,
+		"schedule_resume": (*Manager).handleResumeScheduledMessage,
+		"schedule_delete": (*Manager).handleDeleteScheduledMessage,
+
+		// Relay channel and archive chat
+		"set_relay_channel": (*Manager).handleSetRelayChannelBtn,
+		"set_archive_chat":  (*Manager).handleSetArchiveChatBtn,
+	}
+
+	for day := 0; day < 7; day++ {
+		h["schedule_day_"+strconv.Itoa(day)] = (*Manager).handleScheduleDaySelection
+	}
+
+	// Generated toggle and cycle handlers for the settings registry
+	for _, s := range boolSettings {
+		s := s
+		h[s.key] = func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+			return m.handleToggleSetting(s, bot, token, ownerChat)
+		}
+	}
+	for _, s := range choiceSettings {
+		s := s
+		h[s.key] = func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+			return m.handleCycleSetting(s, bot, token, ownerChat)
+		}
+	}
+
+	return h
+}
+
+// messageEndpoints are the non-text message kinds forwarded to the
+// message handler
+var messageEndpoints = []string{
+	telebot.OnPhoto,
+	telebot.OnVideo,
+	telebot.OnDocument,
+	telebot.OnAudio,
+	telebot.OnVoice,
+	telebot.OnSticker,
+	telebot.OnAnimation,
+	telebot.OnVideoNote,
+	telebot.OnContact,
+	telebot.OnLocation,
+}
+
+// childContextFor resolves the bot behind an update and its registered
+// runtime context
+func (m *Manager) childContextFor(c telebot.Context) (*telebot.Bot, *childContext, bool) {
+	bot := c.Bot()
+	m.mu.RLock()
+	bc, ok := m.childCtxs[bot.Me.ID]
+	m.mu.RUnlock()
+	return bot, bc, ok
+}
+
+// dispatchCallback routes callback queries by button unique. telebot only
+// parses the unique for per-unique registrations, so the raw data is
+// parsed here before the table lookup.
+func (m *Manager) dispatchCallback(c telebot.Context) error {
+	cb := c.Callback()
+	if cb == nil {
+		return nil
+	}
+	if cb.Unique == "" {
+		if match := callbackUniqueRx.FindStringSubmatch(cb.Data); match != nil {
+			cb.Unique = match[1]
+			cb.Data = match[3]
+		}
+	}
+
+	build, ok := childCallbackHandlers[cb.Unique]
+	if !ok {
+		return nil
+	}
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	c = m.maybePlainUI(c, bc)
+	return build(m, bot, bc.token, bc.ownerChat)(c)
+}
+
+// dispatchText routes slash commands through the command table and
+// everything else to the message handler, matching telebot's own
+// command-then-OnText order
+func (m *Manager) dispatchText(c telebot.Context) error {
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	c = m.maybePlainUI(c, bc)
+
+	if text := c.Text(); strings.HasPrefix(text, "/") {
+		name := strings.SplitN(text, " ", 2)[0]
+		if at := strings.IndexByte(name, '@'); at >= 0 {
+			name = name[:at]
+		}
+		if build, ok := childCommandHandlers[name]; ok {
+			return build(m, bot, bc.token, bc.ownerChat)(c)
+		}
+	}
+	return m.createMessageHandler(bot, bc.token, bc.ownerChat)(c)
+}
+
+// dispatchMessage forwards media and contact messages to the message
+// handler
+func (m *Manager) dispatchMessage(c telebot.Context) error {
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	c = m.maybePlainUI(c, bc)
+	return m.createMessageHandler(bot, bc.token, bc.ownerChat)(c)
+}
+
+// dispatchEdited forwards edits of the owner's messages
+func (m *Manager) dispatchEdited(c telebot.Context) error {
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	return m.handleEditedMessage(bot, bc.token, bc.ownerChat)(c)
+}
+
+// dispatchMyChatMember forwards block/unblock membership updates
+func (m *Manager) dispatchMyChatMember(c telebot.Context) error {
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	return m.handleMyChatMember(bot, bc.token, bc.ownerChat)(c)
+}
+
+// dispatchChannelPost forwards channel posts for the relay feature
+func (m *Manager) dispatchChannelPost(c telebot.Context) error {
+	bot, bc, ok := m.childContextFor(c)
+	if !ok {
+		return nil
+	}
+	return m.handleChannelPost(bot, bc.token, bc.ownerChat)(c)
+}
+
+// ==================== List Cursor Encoding ====================
+
+// formatListCursor packs a keyset cursor into callback data as
+// "<unix seconds>:<row id>". TIMESTAMP columns have second precision,
+// so unix seconds round-trip exactly.
+func formatListCursor(createdAt time.Time, id int64) string {
+	return strconv.FormatInt(createdAt.Unix(), 10) + ":" + strconv.FormatInt(id, 10)
+}
+
+// parseListCursor decodes callback data written by formatListCursor;
+// anything malformed falls back to the first page
+func parseListCursor(data string) database.Cursor {
+	secsStr, idStr, ok := strings.Cut(data, ":")
+	if !ok {
+		return database.Cursor{}
+	}
+	secs, err := strconv.ParseInt(secsStr, 10, 64)
+	if err != nil {
+		return database.Cursor{}
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return database.Cursor{}
+	}
+	return database.CursorAfter(time.Unix(secs, 0), id)
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// How often the SLA loop wakes up to look for overdue messages.
+const slaTickInterval = 1 * time.Minute
+
+// ==================== SLA / Response Time Functions ====================
+
+// runSLALoop periodically alerts the owner about messages that have gone
+// unanswered beyond the SLA threshold. Each tick only looks at messages
+// that crossed the threshold since the previous tick, so every overdue
+// message is alerted at most once. Runs until ctx is cancelled by StopBot.
+func (m *Manager) runSLALoop(ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat, botID int64) {
+	ticker := time.NewTicker(slaTickInterval)
+	defer ticker.Stop()
+
+	lastTick := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			minutes := m.slaMinutes(ctx, token, botID)
+			if minutes <= 0 {
+				lastTick = now
+				continue
+			}
+
+			threshold := time.Duration(minutes) * time.Minute
+			overdue, err := m.repo.GetUnansweredBetween(ctx, botID, lastTick.Add(-threshold), now.Add(-threshold))
+			lastTick = now
+			if err != nil {
+				log.Printf("Failed to check SLA for bot %d: %v", botID, err)
+				continue
+			}
+
+			for _, msg := range overdue {
+				alert := fmt.Sprintf("⏱ <b>SLA Alert</b>\n\nThis message has been waiting over %d minutes for a reply.", minutes)
+				opts := &telebot.SendOptions{
+					ReplyTo:   &telebot.Message{ID: msg.AdminMsgID, Chat: ownerChat},
+					ParseMode: telebot.ModeHTML,
+				}
+				if _, err := bot.Send(ownerChat, alert, opts); err != nil {
+					log.Printf("Failed to send SLA alert for message %d: %v", msg.AdminMsgID, err)
+				}
+			}
+		}
+	}
+}
+
+// slaMinutes returns the SLA threshold in minutes (0 = off),
+// cache-first with a DB fallback
+func (m *Manager) slaMinutes(ctx context.Context, token string, botID int64) int {
+	minutes, cacheHit, err := m.cache.GetSLAMinutes(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return minutes
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetSLAMinutes(ctx, token, botModel.SLAMinutes)
+	return botModel.SLAMinutes
+}
+
+// handleSetSLABtn initiates state to change the SLA threshold
+func (m *Manager) handleSetSLABtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_sla_minutes"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state!", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "back_to_settings")
+		menu.Inline(menu.Row(btnCancel))
+
+		current := "OFF"
+		currentBot, err := m.repo.GetBotByToken(ctx, token)
+		if err == nil && currentBot != nil && currentBot.SLAMinutes > 0 {
+			current = fmt.Sprintf("%d minutes", currentBot.SLAMinutes)
+		}
+
+		msg := fmt.Sprintf(`⏱ <b>SLA Alerts</b>
+
+<b>Current:</b> %s
+
+Get an alert under any forwarded message that stays unanswered longer than the threshold.
+
+Send the threshold in minutes (1-1440), or <code>0</code> to turn alerts off.`, current)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// responseTimeSummary computes the average and median of a set of response
+// times given in seconds. Negative samples (clock skew) are discarded;
+// returns zeros when there is no usable data.
+func responseTimeSummary(seconds []int64) (avg, median time.Duration) {
+	var samples []int64
+	for _, s := range seconds {
+		if s >= 0 {
+			samples = append(samples, s)
+		}
+	}
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var sum int64
+	for _, s := range samples {
+		sum += s
+	}
+	avg = time.Duration(sum/int64(len(samples))) * time.Second
+
+	mid := len(samples) / 2
+	if len(samples)%2 == 0 {
+		median = time.Duration((samples[mid-1]+samples[mid])/2) * time.Second
+	} else {
+		median = time.Duration(samples[mid]) * time.Second
+	}
+	return avg, median
+}
+
+// formatResponseDuration renders a response time for the stats screen
+func formatResponseDuration(d time.Duration) string {
+	if d <= 0 {
+		return "—"
+	}
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Spam Scoring Handlers ====================
+
+// Messages scoring above the per-bot threshold are held in a spam review
+// queue instead of reaching the owner's inbox. The owner reviews them
+// with /spam; feedback buttons record verdicts as classifier training
+// data. A threshold of 0 (the default) disables scoring for the bot.
+
+// spamReviewMaxUsers caps the conversations listed in one /spam summary
+const spamReviewMaxUsers = 10
+
+// spamThreshold returns the bot's spam score threshold (percent),
+// cache-first with a DB fallback
+func (m *Manager) spamThreshold(ctx context.Context, token string, botID int64) int {
+	threshold, cacheHit, err := m.cache.GetSpamThreshold(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return threshold
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return 0
+	}
+	m.cache.SetSpamThreshold(ctx, token, botModel.SpamThreshold)
+	return botModel.SpamThreshold
+}
+
+// shouldDivertSpam scores the text and reports whether it should go to
+// the spam review queue instead of the main inbox. Scoring errors fail
+// open - a broken classifier must never block real messages.
+func (m *Manager) shouldDivertSpam(ctx context.Context, token string, botID int64, text string) bool {
+	if text == "" {
+		return false
+	}
+
+	m.mu.RLock()
+	classifier := m.spamClassifier
+	m.mu.RUnlock()
+	if classifier == nil {
+		return false
+	}
+
+	threshold := m.spamThreshold(ctx, token, botID)
+	if threshold <= 0 {
+		return false
+	}
+
+	score, err := classifier.Score(ctx, text)
+	if err != nil {
+		log.Printf("Spam classifier error for bot %d: %v", botID, err)
+		return false
+	}
+
+	return int(score*100) >= threshold
+}
+
+// handleSpamCommand shows the owner the spam review queue:
+// one button per conversation with held messages
+func (m *Manager) handleSpamCommand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+
+		users, err := m.cache.GetSpamUsers(ctx, token)
+		if err != nil {
+			log.Printf("Failed to read spam queue: %v", err)
+			return c.Reply("❌ Failed to load the spam queue.")
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		total := int64(0)
+		for i, userChatID := range users {
+			if i >= spamReviewMaxUsers {
+				break
+			}
+			pending, err := m.cache.CountSpamMessages(ctx, token, userChatID)
+			if err != nil || pending == 0 {
+				continue
+			}
+			total += pending
+			label := fmt.Sprintf("👤 %d (%d)", userChatID, pending)
+			btn := menu.Data(label, "spam_expand", strconv.FormatInt(userChatID, 10))
+			rows = append(rows, menu.Row(btn))
+		}
+
+		if len(rows) == 0 {
+			return c.Reply("🛡 The spam queue is empty.")
+		}
+		menu.Inline(rows...)
+
+		summary := fmt.Sprintf("🛡 <b>%d held message(s) from %d user(s)</b>\n\nTap a conversation to review it:", total, len(rows))
+		return c.Reply(summary, menu, telebot.ModeHTML)
+	}
+}
+
+// handleSpamExpand forwards a user's held messages for review, with the
+// feedback buttons attached to the last one
+func (m *Manager) handleSpamExpand(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid conversation!", ShowAlert: true})
+		}
+
+		msgIDs, err := m.cache.PopSpamMessages(ctx, token, userChatID)
+		if err != nil {
+			log.Printf("Failed to pop spam messages for user %d: %v", userChatID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to load messages!", ShowAlert: true})
+		}
+		if len(msgIDs) == 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: "No held messages."})
+		}
+
+		forwarded := 0
+		var lastSent *telebot.Message
+		for _, msgID := range msgIDs {
+			stored := telebot.StoredMessage{
+				MessageID: strconv.Itoa(msgID),
+				ChatID:    userChatID,
+			}
+			sent, err := bot.Forward(ownerChat, stored)
+			if err != nil {
+				log.Printf("Failed to forward spam message %d from user %d: %v", msgID, userChatID, err)
+				continue
+			}
+			m.linkAdminMessage(ctx, token, botID, sent.ID, userChatID)
+			forwarded++
+			lastSent = sent
+		}
+
+		if lastSent != nil {
+			if _, err := bot.EditReplyMarkup(lastSent, spamFeedbackMenu(userChatID)); err != nil {
+				log.Printf("Failed to attach spam feedback buttons to message %d: %v", lastSent.ID, err)
+			}
+		}
+
+		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Forwarded %d message(s)", forwarded)})
+	}
+}
+
+// spamFeedbackMenu builds the verdict buttons under a reviewed conversation
+func spamFeedbackMenu(userChatID int64) *telebot.ReplyMarkup {
+	menu := &telebot.ReplyMarkup{}
+	data := strconv.FormatInt(userChatID, 10)
+	btnHam := menu.Data("✅ Not spam", "spam_ham", data)
+	btnSpam := menu.Data("🚫 Spam", "spam_confirm", data)
+	menu.Inline(menu.Row(btnHam, btnSpam))
+	return menu
+}
+
+// spamFeedbackHandler builds the handler recording one verdict. Verdicts
+// feed the spam_feedback table so classifier operators can retrain.
+func spamFeedbackHandler(verdict string) childHandler {
+	return func(m *Manager, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+		return func(c telebot.Context) error {
+			if c.Sender().ID != ownerChat.ID {
+				return nil
+			}
+
+			ctx := context.Background()
+			m.mu.RLock()
+			botID := m.botIDs[token]
+			m.mu.RUnlock()
+
+			userChatID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+			if err != nil {
+				return c.Respond(&telebot.CallbackResponse{Text: "Invalid conversation!", ShowAlert: true})
+			}
+
+			if err := m.repo.RecordSpamFeedback(ctx, botID, userChatID, verdict); err != nil {
+				log.Printf("Failed to record spam feedback for bot %d: %v", botID, err)
+				return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to save feedback!", ShowAlert: true})
+			}
+
+			toast := "🚫 Marked as spam"
+			if verdict == models.SpamVerdictHam {
+				toast = "✅ Marked as not spam"
+			}
+			return c.Respond(&telebot.CallbackResponse{Text: toast})
+		}
+	}
+}
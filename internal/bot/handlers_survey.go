@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// How long before a user can be asked for another rating.
+const surveyCooldown = 24 * time.Hour
+
+// ==================== Satisfaction Survey Handlers ====================
+
+// maybeSendSurvey sends the 1-5 star rating prompt to a user after the
+// owner's reply was delivered, if the survey is enabled and the user was
+// not already asked within the cooldown window
+func (m *Manager) maybeSendSurvey(ctx context.Context, bot *telebot.Bot, token string, botID int64, userChat *telebot.Chat) {
+	if !m.surveyEnabled(ctx, token, botID) {
+		return
+	}
+
+	first, err := m.cache.MarkSurveySent(ctx, token, userChat.ID, surveyCooldown)
+	if err != nil {
+		log.Printf("Failed to check survey cooldown for user %d: %v", userChat.ID, err)
+		return
+	}
+	if !first {
+		return // Already asked recently
+	}
+
+	menu := &telebot.ReplyMarkup{}
+	var btns []telebot.Btn
+	for stars := 1; stars <= 5; stars++ {
+		btns = append(btns, menu.Data(strings.Repeat("⭐", stars), "rate_conv", strconv.Itoa(stars)))
+	}
+	menu.Inline(menu.Row(btns...))
+
+	if _, err := bot.Send(userChat, "How satisfied are you with the reply you received?", menu); err != nil {
+		log.Printf("Failed to send survey prompt to user %d: %v", userChat.ID, err)
+	}
+}
+
+// surveyEnabled reports whether the satisfaction survey is on,
+// cache-first with a DB fallback
+func (m *Manager) surveyEnabled(ctx context.Context, token string, botID int64) bool {
+	enabled, cacheHit, err := m.cache.GetSurveyEnabled(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return false
+	}
+	m.cache.SetSurveyEnabled(ctx, token, botModel.SurveyEnabled)
+	return botModel.SurveyEnabled
+}
+
+// handleRateConversation stores the star rating a user tapped. Unlike most
+// child handlers this one accepts every sender, not just the owner.
+func (m *Manager) handleRateConversation(bot *telebot.Bot, token string) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		stars, err := strconv.Atoi(strings.TrimSpace(c.Data()))
+		if err != nil || stars < 1 || stars > 5 {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid rating!", ShowAlert: true})
+		}
+
+		if err := m.repo.SaveRating(ctx, botID, c.Sender().ID, stars); err != nil {
+			log.Printf("Failed to save rating from user %d: %v", c.Sender().ID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to save your rating!", ShowAlert: true})
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: "Thank you for your feedback!"})
+		return c.Edit(fmt.Sprintf("🙏 Thanks! You rated this conversation %s.", strings.Repeat("⭐", stars)))
+	}
+}
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	// healthCheckInterval is how often the background loop polls
+	// getWebhookInfo for silent bots
+	healthCheckInterval = 15 * time.Minute
+
+	// webhookStaleAfter is how long a bot may go without updates before
+	// the loop starts asking Telegram what is wrong
+	webhookStaleAfter = 30 * time.Minute
+)
+
+// BotHealth is a live snapshot of one bot's webhook state, combining
+// what this instance knows (last update received) with what Telegram
+// reports via getWebhookInfo.
+type BotHealth struct {
+	Running        bool
+	LastUpdate     time.Time // last webhook update seen here; zero if none since start
+	PendingUpdates int       // updates queued on Telegram's side
+	ErrorAt        time.Time // when Telegram last failed to deliver; zero if never
+	ErrorMessage   string    // Telegram's last_error_message, verbatim
+}
+
+// verifyWebhook confirms that Telegram actually serves the URL we just
+// registered. setWebhook returning ok is not enough: a racing instance
+// or a stale call can leave a different URL in place, and the bot would
+// sit silent. A failed info query is only logged — transient API trouble
+// should not block startup.
+func verifyWebhook(bot *telebot.Bot, token, publicURL string) error {
+	info, err := bot.Webhook()
+	if err != nil {
+		log.Printf("Warning: could not verify webhook for %s...: %v", token[:10], err)
+		return nil
+	}
+	if info.Listen != publicURL {
+		return fmt.Errorf("webhook verification failed: telegram reports %q, want %q", info.Listen, publicURL)
+	}
+	return nil
+}
+
+// BotHealth reports the current health of a bot. Stopped bots yield a
+// zero snapshot with Running false.
+func (m *Manager) BotHealth(botID int64) *BotHealth {
+	bot, token, err := m.GetBotByID(botID)
+	if err != nil {
+		return &BotHealth{}
+	}
+
+	health := &BotHealth{Running: true}
+
+	m.mu.RLock()
+	health.LastUpdate = m.lastActivity[token]
+	m.mu.RUnlock()
+
+	info, err := bot.Webhook()
+	if err != nil {
+		log.Printf("Failed to get webhook info for bot %d: %v", botID, err)
+		return health
+	}
+
+	health.PendingUpdates = info.PendingUpdates
+	health.ErrorMessage = info.ErrorMessage
+	if info.ErrorUnixtime > 0 {
+		health.ErrorAt = time.Unix(info.ErrorUnixtime, 0)
+	}
+	return health
+}
+
+// WebhookAdvice translates Telegram's last_error_message into one
+// actionable sentence for the owner. Unknown messages come back empty;
+// the raw message is always shown alongside.
+func WebhookAdvice(errorMessage string) string {
+	msg := strings.ToLower(errorMessage)
+	switch {
+	case msg == "":
+		return ""
+	case strings.Contains(msg, "ssl") || strings.Contains(msg, "certificate"):
+		return "There is a certificate problem on the server. Check that the HTTPS certificate is valid and not expired."
+	case strings.Contains(msg, "404") || strings.Contains(msg, "wrong response"):
+		return "Telegram gets a wrong response from the webhook URL. The server may be misconfigured — try stopping and starting the bot to re-register it."
+	case strings.Contains(msg, "connection refused") || strings.Contains(msg, "connection timed out") || strings.Contains(msg, "connection reset"):
+		return "Telegram cannot reach the server. It may be down or behind a firewall."
+	case strings.Contains(msg, "webhook is not set") || strings.Contains(msg, "webhook was deleted"):
+		return "The webhook was deleted on Telegram's side. Stop and start the bot to re-register it."
+	default:
+		return ""
+	}
+}
+
+// WebhookIssue returns the error message the health check loop last saw
+// for a bot, or empty if the webhook looked fine
+func (m *Manager) WebhookIssue(botID int64) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.webhookIssues[botID]
+}
+
+// StartHealthChecks launches the background webhook health loop
+func (m *Manager) StartHealthChecks() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.healthCancel = cancel
+	m.mu.Unlock()
+	go m.runHealthCheckLoop(ctx)
+}
+
+// StopHealthChecks stops the webhook health loop
+func (m *Manager) StopHealthChecks() {
+	m.mu.Lock()
+	cancel := m.healthCancel
+	m.healthCancel = nil
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func (m *Manager) runHealthCheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.checkStaleWebhooks(webhookStaleAfter)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkStaleWebhooks asks Telegram about every bot that has gone quiet
+// for longer than staleAfter and records what it reports, so Bot Details
+// can explain a silent bot instead of just showing "Running"
+func (m *Manager) checkStaleWebhooks(staleAfter time.Duration) {
+	type candidate struct {
+		bot   *telebot.Bot
+		botID int64
+	}
+
+	cutoff := time.Now().Add(-staleAfter)
+
+	m.mu.RLock()
+	var candidates []candidate
+	for token, b := range m.bots {
+		if b == nil {
+			continue
+		}
+		if last, ok := m.lastActivity[token]; ok && last.After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, candidate{bot: b, botID: m.botIDs[token]})
+	}
+	m.mu.RUnlock()
+
+	for _, c := range candidates {
+		info, err := c.bot.Webhook()
+		if err != nil {
+			log.Printf("Health check: failed to get webhook info for bot %d: %v", c.botID, err)
+			continue
+		}
+
+		m.mu.Lock()
+		if info.ErrorMessage != "" {
+			if m.webhookIssues[c.botID] != info.ErrorMessage {
+				log.Printf("Health check: bot %d webhook error: %s", c.botID, info.ErrorMessage)
+			}
+			m.webhookIssues[c.botID] = info.ErrorMessage
+		} else {
+			delete(m.webhookIssues, c.botID)
+		}
+		m.mu.Unlock()
+	}
+}
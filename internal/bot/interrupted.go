@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"gopkg.in/telebot.v3"
+)
+
+// broadcastProgressEvery is how many deliveries pass between progress
+// checkpoints. Low enough that an interruption report is close to the
+// truth, high enough to keep Redis writes off the hot path.
+const broadcastProgressEvery = 25
+
+// NotifyInterruptedBroadcasts tells owners about broadcasts a shutdown
+// or deploy cut short. Call once at startup after the active bots are
+// running, so each owner hears it from the bot that was broadcasting.
+func (m *Manager) NotifyInterruptedBroadcasts() {
+	ctx := context.Background()
+
+	jobs, err := m.cache.GetInterruptedBroadcasts(ctx)
+	if err != nil {
+		log.Printf("Failed to check for interrupted broadcasts: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		// Clear first so a crash loop never notifies twice
+		if err := m.cache.ClearBroadcastProgress(ctx, job.BotToken); err != nil {
+			log.Printf("Failed to clear interrupted broadcast state: %v", err)
+			continue
+		}
+
+		m.mu.RLock()
+		bot := m.bots[job.BotToken]
+		m.mu.RUnlock()
+		if bot == nil {
+			continue // bot no longer active here; another shard may own it now
+		}
+
+		text := fmt.Sprintf(`⚠️ <b>Broadcast Interrupted</b>
+
+A server restart stopped your broadcast at %d/%d recipients. It was not resumed — send it again to reach the rest.`,
+			job.Sent, job.Total)
+		if _, err := bot.Send(&telebot.Chat{ID: job.OwnerChatID}, text, telebot.ModeHTML); err != nil {
+			log.Printf("Failed to notify owner %d about interrupted broadcast: %v", job.OwnerChatID, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/cache"
+)
+
+// ==================== Interrupted Broadcast Tests ====================
+
+func TestNotifyInterruptedBroadcasts_NotifiesOwnerOnce(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	const ownerChatID = 999
+	if err := m.StartBot(integrationToken, ownerChatID, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	ctx := context.Background()
+	progress := &cache.BroadcastProgress{
+		BotToken:    integrationToken,
+		OwnerChatID: ownerChatID,
+		Sent:        4200,
+		Total:       10000,
+	}
+	if err := m.cache.SetBroadcastProgress(ctx, progress); err != nil {
+		t.Fatalf("SetBroadcastProgress failed: %v", err)
+	}
+
+	m.NotifyInterruptedBroadcasts()
+
+	call := waitForCall(t, server, "sendMessage")
+	if call.ChatID() != ownerChatID {
+		t.Errorf("Expected notification sent to owner %d, got %d", ownerChatID, call.ChatID())
+	}
+
+	// The job state is consumed, so a restart loop never notifies twice
+	jobs, err := m.cache.GetInterruptedBroadcasts(ctx)
+	if err != nil {
+		t.Fatalf("GetInterruptedBroadcasts failed: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("Expected no remaining interrupted broadcasts, got %d", len(jobs))
+	}
+}
+
+func TestNotifyInterruptedBroadcasts_NoJobs(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	m.NotifyInterruptedBroadcasts()
+
+	if n := server.CallCount("sendMessage"); n != 0 {
+		t.Errorf("Expected no notifications without interrupted jobs, got %d", n)
+	}
+}
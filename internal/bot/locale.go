@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ==================== Owner Date Localization ====================
+
+// Only dates, times and weekday names are localized; the rest of the UI
+// stays English. The owner picks a display language and timezone in the
+// settings menu, and every owner-facing date goes through an ownerClock
+// so the two are always applied together.
+
+// datetimeLocale bundles the weekday names and layout strings of one
+// display language
+type datetimeLocale struct {
+	weekdays [7]string // indexed by time.Weekday (Sunday first)
+	dateTime string    // date-with-minutes layout
+	date     string    // date-only layout
+}
+
+// datetimeLocales maps a locale code to its formats; "" is the English
+// default
+var datetimeLocales = map[string]datetimeLocale{
+	"": {
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dateTime: "2006-01-02 15:04",
+		date:     "2006-01-02",
+	},
+	"ar": {
+		weekdays: [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		dateTime: "2006/01/02 15:04",
+		date:     "2006/01/02",
+	},
+	"de": {
+		weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		dateTime: "02.01.2006 15:04",
+		date:     "02.01.2006",
+	},
+	"es": {
+		weekdays: [7]string{"Domingo", "Lunes", "Martes", "Miércoles", "Jueves", "Viernes", "Sábado"},
+		dateTime: "02/01/2006 15:04",
+		date:     "02/01/2006",
+	},
+	"ru": {
+		weekdays: [7]string{"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"},
+		dateTime: "02.01.2006 15:04",
+		date:     "02.01.2006",
+	},
+}
+
+// ownerClock renders dates the way the bot's owner configured them. A
+// nil tz means no timezone was chosen and times stay in server time.
+type ownerClock struct {
+	locale datetimeLocale
+	tz     *time.Location
+}
+
+// in shifts a time into the owner's timezone, if one is configured
+func (oc ownerClock) in(t time.Time) time.Time {
+	if oc.tz != nil {
+		return t.In(oc.tz)
+	}
+	return t
+}
+
+// DateTime renders a date with minutes
+func (oc ownerClock) DateTime(t time.Time) string {
+	return oc.in(t).Format(oc.locale.dateTime)
+}
+
+// Date renders a date without a time of day
+func (oc ownerClock) Date(t time.Time) string {
+	return oc.in(t).Format(oc.locale.date)
+}
+
+// Weekday translates a weekday name
+func (oc ownerClock) Weekday(d time.Weekday) string {
+	return oc.locale.weekdays[d]
+}
+
+// ownerClock builds the clock for a bot from its locale and timezone
+// settings
+func (m *Manager) ownerClock(ctx context.Context, token string, botID int64) ownerClock {
+	localeCode, tzName := m.localeSettings(ctx, token, botID)
+
+	locale, ok := datetimeLocales[localeCode]
+	if !ok {
+		locale = datetimeLocales[""]
+	}
+
+	var tz *time.Location
+	if tzName != "" {
+		loaded, err := time.LoadLocation(tzName)
+		if err != nil {
+			log.Printf("Failed to load timezone %q: %v", tzName, err)
+		} else {
+			tz = loaded
+		}
+	}
+
+	return ownerClock{locale: locale, tz: tz}
+}
+
+// localeSettings returns the stored locale code and timezone name -
+// cache first, DB backfill on miss
+func (m *Manager) localeSettings(ctx context.Context, token string, botID int64) (string, string) {
+	localeCode, localeHit, err := m.cache.GetBotLocale(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	tzName, tzHit, err := m.cache.GetBotTimezone(ctx, token)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if localeHit && tzHit {
+		return localeCode, tzName
+	}
+
+	// Cache miss - load from DB and cache it
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel == nil {
+		return localeCode, tzName
+	}
+	m.cache.SetBotLocale(ctx, token, botModel.Locale)
+	m.cache.SetBotTimezone(ctx, token, botModel.Timezone)
+	return botModel.Locale, botModel.Timezone
+}
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOwnerClock_LocalizedFormats(t *testing.T) {
+	ts := time.Date(2024, 3, 7, 14, 30, 0, 0, time.UTC) // a Thursday
+
+	tests := []struct {
+		locale       string
+		wantDateTime string
+		wantWeekday  string
+	}{
+		{"", "2024-03-07 14:30", "Thursday"},
+		{"de", "07.03.2024 14:30", "Donnerstag"},
+		{"ru", "07.03.2024 14:30", "Четверг"},
+		{"ar", "2024/03/07 14:30", "الخميس"},
+	}
+
+	for _, tt := range tests {
+		t.Run("locale "+tt.locale, func(t *testing.T) {
+			clock := ownerClock{locale: datetimeLocales[tt.locale]}
+			if got := clock.DateTime(ts); got != tt.wantDateTime {
+				t.Errorf("DateTime() = %q, want %q", got, tt.wantDateTime)
+			}
+			if got := clock.Weekday(ts.Weekday()); got != tt.wantWeekday {
+				t.Errorf("Weekday() = %q, want %q", got, tt.wantWeekday)
+			}
+		})
+	}
+}
+
+func TestOwnerClock_TimezoneShift(t *testing.T) {
+	ts := time.Date(2024, 3, 7, 23, 30, 0, 0, time.UTC)
+
+	cairo, err := time.LoadLocation("Africa/Cairo")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	clock := ownerClock{locale: datetimeLocales[""], tz: cairo}
+	if got := clock.DateTime(ts); got != "2024-03-08 01:30" {
+		t.Errorf("DateTime() = %q, want shifted into Cairo time", got)
+	}
+
+	// No configured timezone leaves the time untouched
+	clock = ownerClock{locale: datetimeLocales[""]}
+	if got := clock.DateTime(ts); got != "2024-03-07 23:30" {
+		t.Errorf("DateTime() without tz = %q, want server time", got)
+	}
+}
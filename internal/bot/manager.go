@@ -3,6 +3,7 @@ package bot
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,42 +13,121 @@ import (
 
 	"github.com/Amr-9/botforge/internal/cache"
 	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/events"
+	"github.com/Amr-9/botforge/internal/features"
+	"github.com/Amr-9/botforge/internal/metrics"
+	"github.com/Amr-9/botforge/internal/plugin"
 	"github.com/Amr-9/botforge/internal/recovery"
+	"github.com/Amr-9/botforge/internal/spam"
 	"gopkg.in/telebot.v3"
 )
 
 // Manager handles the lifecycle of all child bots
 type Manager struct {
-	repo               *database.Repository
-	cache              *cache.Redis
-	bots               map[string]*telebot.Bot      // token -> bot instance
-	botIDs             map[string]int64             // token -> bot ID
-	webhookURL         string
-	mu                 sync.RWMutex
-	recoveryHandler    recovery.Handler
-	restartPolicies    map[string]*recovery.RestartPolicy     // token -> restart policy
-	restartControllers map[string]*recovery.RestartController // token -> restart controller
-	preloadCancels     map[string]context.CancelFunc          // token -> cancel func for preload goroutine
+	repo                Repository
+	cache               cache.Cache
+	bots                map[string]*telebot.Bot // token -> bot instance
+	botIDs              map[string]int64        // token -> bot ID
+	webhookURL          string
+	apiEndpoint         string // overrides the Telegram API base URL (tests only)
+	mu                  sync.RWMutex
+	recoveryHandler     recovery.Handler
+	restartPolicies     map[string]*recovery.RestartPolicy     // token -> restart policy
+	restartControllers  map[string]*recovery.RestartController // token -> restart controller
+	preloadCancels      map[string]context.CancelFunc          // token -> cancel func for preload goroutine
+	digestCancels       map[string]context.CancelFunc          // token -> cancel func for digest loop
+	slaCancels          map[string]context.CancelFunc          // token -> cancel func for SLA loop
+	channelStatsCancels map[string]context.CancelFunc          // token -> cancel func for channel stats loop
+	notifyAdmin         func(text string)                      // delivers abuse report alerts to the factory admin
+	bus                 events.Bus                             // optional event bus; nil means events are dropped
+	spamClassifier      spam.Classifier                        // optional spam scorer; nil disables spam diversion
+	flows               map[string]flow                        // flow name -> multi-step conversation definition
+	childCtxs           map[int64]*childContext                // bot Telegram ID -> context for the shared dispatchers
+	lastActivity        map[string]time.Time                   // token -> time of the last webhook update
+	coldBots            map[string]coldBot                     // token -> state of demoted cold bots
+	tieringCancel       context.CancelFunc                     // stops the cold demotion loop
+	healthCancel        context.CancelFunc                     // stops the webhook health loop
+	retentionCancel     context.CancelFunc                     // stops the content retention loop
+	webhookIssues       map[int64]string                       // bot ID -> last webhook error Telegram reported
+	flowSteps           map[string]flowStep                    // user state -> owning flow step
+	plugins             []plugin.Plugin                        // compile-time extensions attached to every child bot
+	pluginRepo          *database.Repository                   // concrete repository handed to plugins
+	features            *features.Flags                        // runtime feature-flag resolver for handlers
+	botLimiter          *rateLimiter                           // throttles webhook updates per bot token
+	ipLimiter           *rateLimiter                           // throttles webhook updates per client IP
 }
 
 // NewManager creates a new bot manager with default recovery handler
-func NewManager(repo *database.Repository, cache *cache.Redis, webhookURL string) *Manager {
+func NewManager(repo Repository, cache cache.Cache, webhookURL string) *Manager {
 	return NewManagerWithRecovery(repo, cache, webhookURL, recovery.DefaultHandler)
 }
 
 // NewManagerWithRecovery creates a new bot manager with custom recovery handler
-func NewManagerWithRecovery(repo *database.Repository, cache *cache.Redis, webhookURL string, handler recovery.Handler) *Manager {
-	return &Manager{
-		repo:               repo,
-		cache:              cache,
-		bots:               make(map[string]*telebot.Bot),
-		botIDs:             make(map[string]int64),
-		webhookURL:         webhookURL,
-		recoveryHandler:    handler,
-		restartPolicies:    make(map[string]*recovery.RestartPolicy),
-		restartControllers: make(map[string]*recovery.RestartController),
-		preloadCancels:     make(map[string]context.CancelFunc),
+func NewManagerWithRecovery(repo Repository, cache cache.Cache, webhookURL string, handler recovery.Handler) *Manager {
+	m := &Manager{
+		repo:                repo,
+		cache:               cache,
+		bots:                make(map[string]*telebot.Bot),
+		botIDs:              make(map[string]int64),
+		webhookURL:          webhookURL,
+		recoveryHandler:     handler,
+		restartPolicies:     make(map[string]*recovery.RestartPolicy),
+		restartControllers:  make(map[string]*recovery.RestartController),
+		preloadCancels:      make(map[string]context.CancelFunc),
+		digestCancels:       make(map[string]context.CancelFunc),
+		slaCancels:          make(map[string]context.CancelFunc),
+		channelStatsCancels: make(map[string]context.CancelFunc),
+		childCtxs:           make(map[int64]*childContext),
+		lastActivity:        make(map[string]time.Time),
+		coldBots:            make(map[string]coldBot),
+		webhookIssues:       make(map[int64]string),
+		features:            features.New(cache),
+		botLimiter:          newRateLimiter(perBotRate, perBotBurst),
+		ipLimiter:           newRateLimiter(perIPRate, perIPBurst),
 	}
+	m.registerFlows()
+	return m
+}
+
+// SetEventBus installs the bus that internal events are published to.
+// Without one, events are silently dropped.
+func (m *Manager) SetEventBus(bus events.Bus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bus = bus
+}
+
+// SetSpamClassifier installs the spam scorer. Without one, no messages
+// are diverted regardless of the per-bot threshold.
+func (m *Manager) SetSpamClassifier(c spam.Classifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spamClassifier = c
+}
+
+// publishEvent emits an event when a bus is configured
+func (m *Manager) publishEvent(event events.Event) {
+	m.mu.RLock()
+	bus := m.bus
+	m.mu.RUnlock()
+	if bus != nil {
+		bus.Publish(event)
+	}
+}
+
+// publishEventLocked is publishEvent for callers already holding m.mu
+func (m *Manager) publishEventLocked(event events.Event) {
+	if m.bus != nil {
+		m.bus.Publish(event)
+	}
+}
+
+// SetAPIEndpoint points newly started bots at an alternative Bot API
+// base URL. Used by tests to talk to a fake Telegram server.
+func (m *Manager) SetAPIEndpoint(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiEndpoint = url
 }
 
 // RegisterExistingBot manually adds a bot to the manager
@@ -55,10 +135,12 @@ func (m *Manager) RegisterExistingBot(token string, bot *telebot.Bot) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Ensure webhook is set
+	// Ensure webhook is set; the factory bot needs the base update types
+	// plus inline queries for quick bot lookup
 	publicURL := fmt.Sprintf("%s/webhook/%s", m.webhookURL, token)
 	webhook := &telebot.Webhook{
-		Endpoint: &telebot.WebhookEndpoint{PublicURL: publicURL},
+		Endpoint:       &telebot.WebhookEndpoint{PublicURL: publicURL},
+		AllowedUpdates: append(baseAllowedUpdates(), "inline_query"),
 	}
 	if err := bot.SetWebhook(webhook); err != nil {
 		log.Printf("Failed to set webhook for existing bot: %v", err)
@@ -93,8 +175,22 @@ func (m *Manager) RegisterExistingBot(token string, bot *telebot.Bot) {
 	log.Printf("Registered existing bot: %s...", tokenPrefix)
 }
 
+// maxUpdateBytes caps webhook request bodies. The biggest legitimate
+// updates (long messages with full entity lists) stay well under this.
+const maxUpdateBytes = 1 << 20 // 1 MiB
+
 // ServeHTTP handles incoming webhook requests
 func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Telegram only ever POSTs JSON; anything else is a probe
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		http.Error(w, "Unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
 	// Path format: /webhook/{token}
 	parts := strings.Split(r.URL.Path, "/")
 	if len(parts) < 3 {
@@ -108,18 +204,51 @@ func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Throttle before the bot lookup so a flood scoped to one bot or
+	// coming from one source never consumes all server capacity
+	if !m.ipLimiter.allow(clientIP(r)) {
+		metrics.CountEvent("webhook_throttled_ip")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+		return
+	}
+	if !m.botLimiter.allow(token) {
+		metrics.CountEvent("webhook_throttled_bot")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+		return
+	}
+
 	m.mu.RLock()
 	bot, exists := m.bots[token]
 	m.mu.RUnlock()
 
+	if !exists && m.promoteColdBot(token) {
+		// A cold bot is re-promoted on its first incoming update
+		m.mu.RLock()
+		bot, exists = m.bots[token]
+		m.mu.RUnlock()
+	}
 	if !exists {
-		http.Error(w, "Bot not found", http.StatusNotFound)
+		// 200, not 404: Telegram retries non-2xx responses for days, so
+		// a deleted bot whose webhook still points here would hammer us.
+		// Acknowledging the update makes Telegram drop it.
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 
-	// Decode update
+	// Stamp activity for the warm/cold tiering
+	m.touchBot(token)
+
+	// Decode update. Real updates are tiny (large media travels as file
+	// IDs), so anything above the cap is not from Telegram. Unknown
+	// fields stay allowed: Telegram adds new ones with every API release.
+	r.Body = http.MaxBytesReader(w, r.Body, maxUpdateBytes)
 	var update telebot.Update
 	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "Body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(w, "Invalid body", http.StatusBadRequest)
 		return
 	}
@@ -134,6 +263,12 @@ func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			"type":  "process_update",
 			"token": tokenPrefix,
 		})
+		// telebot has no endpoint for message_reaction updates, so they
+		// are routed here instead of through ProcessUpdate
+		if update.MessageReaction != nil {
+			m.handleMessageReaction(bot, token, update.MessageReaction)
+			return
+		}
 		bot.ProcessUpdate(update)
 	}()
 }
@@ -155,6 +290,7 @@ func (m *Manager) StartBot(token string, ownerChatID int64, botID int64) error {
 	// Create bot settings with Webhook poller
 	settings := telebot.Settings{
 		Token:  token,
+		URL:    m.apiEndpoint,   // empty means the real Telegram API
 		Poller: &ManualPoller{}, // Use ManualPoller to avoid port binding
 	}
 
@@ -164,20 +300,32 @@ func (m *Manager) StartBot(token string, ownerChatID int64, botID int64) error {
 		return err
 	}
 
-	// Set Webhook on Telegram side
+	// Set Webhook on Telegram side, only requesting the update types the
+	// bot actually handles to reduce update volume
 	webhook := &telebot.Webhook{
-		Endpoint: &telebot.WebhookEndpoint{PublicURL: publicURL},
+		Endpoint:       &telebot.WebhookEndpoint{PublicURL: publicURL},
+		AllowedUpdates: m.allowedUpdates(context.Background(), botID),
 	}
 	if err := bot.SetWebhook(webhook); err != nil {
 		return fmt.Errorf("failed to set webhook: %w", err)
 	}
 
+	// Confirm the registration took before declaring the bot healthy
+	if err := verifyWebhook(bot, token, publicURL); err != nil {
+		return err
+	}
+
 	// Register handlers
 	m.registerChildHandlers(bot, token, ownerChatID)
 
+	// Attach handlers of compile-time plugins, if any
+	m.registerPlugins(bot, token, ownerChatID, botID)
+
 	// Store bot
 	m.bots[token] = bot
 	m.botIDs[token] = botID
+	m.lastActivity[token] = time.Now()
+	delete(m.coldBots, token)
 
 	// Preload bot settings into cache (async to not block startup)
 	// Use cancellable context to prevent goroutine leak when bot is stopped
@@ -185,6 +333,25 @@ func (m *Manager) StartBot(token string, ownerChatID int64, botID int64) error {
 	m.preloadCancels[token] = preloadCancel
 	go m.preloadBotSettings(preloadCtx, token, botID)
 
+	// Publish command lists and the menu button to Telegram (async to not
+	// block startup)
+	go m.syncBotCommands(context.Background(), bot, token, &telebot.Chat{ID: ownerChatID}, botID)
+
+	// Start the digest loop; it idles until the owner enables digest mode
+	digestCtx, digestCancel := context.WithCancel(context.Background())
+	m.digestCancels[token] = digestCancel
+	go m.runDigestLoop(digestCtx, bot, token, &telebot.Chat{ID: ownerChatID}, botID)
+
+	// Start the SLA loop; it idles until the owner sets a threshold
+	slaCtx, slaCancel := context.WithCancel(context.Background())
+	m.slaCancels[token] = slaCancel
+	go m.runSLALoop(slaCtx, bot, token, &telebot.Chat{ID: ownerChatID}, botID)
+
+	// Start the channel stats loop; it samples forced channel member counts
+	channelStatsCtx, channelStatsCancel := context.WithCancel(context.Background())
+	m.channelStatsCancels[token] = channelStatsCancel
+	go m.runChannelStatsLoop(channelStatsCtx, bot, token, botID)
+
 	// Create restart policy and controller for child bot
 	policy := recovery.NewRestartPolicy(3, 5*time.Second, 1*time.Minute)
 	m.restartPolicies[token] = policy
@@ -210,9 +377,59 @@ func (m *Manager) StartBot(token string, ownerChatID int64, botID int64) error {
 
 	log.Printf("Started webhook for bot: %s... (ID: %d)", tokenPrefix, botID)
 
+	m.publishEventLocked(events.Event{
+		Name:        events.BotStarted,
+		BotID:       botID,
+		OwnerChatID: ownerChatID,
+	})
+
 	return nil
 }
 
+// baseAllowedUpdates returns the update types every bot handles.
+// my_chat_member keeps block/unblock tracking current.
+func baseAllowedUpdates() []string {
+	return []string{"message", "edited_message", "callback_query", "my_chat_member"}
+}
+
+// allowedUpdates builds the allowed_updates list for a child bot based on
+// which features are enabled, so Telegram only delivers what is handled
+func (m *Manager) allowedUpdates(ctx context.Context, botID int64) []string {
+	updates := baseAllowedUpdates()
+
+	botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+	if err != nil {
+		log.Printf("Failed to load settings for allowed_updates (bot %d): %v", botID, err)
+		return updates
+	}
+	if botModel != nil && botModel.ForcedSubEnabled {
+		// Needed to track channel membership changes and join requests
+		updates = append(updates, "chat_member", "chat_join_request")
+	}
+	if botModel != nil && botModel.RelayChannelID != 0 {
+		// Needed to mirror posts from the relay source channel
+		updates = append(updates, "channel_post")
+	}
+	if botModel != nil && botModel.ReactionActions != "" {
+		// Needed to trigger the owner's reaction workflows
+		updates = append(updates, "message_reaction")
+	}
+	return updates
+}
+
+// refreshWebhook re-registers the webhook so allowed_updates reflects the
+// current feature set, e.g. after forced subscription is toggled
+func (m *Manager) refreshWebhook(ctx context.Context, bot *telebot.Bot, token string, botID int64) {
+	publicURL := fmt.Sprintf("%s/webhook/%s", m.webhookURL, token)
+	webhook := &telebot.Webhook{
+		Endpoint:       &telebot.WebhookEndpoint{PublicURL: publicURL},
+		AllowedUpdates: m.allowedUpdates(ctx, botID),
+	}
+	if err := bot.SetWebhook(webhook); err != nil {
+		log.Printf("Failed to refresh webhook for bot %d: %v", botID, err)
+	}
+}
+
 // preloadBotSettings loads all bot settings into cache on startup
 func (m *Manager) preloadBotSettings(ctx context.Context, token string, botID int64) {
 	tokenPrefix := token[:10]
@@ -225,29 +442,19 @@ func (m *Manager) preloadBotSettings(ctx context.Context, token string, botID in
 	default:
 	}
 
-	// Fetch bot settings from DB
-	botModel, err := m.repo.GetBotByToken(ctx, token)
+	// Fetch all settings from DB in a single query
+	settings, err := m.repo.GetBotRuntimeSettings(ctx, botID)
 	if err != nil {
 		log.Printf("Failed to preload settings for bot %s...: %v", tokenPrefix, err)
 		return
 	}
 
-	if botModel == nil {
+	if settings == nil {
 		return
 	}
 
 	// Preload all settings into Redis
-	startMsg := ""
-	if botModel.StartMessage != "" {
-		startMsg = botModel.StartMessage
-	}
-
-	err = m.cache.PreloadBotSettings(ctx, token,
-		startMsg,
-		botModel.ForwardAutoReplies,
-		botModel.ShowSentConfirmation,
-		botModel.ForcedSubEnabled,
-	)
+	err = m.cache.PreloadBotSettings(ctx, token, settings)
 	if err != nil {
 		log.Printf("Failed to preload settings to cache for bot %s...: %v", tokenPrefix, err)
 	} else {
@@ -314,6 +521,9 @@ func (m *Manager) StopBot(token string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// A demoted bot only has a cold-tier entry left to clean up
+	delete(m.coldBots, token)
+
 	if bot, exists := m.bots[token]; exists {
 		tokenPrefix := token[:10]
 
@@ -323,12 +533,34 @@ func (m *Manager) StopBot(token string) {
 			delete(m.preloadCancels, token)
 		}
 
+		// Stop the digest loop
+		if cancel, cancelExists := m.digestCancels[token]; cancelExists {
+			cancel()
+			delete(m.digestCancels, token)
+		}
+
+		// Stop the SLA loop
+		if cancel, cancelExists := m.slaCancels[token]; cancelExists {
+			cancel()
+			delete(m.slaCancels, token)
+		}
+
+		// Stop the channel stats loop
+		if cancel, cancelExists := m.channelStatsCancels[token]; cancelExists {
+			cancel()
+			delete(m.channelStatsCancels, token)
+		}
+
 		// Stop the restart controller first to cancel the goroutine
 		if controller, ctrlExists := m.restartControllers[token]; ctrlExists {
 			controller.Stop()
 			delete(m.restartControllers, token)
 		}
 
+		if bot != nil && bot.Me != nil {
+			delete(m.childCtxs, bot.Me.ID)
+		}
+
 		botCopy := bot
 		recovery.SafeGo(
 			func() { botCopy.RemoveWebhook() },
@@ -339,10 +571,19 @@ func (m *Manager) StopBot(token string) {
 			m.recoveryHandler,
 		)
 
+		stoppedBotID := m.botIDs[token]
+		delete(m.webhookIssues, stoppedBotID)
+
 		delete(m.bots, token)
 		delete(m.botIDs, token)
 		delete(m.restartPolicies, token)
+		delete(m.lastActivity, token)
 		log.Printf("Stopped bot: %s...", tokenPrefix)
+
+		m.publishEventLocked(events.Event{
+			Name:  events.BotStopped,
+			BotID: stoppedBotID,
+		})
 	}
 }
 
@@ -360,12 +601,34 @@ func (m *Manager) StopAll() {
 			delete(m.preloadCancels, token)
 		}
 
+		// Stop the digest loop
+		if cancel, cancelExists := m.digestCancels[token]; cancelExists {
+			cancel()
+			delete(m.digestCancels, token)
+		}
+
+		// Stop the SLA loop
+		if cancel, cancelExists := m.slaCancels[token]; cancelExists {
+			cancel()
+			delete(m.slaCancels, token)
+		}
+
+		// Stop the channel stats loop
+		if cancel, cancelExists := m.channelStatsCancels[token]; cancelExists {
+			cancel()
+			delete(m.channelStatsCancels, token)
+		}
+
 		// Stop the restart controller first
 		if controller, ctrlExists := m.restartControllers[token]; ctrlExists {
 			controller.Stop()
 			delete(m.restartControllers, token)
 		}
 
+		if bot != nil && bot.Me != nil {
+			delete(m.childCtxs, bot.Me.ID)
+		}
+
 		botCopy := bot
 		recovery.SafeGo(
 			func() { botCopy.RemoveWebhook() },
@@ -378,6 +641,7 @@ func (m *Manager) StopAll() {
 		delete(m.bots, token)
 		delete(m.botIDs, token)
 		delete(m.restartPolicies, token)
+		delete(m.lastActivity, token)
 	}
 }
 
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/telegramtest"
+)
+
+// ==================== Webhook Path Benchmarks ====================
+//
+// These benchmarks replay synthetic updates against Manager.ServeHTTP
+// with varying bot and user counts, backed by the fake Telegram server.
+// Run with -benchmem to see the per-update allocation profile:
+//
+//	go test -bench=ServeHTTP -benchmem ./internal/bot/
+
+func benchmarkServeHTTP(b *testing.B, numBots, numUsers int) {
+	m, _ := setupIntegrationManager(b)
+
+	tokens := make([]string, numBots)
+	for i := range tokens {
+		tokens[i] = fmt.Sprintf("%d:BENCH_TOKEN", 100000+i)
+		if err := m.StartBot(tokens[i], int64(90000+i), int64(i+1)); err != nil {
+			b.Fatalf("StartBot failed: %v", err)
+		}
+	}
+	b.Cleanup(m.StopAll)
+
+	// Pre-marshal one update body per synthetic user
+	bodies := make([][]byte, numUsers)
+	for i := range bodies {
+		user := telegramtest.User(int64(10000+i), fmt.Sprintf("user%d", i))
+		body, err := json.Marshal(telegramtest.MessageUpdate(user, "benchmark message"))
+		if err != nil {
+			b.Fatalf("Failed to marshal update: %v", err)
+		}
+		bodies[i] = body
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		token := tokens[i%numBots]
+		body := bodies[i%numUsers]
+		req := httptest.NewRequest("POST", "/webhook/"+token, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		m.ServeHTTP(rr, req)
+	}
+}
+
+func BenchmarkServeHTTP_SingleBot(b *testing.B) {
+	benchmarkServeHTTP(b, 1, 100)
+}
+
+func BenchmarkServeHTTP_TenBots(b *testing.B) {
+	benchmarkServeHTTP(b, 10, 1000)
+}
+
+func BenchmarkServeHTTP_UnknownToken(b *testing.B) {
+	m, _ := setupIntegrationManager(b)
+
+	body := []byte(`{"update_id":1}`)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest("POST", "/webhook/unknowntoken", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		m.ServeHTTP(rr, req)
+	}
+}
@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/telegramtest"
+)
+
+// ==================== Integration Tests ====================
+//
+// These tests run a Manager against the fake Telegram API server from
+// internal/telegramtest. The database is a sqlmock with no expectations:
+// every query fails, which exercises the graceful fallbacks the handlers
+// use when the DB is unavailable.
+
+const integrationToken = "123456:INTEGRATION_TOKEN"
+
+func setupIntegrationManager(t testing.TB) (*Manager, *telegramtest.Server) {
+	t.Helper()
+
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	repo := database.NewRepository(database.NewMySQLFromDB(sqlxDB), "")
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to create miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisCache, err := cache.NewRedis(mr.Addr(), "", 0, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create Redis client: %v", err)
+	}
+	t.Cleanup(func() { redisCache.Close() })
+
+	server := telegramtest.NewServer(t)
+	m := NewManager(repo, redisCache, "https://example.com")
+	m.SetAPIEndpoint(server.URL())
+
+	return m, server
+}
+
+// waitForCall polls the fake server until a method has been called,
+// since child bot handlers run asynchronously
+func waitForCall(t *testing.T, server *telegramtest.Server, method string) telegramtest.Call {
+	t.Helper()
+
+	deadline := time.After(3 * time.Second)
+	for {
+		if call, ok := server.LastCall(method); ok {
+			return call
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("Timed out waiting for %s call", method)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestStartBot_SetsWebhookOnFakeServer(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	if err := m.StartBot(integrationToken, 999, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	if !m.IsRunning(integrationToken) {
+		t.Error("Expected bot to be running")
+	}
+	if server.CallCount("setWebhook") != 1 {
+		t.Errorf("Expected 1 setWebhook call, got %d", server.CallCount("setWebhook"))
+	}
+}
+
+func TestUserMessage_ForwardedToOwner(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	const ownerChatID = 999
+	if err := m.StartBot(integrationToken, ownerChatID, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	// Deliver a user message through the webhook endpoint, as Telegram would
+	user := telegramtest.User(555, "alice")
+	body, _ := json.Marshal(telegramtest.MessageUpdate(user, "hello owner"))
+	req := httptest.NewRequest("POST", "/webhook/"+integrationToken, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	m.ServeHTTP(rr, req)
+
+	if rr.Code != 200 {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+
+	call := waitForCall(t, server, "forwardMessage")
+	if call.ChatID() != ownerChatID {
+		t.Errorf("Expected message forwarded to owner chat %d, got %d", ownerChatID, call.ChatID())
+	}
+}
+
+func TestStartBot_VerifiesWebhookRegistration(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	if err := m.StartBot(integrationToken, 999, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	if server.CallCount("getWebhookInfo") != 1 {
+		t.Errorf("Expected 1 getWebhookInfo call, got %d", server.CallCount("getWebhookInfo"))
+	}
+}
+
+func TestBotHealth_ReportsTelegramError(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	if err := m.StartBot(integrationToken, 999, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	server.SetWebhookError(integrationToken, "SSL error: certificate verify failed")
+
+	health := m.BotHealth(1)
+	if !health.Running {
+		t.Error("Expected bot to be reported as running")
+	}
+	if health.ErrorMessage != "SSL error: certificate verify failed" {
+		t.Errorf("Unexpected error message: %q", health.ErrorMessage)
+	}
+	if health.ErrorAt.IsZero() {
+		t.Error("Expected ErrorAt to be set")
+	}
+}
+
+func TestBotHealth_StoppedBot(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+
+	health := m.BotHealth(42)
+	if health.Running {
+		t.Error("Expected stopped bot to be reported as not running")
+	}
+}
+
+func TestHealthCheck_RecordsWebhookIssue(t *testing.T) {
+	m, server := setupIntegrationManager(t)
+
+	if err := m.StartBot(integrationToken, 999, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	server.SetWebhookError(integrationToken, "Connection refused")
+
+	// A zero threshold makes every bot count as stale
+	m.checkStaleWebhooks(0)
+
+	if issue := m.WebhookIssue(1); issue != "Connection refused" {
+		t.Errorf("Expected recorded issue, got %q", issue)
+	}
+
+	// Once Telegram stops reporting the error, the issue clears
+	server.SetWebhookError(integrationToken, "")
+	m.checkStaleWebhooks(0)
+
+	if issue := m.WebhookIssue(1); issue != "" {
+		t.Errorf("Expected issue to clear, got %q", issue)
+	}
+}
+
+func TestWebhookAdvice(t *testing.T) {
+	cases := map[string]string{
+		"SSL error: certificate verify failed":           "certificate problem",
+		"Wrong response from the webhook: 404 Not Found": "wrong response",
+		"Connection refused":                             "cannot reach the server",
+		"":                                               "",
+		"something else":                                 "",
+	}
+
+	for message, want := range cases {
+		advice := WebhookAdvice(message)
+		if want == "" {
+			if advice != "" {
+				t.Errorf("Expected no advice for %q, got %q", message, advice)
+			}
+			continue
+		}
+		if !strings.Contains(advice, want) {
+			t.Errorf("Advice for %q should mention %q, got %q", message, want, advice)
+		}
+	}
+}
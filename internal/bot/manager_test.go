@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/Amr-9/botforge/internal/recovery"
+	"gopkg.in/telebot.v3"
 )
 
 // ==================== NewManager Tests ====================
@@ -399,6 +401,7 @@ func TestServeHTTP_PathTooShort(t *testing.T) {
 	m := NewManager(nil, nil, "https://example.com")
 
 	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
 	m.ServeHTTP(rr, req)
@@ -412,6 +415,7 @@ func TestServeHTTP_EmptyToken(t *testing.T) {
 	m := NewManager(nil, nil, "https://example.com")
 
 	req := httptest.NewRequest(http.MethodPost, "/webhook/", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
 	m.ServeHTTP(rr, req)
@@ -425,12 +429,62 @@ func TestServeHTTP_BotNotFound(t *testing.T) {
 	m := NewManager(nil, nil, "https://example.com")
 
 	req := httptest.NewRequest(http.MethodPost, "/webhook/unknowntoken123", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	m.ServeHTTP(rr, req)
+
+	// 200, not 404, so Telegram stops retrying updates for deleted bots
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 for unknown token, got %d", rr.Code)
+	}
+}
+
+func TestServeHTTP_WrongMethod(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+
+	req := httptest.NewRequest(http.MethodGet, "/webhook/sometoken123456", nil)
+	rr := httptest.NewRecorder()
+
+	m.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET request, got %d", rr.Code)
+	}
+}
+
+func TestServeHTTP_WrongContentType(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/sometoken123456", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "text/plain")
+	rr := httptest.NewRecorder()
+
+	m.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 for non-JSON content type, got %d", rr.Code)
+	}
+}
+
+func TestServeHTTP_BodyTooLarge(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+	token := "oversizetoken12345"
+
+	m.mu.Lock()
+	m.bots[token] = nil
+	m.mu.Unlock()
+
+	// Valid JSON just over the cap, so only the size limit can reject it
+	body := `{"update_id": 1, "pad": "` + strings.Repeat("x", maxUpdateBytes) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook/"+token, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
 	m.ServeHTTP(rr, req)
 
-	if rr.Code != http.StatusNotFound {
-		t.Errorf("Expected 404 for unknown token, got %d", rr.Code)
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for oversized body, got %d", rr.Code)
 	}
 }
 
@@ -443,6 +497,7 @@ func TestServeHTTP_InvalidJSON(t *testing.T) {
 	m.mu.Unlock()
 
 	req := httptest.NewRequest(http.MethodPost, "/webhook/"+token, bytes.NewBufferString("not valid json {{{"))
+	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
 	m.ServeHTTP(rr, req)
@@ -482,6 +537,7 @@ func TestServeHTTP_EmptyBody(t *testing.T) {
 	m.mu.Unlock()
 
 	req := httptest.NewRequest(http.MethodPost, "/webhook/"+token, bytes.NewBufferString(""))
+	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
 	m.ServeHTTP(rr, req)
@@ -600,3 +656,80 @@ func TestManager_ConcurrentGetBotByID(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// ==================== Tiering Tests ====================
+
+func TestDemoteColdBots_MovesInactiveBots(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+
+	coldToken := "coldbottoken12345"
+	warmToken := "warmbottoken12345"
+	factoryToken := "factorytoken12345"
+
+	m.mu.Lock()
+	m.bots[coldToken] = &telebot.Bot{Me: &telebot.User{ID: 42}}
+	m.botIDs[coldToken] = 5
+	m.childCtxs[42] = &childContext{token: coldToken, ownerChat: &telebot.Chat{ID: 777}}
+	m.lastActivity[coldToken] = time.Now().Add(-48 * time.Hour)
+
+	m.bots[warmToken] = &telebot.Bot{Me: &telebot.User{ID: 43}}
+	m.botIDs[warmToken] = 6
+	m.lastActivity[warmToken] = time.Now()
+
+	// Factory bots are registered with ID 0 and must never be demoted
+	m.bots[factoryToken] = &telebot.Bot{Me: &telebot.User{ID: 44}}
+	m.botIDs[factoryToken] = 0
+	m.lastActivity[factoryToken] = time.Now().Add(-48 * time.Hour)
+	m.mu.Unlock()
+
+	if demoted := m.demoteColdBots(24 * time.Hour); demoted != 1 {
+		t.Fatalf("Expected 1 demoted bot, got %d", demoted)
+	}
+
+	if m.IsRunning(coldToken) {
+		t.Error("Cold bot should be removed from bots map")
+	}
+	if !m.IsRunning(warmToken) || !m.IsRunning(factoryToken) {
+		t.Error("Warm and factory bots should stay resident")
+	}
+
+	report := m.ColdBots()
+	if len(report) != 1 {
+		t.Fatalf("Expected 1 cold bot in report, got %d", len(report))
+	}
+	if report[0].BotID != 5 {
+		t.Errorf("Expected bot ID 5 in report, got %d", report[0].BotID)
+	}
+
+	m.mu.RLock()
+	info, hasCold := m.coldBots[coldToken]
+	_, hasCtx := m.childCtxs[42]
+	m.mu.RUnlock()
+	if !hasCold {
+		t.Fatal("Cold bot should be tracked for re-promotion")
+	}
+	if info.ownerChatID != 777 {
+		t.Errorf("Expected owner 777 kept for re-promotion, got %d", info.ownerChatID)
+	}
+	if hasCtx {
+		t.Error("Dispatcher context of a cold bot should be removed")
+	}
+}
+
+func TestStopBot_ClearsColdEntry(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+	token := "coldstoptoken1234"
+
+	m.mu.Lock()
+	m.coldBots[token] = coldBot{ownerChatID: 1, botID: 2}
+	m.mu.Unlock()
+
+	m.StopBot(token)
+
+	m.mu.RLock()
+	_, hasCold := m.coldBots[token]
+	m.mu.RUnlock()
+	if hasCold {
+		t.Error("StopBot should drop the cold-tier entry")
+	}
+}
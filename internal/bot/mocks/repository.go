@@ -0,0 +1,1778 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Amr-9/botforge/internal/bot (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=internal/bot/mocks/repository.go -package=mocks github.com/Amr-9/botforge/internal/bot Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	database "github.com/Amr-9/botforge/internal/database"
+	models "github.com/Amr-9/botforge/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddForcedSubExemption mocks base method.
+func (m *MockRepository) AddForcedSubExemption(ctx context.Context, botID, userChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddForcedSubExemption", ctx, botID, userChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddForcedSubExemption indicates an expected call of AddForcedSubExemption.
+func (mr *MockRepositoryMockRecorder) AddForcedSubExemption(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddForcedSubExemption", reflect.TypeOf((*MockRepository)(nil).AddForcedSubExemption), ctx, botID, userChatID)
+}
+
+// AddWhitelistedUser mocks base method.
+func (m *MockRepository) AddWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWhitelistedUser", ctx, botID, userChatID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWhitelistedUser indicates an expected call of AddWhitelistedUser.
+func (mr *MockRepositoryMockRecorder) AddWhitelistedUser(ctx, botID, userChatID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWhitelistedUser", reflect.TypeOf((*MockRepository)(nil).AddWhitelistedUser), ctx, botID, userChatID, ownerChatID)
+}
+
+// BanUser mocks base method.
+func (m *MockRepository) BanUser(ctx context.Context, botID, userChatID, bannedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, botID, userChatID, bannedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockRepositoryMockRecorder) BanUser(ctx, botID, userChatID, bannedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockRepository)(nil).BanUser), ctx, botID, userChatID, bannedBy)
+}
+
+// CreateAbuseReport mocks base method.
+func (m *MockRepository) CreateAbuseReport(ctx context.Context, botID, reporterChatID int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAbuseReport", ctx, botID, reporterChatID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAbuseReport indicates an expected call of CreateAbuseReport.
+func (mr *MockRepositoryMockRecorder) CreateAbuseReport(ctx, botID, reporterChatID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbuseReport", reflect.TypeOf((*MockRepository)(nil).CreateAbuseReport), ctx, botID, reporterChatID, reason)
+}
+
+// CreateAutoReply mocks base method.
+func (m *MockRepository) CreateAutoReply(ctx context.Context, botID int64, trigger, response, messageType, fileID, caption, triggerType, matchType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAutoReply", ctx, botID, trigger, response, messageType, fileID, caption, triggerType, matchType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAutoReply indicates an expected call of CreateAutoReply.
+func (mr *MockRepositoryMockRecorder) CreateAutoReply(ctx, botID, trigger, response, messageType, fileID, caption, triggerType, matchType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAutoReply", reflect.TypeOf((*MockRepository)(nil).CreateAutoReply), ctx, botID, trigger, response, messageType, fileID, caption, triggerType, matchType)
+}
+
+// CreateBroadcast mocks base method.
+func (m *MockRepository) CreateBroadcast(ctx context.Context, b *models.Broadcast) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBroadcast", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBroadcast indicates an expected call of CreateBroadcast.
+func (mr *MockRepositoryMockRecorder) CreateBroadcast(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBroadcast", reflect.TypeOf((*MockRepository)(nil).CreateBroadcast), ctx, b)
+}
+
+// CreateForcedChannel mocks base method.
+func (m *MockRepository) CreateForcedChannel(ctx context.Context, botID, channelID int64, username, title, inviteLink string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForcedChannel", ctx, botID, channelID, username, title, inviteLink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateForcedChannel indicates an expected call of CreateForcedChannel.
+func (mr *MockRepositoryMockRecorder) CreateForcedChannel(ctx, botID, channelID, username, title, inviteLink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForcedChannel", reflect.TypeOf((*MockRepository)(nil).CreateForcedChannel), ctx, botID, channelID, username, title, inviteLink)
+}
+
+// CreateRoutingRule mocks base method.
+func (m *MockRepository) CreateRoutingRule(ctx context.Context, botID int64, keyword string, destChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoutingRule", ctx, botID, keyword, destChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRoutingRule indicates an expected call of CreateRoutingRule.
+func (mr *MockRepositoryMockRecorder) CreateRoutingRule(ctx, botID, keyword, destChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoutingRule", reflect.TypeOf((*MockRepository)(nil).CreateRoutingRule), ctx, botID, keyword, destChatID)
+}
+
+// CreateScheduledMessage mocks base method.
+func (m *MockRepository) CreateScheduledMessage(ctx context.Context, msg *models.ScheduledMessage) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledMessage", ctx, msg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledMessage indicates an expected call of CreateScheduledMessage.
+func (mr *MockRepositoryMockRecorder) CreateScheduledMessage(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledMessage", reflect.TypeOf((*MockRepository)(nil).CreateScheduledMessage), ctx, msg)
+}
+
+// CreateShortLink mocks base method.
+func (m *MockRepository) CreateShortLink(ctx context.Context, botID int64, code, targetURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShortLink", ctx, botID, code, targetURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShortLink indicates an expected call of CreateShortLink.
+func (mr *MockRepositoryMockRecorder) CreateShortLink(ctx, botID, code, targetURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortLink", reflect.TypeOf((*MockRepository)(nil).CreateShortLink), ctx, botID, code, targetURL)
+}
+
+// DeleteAutoReply mocks base method.
+func (m *MockRepository) DeleteAutoReply(ctx context.Context, botID, replyID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAutoReply", ctx, botID, replyID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAutoReply indicates an expected call of DeleteAutoReply.
+func (mr *MockRepositoryMockRecorder) DeleteAutoReply(ctx, botID, replyID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAutoReply", reflect.TypeOf((*MockRepository)(nil).DeleteAutoReply), ctx, botID, replyID, ownerChatID)
+}
+
+// DeleteForcedChannel mocks base method.
+func (m *MockRepository) DeleteForcedChannel(ctx context.Context, botID, channelID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForcedChannel", ctx, botID, channelID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForcedChannel indicates an expected call of DeleteForcedChannel.
+func (mr *MockRepositoryMockRecorder) DeleteForcedChannel(ctx, botID, channelID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForcedChannel", reflect.TypeOf((*MockRepository)(nil).DeleteForcedChannel), ctx, botID, channelID, ownerChatID)
+}
+
+// DeleteReplyMapping mocks base method.
+func (m *MockRepository) DeleteReplyMapping(ctx context.Context, botID int64, adminMsgID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReplyMapping", ctx, botID, adminMsgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReplyMapping indicates an expected call of DeleteReplyMapping.
+func (mr *MockRepositoryMockRecorder) DeleteReplyMapping(ctx, botID, adminMsgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReplyMapping", reflect.TypeOf((*MockRepository)(nil).DeleteReplyMapping), ctx, botID, adminMsgID)
+}
+
+// DeleteRoutingRule mocks base method.
+func (m *MockRepository) DeleteRoutingRule(ctx context.Context, botID, ruleID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoutingRule", ctx, botID, ruleID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoutingRule indicates an expected call of DeleteRoutingRule.
+func (mr *MockRepositoryMockRecorder) DeleteRoutingRule(ctx, botID, ruleID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoutingRule", reflect.TypeOf((*MockRepository)(nil).DeleteRoutingRule), ctx, botID, ruleID, ownerChatID)
+}
+
+// DeleteScheduledMessage mocks base method.
+func (m *MockRepository) DeleteScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledMessage", ctx, msgID, botID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledMessage indicates an expected call of DeleteScheduledMessage.
+func (mr *MockRepositoryMockRecorder) DeleteScheduledMessage(ctx, msgID, botID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledMessage", reflect.TypeOf((*MockRepository)(nil).DeleteScheduledMessage), ctx, msgID, botID, ownerChatID)
+}
+
+// DeleteShortLink mocks base method.
+func (m *MockRepository) DeleteShortLink(ctx context.Context, botID, linkID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortLink", ctx, botID, linkID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortLink indicates an expected call of DeleteShortLink.
+func (mr *MockRepositoryMockRecorder) DeleteShortLink(ctx, botID, linkID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortLink", reflect.TypeOf((*MockRepository)(nil).DeleteShortLink), ctx, botID, linkID, ownerChatID)
+}
+
+// EraseUserData mocks base method.
+func (m *MockRepository) EraseUserData(ctx context.Context, botID, userChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUserData", ctx, botID, userChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUserData indicates an expected call of EraseUserData.
+func (mr *MockRepositoryMockRecorder) EraseUserData(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUserData", reflect.TypeOf((*MockRepository)(nil).EraseUserData), ctx, botID, userChatID)
+}
+
+// GetActiveUserCount mocks base method.
+func (m *MockRepository) GetActiveUserCount(ctx context.Context, botID int64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveUserCount", ctx, botID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveUserCount indicates an expected call of GetActiveUserCount.
+func (mr *MockRepositoryMockRecorder) GetActiveUserCount(ctx, botID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockRepository)(nil).GetActiveUserCount), ctx, botID, since)
+}
+
+// GetAllUserChatIDs mocks base method.
+func (m *MockRepository) GetAllUserChatIDs(ctx context.Context, botID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUserChatIDs", ctx, botID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUserChatIDs indicates an expected call of GetAllUserChatIDs.
+func (mr *MockRepositoryMockRecorder) GetAllUserChatIDs(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserChatIDs", reflect.TypeOf((*MockRepository)(nil).GetAllUserChatIDs), ctx, botID)
+}
+
+// GetAutoReplies mocks base method.
+func (m *MockRepository) GetAutoReplies(ctx context.Context, botID int64, triggerType string) ([]models.AutoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoReplies", ctx, botID, triggerType)
+	ret0, _ := ret[0].([]models.AutoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoReplies indicates an expected call of GetAutoReplies.
+func (mr *MockRepositoryMockRecorder) GetAutoReplies(ctx, botID, triggerType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoReplies", reflect.TypeOf((*MockRepository)(nil).GetAutoReplies), ctx, botID, triggerType)
+}
+
+// GetAutoReplyByID mocks base method.
+func (m *MockRepository) GetAutoReplyByID(ctx context.Context, botID, replyID int64) (*models.AutoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoReplyByID", ctx, botID, replyID)
+	ret0, _ := ret[0].(*models.AutoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoReplyByID indicates an expected call of GetAutoReplyByID.
+func (mr *MockRepositoryMockRecorder) GetAutoReplyByID(ctx, botID, replyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoReplyByID", reflect.TypeOf((*MockRepository)(nil).GetAutoReplyByID), ctx, botID, replyID)
+}
+
+// GetAutoReplyByTrigger mocks base method.
+func (m *MockRepository) GetAutoReplyByTrigger(ctx context.Context, botID int64, trigger, triggerType string) (*models.AutoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoReplyByTrigger", ctx, botID, trigger, triggerType)
+	ret0, _ := ret[0].(*models.AutoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoReplyByTrigger indicates an expected call of GetAutoReplyByTrigger.
+func (mr *MockRepositoryMockRecorder) GetAutoReplyByTrigger(ctx, botID, trigger, triggerType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoReplyByTrigger", reflect.TypeOf((*MockRepository)(nil).GetAutoReplyByTrigger), ctx, botID, trigger, triggerType)
+}
+
+// GetAutoReplyCount mocks base method.
+func (m *MockRepository) GetAutoReplyCount(ctx context.Context, botID int64, triggerType string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoReplyCount", ctx, botID, triggerType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoReplyCount indicates an expected call of GetAutoReplyCount.
+func (mr *MockRepositoryMockRecorder) GetAutoReplyCount(ctx, botID, triggerType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoReplyCount", reflect.TypeOf((*MockRepository)(nil).GetAutoReplyCount), ctx, botID, triggerType)
+}
+
+// GetBannedUserCount mocks base method.
+func (m *MockRepository) GetBannedUserCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannedUserCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannedUserCount indicates an expected call of GetBannedUserCount.
+func (mr *MockRepositoryMockRecorder) GetBannedUserCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannedUserCount", reflect.TypeOf((*MockRepository)(nil).GetBannedUserCount), ctx, botID)
+}
+
+// GetBannedUsersPage mocks base method.
+func (m *MockRepository) GetBannedUsersPage(ctx context.Context, botID int64, cursor database.Cursor, limit int) ([]models.BannedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannedUsersPage", ctx, botID, cursor, limit)
+	ret0, _ := ret[0].([]models.BannedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannedUsersPage indicates an expected call of GetBannedUsersPage.
+func (mr *MockRepositoryMockRecorder) GetBannedUsersPage(ctx, botID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannedUsersPage", reflect.TypeOf((*MockRepository)(nil).GetBannedUsersPage), ctx, botID, cursor, limit)
+}
+
+// GetBlockedUserCount mocks base method.
+func (m *MockRepository) GetBlockedUserCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUserCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUserCount indicates an expected call of GetBlockedUserCount.
+func (mr *MockRepositoryMockRecorder) GetBlockedUserCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUserCount", reflect.TypeOf((*MockRepository)(nil).GetBlockedUserCount), ctx, botID)
+}
+
+// GetBotByToken mocks base method.
+func (m *MockRepository) GetBotByToken(ctx context.Context, token string) (*models.Bot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotByToken", ctx, token)
+	ret0, _ := ret[0].(*models.Bot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotByToken indicates an expected call of GetBotByToken.
+func (mr *MockRepositoryMockRecorder) GetBotByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotByToken", reflect.TypeOf((*MockRepository)(nil).GetBotByToken), ctx, token)
+}
+
+// GetBotRuntimeSettings mocks base method.
+func (m *MockRepository) GetBotRuntimeSettings(ctx context.Context, botID int64) (*models.BotRuntimeSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotRuntimeSettings", ctx, botID)
+	ret0, _ := ret[0].(*models.BotRuntimeSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotRuntimeSettings indicates an expected call of GetBotRuntimeSettings.
+func (mr *MockRepositoryMockRecorder) GetBotRuntimeSettings(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotRuntimeSettings", reflect.TypeOf((*MockRepository)(nil).GetBotRuntimeSettings), ctx, botID)
+}
+
+// GetBotSettingsByID mocks base method.
+func (m *MockRepository) GetBotSettingsByID(ctx context.Context, botID int64) (*models.Bot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotSettingsByID", ctx, botID)
+	ret0, _ := ret[0].(*models.Bot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotSettingsByID indicates an expected call of GetBotSettingsByID.
+func (mr *MockRepositoryMockRecorder) GetBotSettingsByID(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotSettingsByID", reflect.TypeOf((*MockRepository)(nil).GetBotSettingsByID), ctx, botID)
+}
+
+// GetBroadcastByID mocks base method.
+func (m *MockRepository) GetBroadcastByID(ctx context.Context, botID, broadcastID int64) (*models.Broadcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastByID", ctx, botID, broadcastID)
+	ret0, _ := ret[0].(*models.Broadcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastByID indicates an expected call of GetBroadcastByID.
+func (mr *MockRepositoryMockRecorder) GetBroadcastByID(ctx, botID, broadcastID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastByID", reflect.TypeOf((*MockRepository)(nil).GetBroadcastByID), ctx, botID, broadcastID)
+}
+
+// GetBroadcasts mocks base method.
+func (m *MockRepository) GetBroadcasts(ctx context.Context, botID int64, limit int) ([]models.Broadcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcasts", ctx, botID, limit)
+	ret0, _ := ret[0].([]models.Broadcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcasts indicates an expected call of GetBroadcasts.
+func (mr *MockRepositoryMockRecorder) GetBroadcasts(ctx, botID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcasts", reflect.TypeOf((*MockRepository)(nil).GetBroadcasts), ctx, botID, limit)
+}
+
+// GetChannelMemberHistory mocks base method.
+func (m *MockRepository) GetChannelMemberHistory(ctx context.Context, botID, channelID int64, limit int) ([]models.ChannelMemberCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMemberHistory", ctx, botID, channelID, limit)
+	ret0, _ := ret[0].([]models.ChannelMemberCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMemberHistory indicates an expected call of GetChannelMemberHistory.
+func (mr *MockRepositoryMockRecorder) GetChannelMemberHistory(ctx, botID, channelID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMemberHistory", reflect.TypeOf((*MockRepository)(nil).GetChannelMemberHistory), ctx, botID, channelID, limit)
+}
+
+// GetFirstMessageDate mocks base method.
+func (m *MockRepository) GetFirstMessageDate(ctx context.Context, botID, userChatID int64) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstMessageDate", ctx, botID, userChatID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstMessageDate indicates an expected call of GetFirstMessageDate.
+func (mr *MockRepositoryMockRecorder) GetFirstMessageDate(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstMessageDate", reflect.TypeOf((*MockRepository)(nil).GetFirstMessageDate), ctx, botID, userChatID)
+}
+
+// GetFirstResponseTimes mocks base method.
+func (m *MockRepository) GetFirstResponseTimes(ctx context.Context, botID int64, since time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstResponseTimes", ctx, botID, since)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstResponseTimes indicates an expected call of GetFirstResponseTimes.
+func (mr *MockRepositoryMockRecorder) GetFirstResponseTimes(ctx, botID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstResponseTimes", reflect.TypeOf((*MockRepository)(nil).GetFirstResponseTimes), ctx, botID, since)
+}
+
+// GetForcedChannel mocks base method.
+func (m *MockRepository) GetForcedChannel(ctx context.Context, botID, channelID int64) (*models.ForcedChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForcedChannel", ctx, botID, channelID)
+	ret0, _ := ret[0].(*models.ForcedChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForcedChannel indicates an expected call of GetForcedChannel.
+func (mr *MockRepositoryMockRecorder) GetForcedChannel(ctx, botID, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForcedChannel", reflect.TypeOf((*MockRepository)(nil).GetForcedChannel), ctx, botID, channelID)
+}
+
+// GetForcedChannelCount mocks base method.
+func (m *MockRepository) GetForcedChannelCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForcedChannelCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForcedChannelCount indicates an expected call of GetForcedChannelCount.
+func (mr *MockRepositoryMockRecorder) GetForcedChannelCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForcedChannelCount", reflect.TypeOf((*MockRepository)(nil).GetForcedChannelCount), ctx, botID)
+}
+
+// GetForcedChannels mocks base method.
+func (m *MockRepository) GetForcedChannels(ctx context.Context, botID int64) ([]models.ForcedChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForcedChannels", ctx, botID)
+	ret0, _ := ret[0].([]models.ForcedChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForcedChannels indicates an expected call of GetForcedChannels.
+func (mr *MockRepositoryMockRecorder) GetForcedChannels(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForcedChannels", reflect.TypeOf((*MockRepository)(nil).GetForcedChannels), ctx, botID)
+}
+
+// GetForcedSubExemptions mocks base method.
+func (m *MockRepository) GetForcedSubExemptions(ctx context.Context, botID int64) ([]models.ForcedSubExemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForcedSubExemptions", ctx, botID)
+	ret0, _ := ret[0].([]models.ForcedSubExemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForcedSubExemptions indicates an expected call of GetForcedSubExemptions.
+func (mr *MockRepositoryMockRecorder) GetForcedSubExemptions(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForcedSubExemptions", reflect.TypeOf((*MockRepository)(nil).GetForcedSubExemptions), ctx, botID)
+}
+
+// GetLastDelivery mocks base method.
+func (m *MockRepository) GetLastDelivery(ctx context.Context, botID, userChatID int64) (*models.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastDelivery", ctx, botID, userChatID)
+	ret0, _ := ret[0].(*models.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastDelivery indicates an expected call of GetLastDelivery.
+func (mr *MockRepositoryMockRecorder) GetLastDelivery(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastDelivery", reflect.TypeOf((*MockRepository)(nil).GetLastDelivery), ctx, botID, userChatID)
+}
+
+// GetMessageContentCount mocks base method.
+func (m *MockRepository) GetMessageContentCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageContentCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageContentCount indicates an expected call of GetMessageContentCount.
+func (mr *MockRepositoryMockRecorder) GetMessageContentCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageContentCount", reflect.TypeOf((*MockRepository)(nil).GetMessageContentCount), ctx, botID)
+}
+
+// GetMessageContents mocks base method.
+func (m *MockRepository) GetMessageContents(ctx context.Context, botID int64, limit int) ([]models.MessageContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageContents", ctx, botID, limit)
+	ret0, _ := ret[0].([]models.MessageContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageContents indicates an expected call of GetMessageContents.
+func (mr *MockRepositoryMockRecorder) GetMessageContents(ctx, botID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageContents", reflect.TypeOf((*MockRepository)(nil).GetMessageContents), ctx, botID, limit)
+}
+
+// GetMessageCountSince mocks base method.
+func (m *MockRepository) GetMessageCountSince(ctx context.Context, botID int64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageCountSince", ctx, botID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageCountSince indicates an expected call of GetMessageCountSince.
+func (mr *MockRepositoryMockRecorder) GetMessageCountSince(ctx, botID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageCountSince", reflect.TypeOf((*MockRepository)(nil).GetMessageCountSince), ctx, botID, since)
+}
+
+// GetNewUserCount mocks base method.
+func (m *MockRepository) GetNewUserCount(ctx context.Context, botID int64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewUserCount", ctx, botID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewUserCount indicates an expected call of GetNewUserCount.
+func (mr *MockRepositoryMockRecorder) GetNewUserCount(ctx, botID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewUserCount", reflect.TypeOf((*MockRepository)(nil).GetNewUserCount), ctx, botID, since)
+}
+
+// GetOwnerPlainUI mocks base method.
+func (m *MockRepository) GetOwnerPlainUI(ctx context.Context, ownerChatID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerPlainUI", ctx, ownerChatID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerPlainUI indicates an expected call of GetOwnerPlainUI.
+func (mr *MockRepositoryMockRecorder) GetOwnerPlainUI(ctx, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerPlainUI", reflect.TypeOf((*MockRepository)(nil).GetOwnerPlainUI), ctx, ownerChatID)
+}
+
+// GetOwnerPlan mocks base method.
+func (m *MockRepository) GetOwnerPlan(ctx context.Context, ownerChatID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerPlan", ctx, ownerChatID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerPlan indicates an expected call of GetOwnerPlan.
+func (mr *MockRepositoryMockRecorder) GetOwnerPlan(ctx, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerPlan", reflect.TypeOf((*MockRepository)(nil).GetOwnerPlan), ctx, ownerChatID)
+}
+
+// GetQuickReplyTriggers mocks base method.
+func (m *MockRepository) GetQuickReplyTriggers(ctx context.Context, botID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuickReplyTriggers", ctx, botID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuickReplyTriggers indicates an expected call of GetQuickReplyTriggers.
+func (mr *MockRepositoryMockRecorder) GetQuickReplyTriggers(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuickReplyTriggers", reflect.TypeOf((*MockRepository)(nil).GetQuickReplyTriggers), ctx, botID)
+}
+
+// GetRatingStats mocks base method.
+func (m *MockRepository) GetRatingStats(ctx context.Context, botID int64) (float64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingStats", ctx, botID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRatingStats indicates an expected call of GetRatingStats.
+func (mr *MockRepositoryMockRecorder) GetRatingStats(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingStats", reflect.TypeOf((*MockRepository)(nil).GetRatingStats), ctx, botID)
+}
+
+// GetReachableUserCount mocks base method.
+func (m *MockRepository) GetReachableUserCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReachableUserCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReachableUserCount indicates an expected call of GetReachableUserCount.
+func (mr *MockRepositoryMockRecorder) GetReachableUserCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReachableUserCount", reflect.TypeOf((*MockRepository)(nil).GetReachableUserCount), ctx, botID)
+}
+
+// GetReplyMapping mocks base method.
+func (m *MockRepository) GetReplyMapping(ctx context.Context, botID int64, adminMsgID int) (*models.Reply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplyMapping", ctx, botID, adminMsgID)
+	ret0, _ := ret[0].(*models.Reply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplyMapping indicates an expected call of GetReplyMapping.
+func (mr *MockRepositoryMockRecorder) GetReplyMapping(ctx, botID, adminMsgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplyMapping", reflect.TypeOf((*MockRepository)(nil).GetReplyMapping), ctx, botID, adminMsgID)
+}
+
+// GetRoutingRuleCount mocks base method.
+func (m *MockRepository) GetRoutingRuleCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutingRuleCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutingRuleCount indicates an expected call of GetRoutingRuleCount.
+func (mr *MockRepositoryMockRecorder) GetRoutingRuleCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutingRuleCount", reflect.TypeOf((*MockRepository)(nil).GetRoutingRuleCount), ctx, botID)
+}
+
+// GetRoutingRules mocks base method.
+func (m *MockRepository) GetRoutingRules(ctx context.Context, botID int64) ([]models.RoutingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutingRules", ctx, botID)
+	ret0, _ := ret[0].([]models.RoutingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutingRules indicates an expected call of GetRoutingRules.
+func (mr *MockRepositoryMockRecorder) GetRoutingRules(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutingRules", reflect.TypeOf((*MockRepository)(nil).GetRoutingRules), ctx, botID)
+}
+
+// GetScheduledMessagesPage mocks base method.
+func (m *MockRepository) GetScheduledMessagesPage(ctx context.Context, botID int64, cursor database.Cursor, limit int) ([]models.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledMessagesPage", ctx, botID, cursor, limit)
+	ret0, _ := ret[0].([]models.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledMessagesPage indicates an expected call of GetScheduledMessagesPage.
+func (mr *MockRepositoryMockRecorder) GetScheduledMessagesPage(ctx, botID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledMessagesPage", reflect.TypeOf((*MockRepository)(nil).GetScheduledMessagesPage), ctx, botID, cursor, limit)
+}
+
+// GetShortLinkByCode mocks base method.
+func (m *MockRepository) GetShortLinkByCode(ctx context.Context, code string) (*models.ShortLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortLinkByCode", ctx, code)
+	ret0, _ := ret[0].(*models.ShortLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortLinkByCode indicates an expected call of GetShortLinkByCode.
+func (mr *MockRepositoryMockRecorder) GetShortLinkByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortLinkByCode", reflect.TypeOf((*MockRepository)(nil).GetShortLinkByCode), ctx, code)
+}
+
+// GetShortLinkCount mocks base method.
+func (m *MockRepository) GetShortLinkCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortLinkCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortLinkCount indicates an expected call of GetShortLinkCount.
+func (mr *MockRepositoryMockRecorder) GetShortLinkCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortLinkCount", reflect.TypeOf((*MockRepository)(nil).GetShortLinkCount), ctx, botID)
+}
+
+// GetShortLinks mocks base method.
+func (m *MockRepository) GetShortLinks(ctx context.Context, botID int64) ([]models.ShortLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortLinks", ctx, botID)
+	ret0, _ := ret[0].([]models.ShortLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortLinks indicates an expected call of GetShortLinks.
+func (mr *MockRepositoryMockRecorder) GetShortLinks(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortLinks", reflect.TypeOf((*MockRepository)(nil).GetShortLinks), ctx, botID)
+}
+
+// GetTotalMessageCount mocks base method.
+func (m *MockRepository) GetTotalMessageCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalMessageCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalMessageCount indicates an expected call of GetTotalMessageCount.
+func (mr *MockRepositoryMockRecorder) GetTotalMessageCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalMessageCount", reflect.TypeOf((*MockRepository)(nil).GetTotalMessageCount), ctx, botID)
+}
+
+// GetUnansweredBetween mocks base method.
+func (m *MockRepository) GetUnansweredBetween(ctx context.Context, botID int64, from, to time.Time) ([]models.UnansweredMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnansweredBetween", ctx, botID, from, to)
+	ret0, _ := ret[0].([]models.UnansweredMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnansweredBetween indicates an expected call of GetUnansweredBetween.
+func (mr *MockRepositoryMockRecorder) GetUnansweredBetween(ctx, botID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnansweredBetween", reflect.TypeOf((*MockRepository)(nil).GetUnansweredBetween), ctx, botID, from, to)
+}
+
+// GetUnansweredCount mocks base method.
+func (m *MockRepository) GetUnansweredCount(ctx context.Context, botID int64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnansweredCount", ctx, botID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnansweredCount indicates an expected call of GetUnansweredCount.
+func (mr *MockRepositoryMockRecorder) GetUnansweredCount(ctx, botID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnansweredCount", reflect.TypeOf((*MockRepository)(nil).GetUnansweredCount), ctx, botID, since)
+}
+
+// GetUniqueUserCount mocks base method.
+func (m *MockRepository) GetUniqueUserCount(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUniqueUserCount", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUniqueUserCount indicates an expected call of GetUniqueUserCount.
+func (mr *MockRepositoryMockRecorder) GetUniqueUserCount(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUniqueUserCount", reflect.TypeOf((*MockRepository)(nil).GetUniqueUserCount), ctx, botID)
+}
+
+// GetUserChatID mocks base method.
+func (m *MockRepository) GetUserChatID(ctx context.Context, adminMsgID int, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChatID", ctx, adminMsgID, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChatID indicates an expected call of GetUserChatID.
+func (mr *MockRepositoryMockRecorder) GetUserChatID(ctx, adminMsgID, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChatID", reflect.TypeOf((*MockRepository)(nil).GetUserChatID), ctx, adminMsgID, botID)
+}
+
+// GetUserMessageCount mocks base method.
+func (m *MockRepository) GetUserMessageCount(ctx context.Context, botID, userChatID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMessageCount", ctx, botID, userChatID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMessageCount indicates an expected call of GetUserMessageCount.
+func (mr *MockRepositoryMockRecorder) GetUserMessageCount(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMessageCount", reflect.TypeOf((*MockRepository)(nil).GetUserMessageCount), ctx, botID, userChatID)
+}
+
+// GetWhitelistedUsers mocks base method.
+func (m *MockRepository) GetWhitelistedUsers(ctx context.Context, botID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWhitelistedUsers", ctx, botID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWhitelistedUsers indicates an expected call of GetWhitelistedUsers.
+func (mr *MockRepositoryMockRecorder) GetWhitelistedUsers(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWhitelistedUsers", reflect.TypeOf((*MockRepository)(nil).GetWhitelistedUsers), ctx, botID)
+}
+
+// HasUserInteracted mocks base method.
+func (m *MockRepository) HasUserInteracted(ctx context.Context, botID, userChatID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUserInteracted", ctx, botID, userChatID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUserInteracted indicates an expected call of HasUserInteracted.
+func (mr *MockRepositoryMockRecorder) HasUserInteracted(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUserInteracted", reflect.TypeOf((*MockRepository)(nil).HasUserInteracted), ctx, botID, userChatID)
+}
+
+// IncrementShortLinkClicks mocks base method.
+func (m *MockRepository) IncrementShortLinkClicks(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementShortLinkClicks", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementShortLinkClicks indicates an expected call of IncrementShortLinkClicks.
+func (mr *MockRepositoryMockRecorder) IncrementShortLinkClicks(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementShortLinkClicks", reflect.TypeOf((*MockRepository)(nil).IncrementShortLinkClicks), ctx, code)
+}
+
+// IsForcedSubExempt mocks base method.
+func (m *MockRepository) IsForcedSubExempt(ctx context.Context, botID, userChatID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsForcedSubExempt", ctx, botID, userChatID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsForcedSubExempt indicates an expected call of IsForcedSubExempt.
+func (mr *MockRepositoryMockRecorder) IsForcedSubExempt(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsForcedSubExempt", reflect.TypeOf((*MockRepository)(nil).IsForcedSubExempt), ctx, botID, userChatID)
+}
+
+// IsUserBanned mocks base method.
+func (m *MockRepository) IsUserBanned(ctx context.Context, botID, userChatID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBanned", ctx, botID, userChatID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBanned indicates an expected call of IsUserBanned.
+func (mr *MockRepositoryMockRecorder) IsUserBanned(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBanned", reflect.TypeOf((*MockRepository)(nil).IsUserBanned), ctx, botID, userChatID)
+}
+
+// IsUserWhitelisted mocks base method.
+func (m *MockRepository) IsUserWhitelisted(ctx context.Context, botID, userChatID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserWhitelisted", ctx, botID, userChatID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserWhitelisted indicates an expected call of IsUserWhitelisted.
+func (mr *MockRepositoryMockRecorder) IsUserWhitelisted(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserWhitelisted", reflect.TypeOf((*MockRepository)(nil).IsUserWhitelisted), ctx, botID, userChatID)
+}
+
+// MarkUserUnreachable mocks base method.
+func (m *MockRepository) MarkUserUnreachable(ctx context.Context, botID, userChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserUnreachable", ctx, botID, userChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUserUnreachable indicates an expected call of MarkUserUnreachable.
+func (mr *MockRepositoryMockRecorder) MarkUserUnreachable(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserUnreachable", reflect.TypeOf((*MockRepository)(nil).MarkUserUnreachable), ctx, botID, userChatID)
+}
+
+// PauseScheduledMessage mocks base method.
+func (m *MockRepository) PauseScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseScheduledMessage", ctx, msgID, botID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseScheduledMessage indicates an expected call of PauseScheduledMessage.
+func (mr *MockRepositoryMockRecorder) PauseScheduledMessage(ctx, msgID, botID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseScheduledMessage", reflect.TypeOf((*MockRepository)(nil).PauseScheduledMessage), ctx, msgID, botID, ownerChatID)
+}
+
+// PurgeExpiredMessageContents mocks base method.
+func (m *MockRepository) PurgeExpiredMessageContents(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredMessageContents", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredMessageContents indicates an expected call of PurgeExpiredMessageContents.
+func (mr *MockRepositoryMockRecorder) PurgeExpiredMessageContents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredMessageContents", reflect.TypeOf((*MockRepository)(nil).PurgeExpiredMessageContents), ctx)
+}
+
+// PurgeMessageContents mocks base method.
+func (m *MockRepository) PurgeMessageContents(ctx context.Context, botID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMessageContents", ctx, botID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeMessageContents indicates an expected call of PurgeMessageContents.
+func (mr *MockRepositoryMockRecorder) PurgeMessageContents(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMessageContents", reflect.TypeOf((*MockRepository)(nil).PurgeMessageContents), ctx, botID)
+}
+
+// RecordAudit mocks base method.
+func (m *MockRepository) RecordAudit(ctx context.Context, botID, actorChatID int64, action, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAudit", ctx, botID, actorChatID, action, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAudit indicates an expected call of RecordAudit.
+func (mr *MockRepositoryMockRecorder) RecordAudit(ctx, botID, actorChatID, action, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAudit", reflect.TypeOf((*MockRepository)(nil).RecordAudit), ctx, botID, actorChatID, action, target)
+}
+
+// RecordChannelMemberCount mocks base method.
+func (m *MockRepository) RecordChannelMemberCount(ctx context.Context, botID, channelID int64, memberCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordChannelMemberCount", ctx, botID, channelID, memberCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordChannelMemberCount indicates an expected call of RecordChannelMemberCount.
+func (mr *MockRepositoryMockRecorder) RecordChannelMemberCount(ctx, botID, channelID, memberCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordChannelMemberCount", reflect.TypeOf((*MockRepository)(nil).RecordChannelMemberCount), ctx, botID, channelID, memberCount)
+}
+
+// RecordDelivery mocks base method.
+func (m *MockRepository) RecordDelivery(ctx context.Context, botID, userChatID int64, source, status, errorCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDelivery", ctx, botID, userChatID, source, status, errorCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDelivery indicates an expected call of RecordDelivery.
+func (mr *MockRepositoryMockRecorder) RecordDelivery(ctx, botID, userChatID, source, status, errorCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDelivery", reflect.TypeOf((*MockRepository)(nil).RecordDelivery), ctx, botID, userChatID, source, status, errorCode)
+}
+
+// RecordSpamFeedback mocks base method.
+func (m *MockRepository) RecordSpamFeedback(ctx context.Context, botID, userChatID int64, verdict string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSpamFeedback", ctx, botID, userChatID, verdict)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSpamFeedback indicates an expected call of RecordSpamFeedback.
+func (mr *MockRepositoryMockRecorder) RecordSpamFeedback(ctx, botID, userChatID, verdict any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSpamFeedback", reflect.TypeOf((*MockRepository)(nil).RecordSpamFeedback), ctx, botID, userChatID, verdict)
+}
+
+// RemoveForcedSubExemption mocks base method.
+func (m *MockRepository) RemoveForcedSubExemption(ctx context.Context, botID, userChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveForcedSubExemption", ctx, botID, userChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveForcedSubExemption indicates an expected call of RemoveForcedSubExemption.
+func (mr *MockRepositoryMockRecorder) RemoveForcedSubExemption(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveForcedSubExemption", reflect.TypeOf((*MockRepository)(nil).RemoveForcedSubExemption), ctx, botID, userChatID)
+}
+
+// RemoveWhitelistedUser mocks base method.
+func (m *MockRepository) RemoveWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWhitelistedUser", ctx, botID, userChatID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWhitelistedUser indicates an expected call of RemoveWhitelistedUser.
+func (mr *MockRepositoryMockRecorder) RemoveWhitelistedUser(ctx, botID, userChatID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWhitelistedUser", reflect.TypeOf((*MockRepository)(nil).RemoveWhitelistedUser), ctx, botID, userChatID, ownerChatID)
+}
+
+// ResetBotStats mocks base method.
+func (m *MockRepository) ResetBotStats(ctx context.Context, botID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetBotStats", ctx, botID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetBotStats indicates an expected call of ResetBotStats.
+func (mr *MockRepositoryMockRecorder) ResetBotStats(ctx, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetBotStats", reflect.TypeOf((*MockRepository)(nil).ResetBotStats), ctx, botID)
+}
+
+// ResumeScheduledMessage mocks base method.
+func (m *MockRepository) ResumeScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeScheduledMessage", ctx, msgID, botID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeScheduledMessage indicates an expected call of ResumeScheduledMessage.
+func (mr *MockRepositoryMockRecorder) ResumeScheduledMessage(ctx, msgID, botID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeScheduledMessage", reflect.TypeOf((*MockRepository)(nil).ResumeScheduledMessage), ctx, msgID, botID, ownerChatID)
+}
+
+// SaveMessageContent mocks base method.
+func (m *MockRepository) SaveMessageContent(ctx context.Context, botID, userChatID int64, adminMsgID int, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMessageContent", ctx, botID, userChatID, adminMsgID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMessageContent indicates an expected call of SaveMessageContent.
+func (mr *MockRepositoryMockRecorder) SaveMessageContent(ctx, botID, userChatID, adminMsgID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessageContent", reflect.TypeOf((*MockRepository)(nil).SaveMessageContent), ctx, botID, userChatID, adminMsgID, text)
+}
+
+// SaveMessageLog mocks base method.
+func (m *MockRepository) SaveMessageLog(ctx context.Context, adminMsgID int, userChatID, botID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMessageLog", ctx, adminMsgID, userChatID, botID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMessageLog indicates an expected call of SaveMessageLog.
+func (mr *MockRepositoryMockRecorder) SaveMessageLog(ctx, adminMsgID, userChatID, botID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessageLog", reflect.TypeOf((*MockRepository)(nil).SaveMessageLog), ctx, adminMsgID, userChatID, botID)
+}
+
+// SaveRating mocks base method.
+func (m *MockRepository) SaveRating(ctx context.Context, botID, userChatID int64, stars int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRating", ctx, botID, userChatID, stars)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRating indicates an expected call of SaveRating.
+func (mr *MockRepositoryMockRecorder) SaveRating(ctx, botID, userChatID, stars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRating", reflect.TypeOf((*MockRepository)(nil).SaveRating), ctx, botID, userChatID, stars)
+}
+
+// SaveReplyMapping mocks base method.
+func (m *MockRepository) SaveReplyMapping(ctx context.Context, botID int64, adminMsgID int, userChatID int64, userMsgID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReplyMapping", ctx, botID, adminMsgID, userChatID, userMsgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReplyMapping indicates an expected call of SaveReplyMapping.
+func (mr *MockRepositoryMockRecorder) SaveReplyMapping(ctx, botID, adminMsgID, userChatID, userMsgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReplyMapping", reflect.TypeOf((*MockRepository)(nil).SaveReplyMapping), ctx, botID, adminMsgID, userChatID, userMsgID)
+}
+
+// SearchMessageContents mocks base method.
+func (m *MockRepository) SearchMessageContents(ctx context.Context, botID int64, search string, limit int) ([]models.MessageContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessageContents", ctx, botID, search, limit)
+	ret0, _ := ret[0].([]models.MessageContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessageContents indicates an expected call of SearchMessageContents.
+func (mr *MockRepositoryMockRecorder) SearchMessageContents(ctx, botID, search, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessageContents", reflect.TypeOf((*MockRepository)(nil).SearchMessageContents), ctx, botID, search, limit)
+}
+
+// SetAutoReplyQuickReply mocks base method.
+func (m *MockRepository) SetAutoReplyQuickReply(ctx context.Context, botID, replyID int64, quickReply bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoReplyQuickReply", ctx, botID, replyID, quickReply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutoReplyQuickReply indicates an expected call of SetAutoReplyQuickReply.
+func (mr *MockRepositoryMockRecorder) SetAutoReplyQuickReply(ctx, botID, replyID, quickReply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoReplyQuickReply", reflect.TypeOf((*MockRepository)(nil).SetAutoReplyQuickReply), ctx, botID, replyID, quickReply)
+}
+
+// SetOwnerPlainUI mocks base method.
+func (m *MockRepository) SetOwnerPlainUI(ctx context.Context, ownerChatID int64, plainUI bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOwnerPlainUI", ctx, ownerChatID, plainUI)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOwnerPlainUI indicates an expected call of SetOwnerPlainUI.
+func (mr *MockRepositoryMockRecorder) SetOwnerPlainUI(ctx, ownerChatID, plainUI any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnerPlainUI", reflect.TypeOf((*MockRepository)(nil).SetOwnerPlainUI), ctx, ownerChatID, plainUI)
+}
+
+// UnbanUser mocks base method.
+func (m *MockRepository) UnbanUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanUser", ctx, botID, userChatID, ownerChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanUser indicates an expected call of UnbanUser.
+func (mr *MockRepositoryMockRecorder) UnbanUser(ctx, botID, userChatID, ownerChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanUser", reflect.TypeOf((*MockRepository)(nil).UnbanUser), ctx, botID, userChatID, ownerChatID)
+}
+
+// UpdateBotArchiveChat mocks base method.
+func (m *MockRepository) UpdateBotArchiveChat(ctx context.Context, botID, chatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotArchiveChat", ctx, botID, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotArchiveChat indicates an expected call of UpdateBotArchiveChat.
+func (mr *MockRepositoryMockRecorder) UpdateBotArchiveChat(ctx, botID, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotArchiveChat", reflect.TypeOf((*MockRepository)(nil).UpdateBotArchiveChat), ctx, botID, chatID)
+}
+
+// UpdateBotBlockedMediaTypes mocks base method.
+func (m *MockRepository) UpdateBotBlockedMediaTypes(ctx context.Context, botID int64, types string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotBlockedMediaTypes", ctx, botID, types)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotBlockedMediaTypes indicates an expected call of UpdateBotBlockedMediaTypes.
+func (mr *MockRepositoryMockRecorder) UpdateBotBlockedMediaTypes(ctx, botID, types any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotBlockedMediaTypes", reflect.TypeOf((*MockRepository)(nil).UpdateBotBlockedMediaTypes), ctx, botID, types)
+}
+
+// UpdateBotConfirmationEmoji mocks base method.
+func (m *MockRepository) UpdateBotConfirmationEmoji(ctx context.Context, botID int64, emoji string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotConfirmationEmoji", ctx, botID, emoji)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotConfirmationEmoji indicates an expected call of UpdateBotConfirmationEmoji.
+func (mr *MockRepositoryMockRecorder) UpdateBotConfirmationEmoji(ctx, botID, emoji any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotConfirmationEmoji", reflect.TypeOf((*MockRepository)(nil).UpdateBotConfirmationEmoji), ctx, botID, emoji)
+}
+
+// UpdateBotConfirmationMode mocks base method.
+func (m *MockRepository) UpdateBotConfirmationMode(ctx context.Context, botID int64, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotConfirmationMode", ctx, botID, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotConfirmationMode indicates an expected call of UpdateBotConfirmationMode.
+func (mr *MockRepositoryMockRecorder) UpdateBotConfirmationMode(ctx, botID, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotConfirmationMode", reflect.TypeOf((*MockRepository)(nil).UpdateBotConfirmationMode), ctx, botID, mode)
+}
+
+// UpdateBotContentLogging mocks base method.
+func (m *MockRepository) UpdateBotContentLogging(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotContentLogging", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotContentLogging indicates an expected call of UpdateBotContentLogging.
+func (mr *MockRepositoryMockRecorder) UpdateBotContentLogging(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotContentLogging", reflect.TypeOf((*MockRepository)(nil).UpdateBotContentLogging), ctx, botID, enabled)
+}
+
+// UpdateBotContentRetentionDays mocks base method.
+func (m *MockRepository) UpdateBotContentRetentionDays(ctx context.Context, botID int64, days int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotContentRetentionDays", ctx, botID, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotContentRetentionDays indicates an expected call of UpdateBotContentRetentionDays.
+func (mr *MockRepositoryMockRecorder) UpdateBotContentRetentionDays(ctx, botID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotContentRetentionDays", reflect.TypeOf((*MockRepository)(nil).UpdateBotContentRetentionDays), ctx, botID, days)
+}
+
+// UpdateBotDedupeWindow mocks base method.
+func (m *MockRepository) UpdateBotDedupeWindow(ctx context.Context, botID int64, minutes int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotDedupeWindow", ctx, botID, minutes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotDedupeWindow indicates an expected call of UpdateBotDedupeWindow.
+func (mr *MockRepositoryMockRecorder) UpdateBotDedupeWindow(ctx, botID, minutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotDedupeWindow", reflect.TypeOf((*MockRepository)(nil).UpdateBotDedupeWindow), ctx, botID, minutes)
+}
+
+// UpdateBotDigestInterval mocks base method.
+func (m *MockRepository) UpdateBotDigestInterval(ctx context.Context, botID int64, minutes int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotDigestInterval", ctx, botID, minutes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotDigestInterval indicates an expected call of UpdateBotDigestInterval.
+func (mr *MockRepositoryMockRecorder) UpdateBotDigestInterval(ctx, botID, minutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotDigestInterval", reflect.TypeOf((*MockRepository)(nil).UpdateBotDigestInterval), ctx, botID, minutes)
+}
+
+// UpdateBotDigestKeywords mocks base method.
+func (m *MockRepository) UpdateBotDigestKeywords(ctx context.Context, botID int64, keywords string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotDigestKeywords", ctx, botID, keywords)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotDigestKeywords indicates an expected call of UpdateBotDigestKeywords.
+func (mr *MockRepositoryMockRecorder) UpdateBotDigestKeywords(ctx, botID, keywords any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotDigestKeywords", reflect.TypeOf((*MockRepository)(nil).UpdateBotDigestKeywords), ctx, botID, keywords)
+}
+
+// UpdateBotFilterAction mocks base method.
+func (m *MockRepository) UpdateBotFilterAction(ctx context.Context, botID int64, filterName, action string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotFilterAction", ctx, botID, filterName, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotFilterAction indicates an expected call of UpdateBotFilterAction.
+func (mr *MockRepositoryMockRecorder) UpdateBotFilterAction(ctx, botID, filterName, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotFilterAction", reflect.TypeOf((*MockRepository)(nil).UpdateBotFilterAction), ctx, botID, filterName, action)
+}
+
+// UpdateBotForwardAutoReplies mocks base method.
+func (m *MockRepository) UpdateBotForwardAutoReplies(ctx context.Context, botID int64, forward bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotForwardAutoReplies", ctx, botID, forward)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotForwardAutoReplies indicates an expected call of UpdateBotForwardAutoReplies.
+func (mr *MockRepositoryMockRecorder) UpdateBotForwardAutoReplies(ctx, botID, forward any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotForwardAutoReplies", reflect.TypeOf((*MockRepository)(nil).UpdateBotForwardAutoReplies), ctx, botID, forward)
+}
+
+// UpdateBotHeaderPolicy mocks base method.
+func (m *MockRepository) UpdateBotHeaderPolicy(ctx context.Context, botID int64, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotHeaderPolicy", ctx, botID, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotHeaderPolicy indicates an expected call of UpdateBotHeaderPolicy.
+func (mr *MockRepositoryMockRecorder) UpdateBotHeaderPolicy(ctx, botID, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotHeaderPolicy", reflect.TypeOf((*MockRepository)(nil).UpdateBotHeaderPolicy), ctx, botID, policy)
+}
+
+// UpdateBotLocale mocks base method.
+func (m *MockRepository) UpdateBotLocale(ctx context.Context, botID int64, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotLocale", ctx, botID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotLocale indicates an expected call of UpdateBotLocale.
+func (mr *MockRepositoryMockRecorder) UpdateBotLocale(ctx, botID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotLocale", reflect.TypeOf((*MockRepository)(nil).UpdateBotLocale), ctx, botID, locale)
+}
+
+// UpdateBotMaxFileSize mocks base method.
+func (m *MockRepository) UpdateBotMaxFileSize(ctx context.Context, botID int64, sizeMB int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotMaxFileSize", ctx, botID, sizeMB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotMaxFileSize indicates an expected call of UpdateBotMaxFileSize.
+func (mr *MockRepositoryMockRecorder) UpdateBotMaxFileSize(ctx, botID, sizeMB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotMaxFileSize", reflect.TypeOf((*MockRepository)(nil).UpdateBotMaxFileSize), ctx, botID, sizeMB)
+}
+
+// UpdateBotMenuButtonEnabled mocks base method.
+func (m *MockRepository) UpdateBotMenuButtonEnabled(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotMenuButtonEnabled", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotMenuButtonEnabled indicates an expected call of UpdateBotMenuButtonEnabled.
+func (mr *MockRepositoryMockRecorder) UpdateBotMenuButtonEnabled(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotMenuButtonEnabled", reflect.TypeOf((*MockRepository)(nil).UpdateBotMenuButtonEnabled), ctx, botID, enabled)
+}
+
+// UpdateBotMessageTTL mocks base method.
+func (m *MockRepository) UpdateBotMessageTTL(ctx context.Context, botID int64, hours int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotMessageTTL", ctx, botID, hours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotMessageTTL indicates an expected call of UpdateBotMessageTTL.
+func (mr *MockRepositoryMockRecorder) UpdateBotMessageTTL(ctx, botID, hours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotMessageTTL", reflect.TypeOf((*MockRepository)(nil).UpdateBotMessageTTL), ctx, botID, hours)
+}
+
+// UpdateBotPrivacyNotice mocks base method.
+func (m *MockRepository) UpdateBotPrivacyNotice(ctx context.Context, botID int64, notice string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotPrivacyNotice", ctx, botID, notice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotPrivacyNotice indicates an expected call of UpdateBotPrivacyNotice.
+func (mr *MockRepositoryMockRecorder) UpdateBotPrivacyNotice(ctx, botID, notice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotPrivacyNotice", reflect.TypeOf((*MockRepository)(nil).UpdateBotPrivacyNotice), ctx, botID, notice)
+}
+
+// UpdateBotReactionActions mocks base method.
+func (m *MockRepository) UpdateBotReactionActions(ctx context.Context, botID int64, actions string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotReactionActions", ctx, botID, actions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotReactionActions indicates an expected call of UpdateBotReactionActions.
+func (mr *MockRepositoryMockRecorder) UpdateBotReactionActions(ctx, botID, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotReactionActions", reflect.TypeOf((*MockRepository)(nil).UpdateBotReactionActions), ctx, botID, actions)
+}
+
+// UpdateBotRelayChannel mocks base method.
+func (m *MockRepository) UpdateBotRelayChannel(ctx context.Context, botID, channelID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotRelayChannel", ctx, botID, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotRelayChannel indicates an expected call of UpdateBotRelayChannel.
+func (mr *MockRepositoryMockRecorder) UpdateBotRelayChannel(ctx, botID, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotRelayChannel", reflect.TypeOf((*MockRepository)(nil).UpdateBotRelayChannel), ctx, botID, channelID)
+}
+
+// UpdateBotRichHeaderEnabled mocks base method.
+func (m *MockRepository) UpdateBotRichHeaderEnabled(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotRichHeaderEnabled", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotRichHeaderEnabled indicates an expected call of UpdateBotRichHeaderEnabled.
+func (mr *MockRepositoryMockRecorder) UpdateBotRichHeaderEnabled(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotRichHeaderEnabled", reflect.TypeOf((*MockRepository)(nil).UpdateBotRichHeaderEnabled), ctx, botID, enabled)
+}
+
+// UpdateBotSLAMinutes mocks base method.
+func (m *MockRepository) UpdateBotSLAMinutes(ctx context.Context, botID int64, minutes int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotSLAMinutes", ctx, botID, minutes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotSLAMinutes indicates an expected call of UpdateBotSLAMinutes.
+func (mr *MockRepositoryMockRecorder) UpdateBotSLAMinutes(ctx, botID, minutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotSLAMinutes", reflect.TypeOf((*MockRepository)(nil).UpdateBotSLAMinutes), ctx, botID, minutes)
+}
+
+// UpdateBotShowSentConfirmation mocks base method.
+func (m *MockRepository) UpdateBotShowSentConfirmation(ctx context.Context, botID int64, show bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotShowSentConfirmation", ctx, botID, show)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotShowSentConfirmation indicates an expected call of UpdateBotShowSentConfirmation.
+func (mr *MockRepositoryMockRecorder) UpdateBotShowSentConfirmation(ctx, botID, show any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotShowSentConfirmation", reflect.TypeOf((*MockRepository)(nil).UpdateBotShowSentConfirmation), ctx, botID, show)
+}
+
+// UpdateBotSpamThreshold mocks base method.
+func (m *MockRepository) UpdateBotSpamThreshold(ctx context.Context, botID int64, threshold int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotSpamThreshold", ctx, botID, threshold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotSpamThreshold indicates an expected call of UpdateBotSpamThreshold.
+func (mr *MockRepositoryMockRecorder) UpdateBotSpamThreshold(ctx, botID, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotSpamThreshold", reflect.TypeOf((*MockRepository)(nil).UpdateBotSpamThreshold), ctx, botID, threshold)
+}
+
+// UpdateBotStartMessage mocks base method.
+func (m *MockRepository) UpdateBotStartMessage(ctx context.Context, botID int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotStartMessage", ctx, botID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotStartMessage indicates an expected call of UpdateBotStartMessage.
+func (mr *MockRepositoryMockRecorder) UpdateBotStartMessage(ctx, botID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotStartMessage", reflect.TypeOf((*MockRepository)(nil).UpdateBotStartMessage), ctx, botID, message)
+}
+
+// UpdateBotStatsDigest mocks base method.
+func (m *MockRepository) UpdateBotStatsDigest(ctx context.Context, botID int64, cadence string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotStatsDigest", ctx, botID, cadence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotStatsDigest indicates an expected call of UpdateBotStatsDigest.
+func (mr *MockRepositoryMockRecorder) UpdateBotStatsDigest(ctx, botID, cadence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotStatsDigest", reflect.TypeOf((*MockRepository)(nil).UpdateBotStatsDigest), ctx, botID, cadence)
+}
+
+// UpdateBotSurveyEnabled mocks base method.
+func (m *MockRepository) UpdateBotSurveyEnabled(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotSurveyEnabled", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotSurveyEnabled indicates an expected call of UpdateBotSurveyEnabled.
+func (mr *MockRepositoryMockRecorder) UpdateBotSurveyEnabled(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotSurveyEnabled", reflect.TypeOf((*MockRepository)(nil).UpdateBotSurveyEnabled), ctx, botID, enabled)
+}
+
+// UpdateBotTimezone mocks base method.
+func (m *MockRepository) UpdateBotTimezone(ctx context.Context, botID int64, tz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotTimezone", ctx, botID, tz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotTimezone indicates an expected call of UpdateBotTimezone.
+func (mr *MockRepositoryMockRecorder) UpdateBotTimezone(ctx, botID, tz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotTimezone", reflect.TypeOf((*MockRepository)(nil).UpdateBotTimezone), ctx, botID, tz)
+}
+
+// UpdateBotWhitelistEnabled mocks base method.
+func (m *MockRepository) UpdateBotWhitelistEnabled(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotWhitelistEnabled", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotWhitelistEnabled indicates an expected call of UpdateBotWhitelistEnabled.
+func (mr *MockRepositoryMockRecorder) UpdateBotWhitelistEnabled(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotWhitelistEnabled", reflect.TypeOf((*MockRepository)(nil).UpdateBotWhitelistEnabled), ctx, botID, enabled)
+}
+
+// UpdateBotWhitelistMessage mocks base method.
+func (m *MockRepository) UpdateBotWhitelistMessage(ctx context.Context, botID int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotWhitelistMessage", ctx, botID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotWhitelistMessage indicates an expected call of UpdateBotWhitelistMessage.
+func (mr *MockRepositoryMockRecorder) UpdateBotWhitelistMessage(ctx, botID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotWhitelistMessage", reflect.TypeOf((*MockRepository)(nil).UpdateBotWhitelistMessage), ctx, botID, message)
+}
+
+// UpdateForcedSubEnabled mocks base method.
+func (m *MockRepository) UpdateForcedSubEnabled(ctx context.Context, botID int64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateForcedSubEnabled", ctx, botID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateForcedSubEnabled indicates an expected call of UpdateForcedSubEnabled.
+func (mr *MockRepositoryMockRecorder) UpdateForcedSubEnabled(ctx, botID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateForcedSubEnabled", reflect.TypeOf((*MockRepository)(nil).UpdateForcedSubEnabled), ctx, botID, enabled)
+}
+
+// UpdateForcedSubGraceCount mocks base method.
+func (m *MockRepository) UpdateForcedSubGraceCount(ctx context.Context, botID int64, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateForcedSubGraceCount", ctx, botID, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateForcedSubGraceCount indicates an expected call of UpdateForcedSubGraceCount.
+func (mr *MockRepositoryMockRecorder) UpdateForcedSubGraceCount(ctx, botID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateForcedSubGraceCount", reflect.TypeOf((*MockRepository)(nil).UpdateForcedSubGraceCount), ctx, botID, count)
+}
+
+// UpdateForcedSubMessage mocks base method.
+func (m *MockRepository) UpdateForcedSubMessage(ctx context.Context, botID int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateForcedSubMessage", ctx, botID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateForcedSubMessage indicates an expected call of UpdateForcedSubMessage.
+func (mr *MockRepositoryMockRecorder) UpdateForcedSubMessage(ctx, botID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateForcedSubMessage", reflect.TypeOf((*MockRepository)(nil).UpdateForcedSubMessage), ctx, botID, message)
+}
+
+// UpsertBotUser mocks base method.
+func (m *MockRepository) UpsertBotUser(ctx context.Context, botID, userChatID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBotUser", ctx, botID, userChatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertBotUser indicates an expected call of UpsertBotUser.
+func (mr *MockRepositoryMockRecorder) UpsertBotUser(ctx, botID, userChatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBotUser", reflect.TypeOf((*MockRepository)(nil).UpsertBotUser), ctx, botID, userChatID)
+}
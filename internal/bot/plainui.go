@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Plain Text UI ====================
+
+// Owners using screen readers can opt into text-only menus: decorative
+// emoji are stripped from button labels just before a keyboard goes out,
+// so handlers keep building their menus exactly as before.
+
+// PlainUI wraps a context so outgoing keyboards are rendered with
+// text-only button labels
+func PlainUI(c telebot.Context) telebot.Context {
+	return plainUIContext{Context: c}
+}
+
+// plainUIContext rewrites the reply markup of outgoing messages
+type plainUIContext struct {
+	telebot.Context
+}
+
+func (p plainUIContext) Send(what interface{}, opts ...interface{}) error {
+	return p.Context.Send(what, plainOpts(opts)...)
+}
+
+func (p plainUIContext) Reply(what interface{}, opts ...interface{}) error {
+	return p.Context.Reply(what, plainOpts(opts)...)
+}
+
+func (p plainUIContext) Edit(what interface{}, opts ...interface{}) error {
+	return p.Context.Edit(what, plainOpts(opts)...)
+}
+
+func (p plainUIContext) EditOrSend(what interface{}, opts ...interface{}) error {
+	return p.Context.EditOrSend(what, plainOpts(opts)...)
+}
+
+func (p plainUIContext) EditOrReply(what interface{}, opts ...interface{}) error {
+	return p.Context.EditOrReply(what, plainOpts(opts)...)
+}
+
+// plainOpts replaces every reply markup among the send options with a
+// text-only copy
+func plainOpts(opts []interface{}) []interface{} {
+	for i, opt := range opts {
+		if mk, ok := opt.(*telebot.ReplyMarkup); ok && mk != nil {
+			opts[i] = plainMarkup(mk)
+		}
+	}
+	return opts
+}
+
+// plainMarkup returns a copy of the markup with decorative emoji
+// stripped from all button labels
+func plainMarkup(mk *telebot.ReplyMarkup) *telebot.ReplyMarkup {
+	clean := *mk
+
+	if mk.InlineKeyboard != nil {
+		rows := make([][]telebot.InlineButton, len(mk.InlineKeyboard))
+		for i, row := range mk.InlineKeyboard {
+			rows[i] = make([]telebot.InlineButton, len(row))
+			for j, btn := range row {
+				btn.Text = PlainLabel(btn.Text)
+				rows[i][j] = btn
+			}
+		}
+		clean.InlineKeyboard = rows
+	}
+
+	if mk.ReplyKeyboard != nil {
+		rows := make([][]telebot.ReplyButton, len(mk.ReplyKeyboard))
+		for i, row := range mk.ReplyKeyboard {
+			rows[i] = make([]telebot.ReplyButton, len(row))
+			for j, btn := range row {
+				btn.Text = PlainLabel(btn.Text)
+				rows[i][j] = btn
+			}
+		}
+		clean.ReplyKeyboard = rows
+	}
+
+	return &clean
+}
+
+// plainFallbacks names the buttons whose label is nothing but an emoji
+var plainFallbacks = map[string]string{
+	"⏸️": "Pause",
+	"▶️": "Resume",
+	"❌":  "Delete",
+}
+
+// PlainLabel strips decorative emoji from a button label. Labels that
+// consist only of emoji fall back to a named replacement, or stay
+// unchanged when none is known.
+func PlainLabel(label string) string {
+	var b strings.Builder
+	for _, r := range label {
+		if isDecorative(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	clean := strings.Join(strings.Fields(b.String()), " ")
+	if clean == "" {
+		if name, ok := plainFallbacks[label]; ok {
+			return name
+		}
+		return label
+	}
+	return clean
+}
+
+// isDecorative reports whether a rune is an emoji or one of its
+// combining companions
+func isDecorative(r rune) bool {
+	switch {
+	case r >= 0x1F000: // emoji blocks
+		return true
+	case r >= 0x2190 && r <= 0x2BFF: // arrows, misc symbols, dingbats
+		return true
+	case r == 0x2139: // information source
+		return true
+	case r == 0xFE0F || r == 0x200D || r == 0x20E3: // variation selector, ZWJ, keycap
+		return true
+	}
+	return false
+}
+
+// maybePlainUI substitutes the text-only rendering context when the
+// update comes from an owner who opted into it
+func (m *Manager) maybePlainUI(c telebot.Context, bc *childContext) telebot.Context {
+	sender := c.Sender()
+	if sender == nil || sender.ID != bc.ownerChat.ID {
+		return c
+	}
+	if !m.plainUIEnabled(context.Background(), sender.ID) {
+		return c
+	}
+	return PlainUI(c)
+}
+
+// plainUIEnabled reports whether an owner opted into text-only menus -
+// cache first, DB backfill on miss
+func (m *Manager) plainUIEnabled(ctx context.Context, ownerID int64) bool {
+	enabled, cacheHit, err := m.cache.GetPlainUI(ctx, ownerID)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - load from DB and cache it
+	enabled, err = m.repo.GetOwnerPlainUI(ctx, ownerID)
+	if err != nil {
+		log.Printf("Failed to get plain UI preference: %v", err)
+		return false
+	}
+	m.cache.SetPlainUI(ctx, ownerID, enabled)
+	return enabled
+}
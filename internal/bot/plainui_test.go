@@ -0,0 +1,23 @@
+package bot
+
+import "testing"
+
+func TestPlainLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"📊 Statistics", "Statistics"},
+		{"🚫 Ban User", "Ban User"},
+		{"« Back", "« Back"},
+		{"⏸️", "Pause"}, // emoji-only label with a known name
+		{"🤷", "🤷"},      // emoji-only label without one stays put
+		{"🌐 Language: 🇩🇪", "Language:"},
+	}
+
+	for _, tt := range tests {
+		if got := PlainLabel(tt.label); got != tt.want {
+			t.Errorf("PlainLabel(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
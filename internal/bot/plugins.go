@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"log"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/plugin"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Plugins ====================
+
+// SetPlugins installs the compile-time plugins whose handlers are
+// attached to every child bot as it starts. Call once at startup,
+// before any bot is started; repo is the concrete repository handed to
+// plugins, which may need more than the Manager's own seam.
+func (m *Manager) SetPlugins(repo *database.Repository, plugins []plugin.Plugin) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pluginRepo = repo
+	m.plugins = plugins
+}
+
+// registerPlugins lets every plugin attach its handlers to a freshly
+// started bot. A failing plugin is logged and skipped rather than
+// keeping the bot from starting. Called from StartBot with m.mu held.
+func (m *Manager) registerPlugins(bot *telebot.Bot, token string, ownerChatID, botID int64) {
+	for _, p := range m.plugins {
+		botCtx := &plugin.BotContext{
+			Token:     token,
+			BotID:     botID,
+			OwnerChat: &telebot.Chat{ID: ownerChatID},
+			Repo:      m.pluginRepo,
+			Cache:     m.cache,
+		}
+		if err := p.RegisterHandlers(bot, botCtx); err != nil {
+			log.Printf("Plugin %s failed to register on bot %d: %v", p.Name(), botID, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/plugin"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Plugin Tests ====================
+
+// recordingPlugin captures the context it was registered with
+type recordingPlugin struct {
+	registered []*plugin.BotContext
+	err        error
+}
+
+func (p *recordingPlugin) Name() string { return "recording" }
+
+func (p *recordingPlugin) RegisterHandlers(bot *telebot.Bot, botCtx *plugin.BotContext) error {
+	p.registered = append(p.registered, botCtx)
+	return p.err
+}
+
+func TestStartBot_RegistersPlugins(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+
+	rec := &recordingPlugin{}
+	m.SetPlugins(nil, []plugin.Plugin{rec})
+
+	const ownerChatID = 999
+	if err := m.StartBot(integrationToken, ownerChatID, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	if len(rec.registered) != 1 {
+		t.Fatalf("Expected plugin registered once, got %d times", len(rec.registered))
+	}
+	botCtx := rec.registered[0]
+	if botCtx.Token != integrationToken {
+		t.Errorf("Expected token %q, got %q", integrationToken, botCtx.Token)
+	}
+	if botCtx.BotID != 1 {
+		t.Errorf("Expected bot ID 1, got %d", botCtx.BotID)
+	}
+	if botCtx.OwnerChat == nil || botCtx.OwnerChat.ID != ownerChatID {
+		t.Errorf("Expected owner chat %d, got %+v", ownerChatID, botCtx.OwnerChat)
+	}
+}
+
+func TestStartBot_PluginFailureDoesNotBlockStart(t *testing.T) {
+	m, _ := setupIntegrationManager(t)
+
+	failing := &recordingPlugin{err: telebot.ErrInternal}
+	m.SetPlugins(nil, []plugin.Plugin{failing})
+
+	if err := m.StartBot(integrationToken, 999, 1); err != nil {
+		t.Fatalf("StartBot failed: %v", err)
+	}
+	defer m.StopBot(integrationToken)
+
+	if !m.IsRunning(integrationToken) {
+		t.Error("Expected bot to be running despite plugin failure")
+	}
+}
@@ -0,0 +1,324 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Reaction Workflows ====================
+
+// Owners can bind actions to reacting on a forwarded message: 👍 sends a
+// canned thanks, 👎 bans, and so on. The mapping is stored per bot as
+// comma-separated "emoji=action" pairs; reacting resolves the user behind
+// the message through the reply cache and message_logs, the same path the
+// quick-action buttons use. telebot v3 has no endpoint for
+// message_reaction updates, so ServeHTTP routes them straight to
+// handleMessageReaction.
+
+// Reaction workflow actions
+const (
+	ReactionActionThanks = "thanks"
+	ReactionActionBan    = "ban"
+	ReactionActionMute   = "mute"
+	ReactionActionTag    = "tag"
+)
+
+// reactionThanksMessage is the canned reply sent for the thanks action
+const reactionThanksMessage = "🙏 Thanks for your message! We've received it."
+
+// reactionEmojis is the fixed set of emojis an action can be bound to.
+// Telegram only accepts reactions from its own emoji list, so the menu
+// offers a curated subset rather than free input.
+var reactionEmojis = []string{"👍", "👎", "❤", "🔥", "🎉"}
+
+// reactionActionCycle is the cycle order for one emoji's action button
+var reactionActionCycle = []string{"", ReactionActionThanks, ReactionActionBan, ReactionActionMute, ReactionActionTag}
+
+// reactionActionNames maps actions to their display names
+var reactionActionNames = map[string]string{
+	"":                   "Off",
+	ReactionActionThanks: "Say Thanks",
+	ReactionActionBan:    "Ban",
+	ReactionActionMute:   "Mute 24h",
+	ReactionActionTag:    "Tag",
+}
+
+// parseReactionActions decodes the stored "emoji=action" pairs; malformed
+// or unknown entries are dropped
+func parseReactionActions(stored string) map[string]string {
+	actions := make(map[string]string)
+	for _, pair := range strings.Split(stored, ",") {
+		emoji, action, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || emoji == "" {
+			continue
+		}
+		if _, known := reactionActionNames[action]; !known || action == "" {
+			continue
+		}
+		actions[emoji] = action
+	}
+	return actions
+}
+
+// formatReactionActions encodes a mapping back to the stored form, in
+// the stable menu emoji order
+func formatReactionActions(actions map[string]string) string {
+	var pairs []string
+	for _, emoji := range reactionEmojis {
+		if action := actions[emoji]; action != "" {
+			pairs = append(pairs, emoji+"="+action)
+		}
+	}
+	return strings.Join(pairs, ",")
+}
+
+// reactionActions loads a bot's reaction mapping, cache-first
+func (m *Manager) reactionActions(ctx context.Context, token string, botID int64) map[string]string {
+	stored, cacheHit, err := m.cache.GetReactionActions(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting reaction actions: %v", err)
+	}
+
+	if !cacheHit {
+		botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting bot for reaction actions: %v", err)
+			return nil
+		}
+		if botModel != nil {
+			stored = botModel.ReactionActions
+			m.cache.SetReactionActions(ctx, token, stored)
+		}
+	}
+
+	if stored == "" {
+		return nil
+	}
+	return parseReactionActions(stored)
+}
+
+// addedReactions returns the emojis present in the new reaction list but
+// not the old one, i.e. what this update actually added
+func addedReactions(before, after []telebot.Reaction) []string {
+	var added []string
+	for _, r := range after {
+		found := false
+		for _, o := range before {
+			if o.Emoji == r.Emoji && o.CustomEmoji == r.CustomEmoji {
+				found = true
+				break
+			}
+		}
+		if !found && r.Emoji != "" {
+			added = append(added, r.Emoji)
+		}
+	}
+	return added
+}
+
+// handleMessageReaction runs the configured workflow when the owner
+// reacts to a forwarded message. Reactions from anyone else, reaction
+// removals and unmapped emojis are ignored.
+func (m *Manager) handleMessageReaction(bot *telebot.Bot, token string, mr *telebot.MessageReaction) {
+	if mr.Chat == nil || mr.User == nil {
+		return
+	}
+
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	bc, ok := m.childCtxs[bot.Me.ID]
+	m.mu.RUnlock()
+	if !ok || mr.Chat.ID != bc.ownerChat.ID || mr.User.ID != bc.ownerChat.ID {
+		return
+	}
+
+	ctx := context.Background()
+	actions := m.reactionActions(ctx, token, botID)
+	if len(actions) == 0 {
+		return
+	}
+
+	for _, emoji := range addedReactions(mr.OldReaction, mr.NewReaction) {
+		action, ok := actions[emoji]
+		if !ok {
+			continue
+		}
+
+		userChatID, err := m.resolveConversationUser(ctx, token, botID, mr.MessageID)
+		if err != nil || userChatID == 0 {
+			m.sendReactionFeedback(bot, bc.ownerChat, mr, "⚠️ Could not find the sender behind this message. It may be too old.")
+			return
+		}
+
+		m.runReactionAction(ctx, bot, token, botID, bc.ownerChat, mr, action, userChatID)
+	}
+}
+
+// runReactionAction performs one mapped action against the resolved user
+func (m *Manager) runReactionAction(ctx context.Context, bot *telebot.Bot, token string, botID int64,
+	ownerChat *telebot.Chat, mr *telebot.MessageReaction, action string, userChatID int64) {
+
+	userChat := &telebot.Chat{ID: userChatID}
+
+	switch action {
+	case ReactionActionThanks:
+		_, err := bot.Send(userChat, reactionThanksMessage)
+		status, errorCode := deliveryStatusFor(err)
+		if recordErr := m.repo.RecordDelivery(ctx, botID, userChatID, models.DeliverySourceReply, status, errorCode); recordErr != nil {
+			log.Printf("Failed to record delivery for user %d: %v", userChatID, recordErr)
+		}
+		if err != nil {
+			log.Printf("Failed to send reaction thanks to user %d: %v", userChatID, err)
+			m.sendReactionFeedback(bot, ownerChat, mr, "⚠️ Failed to send the thanks message — the user may have blocked the bot.")
+			return
+		}
+		m.sendReactionFeedback(bot, ownerChat, mr, "🙏 Thanks message sent.")
+
+	case ReactionActionBan:
+		if err := m.repo.BanUser(ctx, botID, userChatID, ownerChat.ID); err != nil {
+			log.Printf("Failed to ban user %d from reaction: %v", userChatID, err)
+			m.sendReactionFeedback(bot, ownerChat, mr, "⚠️ Failed to ban the user.")
+			return
+		}
+		m.cache.SetUserBanned(ctx, token, userChatID)
+		m.cache.InvalidateNotBannedCache(ctx, token, userChatID)
+		bot.Send(userChat, "You have been blocked from sending messages to this bot.")
+		m.sendReactionFeedback(bot, ownerChat, mr, fmt.Sprintf("🚫 User <code>%d</code> banned.", userChatID))
+
+	case ReactionActionMute:
+		if err := m.cache.MuteUser(ctx, token, userChatID, muteDuration); err != nil {
+			log.Printf("Failed to mute user %d from reaction: %v", userChatID, err)
+			m.sendReactionFeedback(bot, ownerChat, mr, "⚠️ Failed to mute the user.")
+			return
+		}
+		m.sendReactionFeedback(bot, ownerChat, mr, fmt.Sprintf("🔇 User <code>%d</code> muted for 24 hours.", userChatID))
+
+	case ReactionActionTag:
+		name := fmt.Sprintf("%d", userChatID)
+		if chat, err := bot.ChatByID(userChatID); err == nil && chat.FirstName != "" {
+			name = chat.FirstName
+		}
+		m.sendReactionFeedback(bot, ownerChat, mr,
+			fmt.Sprintf(`🏷 <a href="tg://user?id=%d">%s</a> (<code>%d</code>)`, userChatID, name, userChatID))
+	}
+}
+
+// sendReactionFeedback replies to the reacted message in the owner chat
+func (m *Manager) sendReactionFeedback(bot *telebot.Bot, ownerChat *telebot.Chat, mr *telebot.MessageReaction, text string) {
+	_, err := bot.Send(ownerChat, text, &telebot.SendOptions{
+		ReplyTo:   &telebot.Message{ID: mr.MessageID, Chat: mr.Chat},
+		ParseMode: telebot.ModeHTML,
+	})
+	if err != nil {
+		log.Printf("Failed to send reaction feedback: %v", err)
+	}
+}
+
+// handleReactionsMenu shows the per-emoji action assignments
+func (m *Manager) handleReactionsMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+		if err != nil || botModel == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to get settings!", ShowAlert: true})
+		}
+		actions := parseReactionActions(botModel.ReactionActions)
+
+		msg := `⚡ <b>Reaction Workflows</b>
+
+React to a forwarded message to trigger an action on its sender. Tap an emoji below to cycle its action:
+
+├ <b>Say Thanks</b> — sends a canned thanks to the user
+├ <b>Ban</b> — blocks the user
+├ <b>Mute 24h</b> — hides the user's messages for a day
+└ <b>Tag</b> — posts a clickable mention of the user`
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		for _, emoji := range reactionEmojis {
+			btn := menu.Data(fmt.Sprintf("%s [%s]", emoji, reactionActionNames[actions[emoji]]), "cycle_reaction", emoji)
+			rows = append(rows, menu.Row(btn))
+		}
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		rows = append(rows, menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleCycleReaction advances one emoji's action to the next in the cycle
+func (m *Manager) handleCycleReaction(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		emoji := strings.TrimSpace(c.Data())
+		supported := false
+		for _, e := range reactionEmojis {
+			if e == emoji {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid reaction!", ShowAlert: true})
+		}
+
+		botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+		if err != nil || botModel == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to get settings!", ShowAlert: true})
+		}
+
+		// Advance to the next action in the cycle
+		actions := parseReactionActions(botModel.ReactionActions)
+		current := 0
+		for i, a := range reactionActionCycle {
+			if a == actions[emoji] {
+				current = i
+				break
+			}
+		}
+		newAction := reactionActionCycle[(current+1)%len(reactionActionCycle)]
+		if newAction == "" {
+			delete(actions, emoji)
+		} else {
+			actions[emoji] = newAction
+		}
+
+		stored := formatReactionActions(actions)
+		if err := m.repo.UpdateBotReactionActions(ctx, botID, stored); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to update setting!", ShowAlert: true})
+		}
+		m.cache.SetReactionActions(ctx, token, stored)
+
+		// Update allowed_updates so Telegram only delivers reactions while
+		// at least one workflow is configured
+		m.refreshWebhook(ctx, bot, token, botID)
+
+		c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("✅ %s is now %s", emoji, reactionActionNames[newAction])})
+
+		// Refresh the menu
+		return m.handleReactionsMenu(bot, token, ownerChat)(c)
+	}
+}
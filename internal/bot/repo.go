@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -destination=mocks/repository.go -package=mocks github.com/Amr-9/botforge/internal/bot Repository
+
+// The manager consumes the repository through per-domain interfaces so
+// handler logic can be unit-tested against small mocks instead of a full
+// sqlmock setup. *database.Repository satisfies all of them; the
+// generated mock in mocks/ satisfies the combined Repository.
+// BotsRepo covers bot settings and lifecycle (database/bot.go)
+type BotsRepo interface {
+	GetBotByToken(ctx context.Context, token string) (*models.Bot, error)
+	GetBotSettingsByID(ctx context.Context, botID int64) (*models.Bot, error)
+	GetBotRuntimeSettings(ctx context.Context, botID int64) (*models.BotRuntimeSettings, error)
+	UpdateBotStartMessage(ctx context.Context, botID int64, message string) error
+	UpdateBotForwardAutoReplies(ctx context.Context, botID int64, forward bool) error
+	UpdateBotConfirmationEmoji(ctx context.Context, botID int64, emoji string) error
+	UpdateBotRelayChannel(ctx context.Context, botID, channelID int64) error
+	UpdateBotArchiveChat(ctx context.Context, botID, chatID int64) error
+	UpdateBotBlockedMediaTypes(ctx context.Context, botID int64, types string) error
+	UpdateBotMaxFileSize(ctx context.Context, botID int64, sizeMB int) error
+	UpdateBotFilterAction(ctx context.Context, botID int64, filterName string, action string) error
+	UpdateBotDedupeWindow(ctx context.Context, botID int64, minutes int) error
+	UpdateBotSLAMinutes(ctx context.Context, botID int64, minutes int) error
+	UpdateBotMessageTTL(ctx context.Context, botID int64, hours int) error
+	UpdateBotLocale(ctx context.Context, botID int64, locale string) error
+	UpdateBotTimezone(ctx context.Context, botID int64, tz string) error
+	UpdateBotStatsDigest(ctx context.Context, botID int64, cadence string) error
+	UpdateBotMenuButtonEnabled(ctx context.Context, botID int64, enabled bool) error
+	UpdateBotSurveyEnabled(ctx context.Context, botID int64, enabled bool) error
+	UpdateBotDigestInterval(ctx context.Context, botID int64, minutes int) error
+	UpdateBotDigestKeywords(ctx context.Context, botID int64, keywords string) error
+	UpdateBotConfirmationMode(ctx context.Context, botID int64, mode string) error
+	UpdateBotHeaderPolicy(ctx context.Context, botID int64, policy string) error
+	UpdateBotRichHeaderEnabled(ctx context.Context, botID int64, enabled bool) error
+	UpdateBotPrivacyNotice(ctx context.Context, botID int64, notice string) error
+	UpdateBotShowSentConfirmation(ctx context.Context, botID int64, show bool) error
+	UpdateBotWhitelistEnabled(ctx context.Context, botID int64, enabled bool) error
+	UpdateBotWhitelistMessage(ctx context.Context, botID int64, message string) error
+	UpdateBotReactionActions(ctx context.Context, botID int64, actions string) error
+}
+
+// UsersRepo covers message logs, bans and user analytics (database/user.go, bot_users.go, privacy.go)
+type UsersRepo interface {
+	SaveMessageLog(ctx context.Context, adminMsgID int, userChatID int64, botID int64) error
+	GetUserChatID(ctx context.Context, adminMsgID int, botID int64) (int64, error)
+	HasUserInteracted(ctx context.Context, botID int64, userChatID int64) (bool, error)
+	GetFirstMessageDate(ctx context.Context, botID int64, userChatID int64) (time.Time, error)
+	GetUniqueUserCount(ctx context.Context, botID int64) (int64, error)
+	GetAllUserChatIDs(ctx context.Context, botID int64) ([]int64, error)
+	BanUser(ctx context.Context, botID, userChatID, bannedBy int64) error
+	UnbanUser(ctx context.Context, botID, userChatID, ownerChatID int64) error
+	IsUserBanned(ctx context.Context, botID, userChatID int64) (bool, error)
+	GetBannedUsersPage(ctx context.Context, botID int64, cursor database.Cursor, limit int) ([]models.BannedUser, error)
+	GetBannedUserCount(ctx context.Context, botID int64) (int64, error)
+	AddWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error
+	RemoveWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error
+	IsUserWhitelisted(ctx context.Context, botID, userChatID int64) (bool, error)
+	GetWhitelistedUsers(ctx context.Context, botID int64) ([]int64, error)
+	GetTotalMessageCount(ctx context.Context, botID int64) (int64, error)
+	GetUserMessageCount(ctx context.Context, botID, userChatID int64) (int64, error)
+	GetMessageCountSince(ctx context.Context, botID int64, since time.Time) (int64, error)
+	GetActiveUserCount(ctx context.Context, botID int64, since time.Time) (int64, error)
+	GetNewUserCount(ctx context.Context, botID int64, since time.Time) (int64, error)
+	UpsertBotUser(ctx context.Context, botID, userChatID int64) error
+	MarkUserUnreachable(ctx context.Context, botID, userChatID int64) error
+	GetReachableUserCount(ctx context.Context, botID int64) (int64, error)
+	GetBlockedUserCount(ctx context.Context, botID int64) (int64, error)
+	RecordAudit(ctx context.Context, botID, actorChatID int64, action, target string) error
+	EraseUserData(ctx context.Context, botID, userChatID int64) error
+	ResetBotStats(ctx context.Context, botID int64) error
+}
+
+// AutoRepliesRepo covers auto-replies and custom commands (database/auto_reply.go)
+type AutoRepliesRepo interface {
+	CreateAutoReply(ctx context.Context, botID int64, trigger, response, messageType, fileID, caption, triggerType, matchType string) error
+	GetAutoReplies(ctx context.Context, botID int64, triggerType string) ([]models.AutoReply, error)
+	GetAutoReplyByTrigger(ctx context.Context, botID int64, trigger, triggerType string) (*models.AutoReply, error)
+	GetAutoReplyByID(ctx context.Context, botID, replyID int64) (*models.AutoReply, error)
+	DeleteAutoReply(ctx context.Context, botID, replyID, ownerChatID int64) error
+	SetAutoReplyQuickReply(ctx context.Context, botID, replyID int64, quickReply bool) error
+	GetQuickReplyTriggers(ctx context.Context, botID int64) ([]string, error)
+	GetAutoReplyCount(ctx context.Context, botID int64, triggerType string) (int64, error)
+}
+
+// ScheduleRepo covers scheduled messages (database/schedule.go)
+type ScheduleRepo interface {
+	CreateScheduledMessage(ctx context.Context, msg *models.ScheduledMessage) (int64, error)
+	GetScheduledMessagesPage(ctx context.Context, botID int64, cursor database.Cursor, limit int) ([]models.ScheduledMessage, error)
+	PauseScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error
+	ResumeScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error
+	DeleteScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error
+}
+
+// ForcedSubRepo covers forced subscription channels and exemptions (database/forced_sub.go)
+type ForcedSubRepo interface {
+	CreateForcedChannel(ctx context.Context, botID, channelID int64, username, title, inviteLink string) error
+	GetForcedChannels(ctx context.Context, botID int64) ([]models.ForcedChannel, error)
+	GetForcedChannel(ctx context.Context, botID, channelID int64) (*models.ForcedChannel, error)
+	DeleteForcedChannel(ctx context.Context, botID, channelID, ownerChatID int64) error
+	GetForcedChannelCount(ctx context.Context, botID int64) (int64, error)
+	UpdateForcedSubEnabled(ctx context.Context, botID int64, enabled bool) error
+	UpdateForcedSubMessage(ctx context.Context, botID int64, message string) error
+	RecordChannelMemberCount(ctx context.Context, botID, channelID int64, memberCount int) error
+	GetChannelMemberHistory(ctx context.Context, botID, channelID int64, limit int) ([]models.ChannelMemberCount, error)
+	UpdateForcedSubGraceCount(ctx context.Context, botID int64, count int) error
+	AddForcedSubExemption(ctx context.Context, botID, userChatID int64) error
+	RemoveForcedSubExemption(ctx context.Context, botID, userChatID int64) error
+	IsForcedSubExempt(ctx context.Context, botID, userChatID int64) (bool, error)
+	GetForcedSubExemptions(ctx context.Context, botID int64) ([]models.ForcedSubExemption, error)
+}
+
+// RepliesRepo covers reply mappings and delivery outcomes (database/reply.go, delivery.go, response_time.go)
+type RepliesRepo interface {
+	SaveReplyMapping(ctx context.Context, botID int64, adminMsgID int, userChatID int64, userMsgID int) error
+	DeleteReplyMapping(ctx context.Context, botID int64, adminMsgID int) error
+	GetReplyMapping(ctx context.Context, botID int64, adminMsgID int) (*models.Reply, error)
+	RecordDelivery(ctx context.Context, botID, userChatID int64, source, status, errorCode string) error
+	GetLastDelivery(ctx context.Context, botID, userChatID int64) (*models.Delivery, error)
+	GetFirstResponseTimes(ctx context.Context, botID int64, since time.Time) ([]int64, error)
+	GetUnansweredCount(ctx context.Context, botID int64, since time.Time) (int64, error)
+	GetUnansweredBetween(ctx context.Context, botID int64, from, to time.Time) ([]models.UnansweredMessage, error)
+}
+
+// ContentRepo covers opt-in stored message content (database/content.go)
+type ContentRepo interface {
+	SaveMessageContent(ctx context.Context, botID, userChatID int64, adminMsgID int, text string) error
+	GetMessageContents(ctx context.Context, botID int64, limit int) ([]models.MessageContent, error)
+	SearchMessageContents(ctx context.Context, botID int64, search string, limit int) ([]models.MessageContent, error)
+	GetMessageContentCount(ctx context.Context, botID int64) (int64, error)
+	PurgeMessageContents(ctx context.Context, botID int64) (int64, error)
+	PurgeExpiredMessageContents(ctx context.Context) (int64, error)
+	UpdateBotContentLogging(ctx context.Context, botID int64, enabled bool) error
+	UpdateBotContentRetentionDays(ctx context.Context, botID int64, days int) error
+}
+
+// ModerationRepo covers abuse reports, ratings, routing, plans and spam feedback (database/reports.go, ratings.go, routing.go, plans.go, spam.go)
+type ModerationRepo interface {
+	CreateAbuseReport(ctx context.Context, botID, reporterChatID int64, reason string) error
+	SaveRating(ctx context.Context, botID, userChatID int64, stars int) error
+	GetRatingStats(ctx context.Context, botID int64) (float64, int64, error)
+	CreateRoutingRule(ctx context.Context, botID int64, keyword string, destChatID int64) error
+	GetRoutingRules(ctx context.Context, botID int64) ([]models.RoutingRule, error)
+	DeleteRoutingRule(ctx context.Context, botID, ruleID, ownerChatID int64) error
+	GetRoutingRuleCount(ctx context.Context, botID int64) (int64, error)
+	GetOwnerPlan(ctx context.Context, ownerChatID int64) (string, error)
+	GetOwnerPlainUI(ctx context.Context, ownerChatID int64) (bool, error)
+	SetOwnerPlainUI(ctx context.Context, ownerChatID int64, plainUI bool) error
+	RecordSpamFeedback(ctx context.Context, botID, userChatID int64, verdict string) error
+	UpdateBotSpamThreshold(ctx context.Context, botID int64, threshold int) error
+}
+
+// ShortLinksRepo covers owner-created short links (database/shortlinks.go)
+type ShortLinksRepo interface {
+	CreateShortLink(ctx context.Context, botID int64, code, targetURL string) error
+	GetShortLinkByCode(ctx context.Context, code string) (*models.ShortLink, error)
+	GetShortLinks(ctx context.Context, botID int64) ([]models.ShortLink, error)
+	DeleteShortLink(ctx context.Context, botID, linkID, ownerChatID int64) error
+	IncrementShortLinkClicks(ctx context.Context, code string) error
+	GetShortLinkCount(ctx context.Context, botID int64) (int64, error)
+}
+
+// BroadcastsRepo covers the broadcast history (database/broadcasts.go)
+type BroadcastsRepo interface {
+	CreateBroadcast(ctx context.Context, b *models.Broadcast) error
+	GetBroadcasts(ctx context.Context, botID int64, limit int) ([]models.Broadcast, error)
+	GetBroadcastByID(ctx context.Context, botID, broadcastID int64) (*models.Broadcast, error)
+}
+
+// Repository is everything the manager needs from the data layer
+type Repository interface {
+	BotsRepo
+	UsersRepo
+	AutoRepliesRepo
+	ScheduleRepo
+	ForcedSubRepo
+	RepliesRepo
+	ContentRepo
+	ModerationRepo
+	ShortLinksRepo
+	BroadcastsRepo
+}
+
+// Compile-time check that the real repository satisfies the seam
+var _ Repository = (*database.Repository)(nil)
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/bot/mocks"
+	"github.com/Amr-9/botforge/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+// ==================== Repository Seam Tests ====================
+//
+// These tests exercise handler logic against the generated mock instead
+// of a sqlmock-backed repository, demonstrating the per-domain seams.
+
+func TestCustomCommandDocs_SkipsInactiveCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+
+	repo.EXPECT().
+		GetAutoReplies(gomock.Any(), int64(7), "command").
+		Return([]models.AutoReply{
+			{TriggerWord: "faq", Response: "Frequently asked questions", IsActive: true},
+			{TriggerWord: "old", Response: "Retired command", IsActive: false},
+		}, nil)
+
+	m := &Manager{repo: repo}
+	docs := m.customCommandDocs(context.Background(), 7)
+
+	if len(docs) != 1 {
+		t.Fatalf("Expected 1 doc line, got %d: %v", len(docs), docs)
+	}
+	if !strings.HasPrefix(docs[0], "/faq") {
+		t.Errorf("Expected /faq doc line, got %q", docs[0])
+	}
+}
+
+func TestCustomCommandDocs_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+
+	repo.EXPECT().
+		GetAutoReplies(gomock.Any(), int64(7), "command").
+		Return(nil, errors.New("db down"))
+
+	m := &Manager{repo: repo}
+	if docs := m.customCommandDocs(context.Background(), 7); docs != nil {
+		t.Errorf("Expected no docs on repository error, got %v", docs)
+	}
+}
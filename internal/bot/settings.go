@@ -0,0 +1,431 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Settings Registry ====================
+
+// The settings registry declares the per-bot settings shown in the
+// settings menu. Boolean and choice settings get their buttons rendered
+// and their toggle handlers generated from the declarations below, so
+// adding a new setting is one registry entry instead of a hand-written
+// handler. Text settings (start message, confirmation emoji, ...) are
+// edited through the flow framework and keep their own prompts.
+
+// boolSetting declares one ON/OFF setting in the settings menu
+type boolSetting struct {
+	key       string                                                           // callback unique for the toggle button
+	label     string                                                           // button label without the status suffix
+	toast     string                                                           // setting name shown in the confirmation toast
+	defaultOn bool                                                             // status shown when the bot row cannot be loaded
+	value     func(b *models.Bot) bool                                         // reads the current value
+	update    func(m *Manager, ctx context.Context, botID int64, v bool) error // persists the new value
+	sync      func(m *Manager, ctx context.Context, token string, v bool)      // writes the new value through to the cache
+	// onChange runs optional side effects after a successful toggle
+	onChange func(m *Manager, ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat, botID int64, v bool)
+}
+
+// choiceSetting declares a setting that cycles through fixed values
+type choiceSetting struct {
+	key    string                                                             // callback unique for the cycle button
+	label  string                                                             // button label without the value suffix
+	toast  string                                                             // setting name shown in the confirmation toast
+	values []string                                                           // cycle order; unknown values are treated as the first
+	names  map[string]string                                                  // value -> display name
+	value  func(b *models.Bot) string                                         // reads the current value
+	update func(m *Manager, ctx context.Context, botID int64, v string) error // persists the new value
+	sync   func(m *Manager, ctx context.Context, token string, v string)      // writes the new value through to the cache
+}
+
+// boolSettings is the registry of boolean settings. Adding an entry both
+// renders its button in the settings menu and registers its toggle handler.
+var boolSettings = []boolSetting{
+	{
+		key:       "toggle_sent_confirm",
+		label:     "✅ Sent Confirmation",
+		toast:     "Sent confirmation",
+		defaultOn: true,
+		value:     func(b *models.Bot) bool { return b.ShowSentConfirmation },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotShowSentConfirmation(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetShowSentConfirmation(ctx, token, v)
+		},
+	},
+	{
+		key:   "toggle_survey",
+		label: "⭐ Survey",
+		toast: "Satisfaction survey",
+		value: func(b *models.Bot) bool { return b.SurveyEnabled },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotSurveyEnabled(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetSurveyEnabled(ctx, token, v)
+		},
+	},
+	{
+		key:       "toggle_menu_button",
+		label:     "📋 Menu Button",
+		toast:     "Menu button",
+		defaultOn: true,
+		value:     func(b *models.Bot) bool { return b.MenuButtonEnabled },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotMenuButtonEnabled(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetMenuButtonEnabled(ctx, token, v)
+		},
+		onChange: func(m *Manager, ctx context.Context, bot *telebot.Bot, token string, ownerChat *telebot.Chat, botID int64, v bool) {
+			// Push the change to Telegram's command lists
+			m.syncBotCommands(ctx, bot, token, ownerChat, botID)
+		},
+	},
+	{
+		key:   "toggle_rich_header",
+		label: "🪪 Rich Header",
+		toast: "Rich header",
+		value: func(b *models.Bot) bool { return b.RichHeaderEnabled },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotRichHeaderEnabled(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetRichHeaderEnabled(ctx, token, v)
+		},
+	},
+	{
+		key:   "toggle_content_log",
+		label: "📜 Content Logging",
+		toast: "Content logging",
+		value: func(b *models.Bot) bool { return b.ContentLoggingEnabled },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotContentLogging(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetContentLogging(ctx, token, v)
+		},
+	},
+	{
+		key:   "toggle_whitelist",
+		label: "🔒 Whitelist Only",
+		toast: "Whitelist-only mode",
+		value: func(b *models.Bot) bool { return b.WhitelistEnabled },
+		update: func(m *Manager, ctx context.Context, botID int64, v bool) error {
+			return m.repo.UpdateBotWhitelistEnabled(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v bool) {
+			m.cache.SetWhitelistEnabled(ctx, token, v)
+		},
+	},
+}
+
+// choiceSettings is the registry of fixed-value settings
+var choiceSettings = []choiceSetting{
+	{
+		key:    "toggle_confirm_mode",
+		label:  "💬 Confirmation Style",
+		toast:  "Confirmation style",
+		values: []string{models.ConfirmationModeReact, models.ConfirmationModeText},
+		names: map[string]string{
+			models.ConfirmationModeReact: "Reaction",
+			models.ConfirmationModeText:  "Text",
+		},
+		value: func(b *models.Bot) string { return b.ConfirmationMode },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			return m.repo.UpdateBotConfirmationMode(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			m.cache.SetConfirmationMode(ctx, token, v)
+		},
+	},
+	{
+		key:    "toggle_header_policy",
+		label:  "👤 Info Header",
+		toast:  "Info header",
+		values: []string{models.HeaderPolicyNever, models.HeaderPolicyGap, models.HeaderPolicyDaily},
+		names: map[string]string{
+			models.HeaderPolicyNever: "Once",
+			models.HeaderPolicyGap:   "Per Chat",
+			models.HeaderPolicyDaily: "Daily",
+		},
+		value: func(b *models.Bot) string { return b.HeaderPolicy },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			return m.repo.UpdateBotHeaderPolicy(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			m.cache.SetHeaderPolicy(ctx, token, v)
+		},
+	},
+	{
+		key:    "toggle_spam",
+		label:  "🛡 Spam Filter",
+		toast:  "Spam filter",
+		values: []string{"0", "90", "70", "50"},
+		names: map[string]string{
+			"0":  "Off",
+			"90": "Lenient",
+			"70": "Standard",
+			"50": "Strict",
+		},
+		value: func(b *models.Bot) string { return strconv.Itoa(b.SpamThreshold) },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			threshold, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			return m.repo.UpdateBotSpamThreshold(ctx, botID, threshold)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			threshold, err := strconv.Atoi(v)
+			if err != nil {
+				return
+			}
+			m.cache.SetSpamThreshold(ctx, token, threshold)
+		},
+	},
+	{
+		key:    "toggle_retention",
+		label:  "🗓 Retention",
+		toast:  "Content retention",
+		values: []string{"7", "30", "90"},
+		names: map[string]string{
+			"7":  "7 days",
+			"30": "30 days",
+			"90": "90 days",
+		},
+		value: func(b *models.Bot) string { return strconv.Itoa(b.ContentRetentionDays) },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			days, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			return m.repo.UpdateBotContentRetentionDays(ctx, botID, days)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			// Only the retention loop reads this, straight from the DB
+		},
+	},
+	{
+		key:    "toggle_msg_ttl",
+		label:  "⏳ Reply Window",
+		toast:  "Reply window",
+		values: []string{"0", "12", "48", "168"},
+		names: map[string]string{
+			"0":   "Default",
+			"12":  "12 hours",
+			"48":  "2 days",
+			"168": "7 days",
+		},
+		value: func(b *models.Bot) string { return strconv.Itoa(b.MessageTTLHours) },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			hours, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			return m.repo.UpdateBotMessageTTL(ctx, botID, hours)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			hours, err := strconv.Atoi(v)
+			if err != nil {
+				return
+			}
+			m.cache.SetMessageTTLHours(ctx, token, hours)
+		},
+	},
+	{
+		key:    "toggle_locale",
+		label:  "🌐 Language",
+		toast:  "Date language",
+		values: []string{"", "ar", "de", "es", "ru"},
+		names: map[string]string{
+			"":   "English",
+			"ar": "العربية",
+			"de": "Deutsch",
+			"es": "Español",
+			"ru": "Русский",
+		},
+		value: func(b *models.Bot) string { return b.Locale },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			return m.repo.UpdateBotLocale(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			m.cache.SetBotLocale(ctx, token, v)
+		},
+	},
+	{
+		key:    "toggle_timezone",
+		label:  "🕒 Timezone",
+		toast:  "Display timezone",
+		values: []string{"", "UTC", "Europe/London", "Europe/Berlin", "Europe/Moscow", "Africa/Cairo", "Asia/Riyadh"},
+		names: map[string]string{
+			"":              "Server",
+			"UTC":           "UTC",
+			"Europe/London": "London",
+			"Europe/Berlin": "Berlin",
+			"Europe/Moscow": "Moscow",
+			"Africa/Cairo":  "Cairo",
+			"Asia/Riyadh":   "Riyadh",
+		},
+		value: func(b *models.Bot) string { return b.Timezone },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			return m.repo.UpdateBotTimezone(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			m.cache.SetBotTimezone(ctx, token, v)
+		},
+	},
+	{
+		key:    "toggle_stats_digest",
+		label:  "📈 Stats Digest",
+		toast:  "Stats digest",
+		values: []string{"", "daily", "weekly"},
+		names: map[string]string{
+			"":       "Off",
+			"daily":  "Daily",
+			"weekly": "Weekly",
+		},
+		value: func(b *models.Bot) string { return b.StatsDigest },
+		update: func(m *Manager, ctx context.Context, botID int64, v string) error {
+			return m.repo.UpdateBotStatsDigest(ctx, botID, v)
+		},
+		sync: func(m *Manager, ctx context.Context, token string, v string) {
+			// Only the scheduler reads this, straight from the DB
+		},
+	},
+}
+
+// boolSettingByKey looks up a registered boolean setting
+func boolSettingByKey(key string) boolSetting {
+	for _, s := range boolSettings {
+		if s.key == key {
+			return s
+		}
+	}
+	return boolSetting{}
+}
+
+// choiceSettingByKey looks up a registered choice setting
+func choiceSettingByKey(key string) choiceSetting {
+	for _, s := range choiceSettings {
+		if s.key == key {
+			return s
+		}
+	}
+	return choiceSetting{}
+}
+
+// choiceIndex normalizes a stored value to an index into the cycle order;
+// unknown values (including the empty default) read as the first choice
+func (s choiceSetting) choiceIndex(value string) int {
+	for i, v := range s.values {
+		if v == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// settingButton renders the [ON]/[OFF] button for one boolean setting
+func settingButton(menu *telebot.ReplyMarkup, s boolSetting, b *models.Bot) telebot.Btn {
+	on := s.defaultOn
+	if b != nil {
+		on = s.value(b)
+	}
+	status := "OFF"
+	if on {
+		status = "ON"
+	}
+	return menu.Data(fmt.Sprintf("%s [%s]", s.label, status), s.key)
+}
+
+// choiceButton renders the [Value] button for one choice setting
+func choiceButton(menu *telebot.ReplyMarkup, s choiceSetting, b *models.Bot) telebot.Btn {
+	idx := 0
+	if b != nil {
+		idx = s.choiceIndex(s.value(b))
+	}
+	return menu.Data(fmt.Sprintf("%s [%s]", s.label, s.names[s.values[idx]]), s.key)
+}
+
+// handleToggleSetting builds the callback handler for one boolean setting
+func (m *Manager) handleToggleSetting(s boolSetting, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		// Get current setting
+		botModel, err := m.repo.GetBotByToken(ctx, token)
+		if err != nil || botModel == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to get settings!", ShowAlert: true})
+		}
+
+		// Toggle the setting
+		newValue := !s.value(botModel)
+		if err := s.update(m, ctx, botID, newValue); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to update setting!", ShowAlert: true})
+		}
+
+		// Update cache immediately for better performance
+		s.sync(m, ctx, token, newValue)
+
+		if s.onChange != nil {
+			s.onChange(m, ctx, bot, token, ownerChat, botID, newValue)
+		}
+
+		status := "ON"
+		if !newValue {
+			status = "OFF"
+		}
+		c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("✅ %s is now %s", s.toast, status)})
+
+		// Refresh settings menu
+		return m.handleChildSettings(bot, token, ownerChat)(c)
+	}
+}
+
+// handleCycleSetting builds the callback handler for one choice setting
+func (m *Manager) handleCycleSetting(s choiceSetting, bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		// Get current setting
+		botModel, err := m.repo.GetBotByToken(ctx, token)
+		if err != nil || botModel == nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to get settings!", ShowAlert: true})
+		}
+
+		// Advance to the next value in the cycle
+		newValue := s.values[(s.choiceIndex(s.value(botModel))+1)%len(s.values)]
+		if err := s.update(m, ctx, botID, newValue); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "❌ Failed to update setting!", ShowAlert: true})
+		}
+
+		// Update cache immediately for better performance
+		s.sync(m, ctx, token, newValue)
+
+		c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("✅ %s is now %s", s.toast, s.names[newValue])})
+
+		// Refresh settings menu
+		return m.handleChildSettings(bot, token, ownerChat)(c)
+	}
+}
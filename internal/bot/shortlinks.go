@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Short Links ====================
+
+// Owners can mint /l/{code} links bound to this server's domain for
+// broadcast CTAs and referral links. Codes are random and unique across
+// all bots; each bot manages its own set from the settings menu.
+
+// maxShortLinks caps the links one bot can hold
+const maxShortLinks = 25
+
+// HandleShortLink serves /l/<code>: counts the click and 302s to the
+// stored target. Unknown codes get a 404.
+func (m *Manager) HandleShortLink(w http.ResponseWriter, r *http.Request) {
+	code := strings.TrimPrefix(r.URL.Path, "/l/")
+	if code == "" || strings.Contains(code, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	link, err := m.repo.GetShortLinkByCode(ctx, code)
+	if err != nil {
+		log.Printf("Failed to resolve short link %s: %v", code, err)
+		http.NotFound(w, r)
+		return
+	}
+	if link == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := m.repo.IncrementShortLinkClicks(ctx, code); err != nil {
+		log.Printf("Failed to count short link click: %v", err)
+	}
+	http.Redirect(w, r, link.TargetURL, http.StatusFound)
+}
+
+// newShortCode returns a random 8-character link code, or "" if the
+// system randomness source fails
+func newShortCode() string {
+	raw := make([]byte, 4)
+	if _, err := rand.Read(raw); err != nil {
+		log.Printf("Failed to generate short link code: %v", err)
+		return ""
+	}
+	return hex.EncodeToString(raw)
+}
+
+// handleShortLinksMenu lists a bot's short links with their click counts
+func (m *Manager) handleShortLinksMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		links, err := m.repo.GetShortLinks(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting short links: %v", err)
+		}
+
+		var msgBuilder strings.Builder
+		msgBuilder.WriteString("🔗 <b>Short Links</b>\n\n")
+		msgBuilder.WriteString("Links on this server's domain that count clicks before redirecting.\n\n")
+
+		if len(links) > 0 {
+			msgBuilder.WriteString(fmt.Sprintf("<b>Links (%d/%d):</b>\n", len(links), maxShortLinks))
+			for i, link := range links {
+				prefix := "├"
+				if i == len(links)-1 {
+					prefix = "└"
+				}
+				msgBuilder.WriteString(fmt.Sprintf("%s <code>%s/l/%s</code> → %s (%d clicks)\n",
+					prefix, m.webhookURL, link.Code, link.TargetURL, link.Clicks))
+			}
+		} else {
+			msgBuilder.WriteString("<i>No links yet</i>\n")
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		for _, link := range links {
+			btnDelete := menu.Data(fmt.Sprintf("🗑 %s", link.Code), "del_short_link", strconv.FormatInt(link.ID, 10))
+			rows = append(rows, menu.Row(btnDelete))
+		}
+		btnAdd := menu.Data("➕ New Link", "add_short_link")
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		rows = append(rows, menu.Row(btnAdd), menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		return c.Edit(msgBuilder.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// handleAddShortLink initiates the new link flow
+func (m *Manager) handleAddShortLink(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if m.webhookURL == "" {
+			return c.Respond(&telebot.CallbackResponse{Text: "No public URL configured on this server", ShowAlert: true})
+		}
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_short_link"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "short_links_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		msg := `➕ <b>New Short Link</b>
+
+Send the target URL:
+
+<code>https://example.com/my-campaign</code>
+
+A random code is generated for it.`
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleDeleteShortLink removes a link from its button in the menu
+func (m *Manager) handleDeleteShortLink(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		linkID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid link!", ShowAlert: true})
+		}
+
+		if err := m.repo.DeleteShortLink(ctx, botID, linkID, ownerChat.ID); err != nil {
+			log.Printf("Failed to delete short link %d: %v", linkID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to delete link!", ShowAlert: true})
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: "Link deleted"})
+
+		// Refresh menu
+		return m.handleShortLinksMenu(bot, token, ownerChat)(c)
+	}
+}
+
+// processAddShortLink processes the target URL input
+func (m *Manager) processAddShortLink(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	target := strings.TrimSpace(c.Text())
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		return c.Reply("⚠️ Please send a full URL starting with <code>http://</code> or <code>https://</code>", telebot.ModeHTML)
+	}
+	if len(target) > 2048 {
+		return c.Reply("⚠️ The URL must be 2048 characters or less.")
+	}
+
+	count, err := m.repo.GetShortLinkCount(ctx, botID)
+	if err != nil {
+		log.Printf("Failed to get short link count: %v", err)
+	}
+	if count >= maxShortLinks {
+		m.cache.ClearUserState(ctx, token, c.Sender().ID)
+		return c.Reply(fmt.Sprintf("❌ This bot already has %d short links. Delete one first.", maxShortLinks))
+	}
+
+	code := newShortCode()
+	if code == "" {
+		return c.Reply("❌ Failed to generate a link code.")
+	}
+
+	if err := m.repo.CreateShortLink(ctx, botID, code, target); err != nil {
+		log.Printf("Failed to create short link: %v", err)
+		return c.Reply("❌ Failed to save short link.")
+	}
+
+	// Clear state
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	return c.Reply(fmt.Sprintf("✅ <b>Short Link Created!</b>\n\n<code>%s/l/%s</code> → %s",
+		m.webhookURL, code, target), telebot.ModeHTML)
+}
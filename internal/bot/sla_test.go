@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+// ==================== Response Time Summary Tests ====================
+
+func TestResponseTimeSummary_Empty(t *testing.T) {
+	avg, median := responseTimeSummary(nil)
+	if avg != 0 || median != 0 {
+		t.Errorf("Expected zeros for no data, got avg=%v median=%v", avg, median)
+	}
+}
+
+func TestResponseTimeSummary_OddCount(t *testing.T) {
+	avg, median := responseTimeSummary([]int64{60, 30, 300})
+	if avg != 130*time.Second {
+		t.Errorf("Expected avg 130s, got %v", avg)
+	}
+	if median != 60*time.Second {
+		t.Errorf("Expected median 60s, got %v", median)
+	}
+}
+
+func TestResponseTimeSummary_EvenCount(t *testing.T) {
+	_, median := responseTimeSummary([]int64{10, 20, 30, 100})
+	if median != 25*time.Second {
+		t.Errorf("Expected median 25s, got %v", median)
+	}
+}
+
+func TestResponseTimeSummary_DiscardsNegatives(t *testing.T) {
+	avg, median := responseTimeSummary([]int64{-5, 40})
+	if avg != 40*time.Second || median != 40*time.Second {
+		t.Errorf("Expected negatives discarded, got avg=%v median=%v", avg, median)
+	}
+}
+
+func TestFormatResponseDuration(t *testing.T) {
+	cases := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "—"},
+		{45 * time.Second, "45s"},
+		{3*time.Minute + 20*time.Second, "3m 20s"},
+		{2*time.Hour + 5*time.Minute, "2h 5m"},
+	}
+	for _, tc := range cases {
+		if got := formatResponseDuration(tc.d); got != tc.expected {
+			t.Errorf("formatResponseDuration(%v) = %q, expected %q", tc.d, got, tc.expected)
+		}
+	}
+}
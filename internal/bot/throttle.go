@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Webhook throttling limits. Telegram delivers at most ~30 updates/s
+// per bot, so the per-bot bucket only trips on traffic Telegram would
+// never send. The per-IP bucket is far looser because all legitimate
+// updates arrive from a handful of Telegram subnets that carry every
+// bot's traffic; it exists to stop a single scripted source from
+// consuming all capacity.
+const (
+	perBotRate  = 40
+	perBotBurst = 80
+	perIPRate   = 500
+	perIPBurst  = 1000
+)
+
+// rateLimiter is a keyed token-bucket limiter. Buckets refill
+// continuously at rate tokens/s up to burst; stale buckets are swept so
+// the map does not grow with every token or IP ever seen.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{
+		rate:      rate,
+		burst:     burst,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow reports whether one more request for key fits in its bucket
+func (l *rateLimiter) allow(key string) bool {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > time.Minute {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep drops buckets that have been full for a while. A full bucket
+// carries no history, so rebuilding it later is free. Caller holds mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		idle := now.Sub(b.last).Seconds()
+		if b.tokens+idle*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// clientIP extracts the peer address for per-IP throttling. RemoteAddr
+// is used as-is: forwarding headers are spoofable and this server is
+// expected to terminate TLS itself.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
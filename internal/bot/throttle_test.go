@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ==================== Rate Limiter Tests ====================
+
+func TestRateLimiter_BurstThenDeny(t *testing.T) {
+	// Refill rate is negligible within test runtime
+	l := newRateLimiter(0.001, 2)
+
+	if !l.allow("key") || !l.allow("key") {
+		t.Fatal("Expected requests within the burst to be allowed")
+	}
+	if l.allow("key") {
+		t.Error("Expected request beyond the burst to be denied")
+	}
+}
+
+func TestRateLimiter_KeysAreIndependent(t *testing.T) {
+	l := newRateLimiter(0.001, 1)
+
+	if !l.allow("a") {
+		t.Fatal("Expected first request for key a to be allowed")
+	}
+	if !l.allow("b") {
+		t.Error("Expected key b to have its own bucket")
+	}
+	if l.allow("a") {
+		t.Error("Expected key a to be exhausted")
+	}
+}
+
+func TestServeHTTP_ThrottlesPerBot(t *testing.T) {
+	m := NewManager(nil, nil, "https://example.com")
+	token := "throttledtoken1234"
+
+	var last int
+	for i := 0; i < perBotBurst+1; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/webhook/"+token, bytes.NewBufferString("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		m.ServeHTTP(rr, req)
+		last = rr.Code
+	}
+
+	if last != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 after exhausting the per-bot burst, got %d", last)
+	}
+
+	// Other bots are unaffected
+	req := httptest.NewRequest(http.MethodPost, "/webhook/othertoken123456", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	m.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected other bots to pass the throttle, got %d", rr.Code)
+	}
+}
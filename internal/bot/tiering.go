@@ -0,0 +1,190 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+)
+
+// Warm/cold tiering for child bots. Every incoming webhook update stamps
+// the bot's last-activity time; a background loop demotes bots that have
+// seen no updates for ColdAfter. Demotion tears down the telebot instance
+// and its background loops but keeps the webhook registered, so the first
+// update after demotion re-promotes the bot transparently.
+
+const (
+	// ColdAfter is how long a bot may go without updates before it is
+	// demoted to the cold tier
+	ColdAfter = 7 * 24 * time.Hour
+
+	// tieringCheckInterval is how often the demotion loop scans for
+	// cold bots
+	tieringCheckInterval = 1 * time.Hour
+)
+
+// coldBot is the minimal state kept for a demoted bot, enough to restart
+// it when an update arrives
+type coldBot struct {
+	ownerChatID int64
+	botID       int64
+	lastActive  time.Time
+	demotedAt   time.Time
+}
+
+// ColdBotStatus describes one demoted bot for the admin report
+type ColdBotStatus struct {
+	BotID      int64
+	LastActive time.Time
+	DemotedAt  time.Time
+}
+
+// touchBot stamps the bot's last-activity time on an incoming update
+func (m *Manager) touchBot(token string) {
+	m.mu.Lock()
+	m.lastActivity[token] = time.Now()
+	m.mu.Unlock()
+}
+
+// StartTiering launches the background loop that demotes cold bots
+func (m *Manager) StartTiering() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.tieringCancel = cancel
+	m.mu.Unlock()
+	go m.runTieringLoop(ctx)
+}
+
+// StopTiering stops the demotion loop
+func (m *Manager) StopTiering() {
+	m.mu.Lock()
+	if m.tieringCancel != nil {
+		m.tieringCancel()
+		m.tieringCancel = nil
+	}
+	m.mu.Unlock()
+}
+
+// runTieringLoop periodically demotes bots whose last update is older
+// than ColdAfter
+func (m *Manager) runTieringLoop(ctx context.Context) {
+	ticker := time.NewTicker(tieringCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if demoted := m.demoteColdBots(ColdAfter); demoted > 0 {
+				log.Printf("Tiering: demoted %d cold bot(s)", demoted)
+			}
+		}
+	}
+}
+
+// demoteColdBots moves every child bot without updates for olderThan to
+// the cold tier and returns how many were demoted
+func (m *Manager) demoteColdBots(olderThan time.Duration) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	demoted := 0
+	for token, bot := range m.bots {
+		// Factory bots are registered with ID 0 and are never demoted
+		botID := m.botIDs[token]
+		if botID == 0 || bot == nil {
+			continue
+		}
+		lastActive, ok := m.lastActivity[token]
+		if !ok || lastActive.After(cutoff) {
+			continue
+		}
+
+		// Cancel the background loops, same teardown as StopBot but
+		// without removing the webhook
+		for _, cancels := range []map[string]context.CancelFunc{
+			m.preloadCancels, m.digestCancels, m.slaCancels, m.channelStatsCancels,
+		} {
+			if cancel, cancelExists := cancels[token]; cancelExists {
+				cancel()
+				delete(cancels, token)
+			}
+		}
+		if controller, ctrlExists := m.restartControllers[token]; ctrlExists {
+			controller.Stop()
+			delete(m.restartControllers, token)
+		}
+
+		ownerChatID := int64(0)
+		if bot.Me != nil {
+			if bc, ok := m.childCtxs[bot.Me.ID]; ok {
+				ownerChatID = bc.ownerChat.ID
+			}
+			delete(m.childCtxs, bot.Me.ID)
+		}
+
+		m.coldBots[token] = coldBot{
+			ownerChatID: ownerChatID,
+			botID:       botID,
+			lastActive:  lastActive,
+			demotedAt:   time.Now(),
+		}
+		delete(m.bots, token)
+		delete(m.botIDs, token)
+		delete(m.restartPolicies, token)
+		delete(m.lastActivity, token)
+		demoted++
+		log.Printf("Tiering: demoted cold bot %s... (last active %s)",
+			token[:10], lastActive.Format("2006-01-02"))
+	}
+	return demoted
+}
+
+// promoteColdBot restarts a demoted bot on its first incoming update and
+// returns whether the token belonged to a cold bot
+func (m *Manager) promoteColdBot(token string) bool {
+	m.mu.Lock()
+	info, ok := m.coldBots[token]
+	if ok {
+		delete(m.coldBots, token)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	if err := m.StartBot(token, info.ownerChatID, info.botID); err != nil {
+		log.Printf("Tiering: failed to re-promote bot %s...: %v", token[:10], err)
+		// Keep the bot cold so the next update retries
+		m.mu.Lock()
+		m.coldBots[token] = info
+		m.mu.Unlock()
+		return false
+	}
+
+	log.Printf("Tiering: re-promoted bot %s... after %s cold",
+		token[:10], time.Since(info.demotedAt).Round(time.Minute))
+	return true
+}
+
+// ColdBots returns the currently demoted bots, least recently active
+// first, for the admin report
+func (m *Manager) ColdBots() []ColdBotStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := make([]ColdBotStatus, 0, len(m.coldBots))
+	for _, info := range m.coldBots {
+		report = append(report, ColdBotStatus{
+			BotID:      info.botID,
+			LastActive: info.lastActive,
+			DemotedAt:  info.demotedAt,
+		})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].LastActive.Before(report[j].LastActive)
+	})
+	return report
+}
@@ -0,0 +1,256 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Whitelist-Only Mode ====================
+
+// Internal helpdesk bots can restrict access to a pre-approved list of
+// user IDs. Everyone else gets the bot's rejection message and nothing
+// reaches the owner. The toggle lives in the settings registry; the
+// list itself is managed from the whitelist menu below.
+
+// defaultWhitelistMessage is shown to non-whitelisted users when the
+// owner has not set a custom rejection message
+const defaultWhitelistMessage = "🔒 This bot is private. You are not on its access list."
+
+// checkWhitelist enforces whitelist-only mode for one inbound message.
+// Returns the rejection message to send, or "" when the message may
+// proceed (mode off, user whitelisted, or lookups failed).
+func (m *Manager) checkWhitelist(ctx context.Context, token string, botID, userID int64) string {
+	// Check if the mode is enabled (cache-first)
+	enabled, cacheHit, err := m.cache.GetWhitelistEnabled(ctx, token)
+	if err != nil {
+		log.Printf("Cache error getting whitelist enabled: %v", err)
+	}
+
+	if !cacheHit {
+		botModel, err := m.repo.GetBotSettingsByID(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting bot for whitelist check: %v", err)
+			return "" // Allow on error
+		}
+		if botModel != nil {
+			enabled = botModel.WhitelistEnabled
+			m.cache.SetWhitelistEnabled(ctx, token, enabled)
+		}
+	}
+
+	if !enabled {
+		return ""
+	}
+
+	whitelisted, err := m.repo.IsUserWhitelisted(ctx, botID, userID)
+	if err != nil {
+		log.Printf("Error checking whitelist status: %v", err)
+		return "" // Allow on error
+	}
+	if whitelisted {
+		return ""
+	}
+
+	// Rejection path only: the custom message is rarely needed, so it is
+	// read straight from the settings row
+	botModel, _ := m.repo.GetBotSettingsByID(ctx, botID)
+	if botModel != nil && botModel.WhitelistMessage != "" {
+		return botModel.WhitelistMessage
+	}
+	return defaultWhitelistMessage
+}
+
+// handleWhitelistMenu lists the pre-approved user IDs
+func (m *Manager) handleWhitelistMenu(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userIDs, err := m.repo.GetWhitelistedUsers(ctx, botID)
+		if err != nil {
+			log.Printf("Error getting whitelisted users: %v", err)
+		}
+
+		var msgBuilder strings.Builder
+		msgBuilder.WriteString("🗂 <b>Whitelist</b>\n\n")
+		msgBuilder.WriteString("When whitelist-only mode is ON, only these users can interact with the bot. Everyone else gets the rejection message.\n\n")
+
+		if len(userIDs) > 0 {
+			msgBuilder.WriteString(fmt.Sprintf("<b>Approved users (%d):</b>\n", len(userIDs)))
+			for i, userID := range userIDs {
+				prefix := "├"
+				if i == len(userIDs)-1 {
+					prefix = "└"
+				}
+				msgBuilder.WriteString(fmt.Sprintf("%s <code>%d</code>\n", prefix, userID))
+			}
+		} else {
+			msgBuilder.WriteString("<i>No approved users yet</i>\n")
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		var rows []telebot.Row
+		for _, userID := range userIDs {
+			btnRemove := menu.Data(fmt.Sprintf("🗑 %d", userID), "del_whitelist_user", strconv.FormatInt(userID, 10))
+			rows = append(rows, menu.Row(btnRemove))
+		}
+		btnAdd := menu.Data("➕ Add User", "add_whitelist_user")
+		btnMessage := menu.Data("✏️ Rejection Message", "set_whitelist_msg")
+		btnBack := menu.Data("« Back to Settings", "back_to_settings")
+		rows = append(rows, menu.Row(btnAdd), menu.Row(btnMessage), menu.Row(btnBack))
+		menu.Inline(rows...)
+
+		return c.Edit(msgBuilder.String(), menu, telebot.ModeHTML)
+	}
+}
+
+// handleAddWhitelistUser initiates state to add a user ID
+func (m *Manager) handleAddWhitelistUser(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "add_whitelist_user"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "whitelist_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		msg := `➕ <b>Add Whitelisted User</b>
+
+Send the numeric Telegram user ID to approve:
+
+<code>123456789</code>
+
+Users can learn their ID from @userinfobot.`
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// handleRemoveWhitelistUser revokes an approval from its button in the menu
+func (m *Manager) handleRemoveWhitelistUser(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		m.mu.RLock()
+		botID := m.botIDs[token]
+		m.mu.RUnlock()
+
+		userID, err := strconv.ParseInt(strings.TrimSpace(c.Data()), 10, 64)
+		if err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Invalid user!", ShowAlert: true})
+		}
+
+		if err := m.repo.RemoveWhitelistedUser(ctx, botID, userID, ownerChat.ID); err != nil {
+			log.Printf("Failed to remove whitelisted user %d: %v", userID, err)
+			return c.Respond(&telebot.CallbackResponse{Text: "Failed to remove user!", ShowAlert: true})
+		}
+
+		c.Respond(&telebot.CallbackResponse{Text: "User removed"})
+
+		// Refresh menu
+		return m.handleWhitelistMenu(bot, token, ownerChat)(c)
+	}
+}
+
+// handleSetWhitelistMsgBtn initiates state to set the rejection message
+func (m *Manager) handleSetWhitelistMsgBtn(bot *telebot.Bot, token string, ownerChat *telebot.Chat) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if c.Sender().ID != ownerChat.ID {
+			return nil
+		}
+
+		ctx := context.Background()
+		if err := m.cache.SetUserState(ctx, token, c.Sender().ID, "set_whitelist_msg"); err != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: "Error setting state", ShowAlert: true})
+		}
+
+		menu := &telebot.ReplyMarkup{}
+		btnCancel := menu.Data("❌ Cancel", "whitelist_menu")
+		menu.Inline(menu.Row(btnCancel))
+
+		currentMsg := "<i>(Default)</i>"
+		if botModel, err := m.repo.GetBotByToken(ctx, token); err == nil && botModel != nil && botModel.WhitelistMessage != "" {
+			currentMsg = strings.ReplaceAll(botModel.WhitelistMessage, "<", "&lt;")
+			currentMsg = strings.ReplaceAll(currentMsg, ">", "&gt;")
+		}
+
+		msg := fmt.Sprintf(`✏️ <b>Rejection Message</b>
+
+Send the message shown to users who are not on the whitelist (max 500 characters), or <code>-</code> to restore the default.
+
+<b>Current:</b>
+%s`, currentMsg)
+
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+}
+
+// processAddWhitelistUser processes the user ID input
+func (m *Manager) processAddWhitelistUser(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(c.Text()), 10, 64)
+	if err != nil || userID <= 0 {
+		return c.Reply("⚠️ Please send a numeric Telegram user ID, like <code>123456789</code>", telebot.ModeHTML)
+	}
+
+	if err := m.repo.AddWhitelistedUser(ctx, botID, userID, c.Sender().ID); err != nil {
+		log.Printf("Failed to whitelist user %d: %v", userID, err)
+		return c.Reply("❌ Failed to add user to the whitelist.")
+	}
+
+	// Clear state
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	return c.Reply(fmt.Sprintf("✅ <b>User Approved!</b>\n\nUser <code>%d</code> can now interact with this bot.", userID), telebot.ModeHTML)
+}
+
+// processSetWhitelistMsg processes the rejection message input
+func (m *Manager) processSetWhitelistMsg(ctx context.Context, c telebot.Context, token string) error {
+	m.mu.RLock()
+	botID := m.botIDs[token]
+	m.mu.RUnlock()
+
+	message := strings.TrimSpace(c.Text())
+	if message == "-" {
+		message = ""
+	}
+	if len(message) > 500 {
+		return c.Reply("⚠️ The rejection message must be 500 characters or less.")
+	}
+
+	if err := m.repo.UpdateBotWhitelistMessage(ctx, botID, message); err != nil {
+		log.Printf("Failed to update whitelist message: %v", err)
+		return c.Reply("❌ Failed to save rejection message.")
+	}
+
+	// Clear state
+	m.cache.ClearUserState(ctx, token, c.Sender().ID)
+
+	if message == "" {
+		return c.Reply("✅ Rejection message restored to the default.")
+	}
+	return c.Reply("✅ <b>Rejection Message Saved!</b>", telebot.ModeHTML)
+}
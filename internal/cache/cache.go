@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// Cache is the caching contract consumed by the bot manager, the
+// factories and the ops tooling. *Redis is the production
+// implementation; Memory (an embedded miniredis behind the same client)
+// backs dev mode and tests. Keeping consumers on this interface means a
+// new backend only has to satisfy it.
+type Cache interface {
+	SetMessageLink(ctx context.Context, botToken string, adminMsgID int, userChatID int64) error
+	SetMessageLinkWithTTL(ctx context.Context, botToken string, adminMsgID int, userChatID int64, ttl time.Duration) error
+	GetMessageLink(ctx context.Context, botToken string, adminMsgID int) (int64, error)
+	DeleteMessageLink(ctx context.Context, botToken string, adminMsgID int) error
+	HasSession(ctx context.Context, botToken string, userID int64) (bool, error)
+	SetSession(ctx context.Context, botToken string, userID int64, ttl time.Duration) error
+	Close() error
+	Ping(ctx context.Context) error
+	SetBroadcastMode(ctx context.Context, botToken string, adminID int64) error
+	GetBroadcastMode(ctx context.Context, botToken string, adminID int64) (bool, error)
+	ClearBroadcastMode(ctx context.Context, botToken string, adminID int64) error
+	SetUserState(ctx context.Context, botToken string, userID int64, state string) error
+	GetUserState(ctx context.Context, botToken string, userID int64) (string, error)
+	ClearUserState(ctx context.Context, botToken string, userID int64) error
+	SetUserBanned(ctx context.Context, botToken string, userChatID int64) error
+	IsUserBanned(ctx context.Context, botToken string, userChatID int64) (bool, bool, error)
+	RemoveUserBan(ctx context.Context, botToken string, userChatID int64) error
+	CacheNotBanned(ctx context.Context, botToken string, userChatID int64) error
+	IsNotBannedCached(ctx context.Context, botToken string, userChatID int64) (bool, error)
+	InvalidateNotBannedCache(ctx context.Context, botToken string, userChatID int64) error
+	MuteUser(ctx context.Context, botToken string, userChatID int64, ttl time.Duration) error
+	IsUserMuted(ctx context.Context, botToken string, userChatID int64) (bool, error)
+	UnmuteUser(ctx context.Context, botToken string, userChatID int64) error
+	GetMuteTTL(ctx context.Context, botToken string, userChatID int64) (time.Duration, error)
+	GetUserMessageContext(ctx context.Context, botToken string, userChatID int64) (UserMessageContext, error)
+	SetRoutingRules(ctx context.Context, botToken string, rules []RoutingRuleCache) error
+	GetRoutingRules(ctx context.Context, botToken string) ([]RoutingRuleCache, bool, error)
+	InvalidateRoutingRules(ctx context.Context, botToken string) error
+	AddDigestMessage(ctx context.Context, botToken string, userChatID int64, msgID int) error
+	GetDigestState(ctx context.Context, botToken string) (int64, []int64, error)
+	CountDigestMessages(ctx context.Context, botToken string, userChatID int64) (int64, error)
+	ResetDigestCount(ctx context.Context, botToken string) error
+	PopDigestMessages(ctx context.Context, botToken string, userChatID int64) ([]int, error)
+	AddSpamMessage(ctx context.Context, botToken string, userChatID int64, msgID int) error
+	GetSpamUsers(ctx context.Context, botToken string) ([]int64, error)
+	CountSpamMessages(ctx context.Context, botToken string, userChatID int64) (int64, error)
+	PopSpamMessages(ctx context.Context, botToken string, userChatID int64) ([]int, error)
+	SetPendingBroadcast(ctx context.Context, botToken string, adminID int64, msgID int) error
+	GetPendingBroadcast(ctx context.Context, botToken string, adminID int64) (int, error)
+	ClearPendingBroadcast(ctx context.Context, botToken string, adminID int64) error
+	SetBroadcastLinks(ctx context.Context, broadcastID string, urls []string) error
+	GetBroadcastLink(ctx context.Context, broadcastID string, button int) (string, error)
+	IncrementBroadcastClick(ctx context.Context, broadcastID string, button int) error
+	GetBroadcastClicks(ctx context.Context, broadcastID string) (map[int]int64, error)
+	SetBroadcastProgress(ctx context.Context, p *BroadcastProgress) error
+	ClearBroadcastProgress(ctx context.Context, botToken string) error
+	GetInterruptedBroadcasts(ctx context.Context) ([]*BroadcastProgress, error)
+	SetAnnouncement(ctx context.Context, text string, ttl time.Duration) error
+	GetAnnouncement(ctx context.Context) (string, error)
+	ClearAnnouncement(ctx context.Context) error
+	SetFeatureFlag(ctx context.Context, name string, enabled bool) error
+	GetFeatureFlag(ctx context.Context, name string) (bool, bool, error)
+	ClearFeatureFlag(ctx context.Context, name string) error
+	GetFeatureFlags(ctx context.Context) (map[string]bool, error)
+	SetBotFeatureFlag(ctx context.Context, botID int64, name string, enabled bool) error
+	GetBotFeatureFlag(ctx context.Context, botID int64, name string) (bool, bool, error)
+	ClearBotFeatureFlag(ctx context.Context, botID int64, name string) error
+	SetAutoReply(ctx context.Context, botToken, trigger, response, triggerType string) error
+	GetAutoReply(ctx context.Context, botToken, trigger, triggerType string) (string, error)
+	DeleteAutoReply(ctx context.Context, botToken, trigger, triggerType string) error
+	GetAllAutoReplies(ctx context.Context, botToken, triggerType string) (map[string]string, error)
+	SetAutoReplyWithMedia(ctx context.Context, botToken, trigger string, cache *AutoReplyCache, triggerType string) error
+	GetAutoReplyWithMedia(ctx context.Context, botToken, trigger, triggerType string) (*AutoReplyCache, error)
+	GetAllAutoRepliesWithMedia(ctx context.Context, botToken, triggerType string) (map[string]*AutoReplyCache, error)
+	SetQuickReplies(ctx context.Context, botToken string, triggers []string) error
+	GetQuickReplies(ctx context.Context, botToken string) ([]string, bool, error)
+	InvalidateQuickReplies(ctx context.Context, botToken string) error
+	SetTempData(ctx context.Context, botToken string, userID int64, key, value string) error
+	GetTempData(ctx context.Context, botToken string, userID int64, key string) (string, error)
+	ClearTempData(ctx context.Context, botToken string, userID int64, key string) error
+	SetFlowDraft(ctx context.Context, botToken string, userID int64, draft interface{}) error
+	GetFlowDraft(ctx context.Context, botToken string, userID int64, draft interface{}) (bool, error)
+	ClearFlowDraft(ctx context.Context, botToken string, userID int64) error
+	ClearFlowScope(ctx context.Context, scope FlowScope, botToken string, userID int64) error
+	SetScheduleState(ctx context.Context, botToken string, adminID int64, state string) error
+	GetScheduleState(ctx context.Context, botToken string, adminID int64) (string, error)
+	SetScheduleMessageData(ctx context.Context, botToken string, adminID int64, msgType, text, fileID, caption string) error
+	GetScheduleMessageData(ctx context.Context, botToken string, adminID int64) (msgType, text, fileID, caption string, err error)
+	SetScheduleConfig(ctx context.Context, botToken string, adminID int64, scheduleType, scheduleTime, day string) error
+	GetScheduleConfig(ctx context.Context, botToken string, adminID int64) (scheduleType, scheduleTime, day string, err error)
+	ClearScheduleData(ctx context.Context, botToken string, adminID int64) error
+	SetForcedSubEnabled(ctx context.Context, botToken string, enabled bool) error
+	GetForcedSubEnabled(ctx context.Context, botToken string) (bool, bool, error)
+	InvalidateForcedSubEnabled(ctx context.Context, botToken string) error
+	SetUserSubVerified(ctx context.Context, botToken string, userID int64) error
+	IsUserSubVerified(ctx context.Context, botToken string, userID int64) (bool, error)
+	ClearUserSubVerified(ctx context.Context, botToken string, userID int64) error
+	ClearAllUserSubVerified(ctx context.Context, botToken string) error
+	SetShowSentConfirmation(ctx context.Context, botToken string, show bool) error
+	GetShowSentConfirmation(ctx context.Context, botToken string) (bool, bool, error)
+	InvalidateShowSentConfirmation(ctx context.Context, botToken string) error
+	SetConfirmationEmoji(ctx context.Context, botToken string, emoji string) error
+	GetConfirmationEmoji(ctx context.Context, botToken string) (string, bool, error)
+	InvalidateConfirmationEmoji(ctx context.Context, botToken string) error
+	SetDigestInterval(ctx context.Context, botToken string, minutes int) error
+	GetDigestInterval(ctx context.Context, botToken string) (int, bool, error)
+	SetDigestKeywords(ctx context.Context, botToken string, keywords string) error
+	GetDigestKeywords(ctx context.Context, botToken string) (string, bool, error)
+	SetRelayChannel(ctx context.Context, botToken string, channelID int64) error
+	GetRelayChannel(ctx context.Context, botToken string) (int64, bool, error)
+	SetArchiveChat(ctx context.Context, botToken string, chatID int64) error
+	GetArchiveChat(ctx context.Context, botToken string) (int64, bool, error)
+	SetBlockedMediaTypes(ctx context.Context, botToken string, types string) error
+	GetBlockedMediaTypes(ctx context.Context, botToken string) (string, bool, error)
+	SetMaxFileSize(ctx context.Context, botToken string, sizeMB int) error
+	GetMaxFileSize(ctx context.Context, botToken string) (int, bool, error)
+	SetSurveyEnabled(ctx context.Context, botToken string, enabled bool) error
+	GetSurveyEnabled(ctx context.Context, botToken string) (bool, bool, error)
+	SetMenuButtonEnabled(ctx context.Context, botToken string, enabled bool) error
+	GetMenuButtonEnabled(ctx context.Context, botToken string) (bool, bool, error)
+	SetWhitelistEnabled(ctx context.Context, botToken string, enabled bool) error
+	GetWhitelistEnabled(ctx context.Context, botToken string) (bool, bool, error)
+	SetReactionActions(ctx context.Context, botToken string, actions string) error
+	GetReactionActions(ctx context.Context, botToken string) (string, bool, error)
+	MarkSurveySent(ctx context.Context, botToken string, userChatID int64, cooldown time.Duration) (bool, error)
+	SetDedupeWindow(ctx context.Context, botToken string, minutes int) error
+	GetDedupeWindow(ctx context.Context, botToken string) (int, bool, error)
+	SetSLAMinutes(ctx context.Context, botToken string, minutes int) error
+	GetSLAMinutes(ctx context.Context, botToken string) (int, bool, error)
+	SetForcedSubGraceCount(ctx context.Context, botToken string, count int) error
+	GetForcedSubGraceCount(ctx context.Context, botToken string) (int, bool, error)
+	IsDuplicateMessage(ctx context.Context, botToken string, userChatID int64, text string, window time.Duration) (bool, error)
+	SetFilterAction(ctx context.Context, botToken string, filterName string, action string) error
+	GetFilterAction(ctx context.Context, botToken string, filterName string) (string, bool, error)
+	SetConfirmationMode(ctx context.Context, botToken string, mode string) error
+	GetConfirmationMode(ctx context.Context, botToken string) (string, bool, error)
+	SetPrivacyNotice(ctx context.Context, botToken string, notice string) error
+	GetPrivacyNotice(ctx context.Context, botToken string) (string, bool, error)
+	InvalidatePrivacyNotice(ctx context.Context, botToken string) error
+	SetRichHeaderEnabled(ctx context.Context, botToken string, enabled bool) error
+	GetRichHeaderEnabled(ctx context.Context, botToken string) (bool, bool, error)
+	SetContentLogging(ctx context.Context, botToken string, enabled bool) error
+	GetContentLogging(ctx context.Context, botToken string) (bool, bool, error)
+	SetSpamThreshold(ctx context.Context, botToken string, threshold int) error
+	GetSpamThreshold(ctx context.Context, botToken string) (int, bool, error)
+	SetMessageTTLHours(ctx context.Context, botToken string, hours int) error
+	GetMessageTTLHours(ctx context.Context, botToken string) (int, bool, error)
+	SetBotLocale(ctx context.Context, botToken string, locale string) error
+	GetBotLocale(ctx context.Context, botToken string) (string, bool, error)
+	SetBotTimezone(ctx context.Context, botToken string, tz string) error
+	GetBotTimezone(ctx context.Context, botToken string) (string, bool, error)
+	SetPlainUI(ctx context.Context, ownerChatID int64, enabled bool) error
+	GetPlainUI(ctx context.Context, ownerChatID int64) (bool, bool, error)
+	SetHeaderPolicy(ctx context.Context, botToken string, policy string) error
+	GetHeaderPolicy(ctx context.Context, botToken string) (string, bool, error)
+	SetBotUsername(ctx context.Context, botToken string, username string) error
+	GetBotUsername(ctx context.Context, botToken string) (string, bool, error)
+	SetStartMessage(ctx context.Context, botToken string, message string) error
+	GetStartMessage(ctx context.Context, botToken string) (string, bool, error)
+	InvalidateStartMessage(ctx context.Context, botToken string) error
+	SetForwardAutoReplies(ctx context.Context, botToken string, enabled bool) error
+	GetForwardAutoReplies(ctx context.Context, botToken string) (bool, bool, error)
+	InvalidateForwardAutoReplies(ctx context.Context, botToken string) error
+	PurgeUserKeys(ctx context.Context, botToken string, userChatID int64) error
+	PurgeBotKeys(ctx context.Context, botToken string) error
+	InvalidateAllBotSettings(ctx context.Context, botToken string) error
+	PreloadBotSettings(ctx context.Context, botToken string, settings *models.BotRuntimeSettings) error
+}
+
+// Compile-time check that the production implementation satisfies Cache
+var _ Cache = (*Redis)(nil)
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// Memory is an in-process Cache backed by an embedded miniredis. It
+// reuses the whole Redis implementation, so behaviour (key layout, TTLs,
+// pipelines) matches production exactly; only persistence is missing.
+// Dev mode and tests use it to run without a Redis instance.
+type Memory struct {
+	*Redis
+	mr *miniredis.Miniredis
+}
+
+// NewMemory starts an embedded cache. State is lost on Close.
+func NewMemory(messageTTL time.Duration) (*Memory, error) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	redis, err := NewRedis(mr.Addr(), "", 0, messageTTL)
+	if err != nil {
+		mr.Close()
+		return nil, err
+	}
+
+	return &Memory{Redis: redis, mr: mr}, nil
+}
+
+// Close shuts down the client and the embedded server
+func (m *Memory) Close() error {
+	err := m.Redis.Close()
+	m.mr.Close()
+	return err
+}
+
+// FastForward advances the embedded server's clock so tests can expire
+// keys without sleeping
+func (m *Memory) FastForward(d time.Duration) {
+	m.mr.FastForward(d)
+}
+
+var _ Cache = (*Memory)(nil)
@@ -0,0 +1,69 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/cache"
+)
+
+// ==================== Memory Cache Tests ====================
+
+func TestNewMemory_ImplementsCache(t *testing.T) {
+	mem, err := cache.NewMemory(48 * time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer mem.Close()
+
+	// Exercise it through the interface, the way Manager consumes it
+	var c cache.Cache = mem
+	ctx := context.Background()
+
+	if err := c.SetMessageLink(ctx, "token", 42, 999); err != nil {
+		t.Fatalf("SetMessageLink failed: %v", err)
+	}
+
+	userID, err := c.GetMessageLink(ctx, "token", 42)
+	if err != nil {
+		t.Fatalf("GetMessageLink failed: %v", err)
+	}
+	if userID != 999 {
+		t.Errorf("Expected user ID 999, got %d", userID)
+	}
+}
+
+func TestMemory_KeysExpire(t *testing.T) {
+	mem, err := cache.NewMemory(1 * time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer mem.Close()
+
+	ctx := context.Background()
+	if err := mem.SetMessageLink(ctx, "token", 42, 999); err != nil {
+		t.Fatalf("SetMessageLink failed: %v", err)
+	}
+
+	mem.FastForward(2 * time.Hour)
+
+	if _, err := mem.GetMessageLink(ctx, "token", 42); err == nil {
+		t.Error("Expected expired message link to be gone")
+	}
+}
+
+func TestMemory_CloseStopsServer(t *testing.T) {
+	mem, err := cache.NewMemory(48 * time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+
+	if err := mem.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := mem.Ping(context.Background()); err == nil {
+		t.Error("Expected ping to fail after Close")
+	}
+}
@@ -2,12 +2,15 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Amr-9/botforge/internal/models"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -63,12 +66,22 @@ func (r *Redis) generateKey(botToken string, adminMsgID int) string {
 	return fmt.Sprintf("msg:%s:%d", botToken, adminMsgID)
 }
 
-// SetMessageLink stores the mapping between admin message and user chat with TTL
+// SetMessageLink stores the mapping between admin message and user chat
+// using the global TTL
 func (r *Redis) SetMessageLink(ctx context.Context, botToken string, adminMsgID int, userChatID int64) error {
+	return r.SetMessageLinkWithTTL(ctx, botToken, adminMsgID, userChatID, 0)
+}
+
+// SetMessageLinkWithTTL stores the mapping with a per-bot TTL override;
+// zero or negative falls back to the global TTL
+func (r *Redis) SetMessageLinkWithTTL(ctx context.Context, botToken string, adminMsgID int, userChatID int64, ttl time.Duration) error {
 	key := r.generateKey(botToken, adminMsgID)
 	value := strconv.FormatInt(userChatID, 10)
 
-	err := r.client.Set(ctx, key, value, r.ttl).Err()
+	if ttl <= 0 {
+		ttl = r.ttl
+	}
+	err := r.client.Set(ctx, key, value, ttl).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set message link in Redis: %w", err)
 	}
@@ -170,27 +183,17 @@ func IsNil(err error) bool {
 
 // SetUserState sets a temporary state for a user (e.g. waiting for input)
 func (r *Redis) SetUserState(ctx context.Context, botToken string, userID int64, state string) error {
-	key := fmt.Sprintf("state:%s:%d", botToken, userID)
-	return r.client.Set(ctx, key, state, 5*time.Minute).Err()
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, userID, "state", state)
 }
 
 // GetUserState retrieves the current state of a user
 func (r *Redis) GetUserState(ctx context.Context, botToken string, userID int64) (string, error) {
-	key := fmt.Sprintf("state:%s:%d", botToken, userID)
-	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
-		return "", nil
-	}
-	if err != nil {
-		return "", err
-	}
-	return val, nil
+	return r.getFlowField(ctx, FlowScopeOwner, botToken, userID, "state")
 }
 
 // ClearUserState clears the user state
 func (r *Redis) ClearUserState(ctx context.Context, botToken string, userID int64) error {
-	key := fmt.Sprintf("state:%s:%d", botToken, userID)
-	return r.client.Del(ctx, key).Err()
+	return r.client.HDel(ctx, flowKey(FlowScopeOwner, botToken, userID), "state").Err()
 }
 
 // SetUserBanned caches the ban status for a user
@@ -244,6 +247,274 @@ func (r *Redis) InvalidateNotBannedCache(ctx context.Context, botToken string, u
 	return r.client.Del(ctx, key).Err()
 }
 
+// MuteUser temporarily hides a user's messages from the admin. The mute
+// expires on its own after ttl.
+func (r *Redis) MuteUser(ctx context.Context, botToken string, userChatID int64, ttl time.Duration) error {
+	key := fmt.Sprintf("mute:%s:%d", botToken, userChatID)
+	return r.client.Set(ctx, key, "1", ttl).Err()
+}
+
+// IsUserMuted checks whether a user is currently muted
+func (r *Redis) IsUserMuted(ctx context.Context, botToken string, userChatID int64) (bool, error) {
+	key := fmt.Sprintf("mute:%s:%d", botToken, userChatID)
+	_, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnmuteUser lifts a mute before it expires
+func (r *Redis) UnmuteUser(ctx context.Context, botToken string, userChatID int64) error {
+	key := fmt.Sprintf("mute:%s:%d", botToken, userChatID)
+	return r.client.Del(ctx, key).Err()
+}
+
+// GetMuteTTL returns how long a user's mute has left, or 0 when the
+// user is not muted
+func (r *Redis) GetMuteTTL(ctx context.Context, botToken string, userChatID int64) (time.Duration, error) {
+	key := fmt.Sprintf("mute:%s:%d", botToken, userChatID)
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil // Key missing or without expiry
+	}
+	return ttl, nil
+}
+
+// UserMessageContext bundles the per-user flags consulted on every
+// inbound message, loaded with a single round trip instead of several
+// sequential GETs
+type UserMessageContext struct {
+	Banned      bool // positive ban flag cached
+	NotBanned   bool // negative ban flag cached
+	Muted       bool
+	SubVerified bool // forced-sub membership recently verified
+	HasSession  bool
+}
+
+// GetUserMessageContext fetches all per-user message flags with one MGET.
+// On error it returns the zero value, which degrades every check to its
+// existing DB or API fallback.
+func (r *Redis) GetUserMessageContext(ctx context.Context, botToken string, userChatID int64) (UserMessageContext, error) {
+	keys := []string{
+		fmt.Sprintf("ban:%s:%d", botToken, userChatID),
+		fmt.Sprintf("notban:%s:%d", botToken, userChatID),
+		fmt.Sprintf("mute:%s:%d", botToken, userChatID),
+		fmt.Sprintf("sub_verified:%s:%d", botToken, userChatID),
+		fmt.Sprintf("session:%s:%d", botToken, userChatID),
+	}
+
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return UserMessageContext{}, err
+	}
+
+	return UserMessageContext{
+		Banned:      vals[0] != nil,
+		NotBanned:   vals[1] != nil,
+		Muted:       vals[2] != nil,
+		SubVerified: vals[3] != nil,
+		HasSession:  vals[4] != nil,
+	}, nil
+}
+
+// RoutingRuleCache represents a cached keyword routing rule
+type RoutingRuleCache struct {
+	Keyword    string `json:"keyword"`
+	DestChatID int64  `json:"dest_chat_id"`
+}
+
+// ==================== Routing Rule Cache Functions ====================
+
+// SetRoutingRules caches all routing rules for a bot as one entry
+func (r *Redis) SetRoutingRules(ctx context.Context, botToken string, rules []RoutingRuleCache) error {
+	key := fmt.Sprintf("routing_rules:%s", botToken)
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, data, 1*time.Hour).Err()
+}
+
+// GetRoutingRules retrieves the cached routing rules
+// Returns: (rules, cacheHit, error)
+func (r *Redis) GetRoutingRules(ctx context.Context, botToken string) ([]RoutingRuleCache, bool, error) {
+	key := fmt.Sprintf("routing_rules:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, false, nil // Cache miss
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var rules []RoutingRuleCache
+	if err := json.Unmarshal([]byte(val), &rules); err != nil {
+		return nil, false, err
+	}
+	return rules, true, nil
+}
+
+// InvalidateRoutingRules clears the cached routing rules
+func (r *Redis) InvalidateRoutingRules(ctx context.Context, botToken string) error {
+	key := fmt.Sprintf("routing_rules:%s", botToken)
+	return r.client.Del(ctx, key).Err()
+}
+
+// ==================== Digest Accumulation Functions ====================
+
+// AddDigestMessage queues a user message for the next digest instead of
+// forwarding it immediately. Entries expire after a day as a safety net.
+func (r *Redis) AddDigestMessage(ctx context.Context, botToken string, userChatID int64, msgID int) error {
+	listKey := fmt.Sprintf("digest_msgs:%s:%d", botToken, userChatID)
+	pipe := r.client.Pipeline()
+	pipe.RPush(ctx, listKey, strconv.Itoa(msgID))
+	pipe.Expire(ctx, listKey, 24*time.Hour)
+	pipe.SAdd(ctx, fmt.Sprintf("digest_users:%s", botToken), strconv.FormatInt(userChatID, 10))
+	pipe.Expire(ctx, fmt.Sprintf("digest_users:%s", botToken), 24*time.Hour)
+	pipe.Incr(ctx, fmt.Sprintf("digest_count:%s", botToken))
+	pipe.Expire(ctx, fmt.Sprintf("digest_count:%s", botToken), 24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetDigestState returns the total queued message count and the users with
+// pending messages
+func (r *Redis) GetDigestState(ctx context.Context, botToken string) (int64, []int64, error) {
+	val, err := r.client.Get(ctx, fmt.Sprintf("digest_count:%s", botToken)).Result()
+	if err == redis.Nil {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	count, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	members, err := r.client.SMembers(ctx, fmt.Sprintf("digest_users:%s", botToken)).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	users := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		users = append(users, id)
+	}
+	return count, users, nil
+}
+
+// CountDigestMessages returns the number of queued messages for one user
+func (r *Redis) CountDigestMessages(ctx context.Context, botToken string, userChatID int64) (int64, error) {
+	return r.client.LLen(ctx, fmt.Sprintf("digest_msgs:%s:%d", botToken, userChatID)).Result()
+}
+
+// ResetDigestCount clears the total counter after a digest summary is sent.
+// The per-user queues stay until the owner expands them.
+func (r *Redis) ResetDigestCount(ctx context.Context, botToken string) error {
+	return r.client.Del(ctx, fmt.Sprintf("digest_count:%s", botToken)).Err()
+}
+
+// PopDigestMessages drains and returns the queued message IDs for one user
+func (r *Redis) PopDigestMessages(ctx context.Context, botToken string, userChatID int64) ([]int, error) {
+	listKey := fmt.Sprintf("digest_msgs:%s:%d", botToken, userChatID)
+	vals, err := r.client.LRange(ctx, listKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+	pipe.Del(ctx, listKey)
+	pipe.SRem(ctx, fmt.Sprintf("digest_users:%s", botToken), strconv.FormatInt(userChatID, 10))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	msgIDs := make([]int, 0, len(vals))
+	for _, val := range vals {
+		id, err := strconv.Atoi(val)
+		if err != nil {
+			continue
+		}
+		msgIDs = append(msgIDs, id)
+	}
+	return msgIDs, nil
+}
+
+// ==================== Spam Queue Functions ====================
+
+// AddSpamMessage queues a suspected spam message for owner review
+// instead of forwarding it to the main inbox. Entries expire after a
+// day as a safety net, same as the digest queue.
+func (r *Redis) AddSpamMessage(ctx context.Context, botToken string, userChatID int64, msgID int) error {
+	listKey := fmt.Sprintf("spam_msgs:%s:%d", botToken, userChatID)
+	pipe := r.client.Pipeline()
+	pipe.RPush(ctx, listKey, strconv.Itoa(msgID))
+	pipe.Expire(ctx, listKey, 24*time.Hour)
+	pipe.SAdd(ctx, fmt.Sprintf("spam_users:%s", botToken), strconv.FormatInt(userChatID, 10))
+	pipe.Expire(ctx, fmt.Sprintf("spam_users:%s", botToken), 24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetSpamUsers returns the users with queued spam messages
+func (r *Redis) GetSpamUsers(ctx context.Context, botToken string) ([]int64, error) {
+	members, err := r.client.SMembers(ctx, fmt.Sprintf("spam_users:%s", botToken)).Result()
+	if err != nil {
+		return nil, err
+	}
+	users := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		users = append(users, id)
+	}
+	return users, nil
+}
+
+// CountSpamMessages returns the number of queued spam messages for one user
+func (r *Redis) CountSpamMessages(ctx context.Context, botToken string, userChatID int64) (int64, error) {
+	return r.client.LLen(ctx, fmt.Sprintf("spam_msgs:%s:%d", botToken, userChatID)).Result()
+}
+
+// PopSpamMessages drains and returns the queued spam message IDs for one user
+func (r *Redis) PopSpamMessages(ctx context.Context, botToken string, userChatID int64) ([]int, error) {
+	listKey := fmt.Sprintf("spam_msgs:%s:%d", botToken, userChatID)
+	vals, err := r.client.LRange(ctx, listKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+	pipe.Del(ctx, listKey)
+	pipe.SRem(ctx, fmt.Sprintf("spam_users:%s", botToken), strconv.FormatInt(userChatID, 10))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	msgIDs := make([]int, 0, len(vals))
+	for _, val := range vals {
+		id, err := strconv.Atoi(val)
+		if err != nil {
+			continue
+		}
+		msgIDs = append(msgIDs, id)
+	}
+	return msgIDs, nil
+}
+
 // SetPendingBroadcast stores the message ID for pending broadcast confirmation
 func (r *Redis) SetPendingBroadcast(ctx context.Context, botToken string, adminID int64, msgID int) error {
 	key := fmt.Sprintf("pending_broadcast:%s:%d", botToken, adminID)
@@ -273,6 +544,235 @@ func (r *Redis) ClearPendingBroadcast(ctx context.Context, botToken string, admi
 	return r.client.Del(ctx, key).Err()
 }
 
+// ==================== Broadcast Link Click Tracking ====================
+
+// Tracked broadcasts keep their button targets and click counters in one
+// hash per broadcast so everything expires together.
+
+// SetBroadcastLinks stores the target URLs behind a broadcast's tracked
+// buttons, keyed by button index
+func (r *Redis) SetBroadcastLinks(ctx context.Context, broadcastID string, urls []string) error {
+	key := fmt.Sprintf("bclinks:%s", broadcastID)
+	fields := make([]interface{}, 0, len(urls)*2)
+	for i, u := range urls {
+		fields = append(fields, fmt.Sprintf("url:%d", i), u)
+	}
+
+	pipe := r.client.Pipeline()
+	pipe.HSet(ctx, key, fields...)
+	pipe.Expire(ctx, key, 30*24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetBroadcastLink returns the target URL behind one tracked button, or
+// "" when the broadcast is unknown or expired
+func (r *Redis) GetBroadcastLink(ctx context.Context, broadcastID string, button int) (string, error) {
+	key := fmt.Sprintf("bclinks:%s", broadcastID)
+	val, err := r.client.HGet(ctx, key, fmt.Sprintf("url:%d", button)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
+// IncrementBroadcastClick counts one click on a tracked button
+func (r *Redis) IncrementBroadcastClick(ctx context.Context, broadcastID string, button int) error {
+	key := fmt.Sprintf("bclinks:%s", broadcastID)
+	return r.client.HIncrBy(ctx, key, fmt.Sprintf("clicks:%d", button), 1).Err()
+}
+
+// GetBroadcastClicks returns click counts per button index. Buttons that
+// were never clicked have no entry.
+func (r *Redis) GetBroadcastClicks(ctx context.Context, broadcastID string) (map[int]int64, error) {
+	key := fmt.Sprintf("bclinks:%s", broadcastID)
+	vals, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	clicks := make(map[int]int64)
+	for field, val := range vals {
+		if !strings.HasPrefix(field, "clicks:") {
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(field, "clicks:"))
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			continue
+		}
+		clicks[idx] = n
+	}
+	return clicks, nil
+}
+
+// ==================== Broadcast Progress ====================
+
+// BroadcastProgress is the persisted state of a broadcast in flight.
+// It outlives the process so an interrupted broadcast can be reported
+// to its owner after a restart.
+type BroadcastProgress struct {
+	BotToken    string
+	OwnerChatID int64
+	Sent        int
+	Total       int
+}
+
+// SetBroadcastProgress records how far a running broadcast has come.
+// The TTL covers broadcasts that die without cleanup ever happening.
+func (r *Redis) SetBroadcastProgress(ctx context.Context, p *BroadcastProgress) error {
+	key := fmt.Sprintf("bjob:%s", p.BotToken)
+	pipe := r.client.Pipeline()
+	pipe.HSet(ctx, key, "owner", p.OwnerChatID, "sent", p.Sent, "total", p.Total)
+	pipe.Expire(ctx, key, 24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ClearBroadcastProgress removes the job state once a broadcast finishes
+func (r *Redis) ClearBroadcastProgress(ctx context.Context, botToken string) error {
+	return r.client.Del(ctx, fmt.Sprintf("bjob:%s", botToken)).Err()
+}
+
+// GetInterruptedBroadcasts returns the job state of every broadcast that
+// never cleaned up after itself — after a restart these are the ones a
+// shutdown or crash cut short
+func (r *Redis) GetInterruptedBroadcasts(ctx context.Context) ([]*BroadcastProgress, error) {
+	keys, err := r.client.Keys(ctx, "bjob:*").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*BroadcastProgress
+	for _, key := range keys {
+		fields, err := r.client.HGetAll(ctx, key).Result()
+		if err != nil || len(fields) == 0 {
+			continue
+		}
+		owner, _ := strconv.ParseInt(fields["owner"], 10, 64)
+		sent, _ := strconv.Atoi(fields["sent"])
+		total, _ := strconv.Atoi(fields["total"])
+		jobs = append(jobs, &BroadcastProgress{
+			BotToken:    strings.TrimPrefix(key, "bjob:"),
+			OwnerChatID: owner,
+			Sent:        sent,
+			Total:       total,
+		})
+	}
+	return jobs, nil
+}
+
+// ==================== Factory Announcement ====================
+
+// The factory admin's announcement banner is shown on every child bot's
+// admin panel. It lives only in Redis: the TTL is the publication period.
+
+// SetAnnouncement publishes the factory-wide announcement for a period
+func (r *Redis) SetAnnouncement(ctx context.Context, text string, ttl time.Duration) error {
+	return r.client.Set(ctx, "announcement", text, ttl).Err()
+}
+
+// GetAnnouncement returns the active announcement, or "" when none is
+// published or it expired
+func (r *Redis) GetAnnouncement(ctx context.Context) (string, error) {
+	val, err := r.client.Get(ctx, "announcement").Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
+// ClearAnnouncement takes the announcement down before its period ends
+func (r *Redis) ClearAnnouncement(ctx context.Context) error {
+	return r.client.Del(ctx, "announcement").Err()
+}
+
+// ==================== Feature Flags ====================
+
+// Feature flag overrides live in two hashes: "features" holds the
+// deployment-wide values and "features:bot:<id>" per-bot overrides.
+// A flag absent from both falls back to its compiled-in default.
+
+// SetFeatureFlag sets a deployment-wide feature flag override
+func (r *Redis) SetFeatureFlag(ctx context.Context, name string, enabled bool) error {
+	return r.client.HSet(ctx, "features", name, boolField(enabled)).Err()
+}
+
+// GetFeatureFlag retrieves a deployment-wide feature flag override
+// Returns: (enabled, set, error)
+func (r *Redis) GetFeatureFlag(ctx context.Context, name string) (bool, bool, error) {
+	val, err := r.client.HGet(ctx, "features", name).Result()
+	if err == redis.Nil {
+		return false, false, nil // No override
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// ClearFeatureFlag removes a deployment-wide override, returning the
+// flag to its compiled-in default
+func (r *Redis) ClearFeatureFlag(ctx context.Context, name string) error {
+	return r.client.HDel(ctx, "features", name).Err()
+}
+
+// GetFeatureFlags returns all deployment-wide overrides for the admin listing
+func (r *Redis) GetFeatureFlags(ctx context.Context) (map[string]bool, error) {
+	fields, err := r.client.HGetAll(ctx, "features").Result()
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]bool, len(fields))
+	for name, val := range fields {
+		flags[name] = val == "1"
+	}
+	return flags, nil
+}
+
+// SetBotFeatureFlag sets a per-bot feature flag override
+func (r *Redis) SetBotFeatureFlag(ctx context.Context, botID int64, name string, enabled bool) error {
+	key := fmt.Sprintf("features:bot:%d", botID)
+	return r.client.HSet(ctx, key, name, boolField(enabled)).Err()
+}
+
+// GetBotFeatureFlag retrieves a per-bot feature flag override
+// Returns: (enabled, set, error)
+func (r *Redis) GetBotFeatureFlag(ctx context.Context, botID int64, name string) (bool, bool, error) {
+	key := fmt.Sprintf("features:bot:%d", botID)
+	val, err := r.client.HGet(ctx, key, name).Result()
+	if err == redis.Nil {
+		return false, false, nil // No override
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// ClearBotFeatureFlag removes a per-bot override
+func (r *Redis) ClearBotFeatureFlag(ctx context.Context, botID int64, name string) error {
+	key := fmt.Sprintf("features:bot:%d", botID)
+	return r.client.HDel(ctx, key, name).Err()
+}
+
+// boolField encodes a bool the way the flag hashes store it
+func boolField(enabled bool) string {
+	if enabled {
+		return "1"
+	}
+	return "0"
+}
+
 // ==================== Auto-Reply Cache Functions ====================
 
 // SetAutoReply caches an auto-reply response
@@ -399,45 +899,94 @@ func (r *Redis) GetAllAutoRepliesWithMedia(ctx context.Context, botToken, trigge
 	return result, nil
 }
 
-// ==================== Temp Data Cache Functions ====================
-
-// SetTempData stores temporary data during multi-step flows
-func (r *Redis) SetTempData(ctx context.Context, botToken string, userID int64, key, value string) error {
-	redisKey := fmt.Sprintf("temp:%s:%d:%s", botToken, userID, key)
-	return r.client.Set(ctx, redisKey, value, 10*time.Minute).Err()
+// SetQuickReplies caches the quick-reply keyboard button labels for a bot.
+// An empty slice is cached too, so bots without a keyboard don't hit the
+// database on every /start.
+func (r *Redis) SetQuickReplies(ctx context.Context, botToken string, triggers []string) error {
+	key := fmt.Sprintf("setting:quick_replies:%s", botToken)
+	return r.client.Set(ctx, key, strings.Join(triggers, "\n"), 1*time.Hour).Err()
 }
 
-// GetTempData retrieves temporary data
-func (r *Redis) GetTempData(ctx context.Context, botToken string, userID int64, key string) (string, error) {
-	redisKey := fmt.Sprintf("temp:%s:%d:%s", botToken, userID, key)
-	val, err := r.client.Get(ctx, redisKey).Result()
+// GetQuickReplies retrieves the cached quick-reply button labels
+// Returns: (triggers, cacheHit, error)
+func (r *Redis) GetQuickReplies(ctx context.Context, botToken string) ([]string, bool, error) {
+	key := fmt.Sprintf("setting:quick_replies:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", nil
+		return nil, false, nil // Cache miss
 	}
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
-	return val, nil
+	if val == "" {
+		return nil, true, nil // Cached "no keyboard"
+	}
+	return strings.Split(val, "\n"), true, nil
 }
 
-// ClearTempData removes temporary data
-func (r *Redis) ClearTempData(ctx context.Context, botToken string, userID int64, key string) error {
-	redisKey := fmt.Sprintf("temp:%s:%d:%s", botToken, userID, key)
-	return r.client.Del(ctx, redisKey).Err()
+// InvalidateQuickReplies clears the cached quick-reply keyboard
+func (r *Redis) InvalidateQuickReplies(ctx context.Context, botToken string) error {
+	key := fmt.Sprintf("setting:quick_replies:%s", botToken)
+	return r.client.Del(ctx, key).Err()
 }
 
-// ==================== Scheduled Messages Cache Functions ====================
+// ==================== Flow State Functions ====================
+
+// All state for one user's multi-step flow (the state machine position,
+// temp data collected along the way, and the schedule wizard fields)
+// lives in a single hash with a single TTL, so the pieces of a flow can
+// never expire out of sync and produce "Session expired" mid-flow.
+// Hashes are additionally namespaced by role, see FlowScope.
+
+// flowTTL is the idle lifetime of a multi-step flow; every write
+// refreshes it for the whole hash
+const flowTTL = 15 * time.Minute
+
+// FlowScope separates the owner-flow and user-flow namespaces. The same
+// account can own one conversation and be a plain user in another, so a
+// bare token+user key would let an admin wizard and a user-side flow
+// clobber each other's state.
+type FlowScope string
+
+const (
+	// FlowScopeOwner holds admin-side flows: broadcast, schedule
+	// wizard, add-reply and the settings text inputs
+	FlowScopeOwner FlowScope = "owner"
+	// FlowScopeUser holds flows driven by regular users of a bot
+	FlowScopeUser FlowScope = "user"
+)
 
-// SetScheduleState sets the schedule creation state for an admin
-func (r *Redis) SetScheduleState(ctx context.Context, botToken string, adminID int64, state string) error {
-	key := fmt.Sprintf("schedule_state:%s:%d", botToken, adminID)
-	return r.client.Set(ctx, key, state, 15*time.Minute).Err()
+// setFlowFieldsScript writes hash fields and refreshes the TTL in one
+// atomic step. ARGV[1] is the TTL in milliseconds, followed by
+// field/value pairs.
+var setFlowFieldsScript = redis.NewScript(`
+	for i = 2, #ARGV, 2 do
+		redis.call("HSET", KEYS[1], ARGV[i], ARGV[i+1])
+	end
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	return 1
+`)
+
+// flowKey returns the hash key holding one user's flow state in a scope
+func flowKey(scope FlowScope, botToken string, userID int64) string {
+	return fmt.Sprintf("flow:%s:%s:%d", scope, botToken, userID)
 }
 
-// GetScheduleState gets the current schedule state for an admin
-func (r *Redis) GetScheduleState(ctx context.Context, botToken string, adminID int64) (string, error) {
-	key := fmt.Sprintf("schedule_state:%s:%d", botToken, adminID)
-	val, err := r.client.Get(ctx, key).Result()
+// setFlowFields atomically writes field/value pairs to the flow hash and
+// refreshes its TTL
+func (r *Redis) setFlowFields(ctx context.Context, scope FlowScope, botToken string, userID int64, fieldValues ...string) error {
+	args := make([]interface{}, 0, len(fieldValues)+1)
+	args = append(args, flowTTL.Milliseconds())
+	for _, fv := range fieldValues {
+		args = append(args, fv)
+	}
+	return setFlowFieldsScript.Run(ctx, r.client, []string{flowKey(scope, botToken, userID)}, args...).Err()
+}
+
+// getFlowField reads one field of the flow hash; a missing field or hash
+// reads as ""
+func (r *Redis) getFlowField(ctx context.Context, scope FlowScope, botToken string, userID int64, field string) (string, error) {
+	val, err := r.client.HGet(ctx, flowKey(scope, botToken, userID), field).Result()
 	if err == redis.Nil {
 		return "", nil
 	}
@@ -447,89 +996,136 @@ func (r *Redis) GetScheduleState(ctx context.Context, botToken string, adminID i
 	return val, nil
 }
 
-// SetScheduleMessageData stores temporary message data during schedule creation
-func (r *Redis) SetScheduleMessageData(ctx context.Context, botToken string, adminID int64, msgType, text, fileID, caption string) error {
-	pipe := r.client.Pipeline()
-
-	pipe.Set(ctx, fmt.Sprintf("schedule_msg_type:%s:%d", botToken, adminID), msgType, 15*time.Minute)
-	pipe.Set(ctx, fmt.Sprintf("schedule_msg_text:%s:%d", botToken, adminID), text, 15*time.Minute)
-	pipe.Set(ctx, fmt.Sprintf("schedule_file_id:%s:%d", botToken, adminID), fileID, 15*time.Minute)
-	pipe.Set(ctx, fmt.Sprintf("schedule_caption:%s:%d", botToken, adminID), caption, 15*time.Minute)
+// ClearFlowScope drops every flow field one user holds in one scope.
+// Used by /start to recover from a stuck flow without touching the state
+// the same account may hold in the other role.
+func (r *Redis) ClearFlowScope(ctx context.Context, scope FlowScope, botToken string, userID int64) error {
+	return r.client.Del(ctx, flowKey(scope, botToken, userID)).Err()
+}
 
-	_, err := pipe.Exec(ctx)
-	return err
+// SetTempData stores temporary data during multi-step flows
+func (r *Redis) SetTempData(ctx context.Context, botToken string, userID int64, key, value string) error {
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, userID, "temp:"+key, value)
 }
 
-// GetScheduleMessageData retrieves temporary message data
-func (r *Redis) GetScheduleMessageData(ctx context.Context, botToken string, adminID int64) (msgType, text, fileID, caption string, err error) {
-	pipe := r.client.Pipeline()
+// GetTempData retrieves temporary data
+func (r *Redis) GetTempData(ctx context.Context, botToken string, userID int64, key string) (string, error) {
+	return r.getFlowField(ctx, FlowScopeOwner, botToken, userID, "temp:"+key)
+}
 
-	typeCmd := pipe.Get(ctx, fmt.Sprintf("schedule_msg_type:%s:%d", botToken, adminID))
-	textCmd := pipe.Get(ctx, fmt.Sprintf("schedule_msg_text:%s:%d", botToken, adminID))
-	fileCmd := pipe.Get(ctx, fmt.Sprintf("schedule_file_id:%s:%d", botToken, adminID))
-	captionCmd := pipe.Get(ctx, fmt.Sprintf("schedule_caption:%s:%d", botToken, adminID))
+// ClearTempData removes temporary data
+func (r *Redis) ClearTempData(ctx context.Context, botToken string, userID int64, key string) error {
+	return r.client.HDel(ctx, flowKey(FlowScopeOwner, botToken, userID), "temp:"+key).Err()
+}
 
-	_, err = pipe.Exec(ctx)
-	if err != nil && err != redis.Nil {
+// SetFlowDraft stores a flow's typed draft struct as JSON in the flow hash
+func (r *Redis) SetFlowDraft(ctx context.Context, botToken string, userID int64, draft interface{}) error {
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return fmt.Errorf("failed to marshal flow draft: %w", err)
+	}
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, userID, "draft", string(data))
+}
+
+// GetFlowDraft loads the stored draft into the given struct; false means
+// no draft is stored (the flow expired or was never started)
+func (r *Redis) GetFlowDraft(ctx context.Context, botToken string, userID int64, draft interface{}) (bool, error) {
+	val, err := r.getFlowField(ctx, FlowScopeOwner, botToken, userID, "draft")
+	if err != nil || val == "" {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(val), draft); err != nil {
+		return false, fmt.Errorf("failed to unmarshal flow draft: %w", err)
+	}
+	return true, nil
+}
+
+// ClearFlowDraft removes the stored draft
+func (r *Redis) ClearFlowDraft(ctx context.Context, botToken string, userID int64) error {
+	return r.client.HDel(ctx, flowKey(FlowScopeOwner, botToken, userID), "draft").Err()
+}
+
+// ==================== Scheduled Messages Cache Functions ====================
+
+// SetScheduleState sets the schedule creation state for an admin
+func (r *Redis) SetScheduleState(ctx context.Context, botToken string, adminID int64, state string) error {
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, adminID, "schedule_state", state)
+}
+
+// GetScheduleState gets the current schedule state for an admin
+func (r *Redis) GetScheduleState(ctx context.Context, botToken string, adminID int64) (string, error) {
+	return r.getFlowField(ctx, FlowScopeOwner, botToken, adminID, "schedule_state")
+}
+
+// SetScheduleMessageData stores temporary message data during schedule creation
+func (r *Redis) SetScheduleMessageData(ctx context.Context, botToken string, adminID int64, msgType, text, fileID, caption string) error {
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, adminID,
+		"schedule_msg_type", msgType,
+		"schedule_msg_text", text,
+		"schedule_file_id", fileID,
+		"schedule_caption", caption)
+}
+
+// GetScheduleMessageData retrieves temporary message data
+func (r *Redis) GetScheduleMessageData(ctx context.Context, botToken string, adminID int64) (msgType, text, fileID, caption string, err error) {
+	vals, err := r.client.HMGet(ctx, flowKey(FlowScopeOwner, botToken, adminID),
+		"schedule_msg_type", "schedule_msg_text", "schedule_file_id", "schedule_caption").Result()
+	if err != nil {
 		return "", "", "", "", err
 	}
 
-	msgType, _ = typeCmd.Result()
-	text, _ = textCmd.Result()
-	fileID, _ = fileCmd.Result()
-	caption, _ = captionCmd.Result()
+	fields := make([]string, 4)
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			fields[i] = s
+		}
+	}
 
-	return msgType, text, fileID, caption, nil
+	return fields[0], fields[1], fields[2], fields[3], nil
 }
 
 // SetScheduleConfig stores schedule configuration (type, time, day)
 func (r *Redis) SetScheduleConfig(ctx context.Context, botToken string, adminID int64, scheduleType, scheduleTime, day string) error {
-	pipe := r.client.Pipeline()
-
-	pipe.Set(ctx, fmt.Sprintf("schedule_type:%s:%d", botToken, adminID), scheduleType, 15*time.Minute)
-	pipe.Set(ctx, fmt.Sprintf("schedule_time:%s:%d", botToken, adminID), scheduleTime, 15*time.Minute)
+	fieldValues := []string{
+		"schedule_type", scheduleType,
+		"schedule_time", scheduleTime,
+	}
 	if day != "" {
-		pipe.Set(ctx, fmt.Sprintf("schedule_day:%s:%d", botToken, adminID), day, 15*time.Minute)
+		fieldValues = append(fieldValues, "schedule_day", day)
 	}
-
-	_, err := pipe.Exec(ctx)
-	return err
+	return r.setFlowFields(ctx, FlowScopeOwner, botToken, adminID, fieldValues...)
 }
 
 // GetScheduleConfig retrieves schedule configuration
 func (r *Redis) GetScheduleConfig(ctx context.Context, botToken string, adminID int64) (scheduleType, scheduleTime, day string, err error) {
-	pipe := r.client.Pipeline()
-
-	typeCmd := pipe.Get(ctx, fmt.Sprintf("schedule_type:%s:%d", botToken, adminID))
-	timeCmd := pipe.Get(ctx, fmt.Sprintf("schedule_time:%s:%d", botToken, adminID))
-	dayCmd := pipe.Get(ctx, fmt.Sprintf("schedule_day:%s:%d", botToken, adminID))
-
-	_, err = pipe.Exec(ctx)
-	if err != nil && err != redis.Nil {
+	vals, err := r.client.HMGet(ctx, flowKey(FlowScopeOwner, botToken, adminID),
+		"schedule_type", "schedule_time", "schedule_day").Result()
+	if err != nil {
 		return "", "", "", err
 	}
 
-	scheduleType, _ = typeCmd.Result()
-	scheduleTime, _ = timeCmd.Result()
-	day, _ = dayCmd.Result()
+	fields := make([]string, 3)
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			fields[i] = s
+		}
+	}
 
-	return scheduleType, scheduleTime, day, nil
+	return fields[0], fields[1], fields[2], nil
 }
 
 // ClearScheduleData removes all schedule-related temporary data for an admin
 func (r *Redis) ClearScheduleData(ctx context.Context, botToken string, adminID int64) error {
-	keys := []string{
-		fmt.Sprintf("schedule_state:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_msg_type:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_msg_text:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_file_id:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_caption:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_type:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_time:%s:%d", botToken, adminID),
-		fmt.Sprintf("schedule_day:%s:%d", botToken, adminID),
-	}
-
-	return r.client.Del(ctx, keys...).Err()
+	return r.client.HDel(ctx, flowKey(FlowScopeOwner, botToken, adminID),
+		"schedule_state",
+		"schedule_msg_type",
+		"schedule_msg_text",
+		"schedule_file_id",
+		"schedule_caption",
+		"schedule_type",
+		"schedule_target",
+		"schedule_time",
+		"schedule_day").Err()
 }
 
 // ==================== Forced Subscription Cache Functions ====================
@@ -606,6 +1202,31 @@ func (r *Redis) ClearAllUserSubVerified(ctx context.Context, botToken string) er
 
 // ==================== Bot Settings Cache Functions ====================
 
+// SetPlainUI caches an owner's text-only menu preference. Keyed by the
+// owner, not a bot token, since it follows the owner across their bots.
+func (r *Redis) SetPlainUI(ctx context.Context, ownerChatID int64, enabled bool) error {
+	key := fmt.Sprintf("plain_ui:%d", ownerChatID)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetPlainUI retrieves the cached text-only menu preference
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetPlainUI(ctx context.Context, ownerChatID int64) (bool, bool, error) {
+	key := fmt.Sprintf("plain_ui:%d", ownerChatID)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, false, nil // Cache miss
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
 // SetShowSentConfirmation caches the ShowSentConfirmation setting for a bot
 func (r *Redis) SetShowSentConfirmation(ctx context.Context, botToken string, show bool) error {
 	key := fmt.Sprintf("setting:sent_confirm:%s", botToken)
@@ -636,6 +1257,603 @@ func (r *Redis) InvalidateShowSentConfirmation(ctx context.Context, botToken str
 	return r.client.Del(ctx, key).Err()
 }
 
+// SetConfirmationEmoji caches the emoji used for sent-confirmation reactions
+func (r *Redis) SetConfirmationEmoji(ctx context.Context, botToken string, emoji string) error {
+	key := fmt.Sprintf("setting:confirm_emoji:%s", botToken)
+	return r.client.Set(ctx, key, emoji, 1*time.Hour).Err()
+}
+
+// GetConfirmationEmoji retrieves the cached confirmation emoji
+// Returns: (emoji, cacheHit, error)
+func (r *Redis) GetConfirmationEmoji(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:confirm_emoji:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// InvalidateConfirmationEmoji clears the cached confirmation emoji
+func (r *Redis) InvalidateConfirmationEmoji(ctx context.Context, botToken string) error {
+	key := fmt.Sprintf("setting:confirm_emoji:%s", botToken)
+	return r.client.Del(ctx, key).Err()
+}
+
+// SetDigestInterval caches the digest interval (minutes, 0 = off)
+func (r *Redis) SetDigestInterval(ctx context.Context, botToken string, minutes int) error {
+	key := fmt.Sprintf("setting:digest_interval:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(minutes), 1*time.Hour).Err()
+}
+
+// GetDigestInterval retrieves the cached digest interval
+// Returns: (minutes, cacheHit, error)
+func (r *Redis) GetDigestInterval(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:digest_interval:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	minutes, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return minutes, true, nil
+}
+
+// SetDigestKeywords caches the comma-separated urgent keywords
+func (r *Redis) SetDigestKeywords(ctx context.Context, botToken string, keywords string) error {
+	key := fmt.Sprintf("setting:digest_keywords:%s", botToken)
+	return r.client.Set(ctx, key, keywords, 1*time.Hour).Err()
+}
+
+// GetDigestKeywords retrieves the cached urgent keywords
+// Returns: (keywords, cacheHit, error)
+func (r *Redis) GetDigestKeywords(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:digest_keywords:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetRelayChannel caches the relay source channel ID (0 = off)
+func (r *Redis) SetRelayChannel(ctx context.Context, botToken string, channelID int64) error {
+	key := fmt.Sprintf("setting:relay_channel:%s", botToken)
+	return r.client.Set(ctx, key, strconv.FormatInt(channelID, 10), 1*time.Hour).Err()
+}
+
+// GetRelayChannel retrieves the cached relay channel ID
+// Returns: (channelID, cacheHit, error)
+func (r *Redis) GetRelayChannel(ctx context.Context, botToken string) (int64, bool, error) {
+	key := fmt.Sprintf("setting:relay_channel:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	channelID, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return channelID, true, nil
+}
+
+// SetArchiveChat caches the archive chat ID (0 = off)
+func (r *Redis) SetArchiveChat(ctx context.Context, botToken string, chatID int64) error {
+	key := fmt.Sprintf("setting:archive_chat:%s", botToken)
+	return r.client.Set(ctx, key, strconv.FormatInt(chatID, 10), 1*time.Hour).Err()
+}
+
+// GetArchiveChat retrieves the cached archive chat ID
+// Returns: (chatID, cacheHit, error)
+func (r *Redis) GetArchiveChat(ctx context.Context, botToken string) (int64, bool, error) {
+	key := fmt.Sprintf("setting:archive_chat:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	chatID, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return chatID, true, nil
+}
+
+// SetBlockedMediaTypes caches the comma-separated blocked media types
+func (r *Redis) SetBlockedMediaTypes(ctx context.Context, botToken string, types string) error {
+	key := fmt.Sprintf("setting:blocked_media:%s", botToken)
+	return r.client.Set(ctx, key, types, 1*time.Hour).Err()
+}
+
+// GetBlockedMediaTypes retrieves the cached blocked media types
+// Returns: (types, cacheHit, error)
+func (r *Redis) GetBlockedMediaTypes(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:blocked_media:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetMaxFileSize caches the max accepted file size in MB (0 = unlimited)
+func (r *Redis) SetMaxFileSize(ctx context.Context, botToken string, sizeMB int) error {
+	key := fmt.Sprintf("setting:max_file_mb:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(sizeMB), 1*time.Hour).Err()
+}
+
+// GetMaxFileSize retrieves the cached max file size in MB
+// Returns: (sizeMB, cacheHit, error)
+func (r *Redis) GetMaxFileSize(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:max_file_mb:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	sizeMB, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return sizeMB, true, nil
+}
+
+// SetSurveyEnabled caches the satisfaction survey toggle
+func (r *Redis) SetSurveyEnabled(ctx context.Context, botToken string, enabled bool) error {
+	key := fmt.Sprintf("setting:survey_enabled:%s", botToken)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetSurveyEnabled retrieves the cached satisfaction survey toggle
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetSurveyEnabled(ctx context.Context, botToken string) (bool, bool, error) {
+	key := fmt.Sprintf("setting:survey_enabled:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, false, nil // Cache miss
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// SetMenuButtonEnabled caches the Telegram menu button toggle
+func (r *Redis) SetMenuButtonEnabled(ctx context.Context, botToken string, enabled bool) error {
+	key := fmt.Sprintf("setting:menu_button:%s", botToken)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetMenuButtonEnabled retrieves the cached menu button toggle
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetMenuButtonEnabled(ctx context.Context, botToken string) (bool, bool, error) {
+	key := fmt.Sprintf("setting:menu_button:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return true, false, nil // Cache miss - default to shown
+	}
+	if err != nil {
+		return true, false, err
+	}
+	return val == "1", true, nil
+}
+
+// SetReactionActions caches the emoji=action reaction workflow mapping
+func (r *Redis) SetReactionActions(ctx context.Context, botToken string, actions string) error {
+	key := fmt.Sprintf("setting:reaction_actions:%s", botToken)
+	return r.client.Set(ctx, key, actions, 1*time.Hour).Err()
+}
+
+// GetReactionActions retrieves the cached reaction workflow mapping
+// Returns: (actions, cacheHit, error)
+func (r *Redis) GetReactionActions(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:reaction_actions:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetWhitelistEnabled caches the whitelist-only mode toggle
+func (r *Redis) SetWhitelistEnabled(ctx context.Context, botToken string, enabled bool) error {
+	key := fmt.Sprintf("setting:whitelist_enabled:%s", botToken)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetWhitelistEnabled retrieves the cached whitelist-only mode toggle
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetWhitelistEnabled(ctx context.Context, botToken string) (bool, bool, error) {
+	key := fmt.Sprintf("setting:whitelist_enabled:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, false, nil // Cache miss
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// MarkSurveySent records that a survey prompt went to a user and reports
+// whether it was the first one inside the cooldown window, so a user is
+// asked at most once per window per conversation
+func (r *Redis) MarkSurveySent(ctx context.Context, botToken string, userChatID int64, cooldown time.Duration) (bool, error) {
+	key := fmt.Sprintf("survey_sent:%s:%d", botToken, userChatID)
+	return r.client.SetNX(ctx, key, "1", cooldown).Result()
+}
+
+// SetDedupeWindow caches the dedupe window in minutes (0 = off)
+func (r *Redis) SetDedupeWindow(ctx context.Context, botToken string, minutes int) error {
+	key := fmt.Sprintf("setting:dedupe_window:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(minutes), 1*time.Hour).Err()
+}
+
+// GetDedupeWindow retrieves the cached dedupe window in minutes
+// Returns: (minutes, cacheHit, error)
+func (r *Redis) GetDedupeWindow(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:dedupe_window:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	minutes, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return minutes, true, nil
+}
+
+// SetSLAMinutes caches the SLA alert threshold in minutes (0 = off)
+func (r *Redis) SetSLAMinutes(ctx context.Context, botToken string, minutes int) error {
+	key := fmt.Sprintf("setting:sla_minutes:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(minutes), 1*time.Hour).Err()
+}
+
+// GetSLAMinutes retrieves the cached SLA alert threshold in minutes
+// Returns: (minutes, cacheHit, error)
+func (r *Redis) GetSLAMinutes(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:sla_minutes:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	minutes, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return minutes, true, nil
+}
+
+// SetForcedSubGraceCount caches the forced sub grace message count (0 = enforce immediately)
+func (r *Redis) SetForcedSubGraceCount(ctx context.Context, botToken string, count int) error {
+	key := fmt.Sprintf("setting:fsub_grace:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(count), 1*time.Hour).Err()
+}
+
+// GetForcedSubGraceCount retrieves the cached forced sub grace message count
+// Returns: (count, cacheHit, error)
+func (r *Redis) GetForcedSubGraceCount(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:fsub_grace:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	count, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+// IsDuplicateMessage reports whether the user's previous message within the
+// window had the same text, and records the current text for the next check.
+// Only a hash of the text is stored.
+func (r *Redis) IsDuplicateMessage(ctx context.Context, botToken string, userChatID int64, text string, window time.Duration) (bool, error) {
+	key := fmt.Sprintf("lastmsg:%s:%d", botToken, userChatID)
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(text)))
+
+	prev, err := r.client.Get(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	// Record the current message either way, restarting the window
+	if err := r.client.Set(ctx, key, hash, window).Err(); err != nil {
+		return false, err
+	}
+
+	return prev == hash, nil
+}
+
+// SetFilterAction caches the action of a content filter ("off", "drop",
+// "warn" or "flag"). filterName is "profanity" or "links".
+func (r *Redis) SetFilterAction(ctx context.Context, botToken string, filterName string, action string) error {
+	key := fmt.Sprintf("setting:filter_%s:%s", filterName, botToken)
+	return r.client.Set(ctx, key, action, 1*time.Hour).Err()
+}
+
+// GetFilterAction retrieves the cached content filter action
+// Returns: (action, cacheHit, error)
+func (r *Redis) GetFilterAction(ctx context.Context, botToken string, filterName string) (string, bool, error) {
+	key := fmt.Sprintf("setting:filter_%s:%s", filterName, botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetConfirmationMode caches the confirmation mode ("react" or "text")
+func (r *Redis) SetConfirmationMode(ctx context.Context, botToken string, mode string) error {
+	key := fmt.Sprintf("setting:confirm_mode:%s", botToken)
+	return r.client.Set(ctx, key, mode, 1*time.Hour).Err()
+}
+
+// GetConfirmationMode retrieves the cached confirmation mode
+// Returns: (mode, cacheHit, error)
+func (r *Redis) GetConfirmationMode(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:confirm_mode:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetPrivacyNotice caches the bot's privacy notice text
+func (r *Redis) SetPrivacyNotice(ctx context.Context, botToken string, notice string) error {
+	key := fmt.Sprintf("setting:privacy_notice:%s", botToken)
+	return r.client.Set(ctx, key, notice, 1*time.Hour).Err()
+}
+
+// GetPrivacyNotice retrieves the cached privacy notice
+// Returns: (notice, cacheHit, error)
+func (r *Redis) GetPrivacyNotice(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:privacy_notice:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// InvalidatePrivacyNotice clears the cached privacy notice
+func (r *Redis) InvalidatePrivacyNotice(ctx context.Context, botToken string) error {
+	key := fmt.Sprintf("setting:privacy_notice:%s", botToken)
+	return r.client.Del(ctx, key).Err()
+}
+
+// SetRichHeaderEnabled caches the rich user info header toggle
+func (r *Redis) SetRichHeaderEnabled(ctx context.Context, botToken string, enabled bool) error {
+	key := fmt.Sprintf("setting:rich_header:%s", botToken)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetRichHeaderEnabled retrieves the cached rich header toggle
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetRichHeaderEnabled(ctx context.Context, botToken string) (bool, bool, error) {
+	key := fmt.Sprintf("setting:rich_header:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, false, nil // Cache miss
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// SetContentLogging caches the message content logging toggle
+func (r *Redis) SetContentLogging(ctx context.Context, botToken string, enabled bool) error {
+	key := fmt.Sprintf("setting:content_log:%s", botToken)
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	return r.client.Set(ctx, key, val, 1*time.Hour).Err()
+}
+
+// GetContentLogging retrieves the cached content logging toggle
+// Returns: (enabled, cacheHit, error)
+func (r *Redis) GetContentLogging(ctx context.Context, botToken string) (bool, bool, error) {
+	key := fmt.Sprintf("setting:content_log:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, false, nil // Cache miss
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return val == "1", true, nil
+}
+
+// SetSpamThreshold caches the spam score threshold (percent, 0 = off)
+func (r *Redis) SetSpamThreshold(ctx context.Context, botToken string, threshold int) error {
+	key := fmt.Sprintf("setting:spam_threshold:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(threshold), 1*time.Hour).Err()
+}
+
+// GetSpamThreshold retrieves the cached spam score threshold
+// Returns: (threshold, cacheHit, error)
+func (r *Redis) GetSpamThreshold(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:spam_threshold:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	threshold, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return threshold, true, nil
+}
+
+// SetMessageTTLHours caches the per-bot message link TTL (hours, 0 = global)
+func (r *Redis) SetMessageTTLHours(ctx context.Context, botToken string, hours int) error {
+	key := fmt.Sprintf("setting:msg_ttl:%s", botToken)
+	return r.client.Set(ctx, key, strconv.Itoa(hours), 1*time.Hour).Err()
+}
+
+// GetMessageTTLHours retrieves the cached per-bot message link TTL
+// Returns: (hours, cacheHit, error)
+func (r *Redis) GetMessageTTLHours(ctx context.Context, botToken string) (int, bool, error) {
+	key := fmt.Sprintf("setting:msg_ttl:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	hours, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, err
+	}
+	return hours, true, nil
+}
+
+// SetBotLocale caches the owner's display language code ("" = English)
+func (r *Redis) SetBotLocale(ctx context.Context, botToken string, locale string) error {
+	key := fmt.Sprintf("setting:locale:%s", botToken)
+	return r.client.Set(ctx, key, locale, 1*time.Hour).Err()
+}
+
+// GetBotLocale retrieves the cached display language code
+// Returns: (locale, cacheHit, error)
+func (r *Redis) GetBotLocale(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:locale:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetBotTimezone caches the owner's display timezone ("" = server time)
+func (r *Redis) SetBotTimezone(ctx context.Context, botToken string, tz string) error {
+	key := fmt.Sprintf("setting:timezone:%s", botToken)
+	return r.client.Set(ctx, key, tz, 1*time.Hour).Err()
+}
+
+// GetBotTimezone retrieves the cached display timezone
+// Returns: (timezone, cacheHit, error)
+func (r *Redis) GetBotTimezone(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:timezone:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetHeaderPolicy caches the user info header policy ("never", "gap" or "daily")
+func (r *Redis) SetHeaderPolicy(ctx context.Context, botToken string, policy string) error {
+	key := fmt.Sprintf("setting:header_policy:%s", botToken)
+	return r.client.Set(ctx, key, policy, 1*time.Hour).Err()
+}
+
+// GetHeaderPolicy retrieves the cached header policy
+// Returns: (policy, cacheHit, error)
+func (r *Redis) GetHeaderPolicy(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("setting:header_policy:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+// SetBotUsername caches a bot's username to avoid repeated getMe lookups
+func (r *Redis) SetBotUsername(ctx context.Context, botToken string, username string) error {
+	key := fmt.Sprintf("username:%s", botToken)
+	return r.client.Set(ctx, key, username, 24*time.Hour).Err()
+}
+
+// GetBotUsername retrieves the cached bot username
+// Returns: (username, cacheHit, error)
+func (r *Redis) GetBotUsername(ctx context.Context, botToken string) (string, bool, error) {
+	key := fmt.Sprintf("username:%s", botToken)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil // Cache miss
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
 // ==================== Extended Bot Settings Cache ====================
 
 // SetStartMessage caches the bot's start message
@@ -694,27 +1912,175 @@ func (r *Redis) InvalidateForwardAutoReplies(ctx context.Context, botToken strin
 	return r.client.Del(ctx, key).Err()
 }
 
+// PurgeUserKeys removes every per-user cached key for one user of a bot.
+// Called after a GDPR-style erasure so no session, ban or draft state
+// survives the deletion.
+func (r *Redis) PurgeUserKeys(ctx context.Context, botToken string, userChatID int64) error {
+	keys := []string{
+		fmt.Sprintf("session:%s:%d", botToken, userChatID),
+		fmt.Sprintf("ban:%s:%d", botToken, userChatID),
+		fmt.Sprintf("notban:%s:%d", botToken, userChatID),
+		fmt.Sprintf("mute:%s:%d", botToken, userChatID),
+		fmt.Sprintf("sub_verified:%s:%d", botToken, userChatID),
+		fmt.Sprintf("digest_msgs:%s:%d", botToken, userChatID),
+		fmt.Sprintf("lastmsg:%s:%d", botToken, userChatID),
+		fmt.Sprintf("survey_sent:%s:%d", botToken, userChatID),
+		flowKey(FlowScopeOwner, botToken, userChatID),
+		flowKey(FlowScopeUser, botToken, userChatID),
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// PurgeBotKeys removes every cached key for a bot token. Called when a bot
+// is deleted or restored so stale sessions, ban flags, and message links
+// from a previous life of the token cannot leak into the new one.
+func (r *Redis) PurgeBotKeys(ctx context.Context, botToken string) error {
+	patterns := []string{
+		fmt.Sprintf("msg:%s:*", botToken),
+		fmt.Sprintf("session:%s:*", botToken),
+		fmt.Sprintf("ban:%s:*", botToken),
+		fmt.Sprintf("notban:%s:*", botToken),
+		fmt.Sprintf("mute:%s:*", botToken),
+		fmt.Sprintf("flow:%s:%s:*", FlowScopeOwner, botToken),
+		fmt.Sprintf("flow:%s:%s:*", FlowScopeUser, botToken),
+		fmt.Sprintf("autoreply:%s:*", botToken),
+		fmt.Sprintf("sub_verified:%s:*", botToken),
+		fmt.Sprintf("broadcast_mode:%s:*", botToken),
+		fmt.Sprintf("pending_broadcast:%s:*", botToken),
+		fmt.Sprintf("digest_msgs:%s:*", botToken),
+		fmt.Sprintf("lastmsg:%s:*", botToken),
+		fmt.Sprintf("survey_sent:%s:*", botToken),
+		fmt.Sprintf("spam_msgs:%s:*", botToken),
+	}
+
+	for _, pattern := range patterns {
+		keys, err := r.client.Keys(ctx, pattern).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Single-valued keys that have no user component
+	fixed := []string{
+		fmt.Sprintf("setting:start_msg:%s", botToken),
+		fmt.Sprintf("setting:forward_replies:%s", botToken),
+		fmt.Sprintf("setting:sent_confirm:%s", botToken),
+		fmt.Sprintf("setting:confirm_emoji:%s", botToken),
+		fmt.Sprintf("setting:confirm_mode:%s", botToken),
+		fmt.Sprintf("setting:digest_interval:%s", botToken),
+		fmt.Sprintf("setting:digest_keywords:%s", botToken),
+		fmt.Sprintf("setting:relay_channel:%s", botToken),
+		fmt.Sprintf("setting:archive_chat:%s", botToken),
+		fmt.Sprintf("setting:blocked_media:%s", botToken),
+		fmt.Sprintf("setting:max_file_mb:%s", botToken),
+		fmt.Sprintf("setting:filter_profanity:%s", botToken),
+		fmt.Sprintf("setting:filter_links:%s", botToken),
+		fmt.Sprintf("setting:dedupe_window:%s", botToken),
+		fmt.Sprintf("setting:sla_minutes:%s", botToken),
+		fmt.Sprintf("setting:fsub_grace:%s", botToken),
+		fmt.Sprintf("setting:survey_enabled:%s", botToken),
+		fmt.Sprintf("setting:quick_replies:%s", botToken),
+		fmt.Sprintf("setting:menu_button:%s", botToken),
+		fmt.Sprintf("setting:header_policy:%s", botToken),
+		fmt.Sprintf("setting:rich_header:%s", botToken),
+		fmt.Sprintf("setting:privacy_notice:%s", botToken),
+		fmt.Sprintf("setting:content_log:%s", botToken),
+		fmt.Sprintf("setting:spam_threshold:%s", botToken),
+		fmt.Sprintf("setting:msg_ttl:%s", botToken),
+		fmt.Sprintf("setting:locale:%s", botToken),
+		fmt.Sprintf("setting:timezone:%s", botToken),
+		fmt.Sprintf("digest_users:%s", botToken),
+		fmt.Sprintf("digest_count:%s", botToken),
+		fmt.Sprintf("spam_users:%s", botToken),
+		fmt.Sprintf("routing_rules:%s", botToken),
+		fmt.Sprintf("forced_sub_enabled:%s", botToken),
+		fmt.Sprintf("username:%s", botToken),
+	}
+	return r.client.Del(ctx, fixed...).Err()
+}
+
 // InvalidateAllBotSettings clears all cached settings for a bot
 func (r *Redis) InvalidateAllBotSettings(ctx context.Context, botToken string) error {
 	keys := []string{
 		fmt.Sprintf("setting:start_msg:%s", botToken),
 		fmt.Sprintf("setting:forward_replies:%s", botToken),
 		fmt.Sprintf("setting:sent_confirm:%s", botToken),
+		fmt.Sprintf("setting:confirm_emoji:%s", botToken),
+		fmt.Sprintf("setting:confirm_mode:%s", botToken),
+		fmt.Sprintf("setting:digest_interval:%s", botToken),
+		fmt.Sprintf("setting:digest_keywords:%s", botToken),
+		fmt.Sprintf("setting:relay_channel:%s", botToken),
+		fmt.Sprintf("setting:archive_chat:%s", botToken),
+		fmt.Sprintf("setting:blocked_media:%s", botToken),
+		fmt.Sprintf("setting:max_file_mb:%s", botToken),
+		fmt.Sprintf("setting:filter_profanity:%s", botToken),
+		fmt.Sprintf("setting:filter_links:%s", botToken),
+		fmt.Sprintf("setting:dedupe_window:%s", botToken),
+		fmt.Sprintf("setting:sla_minutes:%s", botToken),
+		fmt.Sprintf("setting:fsub_grace:%s", botToken),
+		fmt.Sprintf("setting:survey_enabled:%s", botToken),
+		fmt.Sprintf("setting:quick_replies:%s", botToken),
+		fmt.Sprintf("setting:menu_button:%s", botToken),
+		fmt.Sprintf("setting:header_policy:%s", botToken),
+		fmt.Sprintf("setting:rich_header:%s", botToken),
+		fmt.Sprintf("setting:privacy_notice:%s", botToken),
+		fmt.Sprintf("setting:content_log:%s", botToken),
+		fmt.Sprintf("setting:spam_threshold:%s", botToken),
+		fmt.Sprintf("setting:msg_ttl:%s", botToken),
+		fmt.Sprintf("setting:locale:%s", botToken),
+		fmt.Sprintf("setting:timezone:%s", botToken),
 		fmt.Sprintf("forced_sub_enabled:%s", botToken),
 	}
 	return r.client.Del(ctx, keys...).Err()
 }
 
 // PreloadBotSettings loads all bot settings into cache at once
-func (r *Redis) PreloadBotSettings(ctx context.Context, botToken string, startMsg string, forwardReplies, showSentConfirm, forcedSubEnabled bool) error {
+func (r *Redis) PreloadBotSettings(ctx context.Context, botToken string, settings *models.BotRuntimeSettings) error {
 	pipe := r.client.Pipeline()
 
-	if startMsg != "" {
-		pipe.Set(ctx, fmt.Sprintf("setting:start_msg:%s", botToken), startMsg, 1*time.Hour)
+	if settings.StartMessage != "" {
+		pipe.Set(ctx, fmt.Sprintf("setting:start_msg:%s", botToken), settings.StartMessage, 1*time.Hour)
+	}
+	pipe.Set(ctx, fmt.Sprintf("setting:forward_replies:%s", botToken), boolToString(settings.ForwardAutoReplies), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:sent_confirm:%s", botToken), boolToString(settings.ShowSentConfirmation), 1*time.Hour)
+	if settings.ConfirmationEmoji != "" {
+		pipe.Set(ctx, fmt.Sprintf("setting:confirm_emoji:%s", botToken), settings.ConfirmationEmoji, 1*time.Hour)
+	}
+	if settings.ConfirmationMode != "" {
+		pipe.Set(ctx, fmt.Sprintf("setting:confirm_mode:%s", botToken), settings.ConfirmationMode, 1*time.Hour)
+	}
+	pipe.Set(ctx, fmt.Sprintf("setting:digest_interval:%s", botToken), strconv.Itoa(settings.DigestIntervalMinutes), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:digest_keywords:%s", botToken), settings.DigestUrgentKeywords, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:relay_channel:%s", botToken), strconv.FormatInt(settings.RelayChannelID, 10), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:archive_chat:%s", botToken), strconv.FormatInt(settings.ArchiveChatID, 10), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:blocked_media:%s", botToken), settings.BlockedMediaTypes, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:max_file_mb:%s", botToken), strconv.Itoa(settings.MaxFileSizeMB), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:filter_profanity:%s", botToken), settings.FilterProfanityAction, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:filter_links:%s", botToken), settings.FilterLinksAction, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:dedupe_window:%s", botToken), strconv.Itoa(settings.DedupeWindowMinutes), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:sla_minutes:%s", botToken), strconv.Itoa(settings.SLAMinutes), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:fsub_grace:%s", botToken), strconv.Itoa(settings.ForcedSubGraceCount), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:survey_enabled:%s", botToken), boolToString(settings.SurveyEnabled), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:menu_button:%s", botToken), boolToString(settings.MenuButtonEnabled), 1*time.Hour)
+	if settings.HeaderPolicy != "" {
+		pipe.Set(ctx, fmt.Sprintf("setting:header_policy:%s", botToken), settings.HeaderPolicy, 1*time.Hour)
+	}
+	pipe.Set(ctx, fmt.Sprintf("setting:rich_header:%s", botToken), boolToString(settings.RichHeaderEnabled), 1*time.Hour)
+	if settings.PrivacyNotice != "" {
+		pipe.Set(ctx, fmt.Sprintf("setting:privacy_notice:%s", botToken), settings.PrivacyNotice, 1*time.Hour)
 	}
-	pipe.Set(ctx, fmt.Sprintf("setting:forward_replies:%s", botToken), boolToString(forwardReplies), 1*time.Hour)
-	pipe.Set(ctx, fmt.Sprintf("setting:sent_confirm:%s", botToken), boolToString(showSentConfirm), 1*time.Hour)
-	pipe.Set(ctx, fmt.Sprintf("forced_sub_enabled:%s", botToken), boolToString(forcedSubEnabled), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:content_log:%s", botToken), boolToString(settings.ContentLoggingEnabled), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:spam_threshold:%s", botToken), strconv.Itoa(settings.SpamThreshold), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:msg_ttl:%s", botToken), strconv.Itoa(settings.MessageTTLHours), 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:locale:%s", botToken), settings.Locale, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:timezone:%s", botToken), settings.Timezone, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("setting:reaction_actions:%s", botToken), settings.ReactionActions, 1*time.Hour)
+	pipe.Set(ctx, fmt.Sprintf("forced_sub_enabled:%s", botToken), boolToString(settings.ForcedSubEnabled), 1*time.Hour)
 
 	_, err := pipe.Exec(ctx)
 	return err
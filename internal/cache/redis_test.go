@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/models"
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 )
@@ -222,6 +223,56 @@ func TestBroadcastMode_SetGetClear(t *testing.T) {
 	}
 }
 
+// ==================== Announcement Tests ====================
+
+func TestAnnouncement_SetGetClear(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+
+	// No announcement initially
+	text, err := r.GetAnnouncement(ctx)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if text != "" {
+		t.Errorf("Expected no announcement, got %q", text)
+	}
+
+	// Publish
+	err = r.SetAnnouncement(ctx, "Maintenance on Sunday", 48*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set announcement: %v", err)
+	}
+
+	text, err = r.GetAnnouncement(ctx)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if text != "Maintenance on Sunday" {
+		t.Errorf("Expected announcement text, got %q", text)
+	}
+
+	// Expires with its publication period
+	mr.FastForward(49 * time.Hour)
+	text, _ = r.GetAnnouncement(ctx)
+	if text != "" {
+		t.Errorf("Expected announcement to expire, got %q", text)
+	}
+
+	// Clear takes it down early
+	r.SetAnnouncement(ctx, "Second notice", time.Hour)
+	if err := r.ClearAnnouncement(ctx); err != nil {
+		t.Fatalf("Failed to clear: %v", err)
+	}
+	text, _ = r.GetAnnouncement(ctx)
+	if text != "" {
+		t.Errorf("Expected no announcement after clear, got %q", text)
+	}
+}
+
 // ==================== User State Tests ====================
 
 func TestUserState_SetGetClear(t *testing.T) {
@@ -513,6 +564,52 @@ func TestTempData_SetGetClear(t *testing.T) {
 	}
 }
 
+func TestFlowState_SharedTTL(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+	userID := int64(666)
+
+	// State and temp data share one lifetime
+	if err := r.SetUserState(ctx, botToken, userID, "add_auto_reply_response"); err != nil {
+		t.Fatalf("Failed to set state: %v", err)
+	}
+	if err := r.SetTempData(ctx, botToken, userID, "trigger", "price"); err != nil {
+		t.Fatalf("Failed to set temp data: %v", err)
+	}
+
+	// Each write refreshes the whole flow, so after 10 minutes both the
+	// state and the trigger set at the start are still alive
+	mr.FastForward(10 * time.Minute)
+	if err := r.SetTempData(ctx, botToken, userID, "other", "x"); err != nil {
+		t.Fatalf("Failed to refresh flow: %v", err)
+	}
+	mr.FastForward(10 * time.Minute)
+
+	state, err := r.GetUserState(ctx, botToken, userID)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if state != "add_auto_reply_response" {
+		t.Errorf("Expected state to survive refresh, got %q", state)
+	}
+	trigger, _ := r.GetTempData(ctx, botToken, userID, "trigger")
+	if trigger != "price" {
+		t.Errorf("Expected temp data to survive refresh, got %q", trigger)
+	}
+
+	// With no further writes everything expires together
+	mr.FastForward(16 * time.Minute)
+	state, _ = r.GetUserState(ctx, botToken, userID)
+	trigger, _ = r.GetTempData(ctx, botToken, userID, "trigger")
+	if state != "" || trigger != "" {
+		t.Errorf("Expected whole flow to expire, got state=%q trigger=%q", state, trigger)
+	}
+}
+
 // ==================== Schedule State Tests ====================
 
 func TestScheduleState_SetAndGet(t *testing.T) {
@@ -731,6 +828,319 @@ func TestStartMessage_SetAndGet(t *testing.T) {
 	}
 }
 
+func TestConfirmationEmoji_SetAndGet(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// Set
+	err := r.SetConfirmationEmoji(ctx, botToken, "🔥")
+	if err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	// Get
+	emoji, cacheHit, err := r.GetConfirmationEmoji(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !cacheHit {
+		t.Error("Expected cache hit")
+	}
+	if emoji != "🔥" {
+		t.Errorf("Expected '🔥', got '%s'", emoji)
+	}
+}
+
+func TestMediaPolicy_SetAndGet(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// Set
+	if err := r.SetBlockedMediaTypes(ctx, botToken, "sticker,document"); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+	if err := r.SetMaxFileSize(ctx, botToken, 20); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	// Get
+	blocked, cacheHit, err := r.GetBlockedMediaTypes(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !cacheHit {
+		t.Error("Expected cache hit")
+	}
+	if blocked != "sticker,document" {
+		t.Errorf("Expected 'sticker,document', got '%s'", blocked)
+	}
+
+	sizeMB, cacheHit, err := r.GetMaxFileSize(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !cacheHit {
+		t.Error("Expected cache hit")
+	}
+	if sizeMB != 20 {
+		t.Errorf("Expected 20, got %d", sizeMB)
+	}
+}
+
+func TestFilterAction_SetAndGet(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// Miss before set
+	_, cacheHit, err := r.GetFilterAction(ctx, botToken, "profanity")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if cacheHit {
+		t.Error("Expected cache miss")
+	}
+
+	// Set
+	if err := r.SetFilterAction(ctx, botToken, "profanity", "warn"); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	// Get
+	action, cacheHit, err := r.GetFilterAction(ctx, botToken, "profanity")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !cacheHit {
+		t.Error("Expected cache hit")
+	}
+	if action != "warn" {
+		t.Errorf("Expected 'warn', got '%s'", action)
+	}
+
+	// The other filter has its own key
+	_, cacheHit, err = r.GetFilterAction(ctx, botToken, "links")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if cacheHit {
+		t.Error("Expected cache miss for links filter")
+	}
+}
+
+func TestGetUserMessageContext(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// No flags set - everything false
+	umc, err := r.GetUserMessageContext(ctx, botToken, 111)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if umc.Banned || umc.NotBanned || umc.Muted || umc.SubVerified || umc.HasSession {
+		t.Errorf("Expected zero-value context, got %+v", umc)
+	}
+
+	// Set a few flags through the normal setters
+	if err := r.SetUserBanned(ctx, botToken, 111); err != nil {
+		t.Fatalf("Error setting ban: %v", err)
+	}
+	if err := r.MuteUser(ctx, botToken, 111, time.Hour); err != nil {
+		t.Fatalf("Error muting: %v", err)
+	}
+	if err := r.SetSession(ctx, botToken, 111, time.Hour); err != nil {
+		t.Fatalf("Error setting session: %v", err)
+	}
+
+	umc, err = r.GetUserMessageContext(ctx, botToken, 111)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !umc.Banned || !umc.Muted || !umc.HasSession {
+		t.Errorf("Expected banned/muted/session flags set, got %+v", umc)
+	}
+	if umc.NotBanned || umc.SubVerified {
+		t.Errorf("Expected not-banned and sub-verified unset, got %+v", umc)
+	}
+
+	// Flags are per user
+	umc, err = r.GetUserMessageContext(ctx, botToken, 222)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if umc.Banned || umc.Muted || umc.HasSession {
+		t.Errorf("Expected clean context for other user, got %+v", umc)
+	}
+}
+
+func TestQuickReplies_SetAndGet(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// Cache miss initially
+	_, hit, err := r.GetQuickReplies(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if hit {
+		t.Error("Expected cache miss before set")
+	}
+
+	// Set and get
+	if err := r.SetQuickReplies(ctx, botToken, []string{"📦 Order status", "💬 Support"}); err != nil {
+		t.Fatalf("Error setting quick replies: %v", err)
+	}
+
+	triggers, hit, err := r.GetQuickReplies(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !hit {
+		t.Error("Expected cache hit after set")
+	}
+	if len(triggers) != 2 || triggers[0] != "📦 Order status" {
+		t.Errorf("Unexpected triggers: %v", triggers)
+	}
+
+	// Empty list is a cache hit with no keyboard
+	if err := r.SetQuickReplies(ctx, botToken, nil); err != nil {
+		t.Fatalf("Error setting empty quick replies: %v", err)
+	}
+	triggers, hit, err = r.GetQuickReplies(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !hit {
+		t.Error("Expected cache hit for empty list")
+	}
+	if len(triggers) != 0 {
+		t.Errorf("Expected no triggers, got %v", triggers)
+	}
+
+	// Invalidation brings back the miss
+	if err := r.InvalidateQuickReplies(ctx, botToken); err != nil {
+		t.Fatalf("Error invalidating: %v", err)
+	}
+	_, hit, err = r.GetQuickReplies(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if hit {
+		t.Error("Expected cache miss after invalidation")
+	}
+}
+
+func TestMarkSurveySent_Cooldown(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// First prompt goes through
+	first, err := r.MarkSurveySent(ctx, botToken, 111, time.Hour)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !first {
+		t.Error("Expected first survey prompt to be allowed")
+	}
+
+	// Second prompt inside the cooldown is suppressed
+	first, err = r.MarkSurveySent(ctx, botToken, 111, time.Hour)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if first {
+		t.Error("Expected repeat prompt to be suppressed")
+	}
+
+	// After the cooldown a new prompt is allowed
+	mr.FastForward(2 * time.Hour)
+	first, err = r.MarkSurveySent(ctx, botToken, 111, time.Hour)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !first {
+		t.Error("Expected prompt to be allowed after the cooldown")
+	}
+}
+
+func TestIsDuplicateMessage(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	// First message is never a duplicate
+	dup, err := r.IsDuplicateMessage(ctx, botToken, 111, "hello", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if dup {
+		t.Error("First message should not be a duplicate")
+	}
+
+	// Identical repeat within the window is
+	dup, err = r.IsDuplicateMessage(ctx, botToken, 111, "hello", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !dup {
+		t.Error("Identical repeat should be a duplicate")
+	}
+
+	// Different text resets the comparison
+	dup, err = r.IsDuplicateMessage(ctx, botToken, 111, "goodbye", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if dup {
+		t.Error("Different text should not be a duplicate")
+	}
+
+	// Other users are tracked independently
+	dup, err = r.IsDuplicateMessage(ctx, botToken, 222, "goodbye", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if dup {
+		t.Error("Another user's first message should not be a duplicate")
+	}
+
+	// The window expiring clears the stored message
+	mr.FastForward(6 * time.Minute)
+	dup, err = r.IsDuplicateMessage(ctx, botToken, 111, "goodbye", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if dup {
+		t.Error("Repeat after the window should not be a duplicate")
+	}
+}
+
 func TestPreloadBotSettings(t *testing.T) {
 	r, mr := setupTestRedis(t)
 	defer mr.Close()
@@ -740,7 +1150,12 @@ func TestPreloadBotSettings(t *testing.T) {
 	botToken := "test-bot"
 
 	// Preload all settings
-	err := r.PreloadBotSettings(ctx, botToken, "Hello!", true, false, true)
+	err := r.PreloadBotSettings(ctx, botToken, &models.BotRuntimeSettings{
+		StartMessage:         "Hello!",
+		ForwardAutoReplies:   true,
+		ShowSentConfirmation: false,
+		ForcedSubEnabled:     true,
+	})
 	if err != nil {
 		t.Fatalf("Failed to preload: %v", err)
 	}
@@ -767,6 +1182,44 @@ func TestPreloadBotSettings(t *testing.T) {
 	}
 }
 
+func TestPurgeBotKeys(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+	otherToken := "other-bot"
+
+	// Populate keys across several namespaces for two bots
+	r.SetMessageLink(ctx, botToken, 100, 555)
+	r.SetUserBanned(ctx, botToken, 555)
+	r.SetUserState(ctx, botToken, 555, "some_state")
+	r.SetStartMessage(ctx, botToken, "Hello!")
+	r.SetMessageLink(ctx, otherToken, 200, 777)
+
+	if err := r.PurgeBotKeys(ctx, botToken); err != nil {
+		t.Fatalf("PurgeBotKeys failed: %v", err)
+	}
+
+	// All purged bot keys should be gone
+	if _, err := r.GetMessageLink(ctx, botToken, 100); !cache.IsNil(err) {
+		t.Error("Expected message link to be purged")
+	}
+	banned, cacheHit, _ := r.IsUserBanned(ctx, botToken, 555)
+	if banned || cacheHit {
+		t.Error("Expected ban flag to be purged")
+	}
+	if _, hit, _ := r.GetStartMessage(ctx, botToken); hit {
+		t.Error("Expected start message to be purged")
+	}
+
+	// Other bot's keys must survive
+	if _, err := r.GetMessageLink(ctx, otherToken, 200); err != nil {
+		t.Errorf("Other bot's message link should survive purge: %v", err)
+	}
+}
+
 func TestInvalidateAllBotSettings(t *testing.T) {
 	r, mr := setupTestRedis(t)
 	defer mr.Close()
@@ -776,7 +1229,12 @@ func TestInvalidateAllBotSettings(t *testing.T) {
 	botToken := "test-bot"
 
 	// Preload then invalidate
-	r.PreloadBotSettings(ctx, botToken, "Hello!", true, true, true)
+	r.PreloadBotSettings(ctx, botToken, &models.BotRuntimeSettings{
+		StartMessage:         "Hello!",
+		ForwardAutoReplies:   true,
+		ShowSentConfirmation: true,
+		ForcedSubEnabled:     true,
+	})
 	err := r.InvalidateAllBotSettings(ctx, botToken)
 	if err != nil {
 		t.Fatalf("Failed to invalidate: %v", err)
@@ -809,3 +1267,146 @@ func TestIsNil(t *testing.T) {
 		t.Error("IsNil should return false for other errors")
 	}
 }
+
+// ==================== Mute Cache Tests ====================
+
+func TestMuteUser_SetAndCheck(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	if err := r.MuteUser(ctx, botToken, 555, 24*time.Hour); err != nil {
+		t.Fatalf("Failed to mute user: %v", err)
+	}
+
+	muted, err := r.IsUserMuted(ctx, botToken, 555)
+	if err != nil {
+		t.Fatalf("Failed to check mute: %v", err)
+	}
+	if !muted {
+		t.Error("Expected user to be muted")
+	}
+
+	// Mute expires on its own
+	mr.FastForward(25 * time.Hour)
+	muted, _ = r.IsUserMuted(ctx, botToken, 555)
+	if muted {
+		t.Error("Expected mute to expire after TTL")
+	}
+}
+
+func TestUnmuteUser_LiftsMuteEarly(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	if err := r.MuteUser(ctx, botToken, 555, 24*time.Hour); err != nil {
+		t.Fatalf("Failed to mute user: %v", err)
+	}
+	if err := r.UnmuteUser(ctx, botToken, 555); err != nil {
+		t.Fatalf("Failed to unmute user: %v", err)
+	}
+
+	muted, err := r.IsUserMuted(ctx, botToken, 555)
+	if err != nil {
+		t.Fatalf("Failed to check mute: %v", err)
+	}
+	if muted {
+		t.Error("Expected mute to be lifted")
+	}
+}
+
+func TestGetMuteTTL(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot"
+
+	ttl, err := r.GetMuteTTL(ctx, botToken, 555)
+	if err != nil {
+		t.Fatalf("Failed to get mute TTL: %v", err)
+	}
+	if ttl != 0 {
+		t.Errorf("Expected zero TTL for unmuted user, got %s", ttl)
+	}
+
+	if err := r.MuteUser(ctx, botToken, 555, 24*time.Hour); err != nil {
+		t.Fatalf("Failed to mute user: %v", err)
+	}
+
+	ttl, err = r.GetMuteTTL(ctx, botToken, 555)
+	if err != nil {
+		t.Fatalf("Failed to get mute TTL: %v", err)
+	}
+	if ttl <= 0 || ttl > 24*time.Hour {
+		t.Errorf("Expected TTL close to 24h, got %s", ttl)
+	}
+}
+
+func TestIsUserMuted_NotMuted(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	muted, err := r.IsUserMuted(context.Background(), "test-bot", 555)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if muted {
+		t.Error("Expected user to not be muted")
+	}
+}
+
+// ==================== Bot Username Cache Tests ====================
+
+func TestBotUsername_SetAndGet(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+	botToken := "test-bot-token"
+
+	err := r.SetBotUsername(ctx, botToken, "my_support_bot")
+	if err != nil {
+		t.Fatalf("Failed to set bot username: %v", err)
+	}
+
+	username, hit, err := r.GetBotUsername(ctx, botToken)
+	if err != nil {
+		t.Fatalf("Failed to get bot username: %v", err)
+	}
+	if !hit {
+		t.Error("Expected cache hit")
+	}
+	if username != "my_support_bot" {
+		t.Errorf("Expected my_support_bot, got %s", username)
+	}
+}
+
+func TestBotUsername_Miss(t *testing.T) {
+	r, mr := setupTestRedis(t)
+	defer mr.Close()
+	defer r.Close()
+
+	ctx := context.Background()
+
+	username, hit, err := r.GetBotUsername(ctx, "non-existent")
+	if err != nil {
+		t.Fatalf("Unexpected error on cache miss: %v", err)
+	}
+	if hit {
+		t.Error("Expected cache miss")
+	}
+	if username != "" {
+		t.Errorf("Expected empty username, got %s", username)
+	}
+}
@@ -2,29 +2,61 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// FactoryAccount is one factory bot identity in a multi-factory deployment
+type FactoryAccount struct {
+	Token   string
+	AdminID int64
+}
+
 // Config holds all application configuration
 type Config struct {
 	// Factory Bot
 	FactoryBotToken string
 	AdminID         int64
 
+	// Additional factory bots (multi-brand deployments), each with its
+	// own admin, all sharing the same backend
+	ExtraFactories []FactoryAccount
+
+	// Optional JSON file mapping factory tokens to custom branding strings
+	// (welcome text, button labels). Empty means stock wording.
+	BrandingFile string
+
 	// Webhook
 	WebhookURL string
 	ServerPort string
 
+	// Listen address for the internal shard gRPC API (e.g. ":4211").
+	// Empty disables it; single-instance deployments don't need it.
+	ShardRPCAddr string
+
+	// NATS server URL for the event bus. Empty means the in-process bus,
+	// which is all a single-instance deployment needs.
+	NATSURL string
+
+	// Scoring endpoint for the spam classifier. Empty falls back to the
+	// embedded heuristic; bots still opt in via their own threshold.
+	SpamClassifierURL string
+
 	// MySQL
 	DBHost string
 	DBUser string
 	DBPass string
 	DBName string
 
+	// Optional table prefix so two deployments (staging/production, two
+	// brands) can share one MySQL schema. Empty means plain table names.
+	DBTablePrefix string
+
 	// Redis
 	RedisAddr     string
 	RedisPassword string
@@ -35,23 +67,58 @@ type Config struct {
 
 	// Security
 	EncryptionKey string
+
+	// Development
+	DevMode bool // Run with an embedded in-memory cache and relaxed validation
+
+	// Chaos mode: probabilities (0..1) of injecting a fault into each
+	// outgoing Telegram API call, for validating retry and rate-limit
+	// handling in staging. All zero (the default) disables injection.
+	ChaosRate429     float64
+	ChaosRate5xx     float64
+	ChaosRateTimeout float64
+}
+
+// ChaosEnabled reports whether any fault injection rate is set
+func (c *Config) ChaosEnabled() bool {
+	return c.ChaosRate429 > 0 || c.ChaosRate5xx > 0 || c.ChaosRateTimeout > 0
 }
 
+// devEncryptionKey is the well-known 32-byte key used when DEV_MODE is on
+// and no BOT_ENCRYPTION_KEY is provided. Never used in production.
+const devEncryptionKey = "botforge-dev-key-0123456789abcde"
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if exists
 	_ = godotenv.Load()
 
+	// Sensitive values go through the secrets providers (custom backend,
+	// *_FILE secrets files, then plain env); the rest read env directly
+	secretValues := map[string]string{}
+	for _, name := range []string{"FACTORY_BOT_TOKEN", "DB_USER", "DB_PASS", "REDIS_PASSWORD", "BOT_ENCRYPTION_KEY"} {
+		value, err := getSecret(name)
+		if err != nil {
+			return nil, err
+		}
+		secretValues[name] = value
+	}
+
 	cfg := &Config{
-		FactoryBotToken: os.Getenv("FACTORY_BOT_TOKEN"),
-		WebhookURL:      os.Getenv("WEBHOOK_URL"),
-		ServerPort:      getEnvOrDefault("PORT", "4210"),
-		DBHost:          os.Getenv("DB_HOST"),
-		DBUser:          os.Getenv("DB_USER"),
-		DBPass:          os.Getenv("DB_PASS"),
-		DBName:          os.Getenv("DB_NAME"),
-		RedisAddr:       os.Getenv("REDIS_ADDR"),
-		RedisPassword:   os.Getenv("REDIS_PASSWORD"),
+		FactoryBotToken:   secretValues["FACTORY_BOT_TOKEN"],
+		WebhookURL:        os.Getenv("WEBHOOK_URL"),
+		ServerPort:        getEnvOrDefault("PORT", "4210"),
+		DBHost:            os.Getenv("DB_HOST"),
+		DBUser:            secretValues["DB_USER"],
+		DBPass:            secretValues["DB_PASS"],
+		DBName:            os.Getenv("DB_NAME"),
+		DBTablePrefix:     os.Getenv("DB_TABLE_PREFIX"),
+		RedisAddr:         os.Getenv("REDIS_ADDR"),
+		RedisPassword:     secretValues["REDIS_PASSWORD"],
+		BrandingFile:      os.Getenv("FACTORY_BRANDING_FILE"),
+		ShardRPCAddr:      os.Getenv("SHARD_RPC_ADDR"),
+		NATSURL:           os.Getenv("NATS_URL"),
+		SpamClassifierURL: os.Getenv("SPAM_CLASSIFIER_URL"),
 	}
 
 	// Parse Admin ID
@@ -72,14 +139,55 @@ func Load() (*Config, error) {
 	}
 	cfg.RedisDB = redisDB
 
-	// Parse Message TTL (in hours)
+	// Parse Message TTL (in hours). Reply routing breaks silently once
+	// links expire, so nonsense values are rejected here rather than
+	// surfacing as "user not found" replies weeks later.
 	ttlStr := getEnvOrDefault("MESSAGE_TTL", "48")
 	ttlHours, err := strconv.Atoi(ttlStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid MESSAGE_TTL: %w", err)
 	}
+	if ttlHours < 1 || ttlHours > 720 {
+		return nil, fmt.Errorf("MESSAGE_TTL must be between 1 and 720 hours, got %d", ttlHours)
+	}
 	cfg.MessageTTL = time.Duration(ttlHours) * time.Hour
 
+	// Parse extra factory bots: comma-separated "token=adminID" entries
+	if extras := os.Getenv("EXTRA_FACTORY_BOTS"); extras != "" {
+		for _, entry := range strings.Split(extras, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			parts := strings.SplitN(entry, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("invalid EXTRA_FACTORY_BOTS entry %q (expected token=adminID)", entry)
+			}
+			adminID, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid admin ID in EXTRA_FACTORY_BOTS entry %q: %w", entry, err)
+			}
+			cfg.ExtraFactories = append(cfg.ExtraFactories, FactoryAccount{
+				Token:   parts[0],
+				AdminID: adminID,
+			})
+		}
+	}
+
+	// Parse Dev Mode
+	devModeStr := getEnvOrDefault("DEV_MODE", "false")
+	devMode, err := strconv.ParseBool(devModeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEV_MODE: %w", err)
+	}
+	cfg.DevMode = devMode
+
+	// Dev mode fills in local defaults so the server boots without a
+	// Redis instance, a public webhook URL or a real encryption key
+	if cfg.DevMode && cfg.WebhookURL == "" {
+		cfg.WebhookURL = "http://localhost:" + cfg.ServerPort
+	}
+
 	// Validate required fields
 	if cfg.FactoryBotToken == "" {
 		return nil, fmt.Errorf("FACTORY_BOT_TOKEN is required")
@@ -88,25 +196,139 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("WEBHOOK_URL is required for webhook mode")
 	}
 
-	if cfg.DBHost == "" || cfg.DBUser == "" || cfg.DBName == "" {
+	// Validate the webhook URL now: Telegram rejects non-HTTPS webhooks
+	// and a trailing slash produces routes like //webhook/<token> that
+	// never match, both of which used to fail only at SetWebhook time
+	parsed, err := url.Parse(cfg.WebhookURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("WEBHOOK_URL %q is not a valid URL", cfg.WebhookURL)
+	}
+	if parsed.Scheme != "https" && !cfg.DevMode {
+		return nil, fmt.Errorf("WEBHOOK_URL must use https (Telegram requires it), got scheme %q", parsed.Scheme)
+	}
+	if strings.HasSuffix(cfg.WebhookURL, "/") {
+		return nil, fmt.Errorf("WEBHOOK_URL must not end with a slash (webhook paths are appended to it)")
+	}
+
+	// Validate the port so a typo fails at startup instead of in the
+	// HTTP listener goroutine
+	port, err := strconv.Atoi(cfg.ServerPort)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("PORT must be a number between 1 and 65535, got %q", cfg.ServerPort)
+	}
+
+	// In dev mode an unset DB_HOST selects the in-memory SQLite backend,
+	// so the MySQL settings are only required outside it
+	if (cfg.DBHost == "" || cfg.DBUser == "" || cfg.DBName == "") && !cfg.DevMode {
 		return nil, fmt.Errorf("database configuration (DB_HOST, DB_USER, DB_NAME) is required")
 	}
-	if cfg.RedisAddr == "" {
+	if cfg.RedisAddr == "" && !cfg.DevMode {
 		return nil, fmt.Errorf("REDIS_ADDR is required")
 	}
 
 	// Encryption Key (Must be 32 chars)
-	cfg.EncryptionKey = os.Getenv("BOT_ENCRYPTION_KEY")
+	cfg.EncryptionKey = secretValues["BOT_ENCRYPTION_KEY"]
+	if cfg.EncryptionKey == "" && cfg.DevMode {
+		cfg.EncryptionKey = devEncryptionKey
+	}
 	if cfg.EncryptionKey == "" {
 		return nil, fmt.Errorf("BOT_ENCRYPTION_KEY is required")
 	}
 	if len(cfg.EncryptionKey) != 32 {
-		return nil, fmt.Errorf("BOT_ENCRYPTION_KEY must be exactly 32 bytes")
+		return nil, fmt.Errorf("BOT_ENCRYPTION_KEY must be exactly 32 bytes, got %d", len(cfg.EncryptionKey))
+	}
+
+	// Parse chaos mode fault injection rates
+	if cfg.ChaosRate429, err = parseRate("CHAOS_RATE_429"); err != nil {
+		return nil, err
+	}
+	if cfg.ChaosRate5xx, err = parseRate("CHAOS_RATE_5XX"); err != nil {
+		return nil, err
+	}
+	if cfg.ChaosRateTimeout, err = parseRate("CHAOS_RATE_TIMEOUT"); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
+// parseRate reads an optional probability from the environment and
+// validates it is between 0 and 1
+func parseRate(name string) (float64, error) {
+	rate, err := strconv.ParseFloat(getEnvOrDefault(name, "0"), 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("%s must be a number between 0 and 1", name)
+	}
+	return rate, nil
+}
+
+// Summary renders the effective configuration with every secret
+// redacted, so it can be logged at startup. Operators diagnosing a bad
+// deploy see which values actually took effect, including defaults.
+func (c *Config) Summary() string {
+	var sb strings.Builder
+	sb.WriteString("Effective configuration:\n")
+	fmt.Fprintf(&sb, "  factory bot:      %s (+%d extra)\n", redactToken(c.FactoryBotToken), len(c.ExtraFactories))
+	fmt.Fprintf(&sb, "  webhook URL:      %s\n", c.WebhookURL)
+	fmt.Fprintf(&sb, "  port:             %s\n", c.ServerPort)
+	if c.UseSQLite() {
+		fmt.Fprintf(&sb, "  database:         in-memory SQLite (dev mode)\n")
+	} else {
+		fmt.Fprintf(&sb, "  database:         %s@%s/%s\n", c.DBUser, c.DBHost, c.DBName)
+	}
+	fmt.Fprintf(&sb, "  table prefix:     %s\n", orDefault(c.DBTablePrefix, "(none)"))
+	fmt.Fprintf(&sb, "  redis:            %s db=%d auth=%s\n", c.RedisAddr, c.RedisDB, redactPresence(c.RedisPassword))
+	fmt.Fprintf(&sb, "  message TTL:      %s\n", c.MessageTTL)
+	fmt.Fprintf(&sb, "  encryption key:   %s\n", redactPresence(c.EncryptionKey))
+	fmt.Fprintf(&sb, "  shard RPC:        %s\n", orDefault(c.ShardRPCAddr, "disabled"))
+	fmt.Fprintf(&sb, "  event bus:        %s\n", orDefault(c.NATSURL, "in-process"))
+	fmt.Fprintf(&sb, "  spam classifier:  %s\n", orDefault(c.SpamClassifierURL, "embedded heuristic"))
+	fmt.Fprintf(&sb, "  dev mode:         %t\n", c.DevMode)
+	chaos := "disabled"
+	if c.ChaosEnabled() {
+		chaos = fmt.Sprintf("429=%g 5xx=%g timeout=%g", c.ChaosRate429, c.ChaosRate5xx, c.ChaosRateTimeout)
+	}
+	fmt.Fprintf(&sb, "  chaos mode:       %s", chaos)
+	return sb.String()
+}
+
+// redactToken keeps enough of a bot token to identify the bot (the
+// numeric ID before the colon is public) without exposing the secret
+func redactToken(token string) string {
+	if i := strings.IndexByte(token, ':'); i > 0 {
+		return token[:i] + ":***"
+	}
+	return "***"
+}
+
+// redactPresence reports whether a secret is set without printing it
+func redactPresence(secret string) string {
+	if secret == "" {
+		return "(unset)"
+	}
+	return "(set)"
+}
+
+// orDefault substitutes a description when an optional value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// Factories returns every configured factory account, primary first
+func (c *Config) Factories() []FactoryAccount {
+	return append([]FactoryAccount{{Token: c.FactoryBotToken, AdminID: c.AdminID}}, c.ExtraFactories...)
+}
+
+// UseSQLite reports whether the server should run on the embedded
+// in-memory SQLite backend instead of MySQL: dev mode with no DB_HOST
+// configured. Setting DB_HOST in dev mode keeps using MySQL.
+func (c *Config) UseSQLite() bool {
+	return c.DevMode && c.DBHost == ""
+}
+
 // GetDSN returns MySQL connection string
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true&charset=utf8mb4",
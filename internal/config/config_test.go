@@ -24,6 +24,8 @@ func clearEnv() {
 		"REDIS_DB",
 		"MESSAGE_TTL",
 		"BOT_ENCRYPTION_KEY",
+		"DEV_MODE",
+		"EXTRA_FACTORY_BOTS",
 	}
 	for _, v := range envVars {
 		os.Unsetenv(v)
@@ -441,3 +443,261 @@ func TestLoad_RedisPassword(t *testing.T) {
 		t.Errorf("Expected Redis password 'redis-secret', got '%s'", cfg.RedisPassword)
 	}
 }
+
+// ==================== Multi-Factory Tests ====================
+
+func TestLoad_ExtraFactories(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("EXTRA_FACTORY_BOTS", "111:AAA=123, 222:BBB=456")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(cfg.ExtraFactories) != 2 {
+		t.Fatalf("Expected 2 extra factories, got %d", len(cfg.ExtraFactories))
+	}
+	if cfg.ExtraFactories[0].Token != "111:AAA" || cfg.ExtraFactories[0].AdminID != 123 {
+		t.Errorf("Unexpected first extra factory: %+v", cfg.ExtraFactories[0])
+	}
+
+	// Factories() lists the primary factory first, then the extras
+	factories := cfg.Factories()
+	if len(factories) != 3 {
+		t.Fatalf("Expected 3 factories total, got %d", len(factories))
+	}
+	if factories[0].Token != cfg.FactoryBotToken {
+		t.Error("Expected primary factory first")
+	}
+}
+
+func TestLoad_ExtraFactories_InvalidEntry(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("EXTRA_FACTORY_BOTS", "111:AAA")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Error("Expected error for entry without admin ID")
+	}
+
+	if !strings.Contains(err.Error(), "EXTRA_FACTORY_BOTS") {
+		t.Errorf("Error should mention EXTRA_FACTORY_BOTS, got: %v", err)
+	}
+}
+
+// ==================== Dev Mode Tests ====================
+
+func TestLoad_DevMode_Defaults(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("DEV_MODE", "true")
+	os.Unsetenv("REDIS_ADDR")
+	os.Unsetenv("WEBHOOK_URL")
+	os.Unsetenv("BOT_ENCRYPTION_KEY")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error in dev mode, got: %v", err)
+	}
+
+	if !cfg.DevMode {
+		t.Error("Expected DevMode to be true")
+	}
+	if cfg.WebhookURL != "http://localhost:4210" {
+		t.Errorf("Expected local webhook URL default, got '%s'", cfg.WebhookURL)
+	}
+	if len(cfg.EncryptionKey) != 32 {
+		t.Errorf("Expected 32-byte dev encryption key, got %d bytes", len(cfg.EncryptionKey))
+	}
+}
+
+func TestLoad_DevMode_SQLiteWithoutDBConfig(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("DEV_MODE", "true")
+	os.Unsetenv("DB_HOST")
+	os.Unsetenv("DB_USER")
+	os.Unsetenv("DB_NAME")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error in dev mode without DB config, got: %v", err)
+	}
+
+	if !cfg.UseSQLite() {
+		t.Error("Expected UseSQLite with no DB_HOST in dev mode")
+	}
+
+	// Setting DB_HOST keeps dev mode on MySQL
+	os.Setenv("DB_HOST", "localhost")
+	os.Setenv("DB_USER", "test")
+	os.Setenv("DB_NAME", "test")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.UseSQLite() {
+		t.Error("Expected MySQL to stay selected when DB_HOST is set in dev mode")
+	}
+}
+
+func TestLoad_DevMode_DisabledByDefault(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.DevMode {
+		t.Error("Expected DevMode to be false by default")
+	}
+}
+
+func TestLoad_DevMode_Invalid(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("DEV_MODE", "maybe")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Error("Expected error for invalid DEV_MODE")
+	}
+
+	if !strings.Contains(err.Error(), "DEV_MODE") {
+		t.Errorf("Error should mention DEV_MODE, got: %v", err)
+	}
+}
+
+// ==================== Startup Validation Tests ====================
+
+func TestLoad_WebhookURL_TrailingSlash(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("WEBHOOK_URL", "https://example.com/")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Error("Expected error for trailing slash in WEBHOOK_URL")
+	}
+
+	if !strings.Contains(err.Error(), "slash") {
+		t.Errorf("Error should mention the trailing slash, got: %v", err)
+	}
+}
+
+func TestLoad_WebhookURL_NotHTTPS(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("WEBHOOK_URL", "http://example.com")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Error("Expected error for non-https WEBHOOK_URL")
+	}
+
+	if !strings.Contains(err.Error(), "https") {
+		t.Errorf("Error should mention https, got: %v", err)
+	}
+}
+
+func TestLoad_WebhookURL_HTTPAllowedInDevMode(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("DEV_MODE", "true")
+	os.Setenv("WEBHOOK_URL", "http://localhost:4210")
+
+	if _, err := config.Load(); err != nil {
+		t.Errorf("Expected http webhook to pass in dev mode, got: %v", err)
+	}
+}
+
+func TestLoad_WebhookURL_NotAURL(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("WEBHOOK_URL", "not a url")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Error("Expected error for malformed WEBHOOK_URL")
+	}
+}
+
+func TestLoad_InvalidPort(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	for _, port := range []string{"abc", "0", "70000"} {
+		os.Setenv("PORT", port)
+		_, err := config.Load()
+		if err == nil {
+			t.Errorf("Expected error for PORT %q", port)
+			continue
+		}
+		if !strings.Contains(err.Error(), "PORT") {
+			t.Errorf("Error should mention PORT, got: %v", err)
+		}
+	}
+}
+
+func TestLoad_MessageTTL_OutOfRange(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	for _, ttl := range []string{"0", "-5", "10000"} {
+		os.Setenv("MESSAGE_TTL", ttl)
+		_, err := config.Load()
+		if err == nil {
+			t.Errorf("Expected error for MESSAGE_TTL %q", ttl)
+			continue
+		}
+		if !strings.Contains(err.Error(), "MESSAGE_TTL") {
+			t.Errorf("Error should mention MESSAGE_TTL, got: %v", err)
+		}
+	}
+}
+
+// ==================== Summary Tests ====================
+
+func TestSummary_RedactsSecrets(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+	os.Setenv("FACTORY_BOT_TOKEN", "123456:AAAA-secret-part")
+	os.Setenv("REDIS_PASSWORD", "hunter2")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	summary := cfg.Summary()
+	for _, secret := range []string{"AAAA-secret-part", "hunter2", "12345678901234567890123456789012", "password"} {
+		if strings.Contains(summary, secret) {
+			t.Errorf("Summary leaked secret %q:\n%s", secret, summary)
+		}
+	}
+
+	// Non-secret values must survive so the summary is actually useful
+	for _, want := range []string{"123456:***", "https://example.com/webhook", "localhost:3306", "testdb", "4210"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary missing %q:\n%s", want, summary)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ==================== Secrets Providers ====================
+//
+// Sensitive values (bot tokens, DB credentials, the encryption key) can
+// come from somewhere safer than plain environment variables. Load asks
+// each registered provider in order and falls back to the environment,
+// so existing deployments keep working unchanged.
+
+// SecretsProvider resolves named secrets such as FACTORY_BOT_TOKEN.
+// Implementations for Vault or AWS Secrets Manager live outside this
+// package and are installed with SetSecretsProvider before Load runs.
+type SecretsProvider interface {
+	// Secret returns the value for the named secret. ok is false when
+	// the provider does not manage that name, letting resolution fall
+	// through to the next source.
+	Secret(name string) (value string, ok bool, err error)
+}
+
+var (
+	secretsMu      sync.Mutex
+	customProvider SecretsProvider
+)
+
+// SetSecretsProvider installs a provider consulted before the built-in
+// sources. Passing nil removes it (used by tests).
+func SetSecretsProvider(p SecretsProvider) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	customProvider = p
+}
+
+// fileProvider implements the Docker secrets convention: when
+// <NAME>_FILE is set, the secret is the contents of that file with a
+// single trailing newline stripped.
+type fileProvider struct{}
+
+func (fileProvider) Secret(name string) (string, bool, error) {
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s_FILE: %w", name, err)
+	}
+
+	return strings.TrimRight(string(data), "\n"), true, nil
+}
+
+// getSecret resolves a named secret: custom provider first, then a
+// <NAME>_FILE secrets file, then the plain environment variable
+func getSecret(name string) (string, error) {
+	secretsMu.Lock()
+	custom := customProvider
+	secretsMu.Unlock()
+
+	if custom != nil {
+		value, ok, err := custom.Secret(name)
+		if err != nil {
+			return "", fmt.Errorf("secrets provider failed for %s: %w", name, err)
+		}
+		if ok {
+			return value, nil
+		}
+	}
+
+	value, ok, err := fileProvider{}.Secret(name)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return value, nil
+	}
+
+	return os.Getenv(name), nil
+}
@@ -0,0 +1,136 @@
+package config_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/config"
+)
+
+// ==================== Secrets Provider Tests ====================
+
+// writeSecretFile creates a temp secrets file and points <name>_FILE at it
+func writeSecretFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), strings.ToLower(name))
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+
+	os.Setenv(name+"_FILE", path)
+	t.Cleanup(func() { os.Unsetenv(name + "_FILE") })
+}
+
+func TestLoad_SecretFromFile(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	os.Unsetenv("FACTORY_BOT_TOKEN")
+	writeSecretFile(t, "FACTORY_BOT_TOKEN", "file-token-456\n")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Trailing newline must be stripped (Docker secrets convention)
+	if cfg.FactoryBotToken != "file-token-456" {
+		t.Errorf("Expected token from file, got '%s'", cfg.FactoryBotToken)
+	}
+}
+
+func TestLoad_SecretFileOverridesEnv(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	writeSecretFile(t, "DB_PASS", "file-password")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.DBPass != "file-password" {
+		t.Errorf("Expected file secret to win over env, got '%s'", cfg.DBPass)
+	}
+}
+
+func TestLoad_SecretFileMissing(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	os.Setenv("BOT_ENCRYPTION_KEY_FILE", "/nonexistent/key")
+	defer os.Unsetenv("BOT_ENCRYPTION_KEY_FILE")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Fatal("Expected error for missing secrets file")
+	}
+
+	if !strings.Contains(err.Error(), "BOT_ENCRYPTION_KEY_FILE") {
+		t.Errorf("Error should mention the _FILE variable, got: %v", err)
+	}
+}
+
+// mapProvider is a stand-in for an external backend like Vault
+type mapProvider struct {
+	secrets map[string]string
+	err     error
+}
+
+func (p mapProvider) Secret(name string) (string, bool, error) {
+	if p.err != nil {
+		return "", false, p.err
+	}
+	value, ok := p.secrets[name]
+	return value, ok, nil
+}
+
+func TestLoad_CustomProvider(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	config.SetSecretsProvider(mapProvider{secrets: map[string]string{
+		"FACTORY_BOT_TOKEN": "vault-token-789",
+	}})
+	defer config.SetSecretsProvider(nil)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.FactoryBotToken != "vault-token-789" {
+		t.Errorf("Expected token from provider, got '%s'", cfg.FactoryBotToken)
+	}
+	// Names the provider does not manage fall through to the environment
+	if cfg.DBUser != "root" {
+		t.Errorf("Expected DB user from env, got '%s'", cfg.DBUser)
+	}
+}
+
+func TestLoad_CustomProviderError(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+	setValidEnv()
+
+	config.SetSecretsProvider(mapProvider{err: fmt.Errorf("vault sealed")})
+	defer config.SetSecretsProvider(nil)
+
+	_, err := config.Load()
+	if err == nil {
+		t.Fatal("Expected error when the provider fails")
+	}
+
+	if !strings.Contains(err.Error(), "vault sealed") {
+		t.Errorf("Error should wrap the provider failure, got: %v", err)
+	}
+}
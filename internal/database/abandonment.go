@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+)
+
+// AbandonedBot is one candidate of the abandonment policy: an active bot
+// whose last logged message is older than the policy cutoff
+type AbandonedBot struct {
+	ID          int64      `db:"id"`
+	Token       string     `db:"token"`
+	Username    string     `db:"username"`
+	OwnerChatID int64      `db:"owner_chat_id"`
+	NotifiedAt  *time.Time `db:"abandonment_notified_at"`
+}
+
+// GetAbandonedBots returns active bots that were created before the
+// cutoff and have received no messages since. Tokens are decrypted.
+func (r *Repository) GetAbandonedBots(ctx context.Context, cutoff time.Time) ([]AbandonedBot, error) {
+	var bots []AbandonedBot
+	query := `SELECT b.id, b.token, COALESCE(b.username, '') as username, b.owner_chat_id, b.abandonment_notified_at
+			  FROM bots b
+			  WHERE b.is_active = TRUE AND COALESCE(b.is_suspended, FALSE) = FALSE AND b.deleted_at IS NULL
+			    AND b.created_at < ?
+			    AND NOT EXISTS (SELECT 1 FROM message_logs ml WHERE ml.bot_id = b.id AND ml.created_at > ?)`
+
+	err := r.db().SelectContext(ctx, &bots, query, cutoff, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abandoned bots: %w", err)
+	}
+
+	for i := range bots {
+		decrypted, err := crypto.DecryptDeterministic(bots[i].Token, r.encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt bot token (ID: %d): %w", bots[i].ID, err)
+		}
+		bots[i].Token = decrypted
+	}
+
+	return bots, nil
+}
+
+// MarkAbandonmentNotified records that the owner was warned about the
+// pending deactivation
+func (r *Repository) MarkAbandonmentNotified(ctx context.Context, botID int64) error {
+	query := `UPDATE bots SET abandonment_notified_at = NOW() WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, botID)
+	if err != nil {
+		return fmt.Errorf("failed to mark abandonment notified: %w", err)
+	}
+	return nil
+}
+
+// ClearStaleAbandonmentNotices resets the warning on bots that received
+// traffic again after being notified, so a later quiet period starts a
+// fresh grace window
+func (r *Repository) ClearStaleAbandonmentNotices(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `UPDATE bots b SET b.abandonment_notified_at = NULL
+			  WHERE b.abandonment_notified_at IS NOT NULL
+			    AND EXISTS (SELECT 1 FROM message_logs ml WHERE ml.bot_id = b.id AND ml.created_at > ?)`
+
+	result, err := r.db().ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear stale abandonment notices: %w", err)
+	}
+
+	cleared, _ := result.RowsAffected()
+	return cleared, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// hashAPIKey derives the stored digest of an API key. Keys are random
+// high-entropy strings, so an unsalted SHA-256 is sufficient.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey mints a new API key scoped to one bot. The plaintext key
+// is returned exactly once and only its hash is stored.
+func (r *Repository) CreateAPIKey(ctx context.Context, ownerChatID, botID int64, label, permissions string) (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := "bfk_" + hex.EncodeToString(raw)
+
+	query := `INSERT INTO api_keys (owner_chat_id, bot_id, key_hash, label, permissions)
+			  VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, ownerChatID, botID, hashAPIKey(key), label, permissions)
+	if err != nil {
+		return "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeys returns an owner's keys for one bot, newest first
+func (r *Repository) GetAPIKeys(ctx context.Context, ownerChatID, botID int64) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	query := `SELECT id, owner_chat_id, bot_id, label, permissions, last_used_at, created_at
+			  FROM api_keys WHERE owner_chat_id = ? AND bot_id = ?
+			  ORDER BY created_at DESC`
+
+	err := r.db().SelectContext(ctx, &keys, query, ownerChatID, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey deletes a key, scoped to its owner
+func (r *Repository) RevokeAPIKey(ctx context.Context, ownerChatID, keyID int64) error {
+	query := `DELETE FROM api_keys WHERE id = ? AND owner_chat_id = ?`
+
+	result, err := r.db().ExecContext(ctx, query, keyID, ownerChatID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return checkRowsAffected(result, "revoke API key")
+}
+
+// LookupAPIKey resolves a plaintext key to its record and stamps its
+// last-used time. Unknown keys return nil without error.
+func (r *Repository) LookupAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	query := `SELECT id, owner_chat_id, bot_id, label, permissions, last_used_at, created_at
+			  FROM api_keys WHERE key_hash = ?`
+
+	err := r.db().GetContext(ctx, &apiKey, query, hashAPIKey(key))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	touch := `UPDATE api_keys SET last_used_at = NOW() WHERE id = ?`
+	if _, err := r.db().ExecContext(ctx, touch, apiKey.ID); err != nil {
+		return nil, fmt.Errorf("failed to stamp API key usage: %w", err)
+	}
+
+	return &apiKey, nil
+}
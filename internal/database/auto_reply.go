@@ -16,11 +16,11 @@ func (r *Repository) CreateAutoReply(ctx context.Context, botID int64, trigger,
 			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, TRUE)
 			  ON DUPLICATE KEY UPDATE response = ?, message_type = ?, file_id = ?, caption = ?, match_type = ?, is_active = TRUE`
 
-	_, err := r.mysql.db.ExecContext(ctx, query,
+	_, err := r.db().ExecContext(ctx, query,
 		botID, trigger, response, messageType, fileID, caption, triggerType, matchType,
 		response, messageType, fileID, caption, matchType)
 	if err != nil {
-		return fmt.Errorf("failed to create auto-reply: %w", err)
+		return classifyError("create auto-reply", err)
 	}
 	return nil
 }
@@ -28,11 +28,11 @@ func (r *Repository) CreateAutoReply(ctx context.Context, botID int64, trigger,
 // GetAutoReplies retrieves all auto-replies or commands for a bot
 func (r *Repository) GetAutoReplies(ctx context.Context, botID int64, triggerType string) ([]models.AutoReply, error) {
 	var replies []models.AutoReply
-	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, created_at
+	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, is_quick_reply, created_at
 			  FROM auto_replies WHERE bot_id = ? AND trigger_type = ? AND is_active = TRUE
 			  ORDER BY created_at DESC`
 
-	err := r.mysql.db.SelectContext(ctx, &replies, query, botID, triggerType)
+	err := r.db().SelectContext(ctx, &replies, query, botID, triggerType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get auto-replies: %w", err)
 	}
@@ -42,10 +42,10 @@ func (r *Repository) GetAutoReplies(ctx context.Context, botID int64, triggerTyp
 // GetAutoReplyByTrigger finds an auto-reply by its trigger word
 func (r *Repository) GetAutoReplyByTrigger(ctx context.Context, botID int64, trigger, triggerType string) (*models.AutoReply, error) {
 	var reply models.AutoReply
-	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, created_at
+	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, is_quick_reply, created_at
 			  FROM auto_replies WHERE bot_id = ? AND trigger_word = ? AND trigger_type = ?`
 
-	err := r.mysql.db.GetContext(ctx, &reply, query, botID, trigger, triggerType)
+	err := r.db().GetContext(ctx, &reply, query, botID, trigger, triggerType)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -55,13 +55,14 @@ func (r *Repository) GetAutoReplyByTrigger(ctx context.Context, botID int64, tri
 	return &reply, nil
 }
 
-// GetAutoReplyByID retrieves an auto-reply by its ID
-func (r *Repository) GetAutoReplyByID(ctx context.Context, replyID int64) (*models.AutoReply, error) {
+// GetAutoReplyByID retrieves an auto-reply by its ID, scoped to the owning bot
+// so IDs taken from callback data cannot reach another bot's rows
+func (r *Repository) GetAutoReplyByID(ctx context.Context, botID, replyID int64) (*models.AutoReply, error) {
 	var reply models.AutoReply
-	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, created_at
-			  FROM auto_replies WHERE id = ?`
+	query := `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, is_quick_reply, created_at
+			  FROM auto_replies WHERE id = ? AND bot_id = ?`
 
-	err := r.mysql.db.GetContext(ctx, &reply, query, replyID)
+	err := r.db().GetContext(ctx, &reply, query, replyID, botID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -71,21 +72,54 @@ func (r *Repository) GetAutoReplyByID(ctx context.Context, replyID int64) (*mode
 	return &reply, nil
 }
 
-// DeleteAutoReply removes an auto-reply by ID
-func (r *Repository) DeleteAutoReply(ctx context.Context, botID, replyID int64) error {
-	query := `DELETE FROM auto_replies WHERE id = ? AND bot_id = ?`
-	_, err := r.mysql.db.ExecContext(ctx, query, replyID, botID)
+// DeleteAutoReply removes an auto-reply by ID after verifying that
+// ownerChatID owns the bot
+func (r *Repository) DeleteAutoReply(ctx context.Context, botID, replyID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM auto_replies WHERE id = ? AND bot_id = ?`
+		if _, err := tx.db().ExecContext(ctx, query, replyID, botID); err != nil {
+			return fmt.Errorf("failed to delete auto-reply: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetAutoReplyQuickReply marks or unmarks a keyword auto-reply as a
+// quick-reply keyboard button
+func (r *Repository) SetAutoReplyQuickReply(ctx context.Context, botID, replyID int64, quickReply bool) error {
+	query := `UPDATE auto_replies SET is_quick_reply = ? WHERE id = ? AND bot_id = ? AND trigger_type = 'keyword'`
+	_, err := r.db().ExecContext(ctx, query, quickReply, replyID, botID)
 	if err != nil {
-		return fmt.Errorf("failed to delete auto-reply: %w", err)
+		return fmt.Errorf("failed to update quick-reply flag: %w", err)
 	}
 	return nil
 }
 
+// GetQuickReplyTriggers returns the trigger words of all active keyword
+// auto-replies marked as quick-reply keyboard buttons, oldest first so
+// the keyboard layout stays stable
+func (r *Repository) GetQuickReplyTriggers(ctx context.Context, botID int64) ([]string, error) {
+	var triggers []string
+	query := `SELECT trigger_word FROM auto_replies
+			  WHERE bot_id = ? AND trigger_type = 'keyword' AND is_active = TRUE AND is_quick_reply = TRUE
+			  ORDER BY created_at ASC`
+
+	err := r.db().SelectContext(ctx, &triggers, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quick-reply triggers: %w", err)
+	}
+	return triggers, nil
+}
+
 // GetAutoReplyCount returns the count of auto-replies for a bot by type
 func (r *Repository) GetAutoReplyCount(ctx context.Context, botID int64, triggerType string) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM auto_replies WHERE bot_id = ? AND trigger_type = ? AND is_active = TRUE`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID, triggerType)
+	err := r.db().GetContext(ctx, &count, query, botID, triggerType)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get auto-reply count: %w", err)
 	}
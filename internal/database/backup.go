@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+)
+
+// ==================== Backup / Restore Functions ====================
+
+// backupVersion is bumped whenever the BotBackup layout changes
+const backupVersion = 1
+
+// BotBackup is a full serialization of one bot's rows across all tables,
+// used by the backup and restore CLI commands for tenant-level disaster
+// recovery. The bot token is stored in plaintext so the file can be
+// restored on an instance with a different encryption key - treat backup
+// files as secrets.
+type BotBackup struct {
+	Version           int                         `json:"version"`
+	ExportedAt        time.Time                   `json:"exported_at"`
+	Bot               models.Bot                  `json:"bot"`
+	MessageLogs       []models.MessageLog         `json:"message_logs"`
+	BannedUsers       []models.BannedUser         `json:"banned_users"`
+	ForcedChannels    []models.ForcedChannel      `json:"forced_channels"`
+	ForcedSubExempts  []models.ForcedSubExemption `json:"forced_sub_exemptions"`
+	AutoReplies       []models.AutoReply          `json:"auto_replies"`
+	ScheduledMessages []models.ScheduledMessage   `json:"scheduled_messages"`
+	Replies           []models.Reply              `json:"replies"`
+	Deliveries        []models.Delivery           `json:"deliveries"`
+	BotUsers          []models.BotUser            `json:"bot_users"`
+	RoutingRules      []models.RoutingRule        `json:"routing_rules"`
+	Ratings           []models.Rating             `json:"ratings"`
+}
+
+// BackupBot serializes a bot and all of its dependent rows. The returned
+// backup carries the decrypted bot token.
+func (r *Repository) BackupBot(ctx context.Context, botID int64) (*BotBackup, error) {
+	bot, err := r.GetBotByID(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	if bot == nil {
+		return nil, fmt.Errorf("bot %d not found: %w", botID, ErrNotFound)
+	}
+
+	backup := &BotBackup{
+		Version:    backupVersion,
+		ExportedAt: time.Now().UTC(),
+		Bot:        *bot,
+	}
+
+	selects := []struct {
+		dest  interface{}
+		query string
+	}{
+		{&backup.MessageLogs, `SELECT id, admin_msg_id, user_chat_id, bot_id, created_at FROM message_logs WHERE bot_id = ?`},
+		{&backup.BannedUsers, `SELECT id, bot_id, user_chat_id, banned_by, created_at FROM banned_users WHERE bot_id = ?`},
+		{&backup.ForcedChannels, `SELECT id, bot_id, channel_id, COALESCE(channel_username, '') as channel_username, COALESCE(channel_title, '') as channel_title, COALESCE(invite_link, '') as invite_link, is_active, created_at FROM forced_channels WHERE bot_id = ?`},
+		{&backup.ForcedSubExempts, `SELECT id, bot_id, user_chat_id, created_at FROM forced_sub_exemptions WHERE bot_id = ?`},
+		{&backup.AutoReplies, `SELECT id, bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, is_quick_reply, created_at FROM auto_replies WHERE bot_id = ?`},
+		{&backup.ScheduledMessages, `SELECT * FROM scheduled_messages WHERE bot_id = ?`},
+		{&backup.Replies, `SELECT id, bot_id, admin_msg_id, user_chat_id, user_msg_id, created_at FROM replies WHERE bot_id = ?`},
+		{&backup.Deliveries, `SELECT id, bot_id, user_chat_id, source, status, COALESCE(error_code, '') as error_code, created_at FROM deliveries WHERE bot_id = ?`},
+		{&backup.BotUsers, `SELECT id, bot_id, user_chat_id, is_reachable, blocked_at, created_at, updated_at FROM bot_users WHERE bot_id = ?`},
+		{&backup.RoutingRules, `SELECT id, bot_id, keyword, dest_chat_id, created_at FROM routing_rules WHERE bot_id = ?`},
+		{&backup.Ratings, `SELECT id, bot_id, user_chat_id, stars, created_at FROM ratings WHERE bot_id = ?`},
+	}
+	for _, s := range selects {
+		if err := r.db().SelectContext(ctx, s.dest, s.query, botID); err != nil {
+			return nil, fmt.Errorf("failed to back up bot %d: %w", botID, err)
+		}
+	}
+
+	return backup, nil
+}
+
+// RestoreBot inserts a backup into the database as a new bot, inside a
+// single transaction. The token is re-encrypted under this repository's
+// current key. Returns the new bot ID; restoring a token that already
+// exists fails with ErrDuplicate.
+func (r *Repository) RestoreBotBackup(ctx context.Context, backup *BotBackup) (int64, error) {
+	if backup.Version != backupVersion {
+		return 0, fmt.Errorf("unsupported backup version %d (expected %d)", backup.Version, backupVersion)
+	}
+
+	encryptedToken, err := crypto.EncryptDeterministic(backup.Bot.Token, r.encryptionKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encrypt token: %w", err)
+	}
+
+	var newBotID int64
+	err = r.WithTx(ctx, func(txRepo *Repository) error {
+		b := backup.Bot
+		botQuery := `INSERT INTO bots
+			(token, username, owner_chat_id, factory_id, is_active, start_message,
+			forward_auto_replies, forced_sub_enabled, forced_sub_message, forced_sub_grace_count, show_sent_confirmation,
+			confirmation_emoji, confirmation_mode, digest_interval_minutes, digest_urgent_keywords,
+			relay_channel_id, archive_chat_id, blocked_media_types, max_file_size_mb,
+			filter_profanity_action, filter_links_action, dedupe_window_minutes, sla_minutes,
+			survey_enabled, menu_button_enabled, header_policy, rich_header_enabled, privacy_notice, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+		result, err := txRepo.db().ExecContext(ctx, botQuery,
+			encryptedToken, b.Username, b.OwnerChatID, b.FactoryID, b.IsActive, b.StartMessage,
+			b.ForwardAutoReplies, b.ForcedSubEnabled, b.ForcedSubMessage, b.ForcedSubGraceCount, b.ShowSentConfirmation,
+			b.ConfirmationEmoji, b.ConfirmationMode, b.DigestIntervalMinutes, b.DigestUrgentKeywords,
+			b.RelayChannelID, b.ArchiveChatID, b.BlockedMediaTypes, b.MaxFileSizeMB,
+			b.FilterProfanityAction, b.FilterLinksAction, b.DedupeWindowMinutes, b.SLAMinutes,
+			b.SurveyEnabled, b.MenuButtonEnabled, b.HeaderPolicy, b.RichHeaderEnabled, b.PrivacyNotice, b.CreatedAt)
+		if err != nil {
+			return classifyError("restore bot", err)
+		}
+
+		newBotID, err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get restored bot ID: %w", err)
+		}
+
+		for _, row := range backup.MessageLogs {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO message_logs (admin_msg_id, user_chat_id, bot_id, created_at) VALUES (?, ?, ?, ?)`,
+				row.AdminMsgID, row.UserChatID, newBotID, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.BannedUsers {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO banned_users (bot_id, user_chat_id, banned_by, created_at) VALUES (?, ?, ?, ?)`,
+				newBotID, row.UserChatID, row.BannedBy, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.ForcedChannels {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO forced_channels (bot_id, channel_id, channel_username, channel_title, invite_link, is_active, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				newBotID, row.ChannelID, row.ChannelUsername, row.ChannelTitle, row.InviteLink, row.IsActive, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.ForcedSubExempts {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO forced_sub_exemptions (bot_id, user_chat_id, created_at) VALUES (?, ?, ?)`,
+				newBotID, row.UserChatID, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.AutoReplies {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO auto_replies (bot_id, trigger_word, response, message_type, file_id, caption, trigger_type, match_type, is_active, is_quick_reply, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				newBotID, row.TriggerWord, row.Response, row.MessageType, row.FileID, row.Caption, row.TriggerType, row.MatchType, row.IsActive, row.IsQuickReply, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.ScheduledMessages {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO scheduled_messages (bot_id, owner_chat_id, message_type, message_text, file_id, caption, schedule_type, target_channel_id, scheduled_time, time_of_day, day_of_week, status, last_sent_at, next_run_at, failure_reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				newBotID, row.OwnerChatID, row.MessageType, row.MessageText, row.FileID, row.Caption, row.ScheduleType, row.TargetChannelID, row.ScheduledTime, row.TimeOfDay, row.DayOfWeek, row.Status, row.LastSentAt, row.NextRunAt, row.FailureReason, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.Replies {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO replies (bot_id, admin_msg_id, user_chat_id, user_msg_id, created_at) VALUES (?, ?, ?, ?, ?)`,
+				newBotID, row.AdminMsgID, row.UserChatID, row.UserMsgID, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.Deliveries {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO deliveries (bot_id, user_chat_id, source, status, error_code, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+				newBotID, row.UserChatID, row.Source, row.Status, row.ErrorCode, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.BotUsers {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO bot_users (bot_id, user_chat_id, is_reachable, blocked_at, created_at) VALUES (?, ?, ?, ?, ?)`,
+				newBotID, row.UserChatID, row.IsReachable, row.BlockedAt, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.RoutingRules {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO routing_rules (bot_id, keyword, dest_chat_id, created_at) VALUES (?, ?, ?, ?)`,
+				newBotID, row.Keyword, row.DestChatID, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, row := range backup.Ratings {
+			if err := txRepo.restoreRow(ctx,
+				`INSERT INTO ratings (bot_id, user_chat_id, stars, created_at) VALUES (?, ?, ?, ?)`,
+				newBotID, row.UserChatID, row.Stars, row.CreatedAt); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return newBotID, nil
+}
+
+// restoreRow inserts one dependent row during a restore
+func (r *Repository) restoreRow(ctx context.Context, query string, args ...interface{}) error {
+	if _, err := r.db().ExecContext(ctx, query, args...); err != nil {
+		return classifyError("restore bot data", err)
+	}
+	return nil
+}
@@ -12,18 +12,19 @@ import (
 
 // ==================== Bot Functions ====================
 
-// CreateBot inserts a new bot into the database
-func (r *Repository) CreateBot(ctx context.Context, token string, ownerChatID int64, username string) (*models.Bot, error) {
+// CreateBot inserts a new bot into the database. factoryID is the
+// Telegram ID of the factory bot that registered it (0 if unknown).
+func (r *Repository) CreateBot(ctx context.Context, token string, ownerChatID int64, username string, factoryID int64) (*models.Bot, error) {
 	encryptedToken, err := crypto.EncryptDeterministic(token, r.encryptionKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt token: %w", err)
 	}
 
-	query := `INSERT INTO bots (token, owner_chat_id, username, is_active, start_message) VALUES (?, ?, ?, TRUE, '')`
+	query := `INSERT INTO bots (token, owner_chat_id, username, factory_id, is_active, start_message) VALUES (?, ?, ?, ?, TRUE, '')`
 
-	result, err := r.mysql.db.ExecContext(ctx, query, encryptedToken, ownerChatID, username)
+	result, err := r.db().ExecContext(ctx, query, encryptedToken, ownerChatID, username, factoryID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create bot: %w", err)
+		return nil, classifyError("create bot", err)
 	}
 
 	id, err := result.LastInsertId()
@@ -36,6 +37,7 @@ func (r *Repository) CreateBot(ctx context.Context, token string, ownerChatID in
 		Token:        token, // Return original token to caller
 		Username:     username,
 		OwnerChatID:  ownerChatID,
+		FactoryID:    factoryID,
 		IsActive:     true,
 		StartMessage: "",
 		CreatedAt:    time.Now(),
@@ -50,14 +52,43 @@ func (r *Repository) GetBotByToken(ctx context.Context, token string) (*models.B
 	}
 
 	var bot models.Bot
-	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(start_message, '') as start_message,
+	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(is_suspended, FALSE) as is_suspended, COALESCE(start_message, '') as start_message,
 			  COALESCE(forward_auto_replies, TRUE) as forward_auto_replies,
 			  COALESCE(forced_sub_enabled, FALSE) as forced_sub_enabled,
 			  COALESCE(forced_sub_message, '') as forced_sub_message,
-			  COALESCE(show_sent_confirmation, TRUE) as show_sent_confirmation, created_at
+			  COALESCE(forced_sub_grace_count, 0) as forced_sub_grace_count,
+			  COALESCE(show_sent_confirmation, TRUE) as show_sent_confirmation,
+			  COALESCE(confirmation_emoji, '👍') as confirmation_emoji,
+			  COALESCE(confirmation_mode, 'react') as confirmation_mode,
+			  COALESCE(digest_interval_minutes, 0) as digest_interval_minutes,
+			  COALESCE(digest_urgent_keywords, '') as digest_urgent_keywords,
+			  COALESCE(relay_channel_id, 0) as relay_channel_id,
+			  COALESCE(archive_chat_id, 0) as archive_chat_id,
+			  COALESCE(blocked_media_types, '') as blocked_media_types,
+			  COALESCE(max_file_size_mb, 0) as max_file_size_mb,
+			  COALESCE(filter_profanity_action, 'off') as filter_profanity_action,
+			  COALESCE(filter_links_action, 'off') as filter_links_action,
+			  COALESCE(dedupe_window_minutes, 0) as dedupe_window_minutes,
+			  COALESCE(sla_minutes, 0) as sla_minutes,
+			  COALESCE(survey_enabled, FALSE) as survey_enabled,
+			  COALESCE(menu_button_enabled, TRUE) as menu_button_enabled,
+			  COALESCE(header_policy, 'never') as header_policy,
+			  COALESCE(rich_header_enabled, FALSE) as rich_header_enabled,
+			  COALESCE(privacy_notice, '') as privacy_notice,
+			  COALESCE(content_logging_enabled, FALSE) as content_logging_enabled,
+			  COALESCE(content_retention_days, 30) as content_retention_days,
+			  COALESCE(spam_threshold, 0) as spam_threshold,
+			  COALESCE(message_ttl_hours, 0) as message_ttl_hours,
+			  COALESCE(locale, '') as locale,
+			  COALESCE(timezone, '') as timezone,
+			  COALESCE(stats_digest, '') as stats_digest,
+			  COALESCE(whitelist_enabled, FALSE) as whitelist_enabled,
+			  COALESCE(whitelist_message, '') as whitelist_message,
+			  COALESCE(reaction_actions, '') as reaction_actions,
+			  COALESCE(factory_id, 0) as factory_id, created_at
 			  FROM bots WHERE token = ? AND deleted_at IS NULL`
 
-	err = r.mysql.db.GetContext(ctx, &bot, query, encryptedToken)
+	err = r.db().GetContext(ctx, &bot, query, encryptedToken)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -75,6 +106,164 @@ func (r *Repository) GetBotByToken(ctx context.Context, token string) (*models.B
 	return &bot, nil
 }
 
+// GetBotByID retrieves a bot by its primary key (excludes soft-deleted bots)
+func (r *Repository) GetBotByID(ctx context.Context, botID int64) (*models.Bot, error) {
+	var bot models.Bot
+	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(is_suspended, FALSE) as is_suspended, COALESCE(start_message, '') as start_message,
+			  COALESCE(forward_auto_replies, TRUE) as forward_auto_replies,
+			  COALESCE(forced_sub_enabled, FALSE) as forced_sub_enabled,
+			  COALESCE(forced_sub_message, '') as forced_sub_message,
+			  COALESCE(forced_sub_grace_count, 0) as forced_sub_grace_count,
+			  COALESCE(show_sent_confirmation, TRUE) as show_sent_confirmation,
+			  COALESCE(confirmation_emoji, '👍') as confirmation_emoji,
+			  COALESCE(confirmation_mode, 'react') as confirmation_mode,
+			  COALESCE(digest_interval_minutes, 0) as digest_interval_minutes,
+			  COALESCE(digest_urgent_keywords, '') as digest_urgent_keywords,
+			  COALESCE(relay_channel_id, 0) as relay_channel_id,
+			  COALESCE(archive_chat_id, 0) as archive_chat_id,
+			  COALESCE(blocked_media_types, '') as blocked_media_types,
+			  COALESCE(max_file_size_mb, 0) as max_file_size_mb,
+			  COALESCE(filter_profanity_action, 'off') as filter_profanity_action,
+			  COALESCE(filter_links_action, 'off') as filter_links_action,
+			  COALESCE(dedupe_window_minutes, 0) as dedupe_window_minutes,
+			  COALESCE(sla_minutes, 0) as sla_minutes,
+			  COALESCE(survey_enabled, FALSE) as survey_enabled,
+			  COALESCE(menu_button_enabled, TRUE) as menu_button_enabled,
+			  COALESCE(header_policy, 'never') as header_policy,
+			  COALESCE(rich_header_enabled, FALSE) as rich_header_enabled,
+			  COALESCE(privacy_notice, '') as privacy_notice,
+			  COALESCE(content_logging_enabled, FALSE) as content_logging_enabled,
+			  COALESCE(content_retention_days, 30) as content_retention_days,
+			  COALESCE(spam_threshold, 0) as spam_threshold,
+			  COALESCE(message_ttl_hours, 0) as message_ttl_hours,
+			  COALESCE(locale, '') as locale,
+			  COALESCE(timezone, '') as timezone,
+			  COALESCE(stats_digest, '') as stats_digest,
+			  COALESCE(whitelist_enabled, FALSE) as whitelist_enabled,
+			  COALESCE(whitelist_message, '') as whitelist_message,
+			  COALESCE(reaction_actions, '') as reaction_actions,
+			  COALESCE(factory_id, 0) as factory_id, created_at
+			  FROM bots WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db().GetContext(ctx, &bot, query, botID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bot by id: %w", err)
+	}
+
+	decryptedToken, err := crypto.DecryptDeterministic(bot.Token, r.encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("database data corruption: failed to decrypt token: %w", err)
+	}
+	bot.Token = decryptedToken
+
+	return &bot, nil
+}
+
+// GetBotSettingsByID retrieves a bot's settings by primary key without
+// selecting the token column, so hot paths that only need flags skip
+// encryption entirely. The returned Bot has an empty Token.
+func (r *Repository) GetBotSettingsByID(ctx context.Context, botID int64) (*models.Bot, error) {
+	var bot models.Bot
+	query := `SELECT id, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(is_suspended, FALSE) as is_suspended, COALESCE(start_message, '') as start_message,
+			  COALESCE(forward_auto_replies, TRUE) as forward_auto_replies,
+			  COALESCE(forced_sub_enabled, FALSE) as forced_sub_enabled,
+			  COALESCE(forced_sub_message, '') as forced_sub_message,
+			  COALESCE(forced_sub_grace_count, 0) as forced_sub_grace_count,
+			  COALESCE(show_sent_confirmation, TRUE) as show_sent_confirmation,
+			  COALESCE(confirmation_emoji, '👍') as confirmation_emoji,
+			  COALESCE(confirmation_mode, 'react') as confirmation_mode,
+			  COALESCE(digest_interval_minutes, 0) as digest_interval_minutes,
+			  COALESCE(digest_urgent_keywords, '') as digest_urgent_keywords,
+			  COALESCE(relay_channel_id, 0) as relay_channel_id,
+			  COALESCE(archive_chat_id, 0) as archive_chat_id,
+			  COALESCE(blocked_media_types, '') as blocked_media_types,
+			  COALESCE(max_file_size_mb, 0) as max_file_size_mb,
+			  COALESCE(filter_profanity_action, 'off') as filter_profanity_action,
+			  COALESCE(filter_links_action, 'off') as filter_links_action,
+			  COALESCE(dedupe_window_minutes, 0) as dedupe_window_minutes,
+			  COALESCE(sla_minutes, 0) as sla_minutes,
+			  COALESCE(survey_enabled, FALSE) as survey_enabled,
+			  COALESCE(menu_button_enabled, TRUE) as menu_button_enabled,
+			  COALESCE(header_policy, 'never') as header_policy,
+			  COALESCE(rich_header_enabled, FALSE) as rich_header_enabled,
+			  COALESCE(privacy_notice, '') as privacy_notice,
+			  COALESCE(content_logging_enabled, FALSE) as content_logging_enabled,
+			  COALESCE(content_retention_days, 30) as content_retention_days,
+			  COALESCE(spam_threshold, 0) as spam_threshold,
+			  COALESCE(message_ttl_hours, 0) as message_ttl_hours,
+			  COALESCE(locale, '') as locale,
+			  COALESCE(timezone, '') as timezone,
+			  COALESCE(stats_digest, '') as stats_digest,
+			  COALESCE(whitelist_enabled, FALSE) as whitelist_enabled,
+			  COALESCE(whitelist_message, '') as whitelist_message,
+			  COALESCE(reaction_actions, '') as reaction_actions,
+			  COALESCE(factory_id, 0) as factory_id, created_at
+			  FROM bots WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db().GetContext(ctx, &bot, query, botID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bot settings: %w", err)
+	}
+
+	return &bot, nil
+}
+
+// GetBotRuntimeSettings loads all per-message settings for a bot in one
+// query, for cache preloading at startup. Returns nil when the bot does
+// not exist or is soft-deleted.
+func (r *Repository) GetBotRuntimeSettings(ctx context.Context, botID int64) (*models.BotRuntimeSettings, error) {
+	var settings models.BotRuntimeSettings
+	query := `SELECT id, COALESCE(start_message, '') as start_message,
+			  COALESCE(forward_auto_replies, TRUE) as forward_auto_replies,
+			  COALESCE(forced_sub_enabled, FALSE) as forced_sub_enabled,
+			  COALESCE(forced_sub_message, '') as forced_sub_message,
+			  COALESCE(forced_sub_grace_count, 0) as forced_sub_grace_count,
+			  COALESCE(show_sent_confirmation, TRUE) as show_sent_confirmation,
+			  COALESCE(confirmation_emoji, '👍') as confirmation_emoji,
+			  COALESCE(confirmation_mode, 'react') as confirmation_mode,
+			  COALESCE(digest_interval_minutes, 0) as digest_interval_minutes,
+			  COALESCE(digest_urgent_keywords, '') as digest_urgent_keywords,
+			  COALESCE(relay_channel_id, 0) as relay_channel_id,
+			  COALESCE(archive_chat_id, 0) as archive_chat_id,
+			  COALESCE(blocked_media_types, '') as blocked_media_types,
+			  COALESCE(max_file_size_mb, 0) as max_file_size_mb,
+			  COALESCE(filter_profanity_action, 'off') as filter_profanity_action,
+			  COALESCE(filter_links_action, 'off') as filter_links_action,
+			  COALESCE(dedupe_window_minutes, 0) as dedupe_window_minutes,
+			  COALESCE(sla_minutes, 0) as sla_minutes,
+			  COALESCE(survey_enabled, FALSE) as survey_enabled,
+			  COALESCE(menu_button_enabled, TRUE) as menu_button_enabled,
+			  COALESCE(header_policy, 'never') as header_policy,
+			  COALESCE(rich_header_enabled, FALSE) as rich_header_enabled,
+			  COALESCE(privacy_notice, '') as privacy_notice,
+			  COALESCE(content_logging_enabled, FALSE) as content_logging_enabled,
+			  COALESCE(spam_threshold, 0) as spam_threshold,
+			  COALESCE(message_ttl_hours, 0) as message_ttl_hours,
+			  COALESCE(locale, '') as locale,
+			  COALESCE(timezone, '') as timezone,
+			  COALESCE(stats_digest, '') as stats_digest,
+			  COALESCE(whitelist_enabled, FALSE) as whitelist_enabled,
+			  COALESCE(whitelist_message, '') as whitelist_message,
+			  COALESCE(reaction_actions, '') as reaction_actions
+			  FROM bots WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db().GetContext(ctx, &settings, query, botID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bot runtime settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
 // GetDeletedBotByToken retrieves a soft-deleted bot by its token (for restore)
 func (r *Repository) GetDeletedBotByToken(ctx context.Context, token string) (*models.Bot, error) {
 	encryptedToken, err := crypto.EncryptDeterministic(token, r.encryptionKey)
@@ -86,7 +275,7 @@ func (r *Repository) GetDeletedBotByToken(ctx context.Context, token string) (*m
 	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(start_message, '') as start_message, created_at
 			  FROM bots WHERE token = ? AND deleted_at IS NOT NULL`
 
-	err = r.mysql.db.GetContext(ctx, &bot, query, encryptedToken)
+	err = r.db().GetContext(ctx, &bot, query, encryptedToken)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -112,7 +301,7 @@ func (r *Repository) RestoreBot(ctx context.Context, token string, ownerChatID i
 
 	query := `UPDATE bots SET deleted_at = NULL, is_active = TRUE, owner_chat_id = ?, username = ? WHERE token = ?`
 
-	_, err = r.mysql.db.ExecContext(ctx, query, ownerChatID, username, encryptedToken)
+	_, err = r.db().ExecContext(ctx, query, ownerChatID, username, encryptedToken)
 	if err != nil {
 		return fmt.Errorf("failed to restore bot: %w", err)
 	}
@@ -126,7 +315,7 @@ func (r *Repository) GetAllBots(ctx context.Context) ([]models.Bot, error) {
 	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(start_message, '') as start_message, created_at
 			  FROM bots WHERE deleted_at IS NULL`
 
-	err := r.mysql.db.SelectContext(ctx, &bots, query)
+	err := r.db().SelectContext(ctx, &bots, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all bots: %w", err)
 	}
@@ -148,7 +337,7 @@ func (r *Repository) GetDeletedBotsCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM bots WHERE deleted_at IS NOT NULL`
 
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get deleted bots count: %w", err)
 	}
@@ -162,7 +351,7 @@ func (r *Repository) GetActiveBots(ctx context.Context) ([]models.Bot, error) {
 	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(start_message, '') as start_message, created_at
 			  FROM bots WHERE is_active = TRUE AND deleted_at IS NULL`
 
-	err := r.mysql.db.SelectContext(ctx, &bots, query)
+	err := r.db().SelectContext(ctx, &bots, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active bots: %w", err)
 	}
@@ -188,12 +377,12 @@ func (r *Repository) DeactivateBot(ctx context.Context, token string) error {
 
 	query := `UPDATE bots SET is_active = FALSE WHERE token = ?`
 
-	_, err = r.mysql.db.ExecContext(ctx, query, encryptedToken)
+	result, err := r.db().ExecContext(ctx, query, encryptedToken)
 	if err != nil {
 		return fmt.Errorf("failed to deactivate bot: %w", err)
 	}
 
-	return nil
+	return checkRowsAffected(result, "deactivate bot")
 }
 
 // ActivateBot sets is_active to true for a bot
@@ -203,21 +392,22 @@ func (r *Repository) ActivateBot(ctx context.Context, token string) error {
 		return fmt.Errorf("failed to encrypt token: %w", err)
 	}
 
-	query := `UPDATE bots SET is_active = TRUE WHERE token = ?`
+	// Reactivation also closes any pending abandonment notice
+	query := `UPDATE bots SET is_active = TRUE, abandonment_notified_at = NULL WHERE token = ?`
 
-	_, err = r.mysql.db.ExecContext(ctx, query, encryptedToken)
+	result, err := r.db().ExecContext(ctx, query, encryptedToken)
 	if err != nil {
 		return fmt.Errorf("failed to activate bot: %w", err)
 	}
 
-	return nil
+	return checkRowsAffected(result, "activate bot")
 }
 
 // UpdateBotUsername updates the username for a bot
 func (r *Repository) UpdateBotUsername(ctx context.Context, botID int64, username string) error {
 	query := `UPDATE bots SET username = ? WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, username, botID)
+	_, err := r.db().ExecContext(ctx, query, username, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update username: %w", err)
 	}
@@ -229,7 +419,7 @@ func (r *Repository) UpdateBotUsername(ctx context.Context, botID int64, usernam
 func (r *Repository) UpdateBotStartMessage(ctx context.Context, botID int64, message string) error {
 	query := `UPDATE bots SET start_message = ? WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, message, botID)
+	_, err := r.db().ExecContext(ctx, query, message, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update start message: %w", err)
 	}
@@ -241,7 +431,7 @@ func (r *Repository) UpdateBotStartMessage(ctx context.Context, botID int64, mes
 func (r *Repository) UpdateBotForwardAutoReplies(ctx context.Context, botID int64, forward bool) error {
 	query := `UPDATE bots SET forward_auto_replies = ? WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, forward, botID)
+	_, err := r.db().ExecContext(ctx, query, forward, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update forward_auto_replies: %w", err)
 	}
@@ -249,11 +439,330 @@ func (r *Repository) UpdateBotForwardAutoReplies(ctx context.Context, botID int6
 	return nil
 }
 
+// UpdateBotConfirmationEmoji updates the reaction emoji used for sent confirmations
+func (r *Repository) UpdateBotConfirmationEmoji(ctx context.Context, botID int64, emoji string) error {
+	query := `UPDATE bots SET confirmation_emoji = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, emoji, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update confirmation_emoji: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotRelayChannel sets the channel whose posts are relayed to all bot
+// users. Zero disables the relay.
+func (r *Repository) UpdateBotRelayChannel(ctx context.Context, botID, channelID int64) error {
+	query := `UPDATE bots SET relay_channel_id = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, channelID, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update relay_channel_id: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotArchiveChat sets the chat that receives a copy of every inbound
+// and outbound message. Zero disables archiving.
+func (r *Repository) UpdateBotArchiveChat(ctx context.Context, botID, chatID int64) error {
+	query := `UPDATE bots SET archive_chat_id = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, chatID, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update archive_chat_id: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotBlockedMediaTypes updates the comma-separated media types users
+// may not send ("" = no restrictions)
+func (r *Repository) UpdateBotBlockedMediaTypes(ctx context.Context, botID int64, types string) error {
+	query := `UPDATE bots SET blocked_media_types = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, types, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update blocked_media_types: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotMaxFileSize updates the maximum accepted file size in MB
+// (0 = unlimited)
+func (r *Repository) UpdateBotMaxFileSize(ctx context.Context, botID int64, sizeMB int) error {
+	query := `UPDATE bots SET max_file_size_mb = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, sizeMB, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update max_file_size_mb: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotFilterAction sets the action ("off", "drop", "warn" or "flag")
+// of one built-in content filter. filterName is "profanity" or "links".
+func (r *Repository) UpdateBotFilterAction(ctx context.Context, botID int64, filterName string, action string) error {
+	var query string
+	switch filterName {
+	case "profanity":
+		query = `UPDATE bots SET filter_profanity_action = ? WHERE id = ?`
+	case "links":
+		query = `UPDATE bots SET filter_links_action = ? WHERE id = ?`
+	default:
+		return fmt.Errorf("unknown filter %q", filterName)
+	}
+
+	_, err := r.db().ExecContext(ctx, query, action, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update filter action: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotDedupeWindow updates the window (in minutes) inside which
+// identical consecutive messages from a user are suppressed. Zero disables
+// duplicate suppression.
+func (r *Repository) UpdateBotDedupeWindow(ctx context.Context, botID int64, minutes int) error {
+	query := `UPDATE bots SET dedupe_window_minutes = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, minutes, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update dedupe_window_minutes: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotSLAMinutes updates how long (in minutes) a message may stay
+// unanswered before the owner is alerted. Zero disables SLA alerts.
+func (r *Repository) UpdateBotSLAMinutes(ctx context.Context, botID int64, minutes int) error {
+	query := `UPDATE bots SET sla_minutes = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, minutes, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update sla_minutes: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotMessageTTL updates the reply-routing cache TTL override
+// (hours, 0 = use the global MESSAGE_TTL)
+func (r *Repository) UpdateBotMessageTTL(ctx context.Context, botID int64, hours int) error {
+	query := `UPDATE bots SET message_ttl_hours = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, hours, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update message_ttl_hours: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotLocale updates the owner's display language
+func (r *Repository) UpdateBotLocale(ctx context.Context, botID int64, locale string) error {
+	query := `UPDATE bots SET locale = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, locale, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update locale: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotTimezone updates the owner's display timezone
+func (r *Repository) UpdateBotTimezone(ctx context.Context, botID int64, tz string) error {
+	query := `UPDATE bots SET timezone = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, tz, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotStatsDigest updates the stats digest cadence ("", "daily" or
+// "weekly"). Changing the cadence resets the last-sent marker so the
+// next scheduler pass sends a fresh digest.
+func (r *Repository) UpdateBotStatsDigest(ctx context.Context, botID int64, cadence string) error {
+	query := `UPDATE bots SET stats_digest = ?, stats_digest_last_sent = NULL WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, cadence, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update stats digest: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotWhitelistEnabled toggles whitelist-only mode
+func (r *Repository) UpdateBotWhitelistEnabled(ctx context.Context, botID int64, enabled bool) error {
+	query := `UPDATE bots SET whitelist_enabled = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update whitelist enabled: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotWhitelistMessage sets the rejection message non-whitelisted
+// users receive ("" = stock wording)
+func (r *Repository) UpdateBotWhitelistMessage(ctx context.Context, botID int64, message string) error {
+	query := `UPDATE bots SET whitelist_message = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, message, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update whitelist message: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotReactionActions sets the emoji=action reaction workflow mapping
+func (r *Repository) UpdateBotReactionActions(ctx context.Context, botID int64, actions string) error {
+	query := `UPDATE bots SET reaction_actions = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, actions, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update reaction actions: %w", err)
+	}
+	return nil
+}
+
+// GetStatsDigestBots returns active bots whose stats digest is due:
+// opted in, and last sent longer ago than the cadence (or never)
+func (r *Repository) GetStatsDigestBots(ctx context.Context, now time.Time) ([]models.Bot, error) {
+	var bots []models.Bot
+	query := `SELECT id, owner_chat_id,
+			  COALESCE(stats_digest, '') as stats_digest
+			  FROM bots
+			  WHERE deleted_at IS NULL AND is_active = TRUE AND is_suspended = FALSE
+			    AND COALESCE(stats_digest, '') != ''
+			    AND (stats_digest_last_sent IS NULL
+			      OR (stats_digest = 'daily' AND stats_digest_last_sent <= ?)
+			      OR (stats_digest = 'weekly' AND stats_digest_last_sent <= ?))`
+
+	err := r.db().SelectContext(ctx, &bots, query, now.Add(-24*time.Hour), now.Add(-7*24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats digest bots: %w", err)
+	}
+	return bots, nil
+}
+
+// MarkStatsDigestSent records when a bot's stats digest last went out
+func (r *Repository) MarkStatsDigestSent(ctx context.Context, botID int64, sentAt time.Time) error {
+	query := `UPDATE bots SET stats_digest_last_sent = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, sentAt, botID)
+	if err != nil {
+		return fmt.Errorf("failed to mark stats digest sent: %w", err)
+	}
+	return nil
+}
+
+// UpdateBotMenuButtonEnabled toggles the Telegram commands menu button
+func (r *Repository) UpdateBotMenuButtonEnabled(ctx context.Context, botID int64, enabled bool) error {
+	query := `UPDATE bots SET menu_button_enabled = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update menu_button_enabled: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotSurveyEnabled toggles the post-reply satisfaction survey
+func (r *Repository) UpdateBotSurveyEnabled(ctx context.Context, botID int64, enabled bool) error {
+	query := `UPDATE bots SET survey_enabled = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update survey_enabled: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotDigestInterval updates how often (in minutes) forwarded messages are
+// batched into a digest. Zero disables digest mode.
+func (r *Repository) UpdateBotDigestInterval(ctx context.Context, botID int64, minutes int) error {
+	query := `UPDATE bots SET digest_interval_minutes = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, minutes, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update digest_interval_minutes: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotDigestKeywords updates the comma-separated urgent keywords that
+// bypass digest mode
+func (r *Repository) UpdateBotDigestKeywords(ctx context.Context, botID int64, keywords string) error {
+	query := `UPDATE bots SET digest_urgent_keywords = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, keywords, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update digest_urgent_keywords: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotConfirmationMode updates how sent confirmations are shown ("react" or "text")
+func (r *Repository) UpdateBotConfirmationMode(ctx context.Context, botID int64, mode string) error {
+	query := `UPDATE bots SET confirmation_mode = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, mode, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update confirmation_mode: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotHeaderPolicy updates how often the user info header is re-sent
+// ("never", "gap" or "daily")
+func (r *Repository) UpdateBotHeaderPolicy(ctx context.Context, botID int64, policy string) error {
+	query := `UPDATE bots SET header_policy = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, policy, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update header_policy: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotRichHeaderEnabled toggles the rich user info header (profile
+// photo, counters and permalink)
+func (r *Repository) UpdateBotRichHeaderEnabled(ctx context.Context, botID int64, enabled bool) error {
+	query := `UPDATE bots SET rich_header_enabled = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update rich_header_enabled: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotPrivacyNotice updates the privacy notice text appended to the
+// welcome message and served by /privacy. Empty disables the notice.
+func (r *Repository) UpdateBotPrivacyNotice(ctx context.Context, botID int64, notice string) error {
+	query := `UPDATE bots SET privacy_notice = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, notice, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update privacy_notice: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateBotShowSentConfirmation updates the show_sent_confirmation setting for a bot
 func (r *Repository) UpdateBotShowSentConfirmation(ctx context.Context, botID int64, show bool) error {
 	query := `UPDATE bots SET show_sent_confirmation = ? WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, show, botID)
+	_, err := r.db().ExecContext(ctx, query, show, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update show_sent_confirmation: %w", err)
 	}
@@ -270,21 +779,21 @@ func (r *Repository) DeleteBot(ctx context.Context, token string) error {
 
 	query := `UPDATE bots SET deleted_at = NOW(), is_active = FALSE WHERE token = ? AND deleted_at IS NULL`
 
-	_, err = r.mysql.db.ExecContext(ctx, query, encryptedToken)
+	result, err := r.db().ExecContext(ctx, query, encryptedToken)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete bot: %w", err)
 	}
 
-	return nil
+	return checkRowsAffected(result, "soft delete bot")
 }
 
 // GetBotsByOwner retrieves all bots owned by a specific user (excludes soft-deleted)
 func (r *Repository) GetBotsByOwner(ctx context.Context, ownerChatID int64) ([]models.Bot, error) {
 	var bots []models.Bot
-	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(start_message, '') as start_message, created_at
+	query := `SELECT id, token, COALESCE(username, '') as username, owner_chat_id, is_active, COALESCE(is_suspended, FALSE) as is_suspended, COALESCE(start_message, '') as start_message, created_at
 			  FROM bots WHERE owner_chat_id = ? AND deleted_at IS NULL`
 
-	err := r.mysql.db.SelectContext(ctx, &bots, query, ownerChatID)
+	err := r.db().SelectContext(ctx, &bots, query, ownerChatID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bots by owner: %w", err)
 	}
@@ -300,3 +809,65 @@ func (r *Repository) GetBotsByOwner(ctx context.Context, ownerChatID int64) ([]m
 
 	return bots, nil
 }
+
+// RekeyBotTokens re-encrypts every stored bot token (including soft-deleted
+// bots) with newKey, inside a single transaction. The repository must have
+// been created with the key the tokens are currently encrypted with.
+// Returns the number of tokens rewritten.
+func (r *Repository) RekeyBotTokens(ctx context.Context, newKey string) (int64, error) {
+	var rekeyed int64
+
+	err := r.WithTx(ctx, func(txRepo *Repository) error {
+		var rows []struct {
+			ID    int64  `db:"id"`
+			Token string `db:"token"`
+		}
+		query := `SELECT id, token FROM bots`
+		if err := txRepo.db().SelectContext(ctx, &rows, query); err != nil {
+			return fmt.Errorf("failed to load bot tokens: %w", err)
+		}
+
+		for _, row := range rows {
+			decrypted, err := crypto.DecryptDeterministic(row.Token, r.encryptionKey)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt bot token (ID: %d): %w", row.ID, err)
+			}
+			reencrypted, err := crypto.EncryptDeterministic(decrypted, newKey)
+			if err != nil {
+				return fmt.Errorf("failed to re-encrypt bot token (ID: %d): %w", row.ID, err)
+			}
+
+			updateQuery := `UPDATE bots SET token = ? WHERE id = ?`
+			if _, err := txRepo.db().ExecContext(ctx, updateQuery, reencrypted, row.ID); err != nil {
+				return fmt.Errorf("failed to update bot token (ID: %d): %w", row.ID, err)
+			}
+			rekeyed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rekeyed, nil
+}
+
+// PurgeDeletedBots permanently removes bots soft-deleted more than olderThanDays
+// ago. Dependent rows are cleaned up by the ON DELETE CASCADE constraints.
+// Returns the number of bots removed.
+func (r *Repository) PurgeDeletedBots(ctx context.Context, olderThanDays int) (int64, error) {
+	query := `DELETE FROM bots WHERE deleted_at IS NOT NULL AND deleted_at < DATE_SUB(NOW(), INTERVAL ? DAY)`
+
+	result, err := r.db().ExecContext(ctx, query, olderThanDays)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted bots: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get purged bot count: %w", err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ==================== Bot User Functions ====================
+
+// UpsertBotUser records that a user can be reached by the bot, creating the
+// row on first contact and clearing any previous block
+func (r *Repository) UpsertBotUser(ctx context.Context, botID, userChatID int64) error {
+	query := `INSERT INTO bot_users (bot_id, user_chat_id, is_reachable)
+			  VALUES (?, ?, TRUE)
+			  ON DUPLICATE KEY UPDATE is_reachable = TRUE, blocked_at = NULL`
+
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID)
+	if err != nil {
+		return classifyError("upsert bot user", err)
+	}
+	return nil
+}
+
+// MarkUserUnreachable records that the bot can no longer message a user,
+// e.g. after they block it
+func (r *Repository) MarkUserUnreachable(ctx context.Context, botID, userChatID int64) error {
+	query := `INSERT INTO bot_users (bot_id, user_chat_id, is_reachable, blocked_at)
+			  VALUES (?, ?, FALSE, NOW())
+			  ON DUPLICATE KEY UPDATE is_reachable = FALSE, blocked_at = NOW()`
+
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID)
+	if err != nil {
+		return classifyError("mark user unreachable", err)
+	}
+	return nil
+}
+
+// GetReachableUserCount returns how many known users the bot can still message
+func (r *Repository) GetReachableUserCount(ctx context.Context, botID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM bot_users WHERE bot_id = ? AND is_reachable = TRUE`
+	err := r.db().GetContext(ctx, &count, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reachable user count: %w", err)
+	}
+	return count, nil
+}
+
+// GetBlockedUserCount returns how many known users have blocked the bot
+func (r *Repository) GetBlockedUserCount(ctx context.Context, botID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM bot_users WHERE bot_id = ? AND is_reachable = FALSE`
+	err := r.db().GetContext(ctx, &count, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get blocked user count: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Broadcast History Functions ====================
+
+// CreateBroadcast records a completed broadcast in the history
+func (r *Repository) CreateBroadcast(ctx context.Context, b *models.Broadcast) error {
+	query := `INSERT INTO broadcasts (bot_id, composer_chat_id, message_id, audience, success_count, blocked_count, failed_count)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query,
+		b.BotID, b.ComposerChatID, b.MessageID, b.Audience, b.SuccessCount, b.BlockedCount, b.FailedCount)
+	if err != nil {
+		return classifyError("create broadcast", err)
+	}
+	return nil
+}
+
+// GetBroadcasts retrieves a bot's most recent broadcasts, newest first
+func (r *Repository) GetBroadcasts(ctx context.Context, botID int64, limit int) ([]models.Broadcast, error) {
+	var broadcasts []models.Broadcast
+	query := `SELECT id, bot_id, composer_chat_id, message_id, audience, success_count, blocked_count, failed_count, sent_at
+			  FROM broadcasts WHERE bot_id = ?
+			  ORDER BY sent_at DESC LIMIT ?`
+
+	err := r.db().SelectContext(ctx, &broadcasts, query, botID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcasts: %w", err)
+	}
+	return broadcasts, nil
+}
+
+// GetBroadcastByID retrieves one broadcast, scoped to the bot so an
+// owner can never reach another bot's history
+func (r *Repository) GetBroadcastByID(ctx context.Context, botID, broadcastID int64) (*models.Broadcast, error) {
+	var broadcast models.Broadcast
+	query := `SELECT id, bot_id, composer_chat_id, message_id, audience, success_count, blocked_count, failed_count, sent_at
+			  FROM broadcasts WHERE id = ? AND bot_id = ?`
+
+	err := r.db().GetContext(ctx, &broadcast, query, broadcastID, botID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast: %w", err)
+	}
+	return &broadcast, nil
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+)
+
+// Message content storage for bots that opted into content logging.
+// Content is encrypted at rest with a key derived per bot from the
+// repository's master key, so rows of different tenants are never
+// readable under the same key.
+
+// contentKey derives the encryption key for one bot's message content
+func (r *Repository) contentKey(botID int64) string {
+	return crypto.DeriveKey(r.encryptionKey, fmt.Sprintf("content:%d", botID))
+}
+
+// SaveMessageContent stores one incoming message's text, encrypted with
+// the bot's derived content key
+func (r *Repository) SaveMessageContent(ctx context.Context, botID, userChatID int64, adminMsgID int, text string) error {
+	encrypted, err := crypto.Encrypt(text, r.contentKey(botID))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message content: %w", err)
+	}
+
+	query := `INSERT INTO message_contents (bot_id, user_chat_id, admin_msg_id, content) VALUES (?, ?, ?, ?)`
+
+	_, err = r.db().ExecContext(ctx, query, botID, userChatID, adminMsgID, encrypted)
+	if err != nil {
+		return fmt.Errorf("failed to save message content: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageContents returns a bot's stored messages, newest first,
+// decrypted. Rows that fail to decrypt (e.g. written under an old master
+// key) are skipped rather than failing the whole read.
+func (r *Repository) GetMessageContents(ctx context.Context, botID int64, limit int) ([]models.MessageContent, error) {
+	var rows []models.MessageContent
+	query := `SELECT id, bot_id, user_chat_id, admin_msg_id, content, created_at
+			  FROM message_contents WHERE bot_id = ?
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	err := r.db().SelectContext(ctx, &rows, query, botID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message contents: %w", err)
+	}
+
+	key := r.contentKey(botID)
+	out := make([]models.MessageContent, 0, len(rows))
+	for _, row := range rows {
+		decrypted, err := crypto.Decrypt(row.Content, key)
+		if err != nil {
+			continue
+		}
+		row.Content = decrypted
+		out = append(out, row)
+	}
+
+	return out, nil
+}
+
+// GetMessageTextsSince returns the decrypted text of messages received
+// after a cutoff, newest first, for keyword aggregation. Rows that fail
+// to decrypt are skipped.
+func (r *Repository) GetMessageTextsSince(ctx context.Context, botID int64, since time.Time, limit int) ([]string, error) {
+	var rows []string
+	query := `SELECT content FROM message_contents
+			  WHERE bot_id = ? AND created_at >= ?
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	err := r.db().SelectContext(ctx, &rows, query, botID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message texts: %w", err)
+	}
+
+	key := r.contentKey(botID)
+	out := make([]string, 0, len(rows))
+	for _, row := range rows {
+		decrypted, err := crypto.Decrypt(row, key)
+		if err != nil {
+			continue
+		}
+		out = append(out, decrypted)
+	}
+	return out, nil
+}
+
+// contentSearchScanLimit caps how many recent rows a search decrypts.
+// Content is encrypted at rest, so a MySQL FULLTEXT index would only
+// ever see ciphertext; matching has to happen in Go after decryption.
+const contentSearchScanLimit = 500
+
+// SearchMessageContents returns stored messages containing the query,
+// case-insensitively, newest first. Only the most recent rows within the
+// scan limit are considered.
+func (r *Repository) SearchMessageContents(ctx context.Context, botID int64, search string, limit int) ([]models.MessageContent, error) {
+	rows, err := r.GetMessageContents(ctx, botID, contentSearchScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(search)
+	var matches []models.MessageContent
+	for _, row := range rows {
+		if strings.Contains(strings.ToLower(row.Content), needle) {
+			matches = append(matches, row)
+			if len(matches) >= limit {
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// GetMessageContentCount returns how many messages are stored for a bot
+func (r *Repository) GetMessageContentCount(ctx context.Context, botID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM message_contents WHERE bot_id = ?`
+
+	err := r.db().GetContext(ctx, &count, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count message contents: %w", err)
+	}
+
+	return count, nil
+}
+
+// PurgeMessageContents deletes all stored content for a bot (the
+// owner-initiated purge). Returns the number of deleted rows.
+func (r *Repository) PurgeMessageContents(ctx context.Context, botID int64) (int64, error) {
+	query := `DELETE FROM message_contents WHERE bot_id = ?`
+
+	result, err := r.db().ExecContext(ctx, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge message contents: %w", err)
+	}
+
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
+
+// PurgeExpiredMessageContents deletes rows older than each bot's own
+// retention window. Run periodically by the retention loop.
+func (r *Repository) PurgeExpiredMessageContents(ctx context.Context) (int64, error) {
+	query := `DELETE mc FROM message_contents mc
+			  JOIN bots b ON b.id = mc.bot_id
+			  WHERE mc.created_at < NOW() - INTERVAL COALESCE(b.content_retention_days, 30) DAY`
+
+	result, err := r.db().ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired message contents: %w", err)
+	}
+
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
+
+// UpdateBotContentLogging toggles message content logging for a bot
+func (r *Repository) UpdateBotContentLogging(ctx context.Context, botID int64, enabled bool) error {
+	query := `UPDATE bots SET content_logging_enabled = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update content_logging_enabled: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotContentRetentionDays updates how long stored content is kept
+func (r *Repository) UpdateBotContentRetentionDays(ctx context.Context, botID int64, days int) error {
+	query := `UPDATE bots SET content_retention_days = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, days, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update content_retention_days: %w", err)
+	}
+
+	return nil
+}
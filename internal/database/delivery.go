@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Delivery Functions ====================
+
+// RecordDelivery stores the outcome of sending a reply or broadcast to a user
+func (r *Repository) RecordDelivery(ctx context.Context, botID, userChatID int64, source, status, errorCode string) error {
+	query := `INSERT INTO deliveries (bot_id, user_chat_id, source, status, error_code) VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID, source, status, errorCode)
+	if err != nil {
+		return classifyError("record delivery", err)
+	}
+
+	return nil
+}
+
+// GetLastDelivery returns the most recent delivery outcome for a user, or
+// nil if nothing has been sent to them yet
+func (r *Repository) GetLastDelivery(ctx context.Context, botID, userChatID int64) (*models.Delivery, error) {
+	var delivery models.Delivery
+	query := `SELECT id, bot_id, user_chat_id, source, status, COALESCE(error_code, '') as error_code, created_at
+			  FROM deliveries WHERE bot_id = ? AND user_chat_id = ?
+			  ORDER BY created_at DESC, id DESC LIMIT 1`
+
+	err := r.db().GetContext(ctx, &delivery, query, botID, userChatID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
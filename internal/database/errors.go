@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"modernc.org/sqlite"
+)
+
+// ==================== Repository Errors ====================
+
+// ErrNotFound is returned when an update or delete matched no rows,
+// e.g. the target bot does not exist or was already soft-deleted.
+// Callers should check with errors.Is.
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate is returned when an insert violates a unique constraint,
+// e.g. registering a token that already belongs to another bot.
+var ErrDuplicate = errors.New("duplicate record")
+
+// ErrUnavailable is returned when the database cannot be reached, so
+// handlers can ask users to retry instead of reporting a permanent failure.
+var ErrUnavailable = errors.New("database unavailable")
+
+// mysqlErrDuplicateEntry is the MySQL error number for unique key violations
+const mysqlErrDuplicateEntry = 1062
+
+// SQLite extended result codes for unique key violations, reported by
+// the dev backend (SQLITE_CONSTRAINT_PRIMARYKEY / SQLITE_CONSTRAINT_UNIQUE)
+const (
+	sqliteErrConstraintPrimaryKey = 1555
+	sqliteErrConstraintUnique     = 2067
+)
+
+// classifyError wraps a driver error with the matching sentinel so callers
+// can branch with errors.Is instead of parsing message strings
+func classifyError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return fmt.Errorf("failed to %s: %w", op, ErrDuplicate)
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code() == sqliteErrConstraintPrimaryKey || sqliteErr.Code() == sqliteErrConstraintUnique) {
+		return fmt.Errorf("failed to %s: %w", op, ErrDuplicate)
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return fmt.Errorf("failed to %s: %w", op, ErrUnavailable)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("failed to %s: %w", op, ErrUnavailable)
+	}
+
+	return fmt.Errorf("failed to %s: %w", op, err)
+}
+
+// checkRowsAffected converts a zero-row update into ErrNotFound so callers
+// can distinguish "bot does not exist" from a real database failure
+func checkRowsAffected(result sql.Result, op string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("failed to %s: %w", op, ErrNotFound)
+	}
+	return nil
+}
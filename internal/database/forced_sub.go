@@ -16,9 +16,9 @@ func (r *Repository) CreateForcedChannel(ctx context.Context, botID, channelID i
 			  VALUES (?, ?, ?, ?, ?, TRUE)
 			  ON DUPLICATE KEY UPDATE channel_username = ?, channel_title = ?, invite_link = ?, is_active = TRUE`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, botID, channelID, username, title, inviteLink, username, title, inviteLink)
+	_, err := r.db().ExecContext(ctx, query, botID, channelID, username, title, inviteLink, username, title, inviteLink)
 	if err != nil {
-		return fmt.Errorf("failed to create forced channel: %w", err)
+		return classifyError("create forced channel", err)
 	}
 	return nil
 }
@@ -32,7 +32,7 @@ func (r *Repository) GetForcedChannels(ctx context.Context, botID int64) ([]mode
 			  FROM forced_channels WHERE bot_id = ? AND is_active = TRUE
 			  ORDER BY created_at ASC`
 
-	err := r.mysql.db.SelectContext(ctx, &channels, query, botID)
+	err := r.db().SelectContext(ctx, &channels, query, botID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get forced channels: %w", err)
 	}
@@ -47,7 +47,7 @@ func (r *Repository) GetForcedChannel(ctx context.Context, botID, channelID int6
 			  is_active, created_at
 			  FROM forced_channels WHERE bot_id = ? AND channel_id = ?`
 
-	err := r.mysql.db.GetContext(ctx, &channel, query, botID, channelID)
+	err := r.db().GetContext(ctx, &channel, query, botID, channelID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -57,21 +57,27 @@ func (r *Repository) GetForcedChannel(ctx context.Context, botID, channelID int6
 	return &channel, nil
 }
 
-// DeleteForcedChannel removes a channel from forced subscription list
-func (r *Repository) DeleteForcedChannel(ctx context.Context, botID, channelID int64) error {
-	query := `DELETE FROM forced_channels WHERE bot_id = ? AND channel_id = ?`
-	_, err := r.mysql.db.ExecContext(ctx, query, botID, channelID)
-	if err != nil {
-		return fmt.Errorf("failed to delete forced channel: %w", err)
-	}
-	return nil
+// DeleteForcedChannel removes a channel from the forced subscription list
+// after verifying that ownerChatID owns the bot
+func (r *Repository) DeleteForcedChannel(ctx context.Context, botID, channelID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM forced_channels WHERE bot_id = ? AND channel_id = ?`
+		if _, err := tx.db().ExecContext(ctx, query, botID, channelID); err != nil {
+			return fmt.Errorf("failed to delete forced channel: %w", err)
+		}
+		return nil
+	})
 }
 
 // GetForcedChannelCount returns count of active forced channels for a bot
 func (r *Repository) GetForcedChannelCount(ctx context.Context, botID int64) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM forced_channels WHERE bot_id = ? AND is_active = TRUE`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID)
+	err := r.db().GetContext(ctx, &count, query, botID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get forced channel count: %w", err)
 	}
@@ -81,7 +87,7 @@ func (r *Repository) GetForcedChannelCount(ctx context.Context, botID int64) (in
 // UpdateForcedSubEnabled toggles the forced subscription feature for a bot
 func (r *Repository) UpdateForcedSubEnabled(ctx context.Context, botID int64, enabled bool) error {
 	query := `UPDATE bots SET forced_sub_enabled = ? WHERE id = ?`
-	_, err := r.mysql.db.ExecContext(ctx, query, enabled, botID)
+	_, err := r.db().ExecContext(ctx, query, enabled, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update forced_sub_enabled: %w", err)
 	}
@@ -91,9 +97,89 @@ func (r *Repository) UpdateForcedSubEnabled(ctx context.Context, botID int64, en
 // UpdateForcedSubMessage updates the custom message for non-subscribers
 func (r *Repository) UpdateForcedSubMessage(ctx context.Context, botID int64, message string) error {
 	query := `UPDATE bots SET forced_sub_message = ? WHERE id = ?`
-	_, err := r.mysql.db.ExecContext(ctx, query, message, botID)
+	_, err := r.db().ExecContext(ctx, query, message, botID)
 	if err != nil {
 		return fmt.Errorf("failed to update forced_sub_message: %w", err)
 	}
 	return nil
 }
+
+// RecordChannelMemberCount stores one member count sample for a channel
+func (r *Repository) RecordChannelMemberCount(ctx context.Context, botID, channelID int64, memberCount int) error {
+	query := `INSERT INTO channel_member_counts (bot_id, channel_id, member_count) VALUES (?, ?, ?)`
+	_, err := r.db().ExecContext(ctx, query, botID, channelID, memberCount)
+	if err != nil {
+		return fmt.Errorf("failed to record channel member count: %w", err)
+	}
+	return nil
+}
+
+// GetChannelMemberHistory retrieves the most recent member count samples
+// for a channel, oldest first
+func (r *Repository) GetChannelMemberHistory(ctx context.Context, botID, channelID int64, limit int) ([]models.ChannelMemberCount, error) {
+	var samples []models.ChannelMemberCount
+	query := `SELECT * FROM (
+		SELECT * FROM channel_member_counts
+		WHERE bot_id = ? AND channel_id = ?
+		ORDER BY recorded_at DESC LIMIT ?
+	) recent ORDER BY recorded_at ASC`
+
+	err := r.db().SelectContext(ctx, &samples, query, botID, channelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel member history: %w", err)
+	}
+	return samples, nil
+}
+
+// UpdateForcedSubGraceCount updates the number of free messages a user may
+// send before forced subscription is enforced (0 = enforce immediately)
+func (r *Repository) UpdateForcedSubGraceCount(ctx context.Context, botID int64, count int) error {
+	query := `UPDATE bots SET forced_sub_grace_count = ? WHERE id = ?`
+	_, err := r.db().ExecContext(ctx, query, count, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update forced_sub_grace_count: %w", err)
+	}
+	return nil
+}
+
+// AddForcedSubExemption exempts a user from forced subscription checks
+func (r *Repository) AddForcedSubExemption(ctx context.Context, botID, userChatID int64) error {
+	query := `INSERT IGNORE INTO forced_sub_exemptions (bot_id, user_chat_id) VALUES (?, ?)`
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID)
+	if err != nil {
+		return fmt.Errorf("failed to add forced sub exemption: %w", err)
+	}
+	return nil
+}
+
+// RemoveForcedSubExemption removes a user's forced subscription exemption
+func (r *Repository) RemoveForcedSubExemption(ctx context.Context, botID, userChatID int64) error {
+	query := `DELETE FROM forced_sub_exemptions WHERE bot_id = ? AND user_chat_id = ?`
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID)
+	if err != nil {
+		return fmt.Errorf("failed to remove forced sub exemption: %w", err)
+	}
+	return nil
+}
+
+// IsForcedSubExempt reports whether a user is on the exemption list
+func (r *Repository) IsForcedSubExempt(ctx context.Context, botID, userChatID int64) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM forced_sub_exemptions WHERE bot_id = ? AND user_chat_id = ?`
+	err := r.db().GetContext(ctx, &count, query, botID, userChatID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check forced sub exemption: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetForcedSubExemptions retrieves all exempted users for a bot
+func (r *Repository) GetForcedSubExemptions(ctx context.Context, botID int64) ([]models.ForcedSubExemption, error) {
+	var exemptions []models.ForcedSubExemption
+	query := `SELECT * FROM forced_sub_exemptions WHERE bot_id = ? ORDER BY created_at ASC`
+	err := r.db().SelectContext(ctx, &exemptions, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forced sub exemptions: %w", err)
+	}
+	return exemptions, nil
+}
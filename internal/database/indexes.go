@@ -0,0 +1,128 @@
+package database
+
+import (
+	"fmt"
+	"log"
+)
+
+// ==================== Index Advisor ====================
+//
+// The hot-path queries only stay fast with the right composite indexes,
+// and CREATE INDEX can silently fail on locked-down schemas (the
+// migration only logs a warning). This advisor re-checks the required
+// indexes after migration and, when one is missing, runs EXPLAIN on a
+// representative query so the log shows what the database actually does
+// without it.
+
+// requiredIndex describes one composite index the hot paths rely on
+type requiredIndex struct {
+	table   string
+	name    string
+	columns string
+	// probe is a representative query; it is EXPLAINed when the index
+	// is missing so the warning includes real access-plan diagnostics
+	probe string
+}
+
+// requiredIndexes lists the composite indexes for message_logs, the
+// largest and hottest table
+var requiredIndexes = []requiredIndex{
+	{
+		table:   "message_logs",
+		name:    "idx_lookup",
+		columns: "admin_msg_id, bot_id",
+		probe:   "SELECT user_chat_id FROM message_logs WHERE admin_msg_id = 1 AND bot_id = 1",
+	},
+	{
+		table:   "message_logs",
+		name:    "idx_bot_user",
+		columns: "bot_id, user_chat_id",
+		probe:   "SELECT COUNT(*) FROM message_logs WHERE bot_id = 1 AND user_chat_id = 1",
+	},
+	{
+		table:   "message_logs",
+		name:    "idx_bot_created",
+		columns: "bot_id, created_at",
+		probe:   "SELECT COUNT(*) FROM message_logs WHERE bot_id = 1 AND created_at > NOW() - INTERVAL 1 DAY",
+	},
+	{
+		table:   "message_logs",
+		name:    "idx_bot_user_created",
+		columns: "bot_id, user_chat_id, created_at",
+		probe:   "SELECT COUNT(*) FROM message_logs WHERE bot_id = 1 AND user_chat_id = 1 AND created_at > NOW() - INTERVAL 1 DAY",
+	},
+}
+
+// hasIndex reports whether the (prefixed) table carries the named index
+func (m *MySQL) hasIndex(table, indexName string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.STATISTICS
+			  WHERE TABLE_SCHEMA = DATABASE()
+			  AND TABLE_NAME = ?
+			  AND INDEX_NAME = ?`
+	if err := m.db.Get(&count, query, m.tablePrefix+table, indexName); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// checkIndexes warns about required indexes that are still missing after
+// migration, with EXPLAIN output for the query each index serves. It
+// never fails startup: a slow deployment is better than no deployment.
+func (m *MySQL) checkIndexes() {
+	// information_schema and MySQL's EXPLAIN output do not exist on the
+	// SQLite dev backend, and an in-memory database has no hot paths
+	// worth advising on
+	if m.dialect == dialectSQLite {
+		return
+	}
+
+	for _, idx := range requiredIndexes {
+		exists, err := m.hasIndex(idx.table, idx.name)
+		if err != nil {
+			log.Printf("Warning: failed to check index %s on %s: %v", idx.name, idx.table, err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		log.Printf("Warning: missing index %s on %s (%s); queries filtering on these columns will scan the table",
+			idx.name, idx.table, idx.columns)
+		m.explainProbe(idx)
+	}
+}
+
+// explainProbe logs the access plan MySQL picks for the probe query
+func (m *MySQL) explainProbe(idx requiredIndex) {
+	rows, err := m.db.Queryx("EXPLAIN " + prefixTables(idx.probe, m.tablePrefix))
+	if err != nil {
+		log.Printf("Warning: EXPLAIN failed for %s probe: %v", idx.name, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		plan := map[string]interface{}{}
+		if err := rows.MapScan(plan); err != nil {
+			log.Printf("Warning: failed to read EXPLAIN output for %s: %v", idx.name, err)
+			return
+		}
+		log.Printf("  EXPLAIN %s: type=%s key=%s rows=%s extra=%s",
+			idx.name, explainField(plan, "type"), explainField(plan, "key"),
+			explainField(plan, "rows"), explainField(plan, "Extra"))
+	}
+}
+
+// explainField renders one EXPLAIN column, which the driver may return
+// as bytes, string or NULL depending on the server version
+func explainField(plan map[string]interface{}, column string) string {
+	switch v := plan[column].(type) {
+	case nil:
+		return "-"
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -16,7 +16,9 @@ import (
 
 // MySQL wraps the sqlx.DB connection
 type MySQL struct {
-	db *sqlx.DB
+	db          *sqlx.DB
+	tablePrefix string // rewrites table names when deployments share one schema
+	dialect     string // empty for MySQL; dev mode swaps in SQLite (see sqlite.go)
 }
 
 // ============================================
@@ -25,6 +27,16 @@ type MySQL struct {
 
 // NewMySQL creates a new MySQL connection with retry logic
 func NewMySQL(dsn string) (*MySQL, error) {
+	return NewMySQLWithPrefix(dsn, "")
+}
+
+// NewMySQLWithPrefix connects like NewMySQL but prefixes every table
+// name, so multiple deployments can share one schema (see tenant.go)
+func NewMySQLWithPrefix(dsn, tablePrefix string) (*MySQL, error) {
+	if err := validateTablePrefix(tablePrefix); err != nil {
+		return nil, err
+	}
+
 	var db *sqlx.DB
 	var err error
 
@@ -52,7 +64,7 @@ func NewMySQL(dsn string) (*MySQL, error) {
 	db.SetConnMaxLifetime(10 * time.Minute) // Increased from 5
 	db.SetConnMaxIdleTime(5 * time.Minute)  // New: prevent stale connections
 
-	mysql := &MySQL{db: db}
+	mysql := &MySQL{db: db, tablePrefix: tablePrefix}
 
 	// Run migrations
 	if err := mysql.migrate(); err != nil {
@@ -134,6 +146,7 @@ var baseTableQueries = []string{
 		trigger_type ENUM('keyword', 'command') NOT NULL DEFAULT 'keyword',
 		match_type ENUM('exact', 'contains') DEFAULT 'contains',
 		is_active BOOLEAN DEFAULT TRUE,
+		is_quick_reply BOOLEAN DEFAULT FALSE,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		UNIQUE KEY idx_bot_trigger (bot_id, trigger_word, trigger_type),
 		INDEX idx_auto_replies_bot (bot_id, is_active),
@@ -179,6 +192,206 @@ var baseTableQueries = []string{
 		INDEX idx_bot_active (bot_id, is_active),
 		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Replies table (maps an owner's reply message to the copy sent to the user)
+	`CREATE TABLE IF NOT EXISTS replies (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		admin_msg_id INT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		user_msg_id INT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_bot_admin_msg (bot_id, admin_msg_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Deliveries table (outcome of replies and broadcasts per user)
+	`CREATE TABLE IF NOT EXISTS deliveries (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		source ENUM('reply', 'broadcast') NOT NULL,
+		status ENUM('delivered', 'blocked', 'error') NOT NULL,
+		error_code VARCHAR(64),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_user (bot_id, user_chat_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Bot users table (per-user reachability, kept current by my_chat_member updates)
+	`CREATE TABLE IF NOT EXISTS bot_users (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		is_reachable BOOLEAN DEFAULT TRUE,
+		blocked_at TIMESTAMP NULL DEFAULT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_bot_user (bot_id, user_chat_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Abuse reports table (end-user complaints filed via /report in child bots)
+	`CREATE TABLE IF NOT EXISTS abuse_reports (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		reporter_chat_id BIGINT NOT NULL,
+		reason TEXT,
+		status ENUM('open', 'resolved', 'dismissed') NOT NULL DEFAULT 'open',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_status (bot_id, status),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Ratings table (1-5 star satisfaction scores from end users)
+	`CREATE TABLE IF NOT EXISTS ratings (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		stars TINYINT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_id (bot_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// ToS acceptances table (one row per factory user who accepted the terms)
+	`CREATE TABLE IF NOT EXISTS tos_acceptances (
+		chat_id BIGINT PRIMARY KEY,
+		accepted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Routing rules table (keyword -> destination chat for forwarded messages)
+	`CREATE TABLE IF NOT EXISTS routing_rules (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		keyword VARCHAR(100) NOT NULL,
+		dest_chat_id BIGINT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_bot_keyword (bot_id, keyword),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Audit logs table (records destructive owner/admin actions)
+	`CREATE TABLE IF NOT EXISTS audit_logs (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		actor_chat_id BIGINT NOT NULL,
+		action VARCHAR(32) NOT NULL,
+		target VARCHAR(64),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_id (bot_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Forced sub exemptions table (users who bypass the subscription check)
+	`CREATE TABLE IF NOT EXISTS forced_sub_exemptions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_bot_user (bot_id, user_chat_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Channel member counts table (time series for subscriber growth)
+	`CREATE TABLE IF NOT EXISTS channel_member_counts (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		channel_id BIGINT NOT NULL,
+		member_count INT NOT NULL,
+		recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_channel_history (bot_id, channel_id, recorded_at),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Owner plan tiers (broadcast throughput)
+	`CREATE TABLE IF NOT EXISTS owner_plans (
+		owner_chat_id BIGINT PRIMARY KEY,
+		plan VARCHAR(16) NOT NULL DEFAULT 'free',
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Owner-level UI preferences (accessibility)
+	`CREATE TABLE IF NOT EXISTS owner_prefs (
+		owner_chat_id BIGINT PRIMARY KEY,
+		plain_ui BOOLEAN DEFAULT FALSE,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// API keys for the management API (hashed, scoped to one bot)
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		owner_chat_id BIGINT NOT NULL,
+		bot_id BIGINT NOT NULL,
+		key_hash CHAR(64) NOT NULL UNIQUE,
+		label VARCHAR(64) NOT NULL DEFAULT '',
+		permissions VARCHAR(255) NOT NULL,
+		last_used_at TIMESTAMP NULL DEFAULT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_owner_bot (owner_chat_id, bot_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Encrypted message content (only written when the owner opts in)
+	`CREATE TABLE IF NOT EXISTS message_contents (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		admin_msg_id INT NOT NULL,
+		content TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_created (bot_id, created_at),
+		INDEX idx_bot_user (bot_id, user_chat_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Owner verdicts on spam-scored messages (classifier training data)
+	`CREATE TABLE IF NOT EXISTS spam_feedback (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		verdict VARCHAR(8) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot (bot_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	// Owner-created short links served from /l/{code}. Codes are unique
+	// across all bots; each bot manages its own set.
+	`CREATE TABLE IF NOT EXISTS short_links (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		code VARCHAR(16) NOT NULL,
+		target_url VARCHAR(2048) NOT NULL,
+		clicks BIGINT DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_code (code),
+		INDEX idx_bot (bot_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	`CREATE TABLE IF NOT EXISTS whitelisted_users (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		user_chat_id BIGINT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_bot_user (bot_id, user_chat_id),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+
+	`CREATE TABLE IF NOT EXISTS broadcasts (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		bot_id BIGINT NOT NULL,
+		composer_chat_id BIGINT NOT NULL,
+		message_id INT NOT NULL,
+		audience INT NOT NULL DEFAULT 0,
+		success_count INT NOT NULL DEFAULT 0,
+		blocked_count INT NOT NULL DEFAULT 0,
+		failed_count INT NOT NULL DEFAULT 0,
+		sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_bot_sent (bot_id, sent_at),
+		FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
 }
 
 // ============================================
@@ -188,7 +401,7 @@ var baseTableQueries = []string{
 // migrate runs all database migrations
 func (m *MySQL) migrate() error {
 	for _, query := range baseTableQueries {
-		if _, err := m.db.Exec(query); err != nil {
+		if _, err := m.db.Exec(m.rewriteDDL(prefixTables(query, m.tablePrefix))); err != nil {
 			return fmt.Errorf("failed to create table: %w", err)
 		}
 	}
@@ -209,25 +422,192 @@ func (m *MySQL) migrate() error {
 		log.Printf("Warning: %v", err)
 	}
 
+	// Add quick-reply keyboard flag to auto_replies table
+	if err := m.addColumnIfNotExists("auto_replies", "is_quick_reply", "BOOLEAN DEFAULT FALSE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
 	// Add username column to bots table
 	if err := m.addColumnIfNotExists("bots", "username", "VARCHAR(255) DEFAULT NULL"); err != nil {
 		log.Printf("Warning: %v", err)
 	}
 
-	// Add performance indexes for message_logs
-	// Critical: Used in HasUserInteracted and GetAllUserChatIDs
-	if err := m.addIndexIfNotExists("message_logs", "idx_bot_user", "bot_id, user_chat_id"); err != nil {
+	// Add configurable confirmation emoji to bots table
+	if err := m.addColumnIfNotExists("bots", "confirmation_emoji", "VARCHAR(16) DEFAULT '👍'"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add confirmation mode ("react" or "text") to bots table
+	if err := m.addColumnIfNotExists("bots", "confirmation_mode", "VARCHAR(8) DEFAULT 'react'"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add digest mode settings to bots table
+	if err := m.addColumnIfNotExists("bots", "digest_interval_minutes", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "digest_urgent_keywords", "TEXT"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add channel relay source to bots table
+	if err := m.addColumnIfNotExists("bots", "relay_channel_id", "BIGINT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add archive chat to bots table
+	if err := m.addColumnIfNotExists("bots", "archive_chat_id", "BIGINT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Record which factory bot registered each bot (multi-factory deployments)
+	if err := m.addColumnIfNotExists("bots", "factory_id", "BIGINT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Flag bots suspended by the factory admin after abuse reports
+	if err := m.addColumnIfNotExists("bots", "is_suspended", "BOOLEAN DEFAULT FALSE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add media policy settings to bots table
+	if err := m.addColumnIfNotExists("bots", "blocked_media_types", "TEXT"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "max_file_size_mb", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add content filter actions to bots table
+	if err := m.addColumnIfNotExists("bots", "filter_profanity_action", "VARCHAR(8) DEFAULT 'off'"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "filter_links_action", "VARCHAR(8) DEFAULT 'off'"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add dedupe window to bots table
+	if err := m.addColumnIfNotExists("bots", "dedupe_window_minutes", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add SLA threshold to bots table
+	if err := m.addColumnIfNotExists("bots", "sla_minutes", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add satisfaction survey toggle to bots table
+	if err := m.addColumnIfNotExists("bots", "survey_enabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add Telegram menu button toggle to bots table
+	if err := m.addColumnIfNotExists("bots", "menu_button_enabled", "BOOLEAN DEFAULT TRUE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add user info header re-send policy to bots table
+	if err := m.addColumnIfNotExists("bots", "header_policy", "VARCHAR(8) DEFAULT 'never'"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add rich user info header toggle to bots table
+	if err := m.addColumnIfNotExists("bots", "rich_header_enabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add privacy notice text to bots table
+	if err := m.addColumnIfNotExists("bots", "privacy_notice", "TEXT DEFAULT NULL"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add forced subscription grace period to bots table
+	if err := m.addColumnIfNotExists("bots", "forced_sub_grace_count", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add channel target to scheduled messages (0 = broadcast to users)
+	if err := m.addColumnIfNotExists("scheduled_messages", "target_channel_id", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add abandonment policy state to bots table
+	if err := m.addColumnIfNotExists("bots", "abandonment_notified_at", "TIMESTAMP NULL DEFAULT NULL"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add opt-in message content logging with per-bot retention
+	if err := m.addColumnIfNotExists("bots", "content_logging_enabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "content_retention_days", "INT DEFAULT 30"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add per-bot spam score threshold
+	if err := m.addColumnIfNotExists("bots", "spam_threshold", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add per-bot reply-routing cache TTL override
+	if err := m.addColumnIfNotExists("bots", "message_ttl_hours", "INT DEFAULT 0"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add owner display language and timezone for date rendering
+	if err := m.addColumnIfNotExists("bots", "locale", "VARCHAR(8) DEFAULT ''"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "timezone", "VARCHAR(64) DEFAULT ''"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add the opt-in stats digest cadence and its last-sent marker
+	if err := m.addColumnIfNotExists("bots", "stats_digest", "VARCHAR(8) DEFAULT ''"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := m.addColumnIfNotExists("bots", "stats_digest_last_sent", "TIMESTAMP NULL"); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Add whitelist-only mode columns to bots table
+	if err := m.addColumnIfNotExists("bots", "whitelist_enabled", "BOOLEAN DEFAULT FALSE"); err != nil {
 		log.Printf("Warning: %v", err)
 	}
-	// Important: Improves GetMessageCountSince and GetActiveUserCount
-	if err := m.addIndexIfNotExists("message_logs", "idx_bot_created", "bot_id, created_at"); err != nil {
+	if err := m.addColumnIfNotExists("bots", "whitelist_message", "VARCHAR(500) DEFAULT ''"); err != nil {
 		log.Printf("Warning: %v", err)
 	}
-	// Helpful: Improves complex queries with all three columns
-	if err := m.addIndexIfNotExists("message_logs", "idx_bot_user_created", "bot_id, user_chat_id, created_at"); err != nil {
+
+	// Add reaction workflow mapping column to bots table
+	if err := m.addColumnIfNotExists("bots", "reaction_actions", "VARCHAR(500) DEFAULT ''"); err != nil {
 		log.Printf("Warning: %v", err)
 	}
 
+	// Create the composite indexes the hot-path queries rely on
+	// (HasUserInteracted, GetMessageCountSince, GetActiveUserCount and
+	// friends); the full list with diagnostics lives in indexes.go
+	for _, idx := range requiredIndexes {
+		if err := m.addIndexIfNotExists(idx.table, idx.name, idx.columns); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	// Warn (with EXPLAIN output) about any index that is still missing,
+	// e.g. because the DB user lacks the INDEX privilege
+	m.checkIndexes()
+
+	return nil
+}
+
+// RunPluginMigrations executes a plugin's own migration statements,
+// applying the configured table prefix the same way the core migrations
+// do. Statements should be idempotent (CREATE TABLE IF NOT EXISTS etc).
+func (m *MySQL) RunPluginMigrations(name string, queries []string) error {
+	for _, query := range queries {
+		if _, err := m.db.Exec(m.rewriteDDL(prefixTables(query, m.tablePrefix))); err != nil {
+			return fmt.Errorf("plugin %s migration failed: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -235,11 +615,24 @@ func (m *MySQL) migrate() error {
 // Helper Functions
 // ============================================
 
+// rewriteDDL adapts a migration statement to the active dialect
+func (m *MySQL) rewriteDDL(query string) string {
+	if m.dialect == dialectSQLite {
+		return toSQLiteDDL(query)
+	}
+	return query
+}
+
 // addColumnIfNotExists safely adds a column if it doesn't exist
 func (m *MySQL) addColumnIfNotExists(table, column, definition string) error {
+	table = m.tablePrefix + table
+
 	var count int
 	query := `SELECT COUNT(*) FROM information_schema.COLUMNS
 			  WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	if m.dialect == dialectSQLite {
+		query = `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`
+	}
 	if err := m.db.Get(&count, query, table, column); err != nil {
 		return fmt.Errorf("failed to check column existence: %w", err)
 	}
@@ -257,6 +650,16 @@ func (m *MySQL) addColumnIfNotExists(table, column, definition string) error {
 
 // addIndexIfNotExists safely adds an index if it doesn't exist
 func (m *MySQL) addIndexIfNotExists(table, indexName, columns string) error {
+	table = m.tablePrefix + table
+
+	if m.dialect == dialectSQLite {
+		createQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", indexName, table, columns)
+		if _, err := m.db.Exec(createQuery); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", indexName, err)
+		}
+		return nil
+	}
+
 	var count int
 	query := `SELECT COUNT(*) FROM information_schema.STATISTICS 
 			  WHERE TABLE_SCHEMA = DATABASE() 
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// GetOwnerPlan returns the owner's plan tier. Owners without an assigned
+// plan are on the free tier.
+func (r *Repository) GetOwnerPlan(ctx context.Context, ownerChatID int64) (string, error) {
+	var plan string
+	query := `SELECT plan FROM owner_plans WHERE owner_chat_id = ?`
+
+	err := r.db().GetContext(ctx, &plan, query, ownerChatID)
+	if err == sql.ErrNoRows {
+		return models.PlanFree, nil
+	}
+	if err != nil {
+		return models.PlanFree, fmt.Errorf("failed to get owner plan: %w", err)
+	}
+	return plan, nil
+}
+
+// SetOwnerPlan assigns a plan tier to an owner
+func (r *Repository) SetOwnerPlan(ctx context.Context, ownerChatID int64, plan string) error {
+	query := `INSERT INTO owner_plans (owner_chat_id, plan) VALUES (?, ?)
+			  ON DUPLICATE KEY UPDATE plan = VALUES(plan)`
+
+	_, err := r.db().ExecContext(ctx, query, ownerChatID, plan)
+	if err != nil {
+		return fmt.Errorf("failed to set owner plan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// GetOwnerPlainUI returns whether the owner opted into text-only menus.
+// Owners without a stored preference get the regular emoji UI.
+func (r *Repository) GetOwnerPlainUI(ctx context.Context, ownerChatID int64) (bool, error) {
+	var plainUI bool
+	query := `SELECT plain_ui FROM owner_prefs WHERE owner_chat_id = ?`
+
+	err := r.db().GetContext(ctx, &plainUI, query, ownerChatID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get plain UI preference: %w", err)
+	}
+	return plainUI, nil
+}
+
+// SetOwnerPlainUI stores the owner's text-only menu preference
+func (r *Repository) SetOwnerPlainUI(ctx context.Context, ownerChatID int64, plainUI bool) error {
+	query := `INSERT INTO owner_prefs (owner_chat_id, plain_ui) VALUES (?, ?)
+			  ON DUPLICATE KEY UPDATE plain_ui = VALUES(plain_ui)`
+
+	_, err := r.db().ExecContext(ctx, query, ownerChatID, plainUI)
+	if err != nil {
+		return fmt.Errorf("failed to set plain UI preference: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ==================== Privacy / Erasure Functions ====================
+
+// RecordAudit stores one row in the audit log for a destructive action.
+// Failures are returned to the caller but should not abort the action
+// itself - the data change has already happened.
+func (r *Repository) RecordAudit(ctx context.Context, botID, actorChatID int64, action, target string) error {
+	query := `INSERT INTO audit_logs (bot_id, actor_chat_id, action, target) VALUES (?, ?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, actorChatID, action, target)
+	if err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	return nil
+}
+
+// EraseUserData deletes every row tied to one user of a bot (messages,
+// replies, deliveries, ban entry, reachability, ratings, stored content,
+// forced-sub exemption, spam feedback, whitelist entry and abuse
+// reports they filed) inside a single transaction, for GDPR-style
+// erasure requests. Every table with a per-user column must be listed
+// here; the coverage test in privacy_test.go fails when a migration
+// adds one that is not.
+func (r *Repository) EraseUserData(ctx context.Context, botID, userChatID int64) error {
+	queries := []string{
+		`DELETE FROM message_logs WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM replies WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM deliveries WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM banned_users WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM bot_users WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM ratings WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM message_contents WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM forced_sub_exemptions WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM spam_feedback WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM whitelisted_users WHERE bot_id = ? AND user_chat_id = ?`,
+		`DELETE FROM abuse_reports WHERE bot_id = ? AND reporter_chat_id = ?`,
+	}
+
+	return r.WithTx(ctx, func(txRepo *Repository) error {
+		for _, query := range queries {
+			if _, err := txRepo.db().ExecContext(ctx, query, botID, userChatID); err != nil {
+				return fmt.Errorf("failed to erase user data: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ResetBotStats deletes all statistics-bearing rows for a bot (message
+// logs, replies, deliveries and ratings) inside a single transaction.
+// Configuration, bans and the user reachability list are kept.
+func (r *Repository) ResetBotStats(ctx context.Context, botID int64) error {
+	queries := []string{
+		`DELETE FROM message_logs WHERE bot_id = ?`,
+		`DELETE FROM replies WHERE bot_id = ?`,
+		`DELETE FROM deliveries WHERE bot_id = ?`,
+		`DELETE FROM ratings WHERE bot_id = ?`,
+	}
+
+	return r.WithTx(ctx, func(txRepo *Repository) error {
+		for _, query := range queries {
+			if _, err := txRepo.db().ExecContext(ctx, query, botID); err != nil {
+				return fmt.Errorf("failed to reset bot stats: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/database"
+)
+
+// ==================== Privacy / Erasure Tests ====================
+
+// userIdentityColumns are the column names that mark a table as holding
+// per-user data EraseUserData must cover. A new per-user table should
+// key users with one of these names (or extend this list).
+var userIdentityColumns = []string{"user_chat_id", "reporter_chat_id"}
+
+// userTableSeeds inserts one row per table that stores per-user data.
+// ?1 is the bot ID and ?2 the user chat ID; columns that need distinct
+// values per user (message IDs) reuse ?2.
+var userTableSeeds = map[string]string{
+	"message_logs":          `INSERT INTO message_logs (bot_id, user_chat_id, admin_msg_id) VALUES (?1, ?2, ?2)`,
+	"replies":               `INSERT INTO replies (bot_id, user_chat_id, admin_msg_id, user_msg_id) VALUES (?1, ?2, ?2, ?2)`,
+	"deliveries":            `INSERT INTO deliveries (bot_id, user_chat_id, source, status) VALUES (?1, ?2, 'reply', 'delivered')`,
+	"banned_users":          `INSERT INTO banned_users (bot_id, user_chat_id, banned_by) VALUES (?1, ?2, 999)`,
+	"bot_users":             `INSERT INTO bot_users (bot_id, user_chat_id) VALUES (?1, ?2)`,
+	"ratings":               `INSERT INTO ratings (bot_id, user_chat_id, stars) VALUES (?1, ?2, 5)`,
+	"message_contents":      `INSERT INTO message_contents (bot_id, user_chat_id, admin_msg_id, content) VALUES (?1, ?2, ?2, 'x')`,
+	"forced_sub_exemptions": `INSERT INTO forced_sub_exemptions (bot_id, user_chat_id) VALUES (?1, ?2)`,
+	"spam_feedback":         `INSERT INTO spam_feedback (bot_id, user_chat_id, verdict) VALUES (?1, ?2, 'spam')`,
+	"whitelisted_users":     `INSERT INTO whitelisted_users (bot_id, user_chat_id) VALUES (?1, ?2)`,
+	"abuse_reports":         `INSERT INTO abuse_reports (bot_id, reporter_chat_id, reason) VALUES (?1, ?2, 'spam')`,
+}
+
+// userTable is one discovered per-user table and the column that keys
+// its rows by user
+type userTable struct {
+	name   string
+	column string
+}
+
+// userTables discovers every schema table with a per-user identity
+// column, so the erasure coverage below cannot silently fall behind new
+// migrations
+func userTables(t *testing.T, db *database.MySQL) []userTable {
+	t.Helper()
+
+	var tables []string
+	if err := db.DB().Select(&tables,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`); err != nil {
+		t.Fatalf("Failed to list tables: %v", err)
+	}
+
+	var userKeyed []userTable
+	for _, table := range tables {
+		for _, column := range userIdentityColumns {
+			var count int
+			if err := db.DB().Get(&count,
+				`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column); err != nil {
+				t.Fatalf("Failed to inspect table %s: %v", table, err)
+			}
+			if count > 0 {
+				userKeyed = append(userKeyed, userTable{name: table, column: column})
+				break
+			}
+		}
+	}
+	return userKeyed
+}
+
+func TestEraseUserData_CoversAllUserTables(t *testing.T) {
+	db, repo := setupSQLiteRepo(t)
+	ctx := context.Background()
+
+	bot, err := repo.CreateBot(ctx, "123456:ABC-DEF", 999, "testbot", 1)
+	if err != nil {
+		t.Fatalf("CreateBot failed: %v", err)
+	}
+
+	tables := userTables(t, db)
+	for _, table := range tables {
+		seed, ok := userTableSeeds[table.name]
+		if !ok {
+			t.Fatalf("Table %s has a %s column but no seed here; add it and make sure EraseUserData deletes from it", table.name, table.column)
+		}
+		// One row for the erased user and one for a bystander
+		for _, userChatID := range []int64{555, 556} {
+			if _, err := db.DB().Exec(seed, bot.ID, userChatID); err != nil {
+				t.Fatalf("Failed to seed %s: %v", table.name, err)
+			}
+		}
+	}
+
+	if err := repo.EraseUserData(ctx, bot.ID, 555); err != nil {
+		t.Fatalf("EraseUserData failed: %v", err)
+	}
+
+	for _, table := range tables {
+		var count int
+		if err := db.DB().Get(&count,
+			`SELECT COUNT(*) FROM `+table.name+` WHERE bot_id = ? AND `+table.column+` = ?`, bot.ID, 555); err != nil {
+			t.Fatalf("Failed to count rows in %s: %v", table.name, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no rows left in %s for the erased user, got %d", table.name, count)
+		}
+
+		if err := db.DB().Get(&count,
+			`SELECT COUNT(*) FROM `+table.name+` WHERE bot_id = ? AND `+table.column+` = ?`, bot.ID, 556); err != nil {
+			t.Fatalf("Failed to count rows in %s: %v", table.name, err)
+		}
+		if count != 1 {
+			t.Errorf("Expected the other user's row in %s to survive, got %d", table.name, count)
+		}
+	}
+}
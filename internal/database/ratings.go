@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ==================== Rating Functions ====================
+
+// SaveRating stores a 1-5 star satisfaction score from an end user
+func (r *Repository) SaveRating(ctx context.Context, botID, userChatID int64, stars int) error {
+	query := `INSERT INTO ratings (bot_id, user_chat_id, stars) VALUES (?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID, stars)
+	if err != nil {
+		return classifyError("save rating", err)
+	}
+
+	return nil
+}
+
+// GetRatingStats returns the average star rating and the number of ratings
+// collected for a bot. Average is 0 when there are no ratings.
+func (r *Repository) GetRatingStats(ctx context.Context, botID int64) (float64, int64, error) {
+	var stats struct {
+		Average float64 `db:"average"`
+		Count   int64   `db:"count"`
+	}
+	query := `SELECT COALESCE(AVG(stars), 0) as average, COUNT(*) as count FROM ratings WHERE bot_id = ?`
+
+	err := r.db().GetContext(ctx, &stats, query, botID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get rating stats: %w", err)
+	}
+
+	return stats.Average, stats.Count, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Reply Mapping Functions ====================
+
+// SaveReplyMapping stores which user-side message a reply was copied to.
+// Re-sending the same admin message updates the mapping in place.
+func (r *Repository) SaveReplyMapping(ctx context.Context, botID int64, adminMsgID int, userChatID int64, userMsgID int) error {
+	query := `INSERT INTO replies (bot_id, admin_msg_id, user_chat_id, user_msg_id)
+			  VALUES (?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE user_chat_id = ?, user_msg_id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, botID, adminMsgID, userChatID, userMsgID, userChatID, userMsgID)
+	if err != nil {
+		return classifyError("save reply mapping", err)
+	}
+
+	return nil
+}
+
+// DeleteReplyMapping removes the mapping once the user-side copy is gone
+func (r *Repository) DeleteReplyMapping(ctx context.Context, botID int64, adminMsgID int) error {
+	query := `DELETE FROM replies WHERE bot_id = ? AND admin_msg_id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, botID, adminMsgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reply mapping: %w", err)
+	}
+
+	return nil
+}
+
+// GetReplyMapping returns the user-side copy of an owner's reply message,
+// or nil if the message was never copied to a user
+func (r *Repository) GetReplyMapping(ctx context.Context, botID int64, adminMsgID int) (*models.Reply, error) {
+	var reply models.Reply
+	query := `SELECT id, bot_id, admin_msg_id, user_chat_id, user_msg_id, created_at
+			  FROM replies WHERE bot_id = ? AND admin_msg_id = ?`
+
+	err := r.db().GetContext(ctx, &reply, query, botID, adminMsgID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reply mapping: %w", err)
+	}
+
+	return &reply, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Abuse Report Functions ====================
+
+// CreateAbuseReport files an end-user complaint against a bot
+func (r *Repository) CreateAbuseReport(ctx context.Context, botID, reporterChatID int64, reason string) error {
+	query := `INSERT INTO abuse_reports (bot_id, reporter_chat_id, reason) VALUES (?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, reporterChatID, reason)
+	if err != nil {
+		return classifyError("create abuse report", err)
+	}
+	return nil
+}
+
+// GetAbuseReportSummaries returns the bots with open reports, most reported
+// first. Each summary carries the owner's all-time report count across all
+// their bots so repeat offenders stand out.
+func (r *Repository) GetAbuseReportSummaries(ctx context.Context, limit int) ([]models.AbuseReportSummary, error) {
+	var summaries []models.AbuseReportSummary
+	query := `SELECT b.id as bot_id, COALESCE(b.username, '') as bot_username, b.owner_chat_id,
+			  COUNT(*) as open_count,
+			  (SELECT COUNT(*) FROM abuse_reports ar2
+			   JOIN bots b2 ON ar2.bot_id = b2.id
+			   WHERE b2.owner_chat_id = b.owner_chat_id) as owner_report_count
+			  FROM abuse_reports ar
+			  JOIN bots b ON ar.bot_id = b.id
+			  WHERE ar.status = 'open'
+			  GROUP BY b.id, b.username, b.owner_chat_id
+			  ORDER BY open_count DESC
+			  LIMIT ?`
+
+	err := r.db().SelectContext(ctx, &summaries, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abuse report summaries: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// GetOpenAbuseReports returns the most recent open reports against a bot
+func (r *Repository) GetOpenAbuseReports(ctx context.Context, botID int64, limit int) ([]models.AbuseReport, error) {
+	var reports []models.AbuseReport
+	query := `SELECT id, bot_id, reporter_chat_id, COALESCE(reason, '') as reason, status, created_at
+			  FROM abuse_reports
+			  WHERE bot_id = ? AND status = 'open'
+			  ORDER BY created_at DESC
+			  LIMIT ?`
+
+	err := r.db().SelectContext(ctx, &reports, query, botID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open abuse reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// CloseAbuseReports moves all open reports against a bot to the given
+// status ("resolved" or "dismissed"). Returns how many were closed.
+func (r *Repository) CloseAbuseReports(ctx context.Context, botID int64, status string) (int64, error) {
+	query := `UPDATE abuse_reports SET status = ? WHERE bot_id = ? AND status = 'open'`
+
+	result, err := r.db().ExecContext(ctx, query, status, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close abuse reports: %w", err)
+	}
+
+	closed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get closed report count: %w", err)
+	}
+
+	return closed, nil
+}
+
+// SetBotSuspended marks a bot as suspended (or lifts the suspension).
+// Suspended bots cannot be restarted by their owner.
+func (r *Repository) SetBotSuspended(ctx context.Context, botID int64, suspended bool) error {
+	query := `UPDATE bots SET is_suspended = ? WHERE id = ?`
+
+	result, err := r.db().ExecContext(ctx, query, suspended, botID)
+	if err != nil {
+		return fmt.Errorf("failed to set bot suspension: %w", err)
+	}
+
+	return checkRowsAffected(result, "set bot suspension")
+}
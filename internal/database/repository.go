@@ -1,5 +1,22 @@
 package database
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sqlExecutor is the subset of sqlx operations shared by *sqlx.DB and
+// *sqlx.Tx, so repository methods can run standalone or inside WithTx
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
 // Repository handles all database operations
 // Methods are organized across multiple files by domain:
 // - bot.go: Bot CRUD operations
@@ -10,6 +27,7 @@ package database
 type Repository struct {
 	mysql         *MySQL
 	encryptionKey string
+	tx            *sqlx.Tx // non-nil when this instance runs inside WithTx
 }
 
 // NewRepository creates a new repository instance
@@ -19,3 +37,109 @@ func NewRepository(mysql *MySQL, encryptionKey string) *Repository {
 		encryptionKey: encryptionKey,
 	}
 }
+
+// db returns the executor statements run on: the shared pool normally, or
+// the active transaction inside WithTx. When a table prefix is
+// configured the executor rewrites table names on the way through, and
+// on the SQLite dev backend a second wrapper rewrites the MySQL-isms.
+func (r *Repository) db() sqlExecutor {
+	var exec sqlExecutor = r.mysql.db
+	if r.tx != nil {
+		exec = r.tx
+	}
+	if r.mysql.tablePrefix != "" {
+		exec = prefixedExecutor{inner: exec, prefix: r.mysql.tablePrefix}
+	}
+	if r.mysql.dialect == dialectSQLite {
+		exec = sqliteExecutor{inner: exec}
+	}
+	return exec
+}
+
+// WithTx runs fn inside a database transaction. The Repository passed to fn
+// routes all statements through that transaction; it is rolled back if fn
+// returns an error or panics, and committed otherwise. Nested calls reuse
+// the already-open transaction.
+func (r *Repository) WithTx(ctx context.Context, fn func(txRepo *Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.mysql.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	txRepo := &Repository{
+		mysql:         r.mysql,
+		encryptionKey: r.encryptionKey,
+		tx:            tx,
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(txRepo); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to roll back transaction after %v: %w", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ==================== Keyset Pagination ====================
+
+// Cursor marks the last row of the previous page for keyset pagination
+// over (created_at, id). The zero Cursor means "first page". Unlike
+// LIMIT/OFFSET this stays fast on large tables and does not skip rows
+// when earlier pages change under concurrent writes.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// IsZero reports whether the cursor points at the first page
+func (c Cursor) IsZero() bool {
+	return c.CreatedAt.IsZero() && c.ID == 0
+}
+
+// CursorAfter returns the cursor for the page following the given row
+func CursorAfter(createdAt time.Time, id int64) Cursor {
+	return Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// ==================== Ownership Verification ====================
+
+// VerifyBotOwnership reports whether the given chat owns the bot
+func (r *Repository) VerifyBotOwnership(ctx context.Context, botID, ownerChatID int64) (bool, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM bots WHERE id = ? AND owner_chat_id = ? AND deleted_at IS NULL`
+	err := r.db().GetContext(ctx, &count, query, botID, ownerChatID)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify bot ownership: %w", err)
+	}
+	return count > 0, nil
+}
+
+// requireBotOwner guards mutations: it fails unless ownerChatID owns botID,
+// so authorization holds even if a handler-level check is bypassed
+func (r *Repository) requireBotOwner(ctx context.Context, botID, ownerChatID int64) error {
+	owned, err := r.VerifyBotOwnership(ctx, botID, ownerChatID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return fmt.Errorf("bot %d is not owned by chat %d", botID, ownerChatID)
+	}
+	return nil
+}
@@ -2,11 +2,16 @@ package database_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/models"
+	"github.com/Amr-9/botforge/internal/utils/crypto"
 	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -24,11 +29,11 @@ func TestCreateBot_Extended(t *testing.T) {
 	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
 
 	mock.ExpectExec("INSERT INTO bots").
-		WithArgs(sqlmock.AnyArg(), int64(12345), "testbot").
+		WithArgs(sqlmock.AnyArg(), int64(12345), "testbot", int64(777000)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	ctx := context.Background()
-	bot, err := repo.CreateBot(ctx, "123456789:ABCdef", int64(12345), "testbot")
+	bot, err := repo.CreateBot(ctx, "123456789:ABCdef", int64(12345), "testbot", int64(777000))
 	if err != nil {
 		t.Fatalf("CreateBot failed: %v", err)
 	}
@@ -42,6 +47,59 @@ func TestCreateBot_Extended(t *testing.T) {
 	}
 }
 
+func TestCreateBot_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	// Token already registered - unique key violation
+	mock.ExpectExec("INSERT INTO bots").
+		WithArgs(sqlmock.AnyArg(), int64(12345), "testbot", int64(777000)).
+		WillReturnError(&mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	ctx := context.Background()
+	_, err = repo.CreateBot(ctx, "123456789:ABCdef", int64(12345), "testbot", int64(777000))
+	if !errors.Is(err, database.ErrDuplicate) {
+		t.Fatalf("Expected ErrDuplicate, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestCreateBot_Unavailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectExec("INSERT INTO bots").
+		WithArgs(sqlmock.AnyArg(), int64(12345), "testbot", int64(777000)).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	_, err = repo.CreateBot(ctx, "123456789:ABCdef", int64(12345), "testbot", int64(777000))
+	if !errors.Is(err, database.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
 func TestGetBotsByOwner_Extended(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -77,6 +135,119 @@ func TestGetBotsByOwner_Extended(t *testing.T) {
 	}
 }
 
+func TestGetBotSettingsByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	// No token column - settings lookups skip encryption entirely
+	rows := sqlmock.NewRows([]string{"id", "username", "owner_chat_id", "is_active", "start_message",
+		"forward_auto_replies", "forced_sub_enabled", "forced_sub_message", "show_sent_confirmation", "created_at"}).
+		AddRow(1, "testbot", 12345, true, "Welcome!", true, false, "", true, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM bots WHERE id").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	bot, err := repo.GetBotSettingsByID(ctx, int64(1))
+	if err != nil {
+		t.Fatalf("GetBotSettingsByID failed: %v", err)
+	}
+
+	if bot == nil {
+		t.Fatal("Expected bot to be returned")
+	}
+	if bot.Token != "" {
+		t.Errorf("Expected empty token, got %q", bot.Token)
+	}
+	if !bot.ForwardAutoReplies {
+		t.Error("Expected forward_auto_replies to be true")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestGetBotRuntimeSettings_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	rows := sqlmock.NewRows([]string{"id", "start_message", "forward_auto_replies",
+		"forced_sub_enabled", "forced_sub_message", "show_sent_confirmation"}).
+		AddRow(1, "Welcome!", true, true, "Join first!", false)
+
+	mock.ExpectQuery("SELECT (.+) FROM bots WHERE id").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	settings, err := repo.GetBotRuntimeSettings(ctx, int64(1))
+	if err != nil {
+		t.Fatalf("GetBotRuntimeSettings failed: %v", err)
+	}
+
+	if settings == nil {
+		t.Fatal("Expected settings to be returned")
+	}
+	if settings.StartMessage != "Welcome!" {
+		t.Errorf("Expected start message 'Welcome!', got %q", settings.StartMessage)
+	}
+	if !settings.ForcedSubEnabled {
+		t.Error("Expected forced_sub_enabled to be true")
+	}
+	if settings.ShowSentConfirmation {
+		t.Error("Expected show_sent_confirmation to be false")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestGetBotSettingsByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectQuery("SELECT (.+) FROM bots WHERE id").
+		WithArgs(int64(999)).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	bot, err := repo.GetBotSettingsByID(ctx, int64(999))
+	if err != nil {
+		t.Fatalf("Expected nil error for missing bot, got: %v", err)
+	}
+	if bot != nil {
+		t.Error("Expected nil bot for missing row")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
 func TestDeleteBot_Extended(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -117,10 +288,17 @@ func TestBanUser_Extended(t *testing.T) {
 	mysql := database.NewMySQLFromDB(sqlxDB)
 	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(12345)).
+		WillReturnRows(ownerRows)
+
 	// Match actual query: INSERT INTO banned_users with ON DUPLICATE KEY UPDATE
 	mock.ExpectExec("INSERT INTO banned_users").
 		WithArgs(int64(1), int64(99999), int64(12345), int64(12345)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	ctx := context.Background()
 	err = repo.BanUser(ctx, int64(1), int64(99999), int64(12345))
@@ -144,13 +322,20 @@ func TestUnbanUser_Extended(t *testing.T) {
 	mysql := database.NewMySQLFromDB(sqlxDB)
 	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(12345)).
+		WillReturnRows(ownerRows)
+
 	// Match actual query: DELETE FROM banned_users WHERE bot_id = ? AND user_chat_id = ?
 	mock.ExpectExec("DELETE FROM banned_users WHERE bot_id").
 		WithArgs(int64(1), int64(99999)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	ctx := context.Background()
-	err = repo.UnbanUser(ctx, int64(1), int64(99999))
+	err = repo.UnbanUser(ctx, int64(1), int64(99999), int64(12345))
 	if err != nil {
 		t.Fatalf("UnbanUser failed: %v", err)
 	}
@@ -383,6 +568,60 @@ func TestActivateBot(t *testing.T) {
 	}
 }
 
+func TestActivateBot_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	// No row matches the token - zero rows affected
+	mock.ExpectExec("UPDATE bots SET is_active").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := context.Background()
+	err = repo.ActivateBot(ctx, "123456789:ABCdef")
+	if !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestDeleteBot_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	// Bot already soft-deleted or never existed - zero rows affected
+	mock.ExpectExec("UPDATE bots SET deleted_at = NOW\\(\\), is_active = FALSE WHERE token").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := context.Background()
+	err = repo.DeleteBot(ctx, "123456789:ABCdef")
+	if !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
 // ==================== Message Log Tests ====================
 
 func TestSaveMessageLog_Success(t *testing.T) {
@@ -997,6 +1236,79 @@ func TestGetGlobalForcedChannelCount_Success(t *testing.T) {
 	}
 }
 
+func TestGetGlobalSharedUserCount_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(42)
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM \\(").
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	count, err := repo.GetGlobalSharedUserCount(ctx)
+	if err != nil {
+		t.Fatalf("GetGlobalSharedUserCount failed: %v", err)
+	}
+
+	if count != 42 {
+		t.Errorf("Expected 42, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestGetTopAudienceOverlaps_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	rows := sqlmock.NewRows([]string{"bot_a_username", "bot_b_username", "shared_users"}).
+		AddRow("support_bot", "sales_bot", 120).
+		AddRow("support_bot", "news_bot", 35)
+
+	mock.ExpectQuery("SELECT ba.username AS bot_a_username").
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	overlaps, err := repo.GetTopAudienceOverlaps(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetTopAudienceOverlaps failed: %v", err)
+	}
+
+	if len(overlaps) != 2 {
+		t.Fatalf("Expected 2 overlaps, got %d", len(overlaps))
+	}
+
+	if overlaps[0].BotAUsername != "support_bot" || overlaps[0].BotBUsername != "sales_bot" {
+		t.Errorf("Unexpected first pair: %s / %s", overlaps[0].BotAUsername, overlaps[0].BotBUsername)
+	}
+
+	if overlaps[0].SharedUsers != 120 {
+		t.Errorf("Expected 120 shared users, got %d", overlaps[0].SharedUsers)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
 func TestGetUniqueOwnerCount_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -1164,3 +1476,305 @@ func TestGetDeletedBotsCount_Success(t *testing.T) {
 		t.Errorf("Unmet expectations: %v", err)
 	}
 }
+
+// ==================== Backup / Restore Tests ====================
+
+func TestBackupBot_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectQuery("SELECT (.+) FROM bots WHERE id").
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	_, err = repo.BackupBot(ctx, 999)
+	if !errors.Is(err, database.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestRestoreBot_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	backup := &database.BotBackup{
+		Version:    1,
+		ExportedAt: time.Now(),
+		Bot: models.Bot{
+			ID:          3,
+			Token:       "123456789:ABCdef",
+			Username:    "restoredbot",
+			OwnerChatID: 12345,
+			IsActive:    true,
+			CreatedAt:   time.Now(),
+		},
+		RoutingRules: []models.RoutingRule{
+			{ID: 1, BotID: 3, Keyword: "refund", DestChatID: -100123, CreatedAt: time.Now()},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO bots").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO routing_rules").
+		WithArgs(int64(7), "refund", int64(-100123), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	newBotID, err := repo.RestoreBotBackup(ctx, backup)
+	if err != nil {
+		t.Fatalf("RestoreBotBackup failed: %v", err)
+	}
+
+	if newBotID != 7 {
+		t.Errorf("Expected new bot ID 7, got %d", newBotID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestRestoreBot_UnsupportedVersion(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	ctx := context.Background()
+	_, err = repo.RestoreBotBackup(ctx, &database.BotBackup{Version: 99})
+	if err == nil {
+		t.Error("Expected error for unsupported backup version")
+	}
+}
+
+// ==================== Abandonment Policy Tests ====================
+
+func TestGetAbandonedBots_DecryptsTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	key := "12345678901234567890123456789012"
+	repo := database.NewRepository(mysql, key)
+
+	encrypted, err := crypto.EncryptDeterministic("123456789:ABCdef", key)
+	if err != nil {
+		t.Fatalf("Failed to encrypt token: %v", err)
+	}
+
+	notified := time.Now().Add(-20 * 24 * time.Hour)
+	cutoff := time.Now().AddDate(0, -3, 0)
+	rows := sqlmock.NewRows([]string{"id", "token", "username", "owner_chat_id", "abandonment_notified_at"}).
+		AddRow(int64(1), encrypted, "quietbot", int64(777), nil).
+		AddRow(int64(2), encrypted, "warnedbot", int64(888), notified)
+
+	mock.ExpectQuery("SELECT b.id, b.token").
+		WithArgs(cutoff, cutoff).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	bots, err := repo.GetAbandonedBots(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("GetAbandonedBots failed: %v", err)
+	}
+
+	if len(bots) != 2 {
+		t.Fatalf("Expected 2 bots, got %d", len(bots))
+	}
+	if bots[0].Token != "123456789:ABCdef" {
+		t.Errorf("Expected decrypted token, got %q", bots[0].Token)
+	}
+	if bots[0].NotifiedAt != nil {
+		t.Error("First bot should have no notice yet")
+	}
+	if bots[1].NotifiedAt == nil {
+		t.Error("Second bot should carry its notice timestamp")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestMarkAbandonmentNotified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectExec("UPDATE bots SET abandonment_notified_at = NOW").
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkAbandonmentNotified(context.Background(), int64(5)); err != nil {
+		t.Fatalf("MarkAbandonmentNotified failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+// ==================== Whitelist Tests ====================
+
+func TestAddWhitelistedUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(12345)).
+		WillReturnRows(ownerRows)
+
+	// Match actual query: INSERT INTO whitelisted_users with ON DUPLICATE KEY UPDATE
+	mock.ExpectExec("INSERT INTO whitelisted_users").
+		WithArgs(int64(1), int64(99999)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	err = repo.AddWhitelistedUser(ctx, int64(1), int64(99999), int64(12345))
+	if err != nil {
+		t.Fatalf("AddWhitelistedUser failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestRemoveWhitelistedUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(12345)).
+		WillReturnRows(ownerRows)
+
+	mock.ExpectExec("DELETE FROM whitelisted_users WHERE bot_id").
+		WithArgs(int64(1), int64(99999)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	err = repo.RemoveWhitelistedUser(ctx, int64(1), int64(99999), int64(12345))
+	if err != nil {
+		t.Fatalf("RemoveWhitelistedUser failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestIsUserWhitelisted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	rows := sqlmock.NewRows([]string{"1"}).AddRow(1)
+	mock.ExpectQuery("SELECT 1 FROM whitelisted_users WHERE bot_id").
+		WithArgs(int64(1), int64(99999)).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	whitelisted, err := repo.IsUserWhitelisted(ctx, int64(1), int64(99999))
+	if err != nil {
+		t.Fatalf("IsUserWhitelisted failed: %v", err)
+	}
+
+	if !whitelisted {
+		t.Error("Expected user to be whitelisted")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestIsUserWhitelisted_NotListed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+
+	mock.ExpectQuery("SELECT 1 FROM whitelisted_users WHERE bot_id").
+		WithArgs(int64(1), int64(99999)).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	whitelisted, err := repo.IsUserWhitelisted(ctx, int64(1), int64(99999))
+	if err != nil {
+		t.Fatalf("IsUserWhitelisted failed: %v", err)
+	}
+
+	if whitelisted {
+		t.Error("Expected user to not be whitelisted")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// ==================== Message Content Tests ====================
+
+func TestSaveMessageContent_EncryptsBeforeInsert(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO message_contents").
+		WithArgs(int64(5), int64(999), 42, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	ctx := context.Background()
+	if err := repo.SaveMessageContent(ctx, 5, 999, 42, "hello there"); err != nil {
+		t.Fatalf("SaveMessageContent failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+
+}
+
+func TestGetMessageContents_DecryptsRows(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	key := crypto.DeriveKey(testEncryptionKey, "content:5")
+	encrypted, err := crypto.Encrypt("secret message", key)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "user_chat_id", "admin_msg_id", "content", "created_at"}).
+		AddRow(1, 5, 999, 42, encrypted, time.Now()).
+		AddRow(2, 5, 888, 43, "not-valid-ciphertext", time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM message_contents").
+		WithArgs(int64(5), 20).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	contents, err := repo.GetMessageContents(ctx, 5, 20)
+	if err != nil {
+		t.Fatalf("GetMessageContents failed: %v", err)
+	}
+
+	// The undecryptable row is skipped, not fatal
+	if len(contents) != 1 {
+		t.Fatalf("Expected 1 readable message, got %d", len(contents))
+	}
+	if contents[0].Content != "secret message" {
+		t.Errorf("Expected decrypted content, got %q", contents[0].Content)
+	}
+	if contents[0].UserChatID != 999 {
+		t.Errorf("Expected user 999, got %d", contents[0].UserChatID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestPurgeMessageContents_ReturnsDeletedCount(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("DELETE FROM message_contents WHERE bot_id").
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	ctx := context.Background()
+	deleted, err := repo.PurgeMessageContents(ctx, 5)
+	if err != nil {
+		t.Fatalf("PurgeMessageContents failed: %v", err)
+	}
+	if deleted != 7 {
+		t.Errorf("Expected 7 deleted rows, got %d", deleted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestSearchMessageContents_MatchesCaseInsensitively(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	key := crypto.DeriveKey(testEncryptionKey, "content:5")
+	refund, _ := crypto.Encrypt("I want a REFUND please", key)
+	other, _ := crypto.Encrypt("just saying hi", key)
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "user_chat_id", "admin_msg_id", "content", "created_at"}).
+		AddRow(1, 5, 999, 42, refund, time.Now()).
+		AddRow(2, 5, 888, 43, other, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM message_contents").
+		WithArgs(int64(5), sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	matches, err := repo.SearchMessageContents(ctx, 5, "refund", 10)
+	if err != nil {
+		t.Fatalf("SearchMessageContents failed: %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(matches))
+	}
+	if matches[0].UserChatID != 999 {
+		t.Errorf("Expected match from user 999, got %d", matches[0].UserChatID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// ==================== Keyset Pagination Tests ====================
+
+func TestGetBannedUsersPage_FirstPage(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// The first page must not carry a cursor condition
+	mock.ExpectQuery("FROM banned_users WHERE bot_id = \\?\\s+ORDER BY created_at DESC, id DESC LIMIT \\?").
+		WithArgs(int64(5), 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bot_id", "user_chat_id", "banned_by", "created_at"}).
+			AddRow(10, 5, 111, 42, time.Now()))
+
+	users, err := repo.GetBannedUsersPage(context.Background(), 5, database.Cursor{}, 6)
+	if err != nil {
+		t.Fatalf("GetBannedUsersPage failed: %v", err)
+	}
+	if len(users) != 1 || users[0].UserChatID != 111 {
+		t.Errorf("Unexpected page contents: %+v", users)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestGetBannedUsersPage_WithCursor(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	cursorTime := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("AND \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\)").
+		WithArgs(int64(5), cursorTime, cursorTime, int64(10), 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bot_id", "user_chat_id", "banned_by", "created_at"}))
+
+	_, err := repo.GetBannedUsersPage(context.Background(), 5, database.CursorAfter(cursorTime, 10), 6)
+	if err != nil {
+		t.Fatalf("GetBannedUsersPage failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+// ==================== Tenant Table Prefix Tests ====================
+
+func newPrefixedTestRepo(t *testing.T, prefix string) (*database.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDBWithPrefix(sqlxDB, prefix)
+	return database.NewRepository(mysql, "12345678901234567890123456789012"), mock
+}
+
+func TestTablePrefix_AppliedToQueries(t *testing.T) {
+	repo, mock := newPrefixedTestRepo(t, "staging_")
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM staging_bots WHERE id = \\? AND owner_chat_id = \\?").
+		WithArgs(int64(7), int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	owned, err := repo.VerifyBotOwnership(context.Background(), 7, 42)
+	if err != nil {
+		t.Fatalf("VerifyBotOwnership failed: %v", err)
+	}
+	if !owned {
+		t.Error("Expected ownership to be confirmed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestTablePrefix_AppliedToJoins(t *testing.T) {
+	repo, mock := newPrefixedTestRepo(t, "t2_")
+
+	// Every table name in the join must be rewritten, while column names
+	// like bot_id and aliases are left alone
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM t2_auto_replies ar\\s+LEFT JOIN t2_bots b ON ar\\.bot_id = b\\.id").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if _, err := repo.CountOrphanAutoReplies(context.Background()); err != nil {
+		t.Fatalf("CountOrphanAutoReplies failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestTablePrefix_EmptyLeavesQueriesUntouched(t *testing.T) {
+	repo, mock := newPrefixedTestRepo(t, "")
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM bots WHERE id = \\? AND owner_chat_id = \\?").
+		WithArgs(int64(7), int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if _, err := repo.VerifyBotOwnership(context.Background(), 7, 42); err != nil {
+		t.Fatalf("VerifyBotOwnership failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package database_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -100,14 +102,63 @@ func TestDeleteAutoReply_Success(t *testing.T) {
 	repo, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(777)).
+		WillReturnRows(ownerRows)
+
 	mock.ExpectExec("DELETE FROM auto_replies").
 		WithArgs(int64(5), int64(1)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.DeleteAutoReply(context.Background(), 1, 5, 777)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+}
+
+func TestDeleteAutoReply_NotOwner(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// Ownership check finds no matching bot, so the delete never runs
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(888)).
+		WillReturnRows(ownerRows)
+	mock.ExpectRollback()
+
+	err := repo.DeleteAutoReply(context.Background(), 1, 5, 888)
+	if err == nil {
+		t.Error("Expected error when caller does not own the bot")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
 
-	err := repo.DeleteAutoReply(context.Background(), 1, 5)
+func TestGetAutoReplyByID_ScopedToBot(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// No rows for a reply ID belonging to a different bot
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "trigger_word", "response", "message_type", "file_id", "caption", "trigger_type", "match_type", "is_active", "created_at"})
+
+	mock.ExpectQuery("SELECT (.+) FROM auto_replies WHERE id = \\? AND bot_id = \\?").
+		WithArgs(int64(5), int64(2)).
+		WillReturnRows(rows)
+
+	reply, err := repo.GetAutoReplyByID(context.Background(), 2, 5)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
+	if reply != nil {
+		t.Errorf("Expected nil for another bot's reply, got: %+v", reply)
+	}
 }
 
 func TestGetAutoReplyCount(t *testing.T) {
@@ -129,6 +180,48 @@ func TestGetAutoReplyCount(t *testing.T) {
 	}
 }
 
+func TestSetAutoReplyQuickReply_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("UPDATE auto_replies SET is_quick_reply").
+		WithArgs(true, int64(5), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetAutoReplyQuickReply(context.Background(), 1, 5, true)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetQuickReplyTriggers_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"trigger_word"}).
+		AddRow("📦 Order status").
+		AddRow("💬 Support")
+
+	mock.ExpectQuery("SELECT trigger_word FROM auto_replies").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	triggers, err := repo.GetQuickReplyTriggers(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(triggers) != 2 {
+		t.Fatalf("Expected 2 triggers, got %d", len(triggers))
+	}
+	if triggers[0] != "📦 Order status" {
+		t.Errorf("Expected first trigger '📦 Order status', got %q", triggers[0])
+	}
+}
+
 // ==================== Scheduled Messages Tests ====================
 
 func TestCreateScheduledMessage_Success(t *testing.T) {
@@ -172,11 +265,18 @@ func TestPauseScheduledMessage_Success(t *testing.T) {
 	repo, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(777)).
+		WillReturnRows(ownerRows)
+
 	mock.ExpectExec("UPDATE scheduled_messages").
 		WithArgs(int64(10), int64(1)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	err := repo.PauseScheduledMessage(context.Background(), 10, 1)
+	err := repo.PauseScheduledMessage(context.Background(), 10, 1, 777)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -186,11 +286,18 @@ func TestResumeScheduledMessage_Success(t *testing.T) {
 	repo, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(777)).
+		WillReturnRows(ownerRows)
+
 	mock.ExpectExec("UPDATE scheduled_messages").
 		WithArgs(int64(10), int64(1)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	err := repo.ResumeScheduledMessage(context.Background(), 10, 1)
+	err := repo.ResumeScheduledMessage(context.Background(), 10, 1, 777)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -200,11 +307,18 @@ func TestDeleteScheduledMessage_Success(t *testing.T) {
 	repo, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(777)).
+		WillReturnRows(ownerRows)
+
 	mock.ExpectExec("UPDATE scheduled_messages").
 		WithArgs(int64(10), int64(1)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	err := repo.DeleteScheduledMessage(context.Background(), 10, 1)
+	err := repo.DeleteScheduledMessage(context.Background(), 10, 1, 777)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -270,11 +384,18 @@ func TestDeleteForcedChannel_Success(t *testing.T) {
 	repo, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	mock.ExpectBegin()
+	ownerRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM bots WHERE id").
+		WithArgs(int64(1), int64(777)).
+		WillReturnRows(ownerRows)
+
 	mock.ExpectExec("DELETE FROM forced_channels").
 		WithArgs(int64(1), int64(-1001234567890)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	err := repo.DeleteForcedChannel(context.Background(), 1, -1001234567890)
+	err := repo.DeleteForcedChannel(context.Background(), 1, -1001234567890, 777)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -360,6 +481,701 @@ type ScheduledMessageForTest struct {
 
 // ==================== Setup Helper ====================
 
+// ==================== Transaction Tests ====================
+
+func TestWithTx_Commit(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO message_logs").
+		WithArgs(1, int64(2), int64(3)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	err := repo.WithTx(ctx, func(tx *database.Repository) error {
+		return tx.SaveMessageLog(ctx, 1, 2, 3)
+	})
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestWithTx_RollbackOnError(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	wantErr := errors.New("boom")
+	err := repo.WithTx(context.Background(), func(tx *database.Repository) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Expected original error back, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Delivery Tests ====================
+
+func TestRecordDelivery_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO deliveries").
+		WithArgs(int64(1), int64(555), "reply", "blocked", "blocked_by_user").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.RecordDelivery(context.Background(), 1, 555, "reply", "blocked", "blocked_by_user")
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetLastDelivery_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "user_chat_id", "source", "status", "error_code", "created_at"}).
+		AddRow(7, 1, 555, "broadcast", "delivered", "", time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM deliveries WHERE bot_id").
+		WithArgs(int64(1), int64(555)).
+		WillReturnRows(rows)
+
+	delivery, err := repo.GetLastDelivery(context.Background(), 1, 555)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if delivery == nil {
+		t.Fatal("Expected delivery to be returned")
+	}
+	if delivery.Status != "delivered" {
+		t.Errorf("Expected status 'delivered', got %q", delivery.Status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetLastDelivery_NoRows(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT (.+) FROM deliveries WHERE bot_id").
+		WithArgs(int64(1), int64(555)).
+		WillReturnError(sql.ErrNoRows)
+
+	delivery, err := repo.GetLastDelivery(context.Background(), 1, 555)
+	if err != nil {
+		t.Fatalf("Expected nil error for no rows, got: %v", err)
+	}
+	if delivery != nil {
+		t.Error("Expected nil delivery when nothing was sent")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Reply Mapping Tests ====================
+
+func TestSaveReplyMapping_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO replies").
+		WithArgs(int64(1), 42, int64(555), 99, int64(555), 99).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveReplyMapping(context.Background(), 1, 42, 555, 99)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetReplyMapping_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "admin_msg_id", "user_chat_id", "user_msg_id", "created_at"}).
+		AddRow(1, 1, 42, 555, 99, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM replies WHERE bot_id").
+		WithArgs(int64(1), 42).
+		WillReturnRows(rows)
+
+	reply, err := repo.GetReplyMapping(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if reply == nil {
+		t.Fatal("Expected reply mapping to be returned")
+	}
+	if reply.UserMsgID != 99 {
+		t.Errorf("Expected user msg ID 99, got %d", reply.UserMsgID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteReplyMapping_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("DELETE FROM replies WHERE bot_id").
+		WithArgs(int64(1), 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteReplyMapping(context.Background(), 1, 42)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpsertBotUser_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO bot_users").
+		WithArgs(int64(1), int64(555)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.UpsertBotUser(context.Background(), 1, 555)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkUserUnreachable_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO bot_users").
+		WithArgs(int64(1), int64(555)).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	err := repo.MarkUserUnreachable(context.Background(), 1, 555)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetReachableUserCount_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(42)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM bot_users WHERE bot_id").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	count, err := repo.GetReachableUserCount(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected 42 reachable users, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateRoutingRule_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO routing_rules").
+		WithArgs(int64(1), "refund", int64(-100123), int64(-100123)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.CreateRoutingRule(context.Background(), 1, "refund", -100123)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRoutingRules_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "keyword", "dest_chat_id", "created_at"}).
+		AddRow(1, 1, "refund", -100123, time.Now()).
+		AddRow(2, 1, "technical", -100456, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM routing_rules WHERE bot_id").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	rules, err := repo.GetRoutingRules(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].Keyword != "refund" || rules[0].DestChatID != -100123 {
+		t.Errorf("Unexpected first rule: %+v", rules[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetReplyMapping_NoRows(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT (.+) FROM replies WHERE bot_id").
+		WithArgs(int64(1), 42).
+		WillReturnError(sql.ErrNoRows)
+
+	reply, err := repo.GetReplyMapping(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("Expected nil error for no rows, got: %v", err)
+	}
+	if reply != nil {
+		t.Error("Expected nil mapping for unknown message")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Abuse Report Tests ====================
+
+func TestCreateAbuseReport_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO abuse_reports").
+		WithArgs(int64(1), int64(555), "spam links").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.CreateAbuseReport(context.Background(), 1, 555, "spam links")
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetOpenAbuseReports_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "reporter_chat_id", "reason", "status", "created_at"}).
+		AddRow(3, 1, 555, "spam", "open", time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM abuse_reports").
+		WithArgs(int64(1), 5).
+		WillReturnRows(rows)
+
+	reports, err := repo.GetOpenAbuseReports(context.Background(), 1, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+	if reports[0].Reason != "spam" {
+		t.Errorf("Expected reason 'spam', got %q", reports[0].Reason)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCloseAbuseReports_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("UPDATE abuse_reports SET status").
+		WithArgs("dismissed", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	closed, err := repo.CloseAbuseReports(context.Background(), 1, "dismissed")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if closed != 2 {
+		t.Errorf("Expected 2 closed reports, got %d", closed)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetBotSuspended_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("UPDATE bots SET is_suspended").
+		WithArgs(true, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetBotSuspended(context.Background(), 1, true)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== ToS Acceptance Tests ====================
+
+func TestRecordTOSAcceptance_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO tos_acceptances").
+		WithArgs(int64(555)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.RecordTOSAcceptance(context.Background(), 555)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetTOSAcceptance_Accepted(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	acceptedAt := time.Now()
+	rows := sqlmock.NewRows([]string{"accepted_at"}).AddRow(acceptedAt)
+
+	mock.ExpectQuery("SELECT accepted_at FROM tos_acceptances").
+		WithArgs(int64(555)).
+		WillReturnRows(rows)
+
+	got, err := repo.GetTOSAcceptance(context.Background(), 555)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got == nil {
+		t.Fatal("Expected acceptance timestamp to be returned")
+	}
+	if !got.Equal(acceptedAt) {
+		t.Errorf("Expected timestamp %v, got %v", acceptedAt, got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetTOSAcceptance_NotAccepted(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT accepted_at FROM tos_acceptances").
+		WithArgs(int64(555)).
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.GetTOSAcceptance(context.Background(), 555)
+	if err != nil {
+		t.Fatalf("Expected nil error for no rows, got: %v", err)
+	}
+	if got != nil {
+		t.Error("Expected nil timestamp when terms were never accepted")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Response Time Tests ====================
+
+func TestGetFirstResponseTimes_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	since := time.Now().AddDate(0, 0, -7)
+	rows := sqlmock.NewRows([]string{"seconds"}).
+		AddRow(int64(30)).
+		AddRow(int64(120))
+
+	mock.ExpectQuery("SELECT TIMESTAMPDIFF\\(SECOND, ml.created_at, MIN\\(rp.created_at\\)\\)").
+		WithArgs(int64(1), since).
+		WillReturnRows(rows)
+
+	seconds, err := repo.GetFirstResponseTimes(context.Background(), 1, since)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(seconds) != 2 || seconds[0] != 30 || seconds[1] != 120 {
+		t.Errorf("Expected [30 120], got %v", seconds)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetUnansweredCount_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	since := time.Now().AddDate(0, 0, -7)
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(int64(3))
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+		WithArgs(int64(1), since).
+		WillReturnRows(rows)
+
+	count, err := repo.GetUnansweredCount(context.Background(), 1, since)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 unanswered messages, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetUnansweredBetween_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	from := time.Now().Add(-10 * time.Minute)
+	to := time.Now().Add(-5 * time.Minute)
+	createdAt := time.Now().Add(-8 * time.Minute)
+	rows := sqlmock.NewRows([]string{"admin_msg_id", "user_chat_id", "created_at"}).
+		AddRow(42, int64(555), createdAt)
+
+	mock.ExpectQuery("SELECT ml.admin_msg_id, ml.user_chat_id, ml.created_at").
+		WithArgs(int64(1), from, to).
+		WillReturnRows(rows)
+
+	messages, err := repo.GetUnansweredBetween(context.Background(), 1, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0].AdminMsgID != 42 || messages[0].UserChatID != 555 {
+		t.Errorf("Unexpected message: %+v", messages[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Rating Tests ====================
+
+func TestSaveRating_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO ratings").
+		WithArgs(int64(1), int64(555), 4).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveRating(context.Background(), 1, 555, 4)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRatingStats_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"average", "count"}).AddRow(4.5, int64(12))
+
+	mock.ExpectQuery("SELECT COALESCE\\(AVG\\(stars\\), 0\\)").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	avg, count, err := repo.GetRatingStats(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if avg != 4.5 {
+		t.Errorf("Expected average 4.5, got %f", avg)
+	}
+	if count != 12 {
+		t.Errorf("Expected 12 ratings, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRatingStats_NoRatings(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"average", "count"}).AddRow(0.0, int64(0))
+
+	mock.ExpectQuery("SELECT COALESCE\\(AVG\\(stars\\), 0\\)").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	avg, count, err := repo.GetRatingStats(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if avg != 0 || count != 0 {
+		t.Errorf("Expected zeros for no ratings, got avg=%f count=%d", avg, count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// ==================== Short Link Tests ====================
+
+func TestCreateShortLink_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("INSERT INTO short_links").
+		WithArgs(int64(1), "a1b2c3d4", "https://example.com/promo").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.CreateShortLink(context.Background(), 1, "a1b2c3d4", "https://example.com/promo")
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetShortLinkByCode_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "bot_id", "code", "target_url", "clicks", "created_at"}).
+		AddRow(1, 1, "a1b2c3d4", "https://example.com/promo", 7, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM short_links WHERE code").
+		WithArgs("a1b2c3d4").
+		WillReturnRows(rows)
+
+	link, err := repo.GetShortLinkByCode(context.Background(), "a1b2c3d4")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if link == nil || link.TargetURL != "https://example.com/promo" || link.Clicks != 7 {
+		t.Errorf("Unexpected link: %+v", link)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetShortLinkByCode_NotFound(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT (.+) FROM short_links WHERE code").
+		WithArgs("unknown1").
+		WillReturnError(sql.ErrNoRows)
+
+	link, err := repo.GetShortLinkByCode(context.Background(), "unknown1")
+	if err != nil {
+		t.Fatalf("Expected no error for unknown code, got: %v", err)
+	}
+	if link != nil {
+		t.Errorf("Expected nil link, got: %+v", link)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestIncrementShortLinkClicks_Success(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectExec("UPDATE short_links SET clicks = clicks").
+		WithArgs("a1b2c3d4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.IncrementShortLinkClicks(context.Background(), "a1b2c3d4")
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// testEncryptionKey is the key every sqlmock-backed repository test
+// encrypts with, shared so tests can derive the same keys the code does
+const testEncryptionKey = "12345678901234567890123456789012"
+
 func setupMockDB(t *testing.T) (*database.Repository, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -368,7 +1184,7 @@ func setupMockDB(t *testing.T) (*database.Repository, sqlmock.Sqlmock, func()) {
 
 	sqlxDB := sqlx.NewDb(db, "mysql")
 	mysql := database.NewMySQLFromDB(sqlxDB)
-	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+	repo := database.NewRepository(mysql, testEncryptionKey)
 
 	cleanup := func() {
 		db.Close()
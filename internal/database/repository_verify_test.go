@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// ==================== Integrity Verification Tests ====================
+
+func TestCountOrphanMessageLogs(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM message_logs ml").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountOrphanMessageLogs(context.Background())
+	if err != nil {
+		t.Fatalf("CountOrphanMessageLogs failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 orphans, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestCountOrphanAutoReplies(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM auto_replies ar").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	count, err := repo.CountOrphanAutoReplies(context.Background())
+	if err != nil {
+		t.Fatalf("CountOrphanAutoReplies failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 orphans, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestVerifyTokenDecryption_ReportsAllBadRows(t *testing.T) {
+	repo, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	goodToken, err := crypto.EncryptDeterministic("123456:ABC-DEF", testEncryptionKey)
+	if err != nil {
+		t.Fatalf("Failed to encrypt test token: %v", err)
+	}
+
+	// Two corrupt rows surrounding a healthy one: the check must report
+	// both instead of aborting on the first failure
+	mock.ExpectQuery("SELECT id, token FROM bots").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).
+			AddRow(1, "not-base64!").
+			AddRow(2, goodToken).
+			AddRow(3, "YWJjZGVm"))
+
+	bad, err := repo.VerifyTokenDecryption(context.Background())
+	if err != nil {
+		t.Fatalf("VerifyTokenDecryption failed: %v", err)
+	}
+	if len(bad) != 2 || bad[0] != 1 || bad[1] != 3 {
+		t.Errorf("Expected bad IDs [1 3], got %v", bad)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Response Time Functions ====================
+
+// GetFirstResponseTimes returns, in seconds, how long each user message
+// logged since the given time waited for the owner's first reply. Messages
+// that are still unanswered are not included.
+func (r *Repository) GetFirstResponseTimes(ctx context.Context, botID int64, since time.Time) ([]int64, error) {
+	var seconds []int64
+	query := `SELECT TIMESTAMPDIFF(SECOND, ml.created_at, MIN(rp.created_at)) as seconds
+			  FROM message_logs ml
+			  JOIN replies rp ON rp.bot_id = ml.bot_id AND rp.admin_msg_id = ml.admin_msg_id
+			  WHERE ml.bot_id = ? AND ml.created_at >= ?
+			  GROUP BY ml.id, ml.created_at`
+
+	err := r.db().SelectContext(ctx, &seconds, query, botID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first response times: %w", err)
+	}
+
+	return seconds, nil
+}
+
+// GetUnansweredCount returns how many user messages logged since the given
+// time have no owner reply yet
+func (r *Repository) GetUnansweredCount(ctx context.Context, botID int64, since time.Time) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*)
+			  FROM message_logs ml
+			  LEFT JOIN replies rp ON rp.bot_id = ml.bot_id AND rp.admin_msg_id = ml.admin_msg_id
+			  WHERE ml.bot_id = ? AND ml.created_at >= ? AND rp.id IS NULL`
+
+	err := r.db().GetContext(ctx, &count, query, botID, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unanswered count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetUnansweredBetween returns user messages logged in [from, to) that have
+// no owner reply yet, oldest first. Used by the SLA loop to alert once per
+// message as it crosses the threshold.
+func (r *Repository) GetUnansweredBetween(ctx context.Context, botID int64, from, to time.Time) ([]models.UnansweredMessage, error) {
+	var messages []models.UnansweredMessage
+	query := `SELECT ml.admin_msg_id, ml.user_chat_id, ml.created_at
+			  FROM message_logs ml
+			  LEFT JOIN replies rp ON rp.bot_id = ml.bot_id AND rp.admin_msg_id = ml.admin_msg_id
+			  WHERE ml.bot_id = ? AND ml.created_at >= ? AND ml.created_at < ? AND rp.id IS NULL
+			  ORDER BY ml.created_at ASC
+			  LIMIT 10`
+
+	err := r.db().SelectContext(ctx, &messages, query, botID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unanswered messages: %w", err)
+	}
+
+	return messages, nil
+}
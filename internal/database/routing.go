@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Routing Rule Functions ====================
+
+// CreateRoutingRule adds or updates a keyword routing rule
+func (r *Repository) CreateRoutingRule(ctx context.Context, botID int64, keyword string, destChatID int64) error {
+	query := `INSERT INTO routing_rules (bot_id, keyword, dest_chat_id)
+			  VALUES (?, ?, ?)
+			  ON DUPLICATE KEY UPDATE dest_chat_id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, botID, keyword, destChatID, destChatID)
+	if err != nil {
+		return classifyError("create routing rule", err)
+	}
+	return nil
+}
+
+// GetRoutingRules retrieves all routing rules for a bot
+func (r *Repository) GetRoutingRules(ctx context.Context, botID int64) ([]models.RoutingRule, error) {
+	var rules []models.RoutingRule
+	query := `SELECT id, bot_id, keyword, dest_chat_id, created_at
+			  FROM routing_rules WHERE bot_id = ?
+			  ORDER BY created_at ASC`
+
+	err := r.db().SelectContext(ctx, &rules, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing rules: %w", err)
+	}
+	return rules, nil
+}
+
+// DeleteRoutingRule removes a routing rule after verifying ownership
+func (r *Repository) DeleteRoutingRule(ctx context.Context, botID, ruleID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM routing_rules WHERE bot_id = ? AND id = ?`
+		result, err := tx.db().ExecContext(ctx, query, botID, ruleID)
+		if err != nil {
+			return fmt.Errorf("failed to delete routing rule: %w", err)
+		}
+		return checkRowsAffected(result, "delete routing rule")
+	})
+}
+
+// GetRoutingRuleCount returns the number of routing rules for a bot
+func (r *Repository) GetRoutingRuleCount(ctx context.Context, botID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM routing_rules WHERE bot_id = ?`
+	err := r.db().GetContext(ctx, &count, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get routing rule count: %w", err)
+	}
+	return count, nil
+}
@@ -15,12 +15,12 @@ import (
 func (r *Repository) CreateScheduledMessage(ctx context.Context, msg *models.ScheduledMessage) (int64, error) {
 	query := `INSERT INTO scheduled_messages
 		(bot_id, owner_chat_id, message_type, message_text, file_id, caption,
-		schedule_type, scheduled_time, time_of_day, day_of_week, status, next_run_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		schedule_type, target_channel_id, scheduled_time, time_of_day, day_of_week, status, next_run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := r.mysql.db.ExecContext(ctx, query,
+	result, err := r.db().ExecContext(ctx, query,
 		msg.BotID, msg.OwnerChatID, msg.MessageType, msg.MessageText, msg.FileID, msg.Caption,
-		msg.ScheduleType, msg.ScheduledTime, msg.TimeOfDay, msg.DayOfWeek, msg.Status, msg.NextRunAt)
+		msg.ScheduleType, msg.TargetChannelID, msg.ScheduledTime, msg.TimeOfDay, msg.DayOfWeek, msg.Status, msg.NextRunAt)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to create scheduled message: %w", err)
@@ -41,18 +41,41 @@ func (r *Repository) GetScheduledMessagesByBot(ctx context.Context, botID int64,
 		WHERE bot_id = ? AND status IN ('pending', 'paused')
 		ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
-	err := r.mysql.db.SelectContext(ctx, &messages, query, botID, limit, offset)
+	err := r.db().SelectContext(ctx, &messages, query, botID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get scheduled messages: %w", err)
 	}
 	return messages, nil
 }
 
+// GetScheduledMessagesPage retrieves one page of pending/paused
+// scheduled messages using keyset pagination (zero Cursor = first page)
+func (r *Repository) GetScheduledMessagesPage(ctx context.Context, botID int64, cursor Cursor, limit int) ([]models.ScheduledMessage, error) {
+	var messages []models.ScheduledMessage
+	query := `SELECT * FROM scheduled_messages
+		WHERE bot_id = ? AND status IN ('pending', 'paused')
+		ORDER BY created_at DESC, id DESC LIMIT ?`
+	args := []interface{}{botID, limit}
+	if !cursor.IsZero() {
+		query = `SELECT * FROM scheduled_messages
+		WHERE bot_id = ? AND status IN ('pending', 'paused')
+		AND (created_at < ? OR (created_at = ? AND id < ?))
+		ORDER BY created_at DESC, id DESC LIMIT ?`
+		args = []interface{}{botID, cursor.CreatedAt, cursor.CreatedAt, cursor.ID, limit}
+	}
+
+	err := r.db().SelectContext(ctx, &messages, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled messages page: %w", err)
+	}
+	return messages, nil
+}
+
 // GetScheduledMessagesCount returns count of scheduled messages for a bot
 func (r *Repository) GetScheduledMessagesCount(ctx context.Context, botID int64) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM scheduled_messages WHERE bot_id = ? AND status IN ('pending', 'paused')`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID)
+	err := r.db().GetContext(ctx, &count, query, botID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get scheduled messages count: %w", err)
 	}
@@ -68,7 +91,7 @@ func (r *Repository) GetPendingScheduledMessages(ctx context.Context, beforeTime
 		AND next_run_at <= ?
 		ORDER BY next_run_at ASC LIMIT ?`
 
-	err := r.mysql.db.SelectContext(ctx, &messages, query, beforeTime, limit)
+	err := r.db().SelectContext(ctx, &messages, query, beforeTime, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending scheduled messages: %w", err)
 	}
@@ -81,7 +104,7 @@ func (r *Repository) UpdateScheduledMessageAfterSend(ctx context.Context, msgID
 		SET last_sent_at = ?, next_run_at = ?, updated_at = NOW()
 		WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, lastSent, nextRun, msgID)
+	_, err := r.db().ExecContext(ctx, query, lastSent, nextRun, msgID)
 	if err != nil {
 		return fmt.Errorf("failed to update scheduled message: %w", err)
 	}
@@ -94,50 +117,68 @@ func (r *Repository) UpdateScheduledMessageStatus(ctx context.Context, msgID int
 		SET status = ?, failure_reason = ?, updated_at = NOW()
 		WHERE id = ?`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, status, failureReason, msgID)
+	_, err := r.db().ExecContext(ctx, query, status, failureReason, msgID)
 	if err != nil {
 		return fmt.Errorf("failed to update message status: %w", err)
 	}
 	return nil
 }
 
-// PauseScheduledMessage pauses a scheduled message
-func (r *Repository) PauseScheduledMessage(ctx context.Context, msgID, botID int64) error {
-	query := `UPDATE scheduled_messages
-		SET status = 'paused', updated_at = NOW()
-		WHERE id = ? AND bot_id = ? AND status = 'pending'`
+// PauseScheduledMessage pauses a scheduled message after verifying that
+// ownerChatID owns the bot
+func (r *Repository) PauseScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
 
-	_, err := r.mysql.db.ExecContext(ctx, query, msgID, botID)
-	if err != nil {
-		return fmt.Errorf("failed to pause message: %w", err)
-	}
-	return nil
+		query := `UPDATE scheduled_messages
+			SET status = 'paused', updated_at = NOW()
+			WHERE id = ? AND bot_id = ? AND status = 'pending'`
+
+		if _, err := tx.db().ExecContext(ctx, query, msgID, botID); err != nil {
+			return fmt.Errorf("failed to pause message: %w", err)
+		}
+		return nil
+	})
 }
 
-// ResumeScheduledMessage resumes a paused message
-func (r *Repository) ResumeScheduledMessage(ctx context.Context, msgID, botID int64) error {
-	query := `UPDATE scheduled_messages
-		SET status = 'pending', updated_at = NOW()
-		WHERE id = ? AND bot_id = ? AND status = 'paused'`
+// ResumeScheduledMessage resumes a paused message after verifying that
+// ownerChatID owns the bot
+func (r *Repository) ResumeScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
 
-	_, err := r.mysql.db.ExecContext(ctx, query, msgID, botID)
-	if err != nil {
-		return fmt.Errorf("failed to resume message: %w", err)
-	}
-	return nil
+		query := `UPDATE scheduled_messages
+			SET status = 'pending', updated_at = NOW()
+			WHERE id = ? AND bot_id = ? AND status = 'paused'`
+
+		if _, err := tx.db().ExecContext(ctx, query, msgID, botID); err != nil {
+			return fmt.Errorf("failed to resume message: %w", err)
+		}
+		return nil
+	})
 }
 
-// DeleteScheduledMessage cancels a scheduled message
-func (r *Repository) DeleteScheduledMessage(ctx context.Context, msgID, botID int64) error {
-	query := `UPDATE scheduled_messages
-		SET status = 'cancelled', updated_at = NOW()
-		WHERE id = ? AND bot_id = ?`
+// DeleteScheduledMessage cancels a scheduled message after verifying that
+// ownerChatID owns the bot
+func (r *Repository) DeleteScheduledMessage(ctx context.Context, msgID, botID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
 
-	_, err := r.mysql.db.ExecContext(ctx, query, msgID, botID)
-	if err != nil {
-		return fmt.Errorf("failed to delete message: %w", err)
-	}
-	return nil
+		query := `UPDATE scheduled_messages
+			SET status = 'cancelled', updated_at = NOW()
+			WHERE id = ? AND bot_id = ?`
+
+		if _, err := tx.db().ExecContext(ctx, query, msgID, botID); err != nil {
+			return fmt.Errorf("failed to delete message: %w", err)
+		}
+		return nil
+	})
 }
 
 // GetScheduledMessage retrieves a single scheduled message by ID
@@ -145,7 +186,7 @@ func (r *Repository) GetScheduledMessage(ctx context.Context, msgID int64) (*mod
 	var msg models.ScheduledMessage
 	query := `SELECT * FROM scheduled_messages WHERE id = ?`
 
-	err := r.mysql.db.GetContext(ctx, &msg, query, msgID)
+	err := r.db().GetContext(ctx, &msg, query, msgID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/models"
+)
+
+// ==================== Short Link Functions ====================
+
+// CreateShortLink stores a new short link under a bot's namespace
+func (r *Repository) CreateShortLink(ctx context.Context, botID int64, code, targetURL string) error {
+	query := `INSERT INTO short_links (bot_id, code, target_url) VALUES (?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, code, targetURL)
+	if err != nil {
+		return classifyError("create short link", err)
+	}
+	return nil
+}
+
+// GetShortLinkByCode resolves a code to its link, or nil when unknown.
+// Codes are unique across all bots, so this is the redirect lookup.
+func (r *Repository) GetShortLinkByCode(ctx context.Context, code string) (*models.ShortLink, error) {
+	var link models.ShortLink
+	query := `SELECT id, bot_id, code, target_url, clicks, created_at
+			  FROM short_links WHERE code = ?`
+
+	err := r.db().GetContext(ctx, &link, query, code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	return &link, nil
+}
+
+// GetShortLinks retrieves all short links of a bot
+func (r *Repository) GetShortLinks(ctx context.Context, botID int64) ([]models.ShortLink, error) {
+	var links []models.ShortLink
+	query := `SELECT id, bot_id, code, target_url, clicks, created_at
+			  FROM short_links WHERE bot_id = ?
+			  ORDER BY created_at ASC`
+
+	err := r.db().SelectContext(ctx, &links, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short links: %w", err)
+	}
+	return links, nil
+}
+
+// DeleteShortLink removes a short link after verifying ownership
+func (r *Repository) DeleteShortLink(ctx context.Context, botID, linkID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM short_links WHERE bot_id = ? AND id = ?`
+		result, err := tx.db().ExecContext(ctx, query, botID, linkID)
+		if err != nil {
+			return fmt.Errorf("failed to delete short link: %w", err)
+		}
+		return checkRowsAffected(result, "delete short link")
+	})
+}
+
+// IncrementShortLinkClicks counts one click on a short link
+func (r *Repository) IncrementShortLinkClicks(ctx context.Context, code string) error {
+	query := `UPDATE short_links SET clicks = clicks + 1 WHERE code = ?`
+
+	_, err := r.db().ExecContext(ctx, query, code)
+	if err != nil {
+		return fmt.Errorf("failed to increment short link clicks: %w", err)
+	}
+	return nil
+}
+
+// GetShortLinkCount returns the number of short links for a bot
+func (r *Repository) GetShortLinkCount(ctx context.Context, botID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM short_links WHERE bot_id = ?`
+	err := r.db().GetContext(ctx, &count, query, botID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get short link count: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ==================== Synthetic Traffic ====================
+//
+// The simulate command seeds a bot with fake users and message history
+// so owners can demo dashboards and operators can validate performance
+// work against realistic volume. Synthetic users live in a reserved
+// chat-ID range far above anything Telegram assigns, which keeps them
+// recognizable and makes cleanup a range delete.
+
+// SyntheticUserBase is the first chat ID used for simulated users. Real
+// Telegram IDs are well below it.
+const SyntheticUserBase int64 = 9_000_000_000_000
+
+// InsertSyntheticMessageLog writes a message log row with an explicit
+// timestamp, so simulated history can be spread over past days
+func (r *Repository) InsertSyntheticMessageLog(ctx context.Context, botID int64, adminMsgID int, userChatID int64, createdAt time.Time) error {
+	query := `INSERT INTO message_logs (admin_msg_id, user_chat_id, bot_id, created_at)
+			  VALUES (?, ?, ?, ?)`
+	_, err := r.db().ExecContext(ctx, query, adminMsgID, userChatID, botID, createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert synthetic message log: %w", err)
+	}
+	return nil
+}
+
+// DeleteSyntheticData removes everything the simulator created for a
+// bot and returns how many message logs were dropped
+func (r *Repository) DeleteSyntheticData(ctx context.Context, botID int64) (int64, error) {
+	result, err := r.db().ExecContext(ctx,
+		`DELETE FROM message_logs WHERE bot_id = ? AND user_chat_id >= ?`, botID, SyntheticUserBase)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete synthetic message logs: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted synthetic rows: %w", err)
+	}
+
+	if _, err := r.db().ExecContext(ctx,
+		`DELETE FROM bot_users WHERE bot_id = ? AND user_chat_id >= ?`, botID, SyntheticUserBase); err != nil {
+		return deleted, fmt.Errorf("failed to delete synthetic bot users: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ==================== Spam Feedback Functions ====================
+
+// RecordSpamFeedback stores the owner's verdict on a spam-scored message
+// so classifier operators have labelled training data to pull
+func (r *Repository) RecordSpamFeedback(ctx context.Context, botID, userChatID int64, verdict string) error {
+	query := `INSERT INTO spam_feedback (bot_id, user_chat_id, verdict) VALUES (?, ?, ?)`
+
+	_, err := r.db().ExecContext(ctx, query, botID, userChatID, verdict)
+	if err != nil {
+		return fmt.Errorf("failed to record spam feedback: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBotSpamThreshold updates the spam score threshold (percent, 0 = off)
+func (r *Repository) UpdateBotSpamThreshold(ctx context.Context, botID int64, threshold int) error {
+	query := `UPDATE bots SET spam_threshold = ? WHERE id = ?`
+
+	_, err := r.db().ExecContext(ctx, query, threshold, botID)
+	if err != nil {
+		return fmt.Errorf("failed to update spam_threshold: %w", err)
+	}
+
+	return nil
+}
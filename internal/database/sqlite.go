@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+// ==================== SQLite Dev Backend ====================
+//
+// Dev mode can run the whole factory against an in-memory SQLite
+// database (pure-Go modernc driver), so `go run ./cmd/server` needs no
+// MySQL instance. The schema and the repository queries stay written
+// for MySQL; the handful of MySQL-isms they use are rewritten here at
+// the executor boundary, the same way tenant.go rewrites table names.
+// Everything is lost on restart — for dev mode that is the point.
+
+// dialectSQLite marks a MySQL wrapper as backed by SQLite. The zero
+// dialect means MySQL, so wrappers built by tests via NewMySQLFromDB
+// keep their current behavior.
+const dialectSQLite = "sqlite"
+
+// NewSQLite opens an in-memory SQLite database and runs the same
+// migrations NewMySQL does. The pool is pinned to a single connection:
+// every connection to ":memory:" would otherwise see its own empty
+// database, and one writer at a time sidesteps SQLite's locking.
+func NewSQLite() (*MySQL, error) {
+	dsn := "file::memory:?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+	db, err := sqlx.Connect("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	m := &MySQL{db: db, dialect: dialectSQLite}
+	if err := m.migrate(); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	log.Println("Using in-memory SQLite database; all data is lost on restart")
+	return m, nil
+}
+
+// Schema constructs MySQL accepts but SQLite does not. Inline INDEX
+// entries are dropped outright: the composite indexes the hot paths
+// need are created separately by addIndexIfNotExists, and an in-memory
+// dev database does not miss the rest.
+var (
+	sqliteAutoIncRx   = regexp.MustCompile(`BIGINT AUTO_INCREMENT PRIMARY KEY`)
+	sqliteIndexLineRx = regexp.MustCompile(`,\s*INDEX\s+\w+\s+\([^)]+\)`)
+	sqliteUniqueKeyRx = regexp.MustCompile(`UNIQUE KEY\s+\w+\s+\(`)
+	sqliteEnumRx      = regexp.MustCompile(`ENUM\s*\([^)]*\)`)
+	sqliteOnUpdateRx  = regexp.MustCompile(`\s+ON UPDATE CURRENT_TIMESTAMP`)
+	sqliteEngineRx    = regexp.MustCompile(`\)\s*ENGINE=[^;]*;`)
+)
+
+// toSQLiteDDL adapts one migration statement to SQLite
+func toSQLiteDDL(query string) string {
+	query = sqliteAutoIncRx.ReplaceAllString(query, "INTEGER PRIMARY KEY AUTOINCREMENT")
+	query = sqliteIndexLineRx.ReplaceAllString(query, "")
+	query = sqliteUniqueKeyRx.ReplaceAllString(query, "UNIQUE (")
+	query = sqliteEnumRx.ReplaceAllString(query, "TEXT")
+	query = sqliteOnUpdateRx.ReplaceAllString(query, "")
+	query = sqliteEngineRx.ReplaceAllString(query, ");")
+	return query
+}
+
+// MySQL expressions the repository queries use that SQLite spells
+// differently. See toSQLiteQuery.
+var (
+	sqliteDateSubRx  = regexp.MustCompile(`DATE_SUB\(NOW\(\), INTERVAL \? DAY\)`)
+	sqliteIntervalRx = regexp.MustCompile(`NOW\(\)\s*-\s*INTERVAL\s+(.+?)\s+DAY`)
+	sqliteTimeDiffRx = regexp.MustCompile(`TIMESTAMPDIFF\(SECOND,\s*([^,]+),\s*((?:[^()]|\([^()]*\))+)\)`)
+	sqliteValuesRx   = regexp.MustCompile(`VALUES\((\w+)\)`)
+)
+
+// onDuplicateKeyUpdate is the MySQL upsert clause rewritten for SQLite
+const onDuplicateKeyUpdate = "ON DUPLICATE KEY UPDATE"
+
+// toSQLiteQuery rewrites the MySQL-isms in one repository statement.
+// It is deliberately not a general translator: it covers exactly the
+// constructs this package uses, and queries that stick to portable SQL
+// pass through untouched.
+func toSQLiteQuery(query string) string {
+	query = sqliteDateSubRx.ReplaceAllString(query, `datetime('now', '-' || ? || ' day')`)
+	query = sqliteIntervalRx.ReplaceAllString(query, `datetime('now', '-' || $1 || ' day')`)
+	query = strings.ReplaceAll(query, "NOW()", "CURRENT_TIMESTAMP")
+	query = sqliteTimeDiffRx.ReplaceAllString(query, `(strftime('%s', $2) - strftime('%s', $1))`)
+	if i := strings.Index(query, onDuplicateKeyUpdate); i >= 0 {
+		// VALUES(col) is only rewritten inside the upsert tail, so the
+		// VALUES (...) row list of the INSERT itself is left alone
+		tail := sqliteValuesRx.ReplaceAllString(query[i+len(onDuplicateKeyUpdate):], "excluded.$1")
+		query = query[:i] + "ON CONFLICT DO UPDATE SET" + tail
+	}
+	return query
+}
+
+// sqliteExecutor wraps an executor and rewrites the MySQL-isms in every
+// statement. Repository.db returns one when the SQLite backend is active.
+type sqliteExecutor struct {
+	inner sqlExecutor
+}
+
+func (e sqliteExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return e.inner.ExecContext(ctx, toSQLiteQuery(query), args...)
+}
+
+func (e sqliteExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return e.inner.GetContext(ctx, dest, toSQLiteQuery(query), args...)
+}
+
+func (e sqliteExecutor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return e.inner.SelectContext(ctx, dest, toSQLiteQuery(query), args...)
+}
@@ -0,0 +1,151 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/database"
+)
+
+// ==================== SQLite Dev Backend Tests ====================
+//
+// These run against a real in-memory SQLite database, so they cover the
+// full migration set and the MySQL-to-SQLite query rewriting, not mocks.
+
+func setupSQLiteRepo(t *testing.T) (*database.MySQL, *database.Repository) {
+	t.Helper()
+
+	db, err := database.NewSQLite()
+	if err != nil {
+		t.Fatalf("NewSQLite failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db, database.NewRepository(db, "12345678901234567890123456789012")
+}
+
+func TestSQLite_MigrationsAndBotRoundTrip(t *testing.T) {
+	_, repo := setupSQLiteRepo(t)
+	ctx := context.Background()
+
+	created, err := repo.CreateBot(ctx, "123456:ABC-DEF", 999, "testbot", 1)
+	if err != nil {
+		t.Fatalf("CreateBot failed: %v", err)
+	}
+
+	bot, err := repo.GetBotByToken(ctx, "123456:ABC-DEF")
+	if err != nil {
+		t.Fatalf("GetBotByToken failed: %v", err)
+	}
+	if bot == nil || bot.ID != created.ID || bot.OwnerChatID != 999 {
+		t.Errorf("Expected the created bot back, got %+v", bot)
+	}
+
+	// The UNIQUE constraint on token must survive the DDL translation
+	// and classify as ErrDuplicate like it does on MySQL
+	if _, err := repo.CreateBot(ctx, "123456:ABC-DEF", 777, "otherbot", 1); !errors.Is(err, database.ErrDuplicate) {
+		t.Errorf("Expected ErrDuplicate for a reused token, got %v", err)
+	}
+}
+
+func TestSQLite_UpsertQueries(t *testing.T) {
+	_, repo := setupSQLiteRepo(t)
+	ctx := context.Background()
+
+	bot, err := repo.CreateBot(ctx, "123456:ABC-DEF", 999, "testbot", 1)
+	if err != nil {
+		t.Fatalf("CreateBot failed: %v", err)
+	}
+
+	// ON DUPLICATE KEY UPDATE must become an upsert, not a second row
+	for i := 0; i < 2; i++ {
+		if err := repo.UpsertBotUser(ctx, bot.ID, 555); err != nil {
+			t.Fatalf("UpsertBotUser failed: %v", err)
+		}
+	}
+	reachable, err := repo.GetReachableUserCount(ctx, bot.ID)
+	if err != nil {
+		t.Fatalf("GetReachableUserCount failed: %v", err)
+	}
+	if reachable != 1 {
+		t.Errorf("Expected 1 reachable user after double upsert, got %d", reachable)
+	}
+
+	if err := repo.MarkUserUnreachable(ctx, bot.ID, 555); err != nil {
+		t.Fatalf("MarkUserUnreachable failed: %v", err)
+	}
+	blocked, err := repo.GetBlockedUserCount(ctx, bot.ID)
+	if err != nil {
+		t.Fatalf("GetBlockedUserCount failed: %v", err)
+	}
+	if blocked != 1 {
+		t.Errorf("Expected 1 blocked user, got %d", blocked)
+	}
+
+	// VALUES(plan) in the upsert tail must be rewritten to excluded.plan
+	if err := repo.SetOwnerPlan(ctx, 999, "free"); err != nil {
+		t.Fatalf("SetOwnerPlan failed: %v", err)
+	}
+	if err := repo.SetOwnerPlan(ctx, 999, "pro"); err != nil {
+		t.Fatalf("SetOwnerPlan (update) failed: %v", err)
+	}
+	plan, err := repo.GetOwnerPlan(ctx, 999)
+	if err != nil {
+		t.Fatalf("GetOwnerPlan failed: %v", err)
+	}
+	if plan != "pro" {
+		t.Errorf("Expected plan pro after upsert, got %q", plan)
+	}
+}
+
+func TestSQLite_TimeExpressions(t *testing.T) {
+	db, repo := setupSQLiteRepo(t)
+	ctx := context.Background()
+
+	bot, err := repo.CreateBot(ctx, "123456:ABC-DEF", 999, "testbot", 1)
+	if err != nil {
+		t.Fatalf("CreateBot failed: %v", err)
+	}
+
+	// DeleteBot stamps deleted_at with NOW()
+	if err := repo.DeleteBot(ctx, "123456:ABC-DEF"); err != nil {
+		t.Fatalf("DeleteBot failed: %v", err)
+	}
+
+	// Backdate the deletion so the DATE_SUB(NOW(), INTERVAL ? DAY)
+	// cutoff in PurgeDeletedBots catches it
+	if _, err := db.DB().Exec(`UPDATE bots SET deleted_at = datetime('now', '-10 day') WHERE id = ?`, bot.ID); err != nil {
+		t.Fatalf("Failed to backdate deleted_at: %v", err)
+	}
+	purged, err := repo.PurgeDeletedBots(ctx, 7)
+	if err != nil {
+		t.Fatalf("PurgeDeletedBots failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 purged bot, got %d", purged)
+	}
+
+	// TIMESTAMPDIFF becomes a strftime subtraction
+	bot2, err := repo.CreateBot(ctx, "654321:FED-CBA", 999, "timedbot", 1)
+	if err != nil {
+		t.Fatalf("CreateBot failed: %v", err)
+	}
+	if _, err := db.DB().Exec(`INSERT INTO message_logs (admin_msg_id, user_chat_id, bot_id, created_at)
+		VALUES (1, 555, ?, datetime('now', '-100 seconds'))`, bot2.ID); err != nil {
+		t.Fatalf("Failed to insert message log: %v", err)
+	}
+	if _, err := db.DB().Exec(`INSERT INTO replies (bot_id, admin_msg_id, user_chat_id, user_msg_id)
+		VALUES (?, 1, 555, 2)`, bot2.ID); err != nil {
+		t.Fatalf("Failed to insert reply: %v", err)
+	}
+
+	seconds, err := repo.GetFirstResponseTimes(ctx, bot2.ID, time.Now().UTC().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetFirstResponseTimes failed: %v", err)
+	}
+	if len(seconds) != 1 || seconds[0] < 90 || seconds[0] > 110 {
+		t.Errorf("Expected one response time around 100s, got %v", seconds)
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ==================== Tenant Table Prefixing ====================
+//
+// Two deployments (staging/production, two brands) can share one MySQL
+// schema by giving each a table prefix. The prefix is applied centrally
+// here, at the executor boundary, so repository queries keep using the
+// plain table names. Deployments that want full schema isolation simply
+// use different DB_NAME values instead.
+
+// tenantTables lists every table the schema creates. Longer names come
+// first so the alternation never matches a shorter name inside a longer
+// one that happens to start at a word boundary.
+var tenantTables = []string{
+	"channel_member_counts",
+	"forced_sub_exemptions",
+	"scheduled_messages",
+	"message_contents",
+	"tos_acceptances",
+	"abuse_reports",
+	"auto_replies",
+	"banned_users",
+	"forced_channels",
+	"message_logs",
+	"routing_rules",
+	"spam_feedback",
+	"short_links",
+	"broadcasts",
+	"whitelisted_users",
+	"audit_logs",
+	"bot_users",
+	"deliveries",
+	"owner_plans",
+	"owner_prefs",
+	"api_keys",
+	"ratings",
+	"replies",
+	"bots",
+}
+
+// tableNameRx matches any schema table name on word boundaries, so
+// column names like bot_id or indexes like idx_auto_replies_bot (where
+// the name is glued to underscores) are left alone
+var tableNameRx = regexp.MustCompile(`\b(` + strings.Join(tenantTables, "|") + `)\b`)
+
+// validTablePrefix keeps the prefix safe to splice into SQL
+var validTablePrefix = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// prefixTables rewrites every table name in query with the prefix
+func prefixTables(query, prefix string) string {
+	if prefix == "" {
+		return query
+	}
+	return tableNameRx.ReplaceAllString(query, prefix+"$1")
+}
+
+// prefixedExecutor wraps an executor and rewrites table names in every
+// statement. Repository.db returns one when a prefix is configured.
+type prefixedExecutor struct {
+	inner  sqlExecutor
+	prefix string
+}
+
+func (e prefixedExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return e.inner.ExecContext(ctx, prefixTables(query, e.prefix), args...)
+}
+
+func (e prefixedExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return e.inner.GetContext(ctx, dest, prefixTables(query, e.prefix), args...)
+}
+
+func (e prefixedExecutor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return e.inner.SelectContext(ctx, dest, prefixTables(query, e.prefix), args...)
+}
+
+// validateTablePrefix rejects prefixes that cannot be spliced into SQL
+func validateTablePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if !validTablePrefix.MatchString(prefix) {
+		return fmt.Errorf("table prefix %q may only contain letters, digits and underscores", prefix)
+	}
+	return nil
+}
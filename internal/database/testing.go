@@ -7,3 +7,9 @@ import "github.com/jmoiron/sqlx"
 func NewMySQLFromDB(db *sqlx.DB) *MySQL {
 	return &MySQL{db: db}
 }
+
+// NewMySQLFromDBWithPrefix is NewMySQLFromDB with a table prefix, for
+// testing the tenant prefixing against a mock database
+func NewMySQLFromDBWithPrefix(db *sqlx.DB, tablePrefix string) *MySQL {
+	return &MySQL{db: db, tablePrefix: tablePrefix}
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ==================== ToS Acceptance Functions ====================
+
+// RecordTOSAcceptance stores that a factory user accepted the terms of
+// service. Re-accepting keeps the original timestamp.
+func (r *Repository) RecordTOSAcceptance(ctx context.Context, chatID int64) error {
+	query := `INSERT INTO tos_acceptances (chat_id)
+			  VALUES (?)
+			  ON DUPLICATE KEY UPDATE chat_id = chat_id`
+
+	_, err := r.db().ExecContext(ctx, query, chatID)
+	if err != nil {
+		return classifyError("record tos acceptance", err)
+	}
+	return nil
+}
+
+// GetTOSAcceptance returns when a factory user accepted the terms of
+// service, or nil if they never did
+func (r *Repository) GetTOSAcceptance(ctx context.Context, chatID int64) (*time.Time, error) {
+	var acceptedAt time.Time
+	query := `SELECT accepted_at FROM tos_acceptances WHERE chat_id = ?`
+
+	err := r.db().GetContext(ctx, &acceptedAt, query, chatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tos acceptance: %w", err)
+	}
+
+	return &acceptedAt, nil
+}
@@ -15,9 +15,9 @@ import (
 func (r *Repository) SaveMessageLog(ctx context.Context, adminMsgID int, userChatID int64, botID int64) error {
 	query := `INSERT INTO message_logs (admin_msg_id, user_chat_id, bot_id) VALUES (?, ?, ?)`
 
-	_, err := r.mysql.db.ExecContext(ctx, query, adminMsgID, userChatID, botID)
+	_, err := r.db().ExecContext(ctx, query, adminMsgID, userChatID, botID)
 	if err != nil {
-		return fmt.Errorf("failed to save message log: %w", err)
+		return classifyError("save message log", err)
 	}
 
 	return nil
@@ -28,7 +28,7 @@ func (r *Repository) GetUserChatID(ctx context.Context, adminMsgID int, botID in
 	var userChatID int64
 	query := `SELECT user_chat_id FROM message_logs WHERE admin_msg_id = ? AND bot_id = ? LIMIT 1`
 
-	err := r.mysql.db.GetContext(ctx, &userChatID, query, adminMsgID, botID)
+	err := r.db().GetContext(ctx, &userChatID, query, adminMsgID, botID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -44,7 +44,7 @@ func (r *Repository) HasUserInteracted(ctx context.Context, botID int64, userCha
 	var exists int
 	query := `SELECT 1 FROM message_logs WHERE bot_id = ? AND user_chat_id = ? LIMIT 1`
 
-	err := r.mysql.db.GetContext(ctx, &exists, query, botID, userChatID)
+	err := r.db().GetContext(ctx, &exists, query, botID, userChatID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -60,7 +60,7 @@ func (r *Repository) GetFirstMessageDate(ctx context.Context, botID int64, userC
 	var createdAt time.Time
 	query := `SELECT created_at FROM message_logs WHERE bot_id = ? AND user_chat_id = ? ORDER BY id ASC LIMIT 1`
 
-	err := r.mysql.db.GetContext(ctx, &createdAt, query, botID, userChatID)
+	err := r.db().GetContext(ctx, &createdAt, query, botID, userChatID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return time.Time{}, nil
@@ -76,7 +76,7 @@ func (r *Repository) GetUniqueUserCount(ctx context.Context, botID int64) (int64
 	var count int64
 	query := `SELECT COUNT(DISTINCT user_chat_id) FROM message_logs WHERE bot_id = ?`
 
-	err := r.mysql.db.GetContext(ctx, &count, query, botID)
+	err := r.db().GetContext(ctx, &count, query, botID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get unique user count: %w", err)
 	}
@@ -89,7 +89,7 @@ func (r *Repository) GetAllUserChatIDs(ctx context.Context, botID int64) ([]int6
 	var userChatIDs []int64
 	query := `SELECT DISTINCT user_chat_id FROM message_logs WHERE bot_id = ?`
 
-	err := r.mysql.db.SelectContext(ctx, &userChatIDs, query, botID)
+	err := r.db().SelectContext(ctx, &userChatIDs, query, botID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all user chat ids: %w", err)
 	}
@@ -99,33 +99,45 @@ func (r *Repository) GetAllUserChatIDs(ctx context.Context, botID int64) ([]int6
 
 // ==================== Ban Functions ====================
 
-// BanUser adds a user to the banned list for a bot
+// BanUser adds a user to the banned list for a bot. The ban only goes
+// through if bannedBy actually owns the bot.
 func (r *Repository) BanUser(ctx context.Context, botID, userChatID, bannedBy int64) error {
-	query := `INSERT INTO banned_users (bot_id, user_chat_id, banned_by)
-			  VALUES (?, ?, ?)
-			  ON DUPLICATE KEY UPDATE banned_by = ?, created_at = CURRENT_TIMESTAMP`
-	_, err := r.mysql.db.ExecContext(ctx, query, botID, userChatID, bannedBy, bannedBy)
-	if err != nil {
-		return fmt.Errorf("failed to ban user: %w", err)
-	}
-	return nil
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, bannedBy); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO banned_users (bot_id, user_chat_id, banned_by)
+				  VALUES (?, ?, ?)
+				  ON DUPLICATE KEY UPDATE banned_by = ?, created_at = CURRENT_TIMESTAMP`
+		if _, err := tx.db().ExecContext(ctx, query, botID, userChatID, bannedBy, bannedBy); err != nil {
+			return classifyError("ban user", err)
+		}
+		return nil
+	})
 }
 
-// UnbanUser removes a user from the banned list
-func (r *Repository) UnbanUser(ctx context.Context, botID, userChatID int64) error {
-	query := `DELETE FROM banned_users WHERE bot_id = ? AND user_chat_id = ?`
-	_, err := r.mysql.db.ExecContext(ctx, query, botID, userChatID)
-	if err != nil {
-		return fmt.Errorf("failed to unban user: %w", err)
-	}
-	return nil
+// UnbanUser removes a user from the banned list after verifying that
+// ownerChatID owns the bot
+func (r *Repository) UnbanUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM banned_users WHERE bot_id = ? AND user_chat_id = ?`
+		if _, err := tx.db().ExecContext(ctx, query, botID, userChatID); err != nil {
+			return fmt.Errorf("failed to unban user: %w", err)
+		}
+		return nil
+	})
 }
 
 // IsUserBanned checks if a user is banned for a specific bot
 func (r *Repository) IsUserBanned(ctx context.Context, botID, userChatID int64) (bool, error) {
 	var exists int
 	query := `SELECT 1 FROM banned_users WHERE bot_id = ? AND user_chat_id = ? LIMIT 1`
-	err := r.mysql.db.GetContext(ctx, &exists, query, botID, userChatID)
+	err := r.db().GetContext(ctx, &exists, query, botID, userChatID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -141,18 +153,42 @@ func (r *Repository) GetBannedUsers(ctx context.Context, botID int64, limit, off
 	query := `SELECT id, bot_id, user_chat_id, banned_by, created_at
 			  FROM banned_users WHERE bot_id = ?
 			  ORDER BY created_at DESC LIMIT ? OFFSET ?`
-	err := r.mysql.db.SelectContext(ctx, &users, query, botID, limit, offset)
+	err := r.db().SelectContext(ctx, &users, query, botID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get banned users: %w", err)
 	}
 	return users, nil
 }
 
+// GetBannedUsersPage retrieves one page of banned users using keyset
+// pagination; pass the zero Cursor for the first page and CursorAfter
+// the last returned row for the next one
+func (r *Repository) GetBannedUsersPage(ctx context.Context, botID int64, cursor Cursor, limit int) ([]models.BannedUser, error) {
+	var users []models.BannedUser
+	query := `SELECT id, bot_id, user_chat_id, banned_by, created_at
+			  FROM banned_users WHERE bot_id = ?
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+	args := []interface{}{botID, limit}
+	if !cursor.IsZero() {
+		query = `SELECT id, bot_id, user_chat_id, banned_by, created_at
+			  FROM banned_users WHERE bot_id = ?
+			  AND (created_at < ? OR (created_at = ? AND id < ?))
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+		args = []interface{}{botID, cursor.CreatedAt, cursor.CreatedAt, cursor.ID, limit}
+	}
+
+	err := r.db().SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banned users page: %w", err)
+	}
+	return users, nil
+}
+
 // GetBannedUserCount returns the count of banned users for a bot
 func (r *Repository) GetBannedUserCount(ctx context.Context, botID int64) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM banned_users WHERE bot_id = ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID)
+	err := r.db().GetContext(ctx, &count, query, botID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get banned user count: %w", err)
 	}
@@ -165,18 +201,30 @@ func (r *Repository) GetBannedUserCount(ctx context.Context, botID int64) (int64
 func (r *Repository) GetTotalMessageCount(ctx context.Context, botID int64) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM message_logs WHERE bot_id = ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID)
+	err := r.db().GetContext(ctx, &count, query, botID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total message count: %w", err)
 	}
 	return count, nil
 }
 
+// GetUserMessageCount returns the number of messages a single user has
+// sent to a bot
+func (r *Repository) GetUserMessageCount(ctx context.Context, botID, userChatID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM message_logs WHERE bot_id = ? AND user_chat_id = ?`
+	err := r.db().GetContext(ctx, &count, query, botID, userChatID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user message count: %w", err)
+	}
+	return count, nil
+}
+
 // GetMessageCountSince returns the number of messages since a given time
 func (r *Repository) GetMessageCountSince(ctx context.Context, botID int64, since time.Time) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM message_logs WHERE bot_id = ? AND created_at >= ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID, since)
+	err := r.db().GetContext(ctx, &count, query, botID, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get message count since: %w", err)
 	}
@@ -187,7 +235,7 @@ func (r *Repository) GetMessageCountSince(ctx context.Context, botID int64, sinc
 func (r *Repository) GetActiveUserCount(ctx context.Context, botID int64, since time.Time) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(DISTINCT user_chat_id) FROM message_logs WHERE bot_id = ? AND created_at >= ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, botID, since)
+	err := r.db().GetContext(ctx, &count, query, botID, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get active user count: %w", err)
 	}
@@ -207,7 +255,7 @@ func (r *Repository) GetNewUserCount(ctx context.Context, botID int64, since tim
 			  WHERE ml1.bot_id = ?
 				  AND ml1.created_at >= ?
 				  AND ml2.id IS NULL`
-	err := r.mysql.db.GetContext(ctx, &count, query, since, botID, since)
+	err := r.db().GetContext(ctx, &count, query, since, botID, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get new user count: %w", err)
 	}
@@ -218,7 +266,7 @@ func (r *Repository) GetNewUserCount(ctx context.Context, botID int64, since tim
 func (r *Repository) GetBotFirstActivity(ctx context.Context, botID int64) (time.Time, error) {
 	var createdAt time.Time
 	query := `SELECT MIN(created_at) FROM message_logs WHERE bot_id = ?`
-	err := r.mysql.db.GetContext(ctx, &createdAt, query, botID)
+	err := r.db().GetContext(ctx, &createdAt, query, botID)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to get bot first activity: %w", err)
 	}
@@ -231,7 +279,7 @@ func (r *Repository) GetBotFirstActivity(ctx context.Context, botID int64) (time
 func (r *Repository) GetGlobalUniqueUserCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(DISTINCT user_chat_id) FROM message_logs`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global unique user count: %w", err)
 	}
@@ -242,7 +290,7 @@ func (r *Repository) GetGlobalUniqueUserCount(ctx context.Context) (int64, error
 func (r *Repository) GetGlobalActiveUserCount(ctx context.Context, since time.Time) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(DISTINCT user_chat_id) FROM message_logs WHERE created_at >= ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, since)
+	err := r.db().GetContext(ctx, &count, query, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global active user count: %w", err)
 	}
@@ -258,7 +306,7 @@ func (r *Repository) GetGlobalNewUserCount(ctx context.Context, since time.Time)
 				  WHERE created_at < ?
 			  )
 			  AND created_at >= ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, since, since)
+	err := r.db().GetContext(ctx, &count, query, since, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global new user count: %w", err)
 	}
@@ -269,7 +317,7 @@ func (r *Repository) GetGlobalNewUserCount(ctx context.Context, since time.Time)
 func (r *Repository) GetGlobalTotalMessageCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM message_logs`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global total message count: %w", err)
 	}
@@ -280,7 +328,7 @@ func (r *Repository) GetGlobalTotalMessageCount(ctx context.Context) (int64, err
 func (r *Repository) GetGlobalMessageCountSince(ctx context.Context, since time.Time) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM message_logs WHERE created_at >= ?`
-	err := r.mysql.db.GetContext(ctx, &count, query, since)
+	err := r.db().GetContext(ctx, &count, query, since)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global message count since: %w", err)
 	}
@@ -291,7 +339,7 @@ func (r *Repository) GetGlobalMessageCountSince(ctx context.Context, since time.
 func (r *Repository) GetGlobalBannedUserCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM banned_users`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global banned user count: %w", err)
 	}
@@ -302,7 +350,7 @@ func (r *Repository) GetGlobalBannedUserCount(ctx context.Context) (int64, error
 func (r *Repository) GetGlobalAutoReplyCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM auto_replies WHERE is_active = TRUE`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global auto-reply count: %w", err)
 	}
@@ -313,18 +361,52 @@ func (r *Repository) GetGlobalAutoReplyCount(ctx context.Context) (int64, error)
 func (r *Repository) GetGlobalForcedChannelCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(*) FROM forced_channels WHERE is_active = TRUE`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get global forced channel count: %w", err)
 	}
 	return count, nil
 }
 
+// GetGlobalSharedUserCount returns the number of users who interacted with more than one bot
+func (r *Repository) GetGlobalSharedUserCount(ctx context.Context) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM (
+				  SELECT user_chat_id FROM message_logs
+				  GROUP BY user_chat_id
+				  HAVING COUNT(DISTINCT bot_id) > 1
+			  ) AS shared`
+	err := r.db().GetContext(ctx, &count, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get global shared user count: %w", err)
+	}
+	return count, nil
+}
+
+// GetTopAudienceOverlaps returns the bot pairs that share the most users, largest overlap first
+func (r *Repository) GetTopAudienceOverlaps(ctx context.Context, limit int) ([]models.AudienceOverlap, error) {
+	var overlaps []models.AudienceOverlap
+	query := `SELECT ba.username AS bot_a_username, bb.username AS bot_b_username,
+				  COUNT(DISTINCT a.user_chat_id) AS shared_users
+			  FROM message_logs a
+			  JOIN message_logs b ON a.user_chat_id = b.user_chat_id AND a.bot_id < b.bot_id
+			  JOIN bots ba ON ba.id = a.bot_id AND ba.deleted_at IS NULL
+			  JOIN bots bb ON bb.id = b.bot_id AND bb.deleted_at IS NULL
+			  GROUP BY a.bot_id, b.bot_id, ba.username, bb.username
+			  ORDER BY shared_users DESC
+			  LIMIT ?`
+	err := r.db().SelectContext(ctx, &overlaps, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top audience overlaps: %w", err)
+	}
+	return overlaps, nil
+}
+
 // GetUniqueOwnerCount returns the number of unique bot owners
 func (r *Repository) GetUniqueOwnerCount(ctx context.Context) (int64, error) {
 	var count int64
 	query := `SELECT COUNT(DISTINCT owner_chat_id) FROM bots WHERE deleted_at IS NULL`
-	err := r.mysql.db.GetContext(ctx, &count, query)
+	err := r.db().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get unique owner count: %w", err)
 	}
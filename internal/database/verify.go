@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amr-9/botforge/internal/utils/crypto"
+)
+
+// ==================== Integrity Verification ====================
+//
+// These checks back the `verify` subcommand. They are read-only: the
+// command reports problems and leaves the fix (purge, rekey, restore
+// from backup) to the operator.
+
+// CountOrphanMessageLogs counts message_logs rows whose bot no longer
+// exists. The FK cascades on delete, so orphans only appear after manual
+// SQL or a partial restore.
+func (r *Repository) CountOrphanMessageLogs(ctx context.Context) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM message_logs ml
+			  LEFT JOIN bots b ON ml.bot_id = b.id
+			  WHERE b.id IS NULL`
+
+	err := r.db().GetContext(ctx, &count, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count orphan message logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountOrphanAutoReplies counts auto_replies rows pointing at bots that
+// are missing or soft-deleted. Rows for soft-deleted bots are expected
+// to disappear with the bot once purge runs; anything else is an orphan.
+func (r *Repository) CountOrphanAutoReplies(ctx context.Context) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM auto_replies ar
+			  LEFT JOIN bots b ON ar.bot_id = b.id
+			  WHERE b.id IS NULL OR b.deleted_at IS NOT NULL`
+
+	err := r.db().GetContext(ctx, &count, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count orphan auto replies: %w", err)
+	}
+
+	return count, nil
+}
+
+// VerifyTokenDecryption attempts to decrypt every stored bot token and
+// returns the IDs of bots whose token cannot be decrypted with the
+// current key. Unlike GetAllBots it does not abort on the first failure,
+// so a single corrupt row (or a half-applied rekey) yields a full list.
+func (r *Repository) VerifyTokenDecryption(ctx context.Context) ([]int64, error) {
+	var rows []struct {
+		ID    int64  `db:"id"`
+		Token string `db:"token"`
+	}
+	query := `SELECT id, token FROM bots WHERE deleted_at IS NULL`
+
+	err := r.db().SelectContext(ctx, &rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bot tokens: %w", err)
+	}
+
+	var undecryptable []int64
+	for _, row := range rows {
+		if _, err := crypto.DecryptDeterministic(row.Token, r.encryptionKey); err != nil {
+			undecryptable = append(undecryptable, row.ID)
+		}
+	}
+
+	return undecryptable, nil
+}
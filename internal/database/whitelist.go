@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ==================== Whitelist Functions ====================
+
+// AddWhitelistedUser pre-approves a user for a whitelist-only bot
+func (r *Repository) AddWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO whitelisted_users (bot_id, user_chat_id)
+				  VALUES (?, ?)
+				  ON DUPLICATE KEY UPDATE created_at = created_at`
+		if _, err := tx.db().ExecContext(ctx, query, botID, userChatID); err != nil {
+			return classifyError("whitelist user", err)
+		}
+		return nil
+	})
+}
+
+// RemoveWhitelistedUser revokes a user's pre-approval
+func (r *Repository) RemoveWhitelistedUser(ctx context.Context, botID, userChatID, ownerChatID int64) error {
+	return r.WithTx(ctx, func(tx *Repository) error {
+		if err := tx.requireBotOwner(ctx, botID, ownerChatID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM whitelisted_users WHERE bot_id = ? AND user_chat_id = ?`
+		result, err := tx.db().ExecContext(ctx, query, botID, userChatID)
+		if err != nil {
+			return fmt.Errorf("failed to remove whitelisted user: %w", err)
+		}
+		return checkRowsAffected(result, "remove whitelisted user")
+	})
+}
+
+// IsUserWhitelisted checks whether a user is pre-approved for a bot
+func (r *Repository) IsUserWhitelisted(ctx context.Context, botID, userChatID int64) (bool, error) {
+	var exists int
+	query := `SELECT 1 FROM whitelisted_users WHERE bot_id = ? AND user_chat_id = ? LIMIT 1`
+	err := r.db().GetContext(ctx, &exists, query, botID, userChatID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check whitelist status: %w", err)
+	}
+	return true, nil
+}
+
+// GetWhitelistedUsers retrieves a bot's whitelist, oldest first
+func (r *Repository) GetWhitelistedUsers(ctx context.Context, botID int64) ([]int64, error) {
+	var userIDs []int64
+	query := `SELECT user_chat_id FROM whitelisted_users WHERE bot_id = ? ORDER BY created_at ASC`
+
+	err := r.db().SelectContext(ctx, &userIDs, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get whitelisted users: %w", err)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,120 @@
+// Package events is the internal event bus. Producers publish typed
+// events (bot started, message forwarded, broadcast completed) and
+// downstream consumers — metrics, outgoing webhooks, analytics —
+// subscribe instead of being called inline, so adding a consumer never
+// touches producer code. The in-process bus in this file is the default;
+// nats.go adds a NATS backend for multi-instance deployments.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event names published across the codebase
+const (
+	BotStarted         = "bot_started"
+	BotStopped         = "bot_stopped"
+	MessageForwarded   = "message_forwarded"
+	BroadcastCompleted = "broadcast_completed"
+)
+
+// Event is one thing that happened, with enough context to act on it
+// without a database round trip
+type Event struct {
+	Name        string            `json:"name"`
+	BotID       int64             `json:"bot_id,omitempty"`
+	OwnerChatID int64             `json:"owner_chat_id,omitempty"`
+	At          time.Time         `json:"at"`
+	Fields      map[string]string `json:"fields,omitempty"`
+}
+
+// Handler consumes one event. Handlers run on the bus's dispatch
+// goroutine and must not block.
+type Handler func(Event)
+
+// Bus routes published events to subscribers
+type Bus interface {
+	// Publish delivers the event to all matching subscribers. It never
+	// blocks the caller on slow consumers.
+	Publish(event Event)
+
+	// Subscribe registers a handler for one event name; an empty name
+	// subscribes to every event
+	Subscribe(name string, handler Handler)
+
+	// Close stops delivery and releases any connections
+	Close()
+}
+
+// InProcBus delivers events to subscribers inside the same process
+type InProcBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	queue    chan Event
+	done     chan struct{}
+	closed   bool
+}
+
+// queueSize bounds how many undelivered events are held before new
+// publishes are dropped; dropping beats blocking a message handler
+const queueSize = 1024
+
+// NewInProcBus creates and starts an in-process bus
+func NewInProcBus() *InProcBus {
+	b := &InProcBus{
+		handlers: make(map[string][]Handler),
+		queue:    make(chan Event, queueSize),
+		done:     make(chan struct{}),
+	}
+	go b.dispatch()
+	return b
+}
+
+// Publish queues the event for delivery, stamping At when unset
+func (b *InProcBus) Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	select {
+	case b.queue <- event:
+	default:
+		// Queue full: drop rather than stall the producer
+	}
+}
+
+// Subscribe registers a handler for one event name ("" for all events)
+func (b *InProcBus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Close stops the dispatch goroutine
+func (b *InProcBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+}
+
+func (b *InProcBus) dispatch() {
+	for {
+		select {
+		case event := <-b.queue:
+			b.mu.RLock()
+			handlers := append([]Handler{}, b.handlers[event.Name]...)
+			handlers = append(handlers, b.handlers[""]...)
+			b.mu.RUnlock()
+
+			for _, h := range handlers {
+				h(event)
+			}
+		case <-b.done:
+			return
+		}
+	}
+}
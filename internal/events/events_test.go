@@ -0,0 +1,64 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/events"
+)
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("Condition not met before deadline")
+}
+
+func TestInProcBus_DeliversToNamedSubscriber(t *testing.T) {
+	bus := events.NewInProcBus()
+	defer bus.Close()
+
+	got := make(chan events.Event, 1)
+	bus.Subscribe(events.BotStarted, func(e events.Event) { got <- e })
+
+	bus.Publish(events.Event{Name: events.BotStarted, BotID: 42})
+
+	select {
+	case e := <-got:
+		if e.BotID != 42 {
+			t.Errorf("Expected bot ID 42, got %d", e.BotID)
+		}
+		if e.At.IsZero() {
+			t.Error("Expected At to be stamped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Event was not delivered")
+	}
+}
+
+func TestInProcBus_WildcardSeesEveryEvent(t *testing.T) {
+	bus := events.NewInProcBus()
+	defer bus.Close()
+
+	done := make(chan struct{}, 2)
+	bus.Subscribe("", func(e events.Event) { done <- struct{}{} })
+
+	bus.Publish(events.Event{Name: events.BotStarted})
+	bus.Publish(events.Event{Name: events.BroadcastCompleted})
+
+	waitFor(t, func() bool { return len(done) == 2 })
+}
+
+func TestInProcBus_NoSubscriberIsHarmless(t *testing.T) {
+	bus := events.NewInProcBus()
+	defer bus.Close()
+
+	// Must not panic or block
+	bus.Publish(events.Event{Name: events.MessageForwarded})
+}
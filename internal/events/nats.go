@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// subjectPrefix namespaces BotForge events on a shared NATS cluster
+const subjectPrefix = "botforge.events."
+
+// NATSBus publishes events to a NATS cluster so consumers on other
+// instances see them too. Events are JSON-encoded Event values on
+// subjects "botforge.events.<name>".
+type NATSBus struct {
+	conn *nats.Conn
+}
+
+// NewNATSBus connects to the NATS server at url
+func NewNATSBus(url string) (*NATSBus, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return &NATSBus{conn: conn}, nil
+}
+
+// Publish sends the event to its subject, stamping At when unset
+func (b *NATSBus) Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode event %s: %v", event.Name, err)
+		return
+	}
+	if err := b.conn.Publish(subjectPrefix+event.Name, data); err != nil {
+		log.Printf("Failed to publish event %s: %v", event.Name, err)
+	}
+}
+
+// Subscribe registers a handler for one event name ("" for all events)
+func (b *NATSBus) Subscribe(name string, handler Handler) {
+	subject := subjectPrefix + name
+	if name == "" {
+		subject = subjectPrefix + ">"
+	}
+
+	_, err := b.conn.Subscribe(subject, func(msg *nats.Msg) {
+		var event Event
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("Failed to decode event on %s: %v", msg.Subject, err)
+			return
+		}
+		handler(event)
+	})
+	if err != nil {
+		log.Printf("Failed to subscribe to %s: %v", subject, err)
+	}
+}
+
+// Close drains the connection
+func (b *NATSBus) Close() {
+	if err := b.conn.Drain(); err != nil {
+		log.Printf("Failed to drain NATS connection: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/database"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Abandonment Policy ====================
+
+// The abandonment policy reclaims resources from bots nobody uses any
+// more: owners of bots with zero traffic for abandonedAfter are warned,
+// and bots still quiet after the grace period are deactivated. A
+// deactivated bot can be brought back with one tap on the reactivation
+// button, which reuses the regular start-bot flow.
+
+const (
+	// abandonedAfter is how long a bot may go without any message
+	// traffic before the policy kicks in
+	abandonedAfter = 90 * 24 * time.Hour
+
+	// abandonmentGrace is how long after the owner notice a bot is
+	// given before deactivation
+	abandonmentGrace = 14 * 24 * time.Hour
+
+	// abandonmentTickInterval is how often the policy job runs
+	abandonmentTickInterval = 24 * time.Hour
+)
+
+// StartAbandonmentPolicy launches the daily policy job. Only the primary
+// factory should run it; notices are sent through this factory's bot.
+func (f *Factory) StartAbandonmentPolicy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.abandonCancel = cancel
+	go f.runAbandonmentLoop(ctx)
+	log.Println("Abandonment policy job started")
+}
+
+// runAbandonmentLoop enforces the policy once at startup and then on
+// every tick, until the context is cancelled
+func (f *Factory) runAbandonmentLoop(ctx context.Context) {
+	f.enforceAbandonmentPolicy(ctx)
+
+	ticker := time.NewTicker(abandonmentTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.enforceAbandonmentPolicy(ctx)
+		}
+	}
+}
+
+// enforceAbandonmentPolicy runs one pass: revived bots get their notice
+// cleared, quiet bots get warned, and warned bots past the grace period
+// get deactivated
+func (f *Factory) enforceAbandonmentPolicy(ctx context.Context) {
+	cutoff := time.Now().Add(-abandonedAfter)
+
+	if cleared, err := f.repo.ClearStaleAbandonmentNotices(ctx, cutoff); err != nil {
+		log.Printf("Failed to clear stale abandonment notices: %v", err)
+	} else if cleared > 0 {
+		log.Printf("Abandonment policy: cleared %d notice(s) for revived bots", cleared)
+	}
+
+	candidates, err := f.repo.GetAbandonedBots(ctx, cutoff)
+	if err != nil {
+		log.Printf("Failed to get abandoned bots: %v", err)
+		return
+	}
+
+	for _, candidate := range candidates {
+		if candidate.NotifiedAt == nil {
+			f.notifyAbandonment(ctx, candidate)
+			continue
+		}
+		if time.Since(*candidate.NotifiedAt) >= abandonmentGrace {
+			f.deactivateAbandonedBot(ctx, candidate)
+		}
+	}
+}
+
+// notifyAbandonment warns the owner that the bot is about to be
+// deactivated and records the notice
+func (f *Factory) notifyAbandonment(ctx context.Context, candidate database.AbandonedBot) {
+	deadline := time.Now().Add(abandonmentGrace)
+	msg := fmt.Sprintf(`⚠️ <b>Inactivity Notice</b>
+
+Your bot @%s has received no messages for over %d days.
+
+It will be deactivated on <b>%s</b> unless it gets traffic before then. You can restart it from the factory menu at any time after that.`,
+		candidate.Username,
+		int(abandonedAfter.Hours()/24),
+		deadline.Format("2006-01-02"))
+
+	if _, err := f.bot.Send(&telebot.Chat{ID: candidate.OwnerChatID}, msg, telebot.ModeHTML); err != nil {
+		log.Printf("Failed to send abandonment notice for bot %d: %v", candidate.ID, err)
+		return
+	}
+
+	if err := f.repo.MarkAbandonmentNotified(ctx, candidate.ID); err != nil {
+		log.Printf("Failed to mark abandonment notice for bot %d: %v", candidate.ID, err)
+	}
+}
+
+// deactivateAbandonedBot removes the webhook, flags the bot inactive and
+// offers the owner one-tap reactivation
+func (f *Factory) deactivateAbandonedBot(ctx context.Context, candidate database.AbandonedBot) {
+	f.manager.StopBot(candidate.Token)
+	if err := f.repo.DeactivateBot(ctx, candidate.Token); err != nil {
+		log.Printf("Failed to deactivate abandoned bot %d: %v", candidate.ID, err)
+		return
+	}
+	log.Printf("Abandonment policy: deactivated bot %d (@%s)", candidate.ID, candidate.Username)
+
+	menu := &telebot.ReplyMarkup{}
+	btnReactivate := menu.Data("🔄 Reactivate", CallbackStartBot, strconv.FormatInt(candidate.ID, 10))
+	menu.Inline(menu.Row(btnReactivate))
+
+	msg := fmt.Sprintf(`💤 <b>Bot Deactivated</b>
+
+Your bot @%s was deactivated after %d days without traffic.
+
+Nothing was deleted — tap below to bring it back online.`,
+		candidate.Username,
+		int(abandonedAfter.Hours()/24))
+
+	if _, err := f.bot.Send(&telebot.Chat{ID: candidate.OwnerChatID}, msg, menu, telebot.ModeHTML); err != nil {
+		log.Printf("Failed to send deactivation notice for bot %d: %v", candidate.ID, err)
+	}
+}
@@ -0,0 +1,110 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Branding holds the operator-facing strings of a factory bot. Every field
+// is optional; empty fields fall back to the stock "Bot Factory" wording,
+// so operators can rebrand a deployment without forking handler code.
+type Branding struct {
+	// BrandName replaces "Bot Factory" in the default welcome and menu
+	// headers when the full messages are not overridden
+	BrandName string `json:"brand_name"`
+
+	// WelcomeMessage is the full HTML body sent on /start
+	WelcomeMessage string `json:"welcome_message"`
+
+	// MainMenuMessage is the full HTML body shown when returning to the menu
+	MainMenuMessage string `json:"main_menu_message"`
+
+	// Button labels
+	AddBotLabel string `json:"add_bot_label"`
+	MyBotsLabel string `json:"my_bots_label"`
+	StatsLabel  string `json:"stats_label"`
+	BackLabel   string `json:"back_label"`
+}
+
+// DefaultBranding returns the stock wording used when no overrides are set
+func DefaultBranding() Branding {
+	return Branding{
+		BrandName:   "Bot Factory",
+		AddBotLabel: "➕ Add Bot",
+		MyBotsLabel: "🤖 My Bots",
+		StatsLabel:  "📊 Stats",
+		BackLabel:   "« Back to Menu",
+	}
+}
+
+// withDefaults fills every empty field from the stock wording. The welcome
+// and menu bodies are derived from BrandName so a one-line rebrand still
+// produces coherent messages.
+func (b Branding) withDefaults() Branding {
+	defaults := DefaultBranding()
+	if b.BrandName == "" {
+		b.BrandName = defaults.BrandName
+	}
+	if b.WelcomeMessage == "" {
+		b.WelcomeMessage = fmt.Sprintf(`🤖 <b>Welcome to %s!</b>
+
+I can help you create and manage your own Telegram contact bots.
+
+<b>How it works:</b>
+1. Create a bot with @BotFather
+2. Add it here using the button below
+3. Users message your bot, you receive the messages here
+4. Reply to forward your response back to them
+
+Choose an option below:`, b.BrandName)
+	}
+	if b.MainMenuMessage == "" {
+		b.MainMenuMessage = fmt.Sprintf(`🤖 <b>%s - Main Menu</b>
+
+Choose an option:`, b.BrandName)
+	}
+	if b.AddBotLabel == "" {
+		b.AddBotLabel = defaults.AddBotLabel
+	}
+	if b.MyBotsLabel == "" {
+		b.MyBotsLabel = defaults.MyBotsLabel
+	}
+	if b.StatsLabel == "" {
+		b.StatsLabel = defaults.StatsLabel
+	}
+	if b.BackLabel == "" {
+		b.BackLabel = defaults.BackLabel
+	}
+	return b
+}
+
+// LoadBrandingFile reads a JSON file mapping factory bot tokens to their
+// branding overrides. The special key "default" applies to every factory
+// that has no entry of its own.
+func LoadBrandingFile(path string) (map[string]Branding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branding file: %w", err)
+	}
+
+	brandings := make(map[string]Branding)
+	if err := json.Unmarshal(data, &brandings); err != nil {
+		return nil, fmt.Errorf("failed to parse branding file: %w", err)
+	}
+
+	return brandings, nil
+}
+
+// BrandingFor picks the branding for one factory token out of a loaded
+// branding map, falling back to the "default" entry and then to the stock
+// wording
+func BrandingFor(brandings map[string]Branding, token string) Branding {
+	if b, ok := brandings[token]; ok {
+		return b
+	}
+	if b, ok := brandings["default"]; ok {
+		return b
+	}
+	return Branding{}
+}
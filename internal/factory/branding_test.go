@@ -0,0 +1,122 @@
+package factory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ==================== Branding Default Tests ====================
+
+func TestBranding_WithDefaults_Empty(t *testing.T) {
+	b := Branding{}.withDefaults()
+
+	if b.BrandName != "Bot Factory" {
+		t.Errorf("Expected stock brand name, got %q", b.BrandName)
+	}
+	if !strings.Contains(b.WelcomeMessage, "Welcome to Bot Factory!") {
+		t.Errorf("Expected stock welcome message, got %q", b.WelcomeMessage)
+	}
+	if !strings.Contains(b.MainMenuMessage, "Bot Factory - Main Menu") {
+		t.Errorf("Expected stock menu message, got %q", b.MainMenuMessage)
+	}
+	if b.AddBotLabel != "➕ Add Bot" || b.BackLabel != "« Back to Menu" {
+		t.Errorf("Expected stock button labels, got %q / %q", b.AddBotLabel, b.BackLabel)
+	}
+}
+
+func TestBranding_WithDefaults_BrandNameOnly(t *testing.T) {
+	// A one-line rebrand should flow into the derived messages
+	b := Branding{BrandName: "Acme Bots"}.withDefaults()
+
+	if !strings.Contains(b.WelcomeMessage, "Welcome to Acme Bots!") {
+		t.Errorf("Expected brand name in welcome message, got %q", b.WelcomeMessage)
+	}
+	if !strings.Contains(b.MainMenuMessage, "Acme Bots - Main Menu") {
+		t.Errorf("Expected brand name in menu message, got %q", b.MainMenuMessage)
+	}
+	// Button labels stay stock unless overridden
+	if b.MyBotsLabel != "🤖 My Bots" {
+		t.Errorf("Expected stock My Bots label, got %q", b.MyBotsLabel)
+	}
+}
+
+func TestBranding_WithDefaults_FullOverride(t *testing.T) {
+	b := Branding{
+		BrandName:       "Acme Bots",
+		WelcomeMessage:  "custom welcome",
+		MainMenuMessage: "custom menu",
+		AddBotLabel:     "New Bot",
+	}.withDefaults()
+
+	if b.WelcomeMessage != "custom welcome" {
+		t.Errorf("Expected custom welcome message, got %q", b.WelcomeMessage)
+	}
+	if b.MainMenuMessage != "custom menu" {
+		t.Errorf("Expected custom menu message, got %q", b.MainMenuMessage)
+	}
+	if b.AddBotLabel != "New Bot" {
+		t.Errorf("Expected custom Add Bot label, got %q", b.AddBotLabel)
+	}
+}
+
+// ==================== Branding File Tests ====================
+
+func TestLoadBrandingFile_Valid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "branding.json")
+	content := `{
+		"default": {"brand_name": "Acme Bots"},
+		"123456:TOKEN": {"brand_name": "Other Brand", "back_label": "Back"}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write branding file: %v", err)
+	}
+
+	brandings, err := LoadBrandingFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(brandings) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(brandings))
+	}
+	if brandings["123456:TOKEN"].BackLabel != "Back" {
+		t.Errorf("Expected per-token back label, got %q", brandings["123456:TOKEN"].BackLabel)
+	}
+}
+
+func TestLoadBrandingFile_Missing(t *testing.T) {
+	_, err := LoadBrandingFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Error("Expected error for missing branding file")
+	}
+}
+
+func TestLoadBrandingFile_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "branding.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("Failed to write branding file: %v", err)
+	}
+
+	_, err := LoadBrandingFile(path)
+	if err == nil {
+		t.Error("Expected error for invalid branding file")
+	}
+}
+
+func TestBrandingFor_Precedence(t *testing.T) {
+	brandings := map[string]Branding{
+		"default":       {BrandName: "Default Brand"},
+		"111:TOKEN_ONE": {BrandName: "Brand One"},
+	}
+
+	if got := BrandingFor(brandings, "111:TOKEN_ONE").BrandName; got != "Brand One" {
+		t.Errorf("Expected per-token branding, got %q", got)
+	}
+	if got := BrandingFor(brandings, "222:TOKEN_TWO").BrandName; got != "Default Brand" {
+		t.Errorf("Expected default branding, got %q", got)
+	}
+	if got := BrandingFor(nil, "333:TOKEN_THREE").BrandName; got != "" {
+		t.Errorf("Expected empty branding with no overrides, got %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Announcement Banner (Admin Only) ====================
+
+// handleAnnounceCommand publishes the banner shown on every child bot's
+// admin panel:
+//
+//	/announce <duration> <text>   e.g. /announce 48h Maintenance on Sunday 02:00 UTC
+//	/announce off                 take the banner down early
+func (f *Factory) handleAnnounceCommand(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return nil
+	}
+
+	ctx := context.Background()
+	payload := strings.TrimSpace(c.Message().Payload)
+
+	if payload == "off" {
+		if err := f.cache.ClearAnnouncement(ctx); err != nil {
+			log.Printf("Failed to clear announcement: %v", err)
+			return c.Reply("❌ Failed to clear the announcement.")
+		}
+		return c.Reply("✅ Announcement taken down.")
+	}
+
+	parts := strings.SplitN(payload, " ", 2)
+	if len(parts) != 2 {
+		current, err := f.cache.GetAnnouncement(ctx)
+		if err != nil {
+			log.Printf("Failed to get announcement: %v", err)
+		}
+		usage := "Usage: /announce <duration> <text> (e.g. /announce 48h Maintenance on Sunday), or /announce off"
+		if current != "" {
+			usage += fmt.Sprintf("\n\nCurrent: %s", current)
+		}
+		return c.Reply(usage)
+	}
+
+	duration, err := time.ParseDuration(parts[0])
+	if err != nil || duration <= 0 {
+		return c.Reply("❌ Invalid duration. Use Go notation, e.g. 48h or 30m.")
+	}
+
+	text := strings.TrimSpace(parts[1])
+	if len(text) > 200 {
+		return c.Reply("❌ Keep the announcement under 200 characters.")
+	}
+
+	if err := f.cache.SetAnnouncement(ctx, text, duration); err != nil {
+		log.Printf("Failed to set announcement: %v", err)
+		return c.Reply("❌ Failed to publish the announcement.")
+	}
+
+	return c.Reply(fmt.Sprintf("✅ Announcement published for %s:\n\n📣 %s", duration, text))
+}
@@ -0,0 +1,143 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== API Key Management ====================
+
+// handleAPIKeysBtn lists an owner's API keys for one bot with create
+// and revoke actions. Callback data is the bot ID.
+func (f *Factory) handleAPIKeysBtn(c telebot.Context) error {
+	ctx := context.Background()
+	senderID := c.Sender().ID
+	botRef := c.Data()
+
+	targetBot, err := f.findOwnedBot(ctx, senderID, botRef)
+	if err != nil || targetBot == nil {
+		return c.Edit("❌ Bot not found.", f.getBackButton())
+	}
+
+	keys, err := f.repo.GetAPIKeys(ctx, senderID, targetBot.ID)
+	if err != nil {
+		log.Printf("Failed to get API keys for bot %d: %v", targetBot.ID, err)
+		return c.Edit("❌ Error loading API keys.", f.getBackButton())
+	}
+
+	msg := "🔑 <b>API Keys</b>\n\n"
+	if len(keys) == 0 {
+		msg += "No API keys yet. Create one below.\n\n"
+	} else {
+		msg += "Keys are shown once at creation; only their hash is stored.\n\n"
+	}
+
+	menu := &telebot.ReplyMarkup{}
+	var rows []telebot.Row
+
+	botData := strconv.FormatInt(targetBot.ID, 10)
+
+	for _, key := range keys {
+		lastUsed := "never used"
+		if key.LastUsedAt != nil {
+			lastUsed = "last used " + key.LastUsedAt.Format("2006-01-02")
+		}
+		msg += fmt.Sprintf("• <b>%s</b> — %s (%s)\n", key.Label, key.Permissions, lastUsed)
+
+		revokeData := fmt.Sprintf("%s:%d", botData, key.ID)
+		btnRevoke := menu.Data(fmt.Sprintf("🗑 Revoke %s", key.Label), CallbackRevokeKey, revokeData)
+		rows = append(rows, menu.Row(btnRevoke))
+	}
+
+	btnReadOnly := menu.Data("➕ Read-only key", CallbackNewKey, botData+":read")
+	btnFull := menu.Data("➕ Full-access key", CallbackNewKey, botData+":full")
+	btnBack := menu.Data("« Back", CallbackBotSelect, botData)
+
+	rows = append(rows, menu.Row(btnReadOnly, btnFull))
+	rows = append(rows, menu.Row(btnBack))
+
+	menu.Inline(rows...)
+
+	return c.Edit(msg, menu, telebot.ModeHTML)
+}
+
+// handleNewKeyBtn creates a key from a preset. Callback data is "botID:preset"
+// where preset is "read" or "full".
+func (f *Factory) handleNewKeyBtn(c telebot.Context) error {
+	ctx := context.Background()
+	senderID := c.Sender().ID
+
+	parts := strings.SplitN(c.Data(), ":", 2)
+	if len(parts) != 2 {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid request!", ShowAlert: true})
+	}
+
+	targetBot, err := f.findOwnedBot(ctx, senderID, parts[0])
+	if err != nil || targetBot == nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+	}
+
+	label := "Read-only"
+	permissions := models.APIPermReadStats
+	if parts[1] == "full" {
+		label = "Full access"
+		permissions = strings.Join([]string{
+			models.APIPermReadStats,
+			models.APIPermSendMessages,
+			models.APIPermManageReplies,
+		}, ",")
+	}
+
+	key, err := f.repo.CreateAPIKey(ctx, senderID, targetBot.ID, label, permissions)
+	if err != nil {
+		log.Printf("Failed to create API key for bot %d: %v", targetBot.ID, err)
+		return c.Respond(&telebot.CallbackResponse{Text: "Failed to create key!", ShowAlert: true})
+	}
+
+	// The plaintext is sent in its own message and never stored
+	notice := fmt.Sprintf(`🔑 <b>New API Key</b>
+
+<code>%s</code>
+
+⚠️ Save it now — it will not be shown again.`, key)
+	if _, err := f.bot.Send(c.Sender(), notice, telebot.ModeHTML); err != nil {
+		log.Printf("Failed to send API key to owner %d: %v", senderID, err)
+	}
+
+	c.Callback().Data = parts[0]
+	return f.handleAPIKeysBtn(c)
+}
+
+// handleRevokeKeyBtn deletes a key. Callback data is "botID:keyID".
+func (f *Factory) handleRevokeKeyBtn(c telebot.Context) error {
+	ctx := context.Background()
+	senderID := c.Sender().ID
+
+	parts := strings.SplitN(c.Data(), ":", 2)
+	if len(parts) != 2 {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid request!", ShowAlert: true})
+	}
+
+	keyID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid key!", ShowAlert: true})
+	}
+
+	if err := f.repo.RevokeAPIKey(ctx, senderID, keyID); err != nil {
+		log.Printf("Failed to revoke API key %d: %v", keyID, err)
+		return c.Respond(&telebot.CallbackResponse{Text: "Failed to revoke key!", ShowAlert: true})
+	}
+
+	if err := c.Respond(&telebot.CallbackResponse{Text: "Key revoked"}); err != nil {
+		log.Printf("Failed to respond to callback: %v", err)
+	}
+
+	c.Callback().Data = parts[0]
+	return f.handleAPIKeysBtn(c)
+}
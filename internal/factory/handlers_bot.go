@@ -2,47 +2,123 @@ package factory
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/models"
+	"github.com/Amr-9/botforge/internal/recovery"
+	"github.com/Amr-9/botforge/internal/tgapi"
 	"gopkg.in/telebot.v3"
 )
 
+// apiClient is the shared Telegram API client for helper calls made outside
+// a telebot instance
+var apiClient = tgapi.NewDefaultClient()
+
 // getBotUsername retrieves the bot's username from Telegram API
 func getBotUsername(token string) string {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/getMe", token)
-	resp, err := http.Get(url)
+	me, err := apiClient.GetMe(context.Background(), token)
 	if err != nil {
 		log.Printf("Failed to get bot username: %v", err)
 		return "Unknown"
 	}
-	defer resp.Body.Close()
 
-	var result struct {
-		Ok     bool `json:"ok"`
-		Result struct {
-			Username string `json:"username"`
-		} `json:"result"`
+	if me.Username == "" {
+		return "Unknown"
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("Failed to decode bot info: %v", err)
-		return "Unknown"
+	return me.Username
+}
+
+// resolveBotUsername returns the cached username for a bot or schedules a
+// background backfill on a miss, keeping Telegram API calls out of the UI path
+func (f *Factory) resolveBotUsername(ctx context.Context, botID int64, token string) string {
+	username, hit, err := f.cache.GetBotUsername(ctx, token)
+	if err != nil {
+		log.Printf("Failed to get cached bot username: %v", err)
+	}
+	if hit && username != "" {
+		return username
 	}
 
-	if !result.Ok || result.Result.Username == "" {
-		return "Unknown"
+	f.backfillBotUsername(botID, token)
+	return "Unknown"
+}
+
+// backfillBotUsername fetches a missing username from Telegram in the background
+// and stores it in both the database and the cache
+func (f *Factory) backfillBotUsername(botID int64, token string) {
+	// Skip if a backfill for this token is already running
+	if _, loaded := f.usernameFetches.LoadOrStore(token, struct{}{}); loaded {
+		return
+	}
+
+	recovery.SafeGo(
+		func() {
+			defer f.usernameFetches.Delete(token)
+
+			username := getBotUsername(token)
+			if username == "" || username == "Unknown" {
+				return
+			}
+
+			ctx := context.Background()
+			if err := f.repo.UpdateBotUsername(ctx, botID, username); err != nil {
+				log.Printf("Failed to save bot username to DB: %v", err)
+			}
+			if err := f.cache.SetBotUsername(ctx, token, username); err != nil {
+				log.Printf("Failed to cache bot username: %v", err)
+			}
+		},
+		map[string]string{
+			"type":  "username_backfill",
+			"botID": fmt.Sprintf("%d", botID),
+		},
+		nil,
+	)
+}
+
+// findOwnedBot resolves callback data to one of the sender's bots.
+// New menus carry the numeric bot ID; menus rendered before the switch may
+// still carry a token prefix, so fall back to prefix matching for those.
+func (f *Factory) findOwnedBot(ctx context.Context, senderID int64, data string) (*models.Bot, error) {
+	bots, err := f.repo.GetBotsByOwner(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if botID, parseErr := strconv.ParseInt(data, 10, 64); parseErr == nil {
+		for i := range bots {
+			if bots[i].ID == botID {
+				return &bots[i], nil
+			}
+		}
+		return nil, nil
 	}
 
-	return result.Result.Username
+	// Legacy in-flight menus identify bots by token prefix
+	for i := range bots {
+		if strings.HasPrefix(bots[i].Token, data) {
+			return &bots[i], nil
+		}
+	}
+
+	return nil, nil
 }
 
 // handleAddBotBtn handles add bot button
 func (f *Factory) handleAddBotBtn(c telebot.Context) error {
+	// First-time owners must accept the terms before adding bots
+	if !f.hasAcceptedTOS(context.Background(), c.Sender().ID) {
+		return f.showTOS(c, true)
+	}
+
 	msg := `📝 <b>Add New Bot</b>
 
 Please send me your bot token.
@@ -92,24 +168,18 @@ Use "Add Bot" to add your first bot!`
 		// Get bot username - use stored value or fetch from API
 		username := bot.Username
 		if username == "" {
-			// No stored username, fetch from Telegram API
-			username = getBotUsername(bot.Token)
-			if username != "" && username != "Unknown" {
-				// Save to database for future use
-				if err := f.repo.UpdateBotUsername(ctx, bot.ID, username); err != nil {
-					log.Printf("Failed to save bot username to DB: %v", err)
-				}
-			}
+			// No stored username, use cache and backfill in the background
+			username = f.resolveBotUsername(ctx, bot.ID, bot.Token)
 		}
 
 		btnText := fmt.Sprintf("%s @%s", status, username)
 
-		btn := menu.Data(btnText, CallbackBotSelect, bot.Token[:20])
+		btn := menu.Data(btnText, CallbackBotSelect, strconv.FormatInt(bot.ID, 10))
 		rows = append(rows, menu.Row(btn))
 	}
 
 	// Add back button
-	btnBack := menu.Data("« Back to Menu", CallbackMainMenu)
+	btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
 	rows = append(rows, menu.Row(btnBack))
 
 	menu.Inline(rows...)
@@ -118,86 +188,88 @@ Use "Add Bot" to add your first bot!`
 }
 
 // handleBotDetails shows details for a specific bot
-func (f *Factory) handleBotDetails(c telebot.Context, tokenPrefix string) error {
+func (f *Factory) handleBotDetails(c telebot.Context, botRef string) error {
 	ctx := context.Background()
 	senderID := c.Sender().ID
 
-	// Find the full token
-	bots, err := f.repo.GetBotsByOwner(ctx, senderID)
+	targetBot, err := f.findOwnedBot(ctx, senderID, botRef)
 	if err != nil {
 		return c.Edit("❌ Error loading bot.", f.getBackButton())
 	}
 
-	var targetBot *struct {
-		id          int64
-		token       string
-		username    string
-		ownerChatID int64
-		createdAt   time.Time
-	}
-
-	for _, bot := range bots {
-		if strings.HasPrefix(bot.Token, tokenPrefix) {
-			targetBot = &struct {
-				id          int64
-				token       string
-				username    string
-				ownerChatID int64
-				createdAt   time.Time
-			}{id: bot.ID, token: bot.Token, username: bot.Username, ownerChatID: bot.OwnerChatID, createdAt: bot.CreatedAt}
-			break
-		}
-	}
-
 	if targetBot == nil {
 		return c.Edit("❌ Bot not found.", f.getBackButton())
 	}
 
-	isRunning := f.manager.IsRunning(targetBot.token)
+	isRunning := f.manager.IsRunning(targetBot.Token)
 	status := "🔴 Stopped"
 	if isRunning {
 		status = "🟢 Running"
 	}
 
-	// Get bot username - use stored value or fetch from API
-	username := targetBot.username
+	// Get bot username - use stored value or cached lookup with background backfill
+	username := targetBot.Username
 	if username == "" {
-		// No stored username, fetch from Telegram API
-		username = getBotUsername(targetBot.token)
-		if username != "" && username != "Unknown" {
-			// Save to database for future use
-			if err := f.repo.UpdateBotUsername(ctx, targetBot.id, username); err != nil {
-				log.Printf("Failed to save bot username to DB: %v", err)
-			}
-		}
+		username = f.resolveBotUsername(ctx, targetBot.ID, targetBot.Token)
 	}
 
 	// Format created date
-	createdAt := targetBot.createdAt.Format("2006-01-02 3:04 PM")
+	createdAt := targetBot.CreatedAt.Format("2006-01-02 3:04 PM")
+
+	// For running bots, show what Telegram reports about the webhook so a
+	// silently dead bot is visible here instead of just "Running"
+	healthLines := ""
+	if isRunning {
+		health := f.manager.BotHealth(targetBot.ID)
+		// The background health loop may know about an error even when
+		// the live query comes back clean
+		errorMessage := health.ErrorMessage
+		if errorMessage == "" {
+			errorMessage = f.manager.WebhookIssue(targetBot.ID)
+		}
+		switch {
+		case errorMessage != "":
+			healthLines = fmt.Sprintf("\n<b>Webhook:</b> ⚠️ Telegram reports: %s", errorMessage)
+			if advice := bot.WebhookAdvice(errorMessage); advice != "" {
+				healthLines += fmt.Sprintf("\n💡 %s", advice)
+			}
+		case health.PendingUpdates > 0:
+			healthLines = fmt.Sprintf("\n<b>Webhook:</b> ✅ OK (%d updates pending)", health.PendingUpdates)
+		default:
+			healthLines = "\n<b>Webhook:</b> ✅ OK"
+		}
+		if !health.LastUpdate.IsZero() {
+			healthLines += fmt.Sprintf("\n<b>Last Update:</b> %s", health.LastUpdate.Format("2006-01-02 3:04 PM"))
+		}
+	}
 
 	msg := fmt.Sprintf(`🤖 <b>Bot Details</b>
 
 <b>Username:</b> @%s
 <b>Token:</b> <code>%s</code>
-<b>Status:</b> %s
+<b>Status:</b> %s%s
 <b>Created At:</b> %s
 
-Select an action:`, username, maskToken(targetBot.token), status, createdAt)
+Select an action:`, username, maskToken(targetBot.Token), status, healthLines, createdAt)
 
 	menu := &telebot.ReplyMarkup{}
 	var rows []telebot.Row
 
+	botData := strconv.FormatInt(targetBot.ID, 10)
+
 	if isRunning {
-		btnStop := menu.Data("⏹ Stop Bot", CallbackStopBot, tokenPrefix)
+		btnStop := menu.Data("⏹ Stop Bot", CallbackStopBot, botData)
 		rows = append(rows, menu.Row(btnStop))
 	} else {
-		btnStart := menu.Data("▶️ Start Bot", CallbackStartBot, tokenPrefix)
+		btnStart := menu.Data("▶️ Start Bot", CallbackStartBot, botData)
 		rows = append(rows, menu.Row(btnStart))
 	}
 
-	btnDelete := menu.Data("🗑 Delete Bot", CallbackDeleteBot, tokenPrefix)
+	btnKeys := menu.Data("🔑 API Keys", CallbackAPIKeys, botData)
+	btnDelete := menu.Data("🗑 Delete Bot", CallbackDeleteBot, botData)
 	btnBack := menu.Data("« Back to Bots", CallbackMyBots)
 
+	rows = append(rows, menu.Row(btnKeys))
 	rows = append(rows, menu.Row(btnDelete))
 	rows = append(rows, menu.Row(btnBack))
 
@@ -207,88 +279,79 @@ Select an action:`, username, maskToken(targetBot.token), status, createdAt)
 }
 
 // handleStartBotAction starts a bot
-func (f *Factory) handleStartBotAction(c telebot.Context, tokenPrefix string) error {
+func (f *Factory) handleStartBotAction(c telebot.Context, botRef string) error {
 	ctx := context.Background()
 	senderID := c.Sender().ID
 
-	// Find full token
-	bots, err := f.repo.GetBotsByOwner(ctx, senderID)
+	targetBot, err := f.findOwnedBot(ctx, senderID, botRef)
 	if err != nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Error!", ShowAlert: true})
 	}
 
-	var fullToken string
-	var ownerID int64
-	var botID int64
-	for _, bot := range bots {
-		if strings.HasPrefix(bot.Token, tokenPrefix) {
-			fullToken = bot.Token
-			ownerID = bot.OwnerChatID
-			botID = bot.ID
-			break
-		}
+	if targetBot == nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
 	}
 
-	if fullToken == "" {
-		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+	// Suspended bots can only be reinstated by the admin
+	if targetBot.IsSuspended {
+		return c.Respond(&telebot.CallbackResponse{Text: "⛔ This bot was suspended by the administrator.", ShowAlert: true})
 	}
 
 	// Activate in database
-	if err := f.repo.ActivateBot(ctx, fullToken); err != nil {
+	if err := f.repo.ActivateBot(ctx, targetBot.Token); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+		}
 		return c.Respond(&telebot.CallbackResponse{Text: "Failed to activate!", ShowAlert: true})
 	}
 
 	// Start the bot
-	if err := f.manager.StartBot(fullToken, ownerID, botID); err != nil {
+	if err := f.manager.StartBot(targetBot.Token, targetBot.OwnerChatID, targetBot.ID); err != nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Failed to start: " + err.Error(), ShowAlert: true})
 	}
 
 	c.Respond(&telebot.CallbackResponse{Text: "✅ Bot started!"})
-	return f.handleBotDetails(c, tokenPrefix)
+	return f.handleBotDetails(c, strconv.FormatInt(targetBot.ID, 10))
 }
 
 // handleStopBotAction stops a bot
-func (f *Factory) handleStopBotAction(c telebot.Context, tokenPrefix string) error {
+func (f *Factory) handleStopBotAction(c telebot.Context, botRef string) error {
 	ctx := context.Background()
 	senderID := c.Sender().ID
 
-	// Find full token
-	bots, err := f.repo.GetBotsByOwner(ctx, senderID)
+	targetBot, err := f.findOwnedBot(ctx, senderID, botRef)
 	if err != nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Error!", ShowAlert: true})
 	}
 
-	var fullToken string
-	for _, bot := range bots {
-		if strings.HasPrefix(bot.Token, tokenPrefix) {
-			fullToken = bot.Token
-			break
-		}
-	}
-
-	if fullToken == "" {
+	if targetBot == nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
 	}
 
 	// Deactivate in database
-	f.repo.DeactivateBot(ctx, fullToken)
+	if err := f.repo.DeactivateBot(ctx, targetBot.Token); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+		}
+		log.Printf("Failed to deactivate bot %d: %v", targetBot.ID, err)
+	}
 
 	// Stop the bot
-	f.manager.StopBot(fullToken)
+	f.manager.StopBot(targetBot.Token)
 
 	c.Respond(&telebot.CallbackResponse{Text: "✅ Bot stopped!"})
-	return f.handleBotDetails(c, tokenPrefix)
+	return f.handleBotDetails(c, strconv.FormatInt(targetBot.ID, 10))
 }
 
 // handleDeleteBotConfirm shows delete confirmation
-func (f *Factory) handleDeleteBotConfirm(c telebot.Context, tokenPrefix string) error {
+func (f *Factory) handleDeleteBotConfirm(c telebot.Context, botRef string) error {
 	msg := `⚠️ <b>Confirm Deletion</b>
 
 Are you sure you want to delete this bot?
 This action cannot be undone!`
 
 	menu := &telebot.ReplyMarkup{}
-	btnConfirm := menu.Data("✅ Yes, Delete", CallbackConfirmDel, tokenPrefix)
+	btnConfirm := menu.Data("✅ Yes, Delete", CallbackConfirmDel, botRef)
 	btnCancel := menu.Data("❌ Cancel", CallbackCancelDel)
 
 	menu.Inline(
@@ -299,36 +362,35 @@ This action cannot be undone!`
 }
 
 // handleConfirmDelete actually deletes the bot
-func (f *Factory) handleConfirmDelete(c telebot.Context, tokenPrefix string) error {
+func (f *Factory) handleConfirmDelete(c telebot.Context, botRef string) error {
 	ctx := context.Background()
 	senderID := c.Sender().ID
 
-	// Find full token
-	bots, err := f.repo.GetBotsByOwner(ctx, senderID)
+	targetBot, err := f.findOwnedBot(ctx, senderID, botRef)
 	if err != nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Error!", ShowAlert: true})
 	}
 
-	var fullToken string
-	for _, bot := range bots {
-		if strings.HasPrefix(bot.Token, tokenPrefix) {
-			fullToken = bot.Token
-			break
-		}
-	}
-
-	if fullToken == "" {
+	if targetBot == nil {
 		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
 	}
 
 	// Stop if running
-	f.manager.StopBot(fullToken)
+	f.manager.StopBot(targetBot.Token)
 
 	// Delete from database
-	if err := f.repo.DeleteBot(ctx, fullToken); err != nil {
+	if err := f.repo.DeleteBot(ctx, targetBot.Token); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+		}
 		return c.Respond(&telebot.CallbackResponse{Text: "Failed to delete!", ShowAlert: true})
 	}
 
+	// Purge all cached keys so a later restore starts clean
+	if err := f.cache.PurgeBotKeys(ctx, targetBot.Token); err != nil {
+		log.Printf("Failed to purge cache keys for deleted bot %d: %v", targetBot.ID, err)
+	}
+
 	c.Respond(&telebot.CallbackResponse{Text: "✅ Bot deleted!"})
 
 	// Return to my bots list
@@ -343,33 +405,33 @@ func (f *Factory) handleCancelDeleteBtn(c telebot.Context) error {
 
 // handleBotSelectBtn handles bot selection from list
 func (f *Factory) handleBotSelectBtn(c telebot.Context) error {
-	tokenPrefix := c.Callback().Data
-	log.Printf("[DEBUG] handleBotSelectBtn called - Unique: %s, Data: %s", c.Callback().Unique, tokenPrefix)
-	return f.handleBotDetails(c, tokenPrefix)
+	botRef := c.Callback().Data
+	log.Printf("[DEBUG] handleBotSelectBtn called - Unique: %s, Data: %s", c.Callback().Unique, botRef)
+	return f.handleBotDetails(c, botRef)
 }
 
 // handleStartBotBtn handles start bot button
 func (f *Factory) handleStartBotBtn(c telebot.Context) error {
-	tokenPrefix := c.Callback().Data
-	return f.handleStartBotAction(c, tokenPrefix)
+	botRef := c.Callback().Data
+	return f.handleStartBotAction(c, botRef)
 }
 
 // handleStopBotBtn handles stop bot button
 func (f *Factory) handleStopBotBtn(c telebot.Context) error {
-	tokenPrefix := c.Callback().Data
-	return f.handleStopBotAction(c, tokenPrefix)
+	botRef := c.Callback().Data
+	return f.handleStopBotAction(c, botRef)
 }
 
 // handleDeleteBotBtn handles delete bot button
 func (f *Factory) handleDeleteBotBtn(c telebot.Context) error {
-	tokenPrefix := c.Callback().Data
-	return f.handleDeleteBotConfirm(c, tokenPrefix)
+	botRef := c.Callback().Data
+	return f.handleDeleteBotConfirm(c, botRef)
 }
 
 // handleConfirmDelBtn handles confirm delete button
 func (f *Factory) handleConfirmDelBtn(c telebot.Context) error {
-	tokenPrefix := c.Callback().Data
-	return f.handleConfirmDelete(c, tokenPrefix)
+	botRef := c.Callback().Data
+	return f.handleConfirmDelete(c, botRef)
 }
 
 // handleStatsBtn shows system stats (admin only)
@@ -412,6 +474,7 @@ func (f *Factory) handleStatsBtn(c telebot.Context) error {
 	activeUsers7d, _ := f.repo.GetGlobalActiveUserCount(ctx, now.AddDate(0, 0, -7))
 	newUsersToday, _ := f.repo.GetGlobalNewUserCount(ctx, todayStart)
 	bannedCount, _ := f.repo.GetGlobalBannedUserCount(ctx)
+	sharedUsers, _ := f.repo.GetGlobalSharedUserCount(ctx)
 
 	// Get message statistics
 	totalMessages, _ := f.repo.GetGlobalTotalMessageCount(ctx)
@@ -436,6 +499,7 @@ func (f *Factory) handleStatsBtn(c telebot.Context) error {
 ├ Active (24h): %d
 ├ Active (7d): %d
 ├ New today: %d
+├ Shared (multi-bot): %d
 └ Banned: %d
 
 <b>📨 Messages</b>
@@ -447,10 +511,27 @@ func (f *Factory) handleStatsBtn(c telebot.Context) error {
 ├ Auto-replies: %d
 └ Forced channels: %d`,
 		len(bots), runningCount, len(bots)-runningCount, deletedCount, ownerCount,
-		totalUsers, activeUsers24h, activeUsers7d, newUsersToday, bannedCount,
+		totalUsers, activeUsers24h, activeUsers7d, newUsersToday, sharedUsers, bannedCount,
 		totalMessages, messagesToday, messagesWeek,
 		autoReplyCount, forcedChannelCount)
 
+	// Show which bots share the biggest audiences (if any overlap exists)
+	overlaps, err := f.repo.GetTopAudienceOverlaps(ctx, 5)
+	if err != nil {
+		log.Printf("Failed to get audience overlaps: %v", err)
+	}
+	if len(overlaps) > 0 {
+		msg += "\n\n<b>🔗 Shared Audiences</b>"
+		for i, overlap := range overlaps {
+			branch := "├"
+			if i == len(overlaps)-1 {
+				branch = "└"
+			}
+			msg += fmt.Sprintf("\n%s @%s ↔ @%s: %d users",
+				branch, overlap.BotAUsername, overlap.BotBUsername, overlap.SharedUsers)
+		}
+	}
+
 	return c.Edit(msg, f.getBackButton(), telebot.ModeHTML)
 }
 
@@ -471,6 +552,11 @@ func (f *Factory) processToken(c telebot.Context, token string) error {
 	ctx := context.Background()
 	senderID := c.Sender().ID
 
+	// Tokens sent before the terms were accepted go through the ToS screen
+	if !f.hasAcceptedTOS(ctx, senderID) {
+		return f.showTOS(c, false)
+	}
+
 	// Check if bot already exists (active)
 	existingBot, err := f.repo.GetBotByToken(ctx, token)
 	if err != nil {
@@ -514,11 +600,22 @@ func (f *Factory) processToken(c telebot.Context, token string) error {
 		}
 		botID = deletedBot.ID
 		log.Printf("Bot restored: %s (ID: %d)", botInfo.Username, botID)
+
+		// Drop any keys left over from before the deletion
+		if err := f.cache.PurgeBotKeys(ctx, token); err != nil {
+			log.Printf("Failed to purge stale cache keys for restored bot %d: %v", botID, err)
+		}
 	} else {
 		// Create new bot
-		savedBot, err := f.repo.CreateBot(ctx, token, senderID, botInfo.Username)
+		savedBot, err := f.repo.CreateBot(ctx, token, senderID, botInfo.Username, f.factoryID)
 		if err != nil {
 			log.Printf("Failed to save bot: %v", err)
+			if errors.Is(err, database.ErrDuplicate) {
+				return c.Reply("❌ This bot is already registered.", f.getBackButton())
+			}
+			if errors.Is(err, database.ErrUnavailable) {
+				return c.Reply("❌ Service temporarily unavailable. Please try again in a moment.", f.getBackButton())
+			}
 			return c.Reply("❌ Failed to save bot. Please try again.", f.getBackButton())
 		}
 		botID = savedBot.ID
@@ -0,0 +1,107 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/features"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Feature Flags (Admin Only) ====================
+
+// handleFlagsCommand lists every known feature flag with its effective
+// deployment-wide value and whether that value is an override
+func (f *Factory) handleFlagsCommand(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return nil
+	}
+
+	ctx := context.Background()
+	overrides, err := f.cache.GetFeatureFlags(ctx)
+	if err != nil {
+		log.Printf("Failed to get feature flags: %v", err)
+		return c.Reply("❌ Failed to load feature flags.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🚩 <b>Feature Flags</b>\n\n")
+	for _, flag := range features.Known {
+		enabled := flag.Default
+		source := "default"
+		if val, ok := overrides[flag.Name]; ok {
+			enabled = val
+			source = "override"
+		}
+		state := "❌ off"
+		if enabled {
+			state = "✅ on"
+		}
+		sb.WriteString(fmt.Sprintf("<code>%s</code> — %s (%s)\n%s\n\n", flag.Name, state, source, flag.Description))
+	}
+	sb.WriteString("Set with /flag &lt;name&gt; on|off|clear [bot_id]")
+
+	return c.Reply(sb.String(), telebot.ModeHTML)
+}
+
+// handleFlagCommand changes one feature flag at runtime:
+//
+//	/flag enable_ai_replies on          deployment-wide override
+//	/flag enable_ai_replies clear       back to the compiled-in default
+//	/flag enable_ai_replies on 42       override for bot 42 only
+func (f *Factory) handleFlagCommand(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return nil
+	}
+
+	parts := strings.Fields(c.Message().Payload)
+	if len(parts) != 2 && len(parts) != 3 {
+		return c.Reply("Usage: /flag <name> on|off|clear [bot_id]. See /flags for the list.")
+	}
+
+	name, action := parts[0], parts[1]
+	if _, ok := features.KnownFlag(name); !ok {
+		return c.Reply(fmt.Sprintf("❌ Unknown flag %q. See /flags for the list.", name))
+	}
+	if action != "on" && action != "off" && action != "clear" {
+		return c.Reply("❌ The action must be on, off or clear.")
+	}
+
+	var botID int64
+	if len(parts) == 3 {
+		id, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || id <= 0 {
+			return c.Reply("❌ Invalid bot ID.")
+		}
+		botID = id
+	}
+
+	ctx := context.Background()
+	var err error
+	switch {
+	case botID != 0 && action == "clear":
+		err = f.cache.ClearBotFeatureFlag(ctx, botID, name)
+	case botID != 0:
+		err = f.cache.SetBotFeatureFlag(ctx, botID, name, action == "on")
+	case action == "clear":
+		err = f.cache.ClearFeatureFlag(ctx, name)
+	default:
+		err = f.cache.SetFeatureFlag(ctx, name, action == "on")
+	}
+	if err != nil {
+		log.Printf("Failed to update feature flag %s: %v", name, err)
+		return c.Reply("❌ Failed to update the flag.")
+	}
+
+	scope := "deployment-wide"
+	if botID != 0 {
+		scope = fmt.Sprintf("for bot %d", botID)
+	}
+	if action == "clear" {
+		return c.Reply(fmt.Sprintf("✅ Override for %s cleared %s.", name, scope))
+	}
+	return c.Reply(fmt.Sprintf("✅ Flag %s set to %s %s.", name, action, scope))
+}
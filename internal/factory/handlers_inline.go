@@ -0,0 +1,91 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// handleInlineQuery answers @factorybot inline queries with a searchable
+// list of the sender's bots, so a bot can be found and managed without
+// walking the menu
+func (f *Factory) handleInlineQuery(c telebot.Context) error {
+	ctx := context.Background()
+	query := c.Query()
+
+	bots, err := f.repo.GetBotsByOwner(ctx, query.Sender.ID)
+	if err != nil {
+		log.Printf("Failed to get bots for inline query: %v", err)
+		bots = nil
+	}
+
+	return c.Answer(&telebot.QueryResponse{
+		Results:    f.renderInlineBotResults(ctx, bots, query.Text),
+		IsPersonal: true, // results are the sender's own bots
+		CacheTime:  5,
+	})
+}
+
+// renderInlineBotResults builds one compact article per bot whose username
+// matches the query text (an empty query matches everything). Telegram
+// caps inline answers at 50 results.
+func (f *Factory) renderInlineBotResults(ctx context.Context, bots []models.Bot, query string) telebot.Results {
+	results := telebot.Results{}
+
+	for _, b := range bots {
+		username := b.Username
+		if username == "" {
+			username = f.resolveBotUsername(ctx, b.ID, b.Token)
+		}
+		if !matchesInlineQuery(username, query) {
+			continue
+		}
+
+		running := f.manager.IsRunning(b.Token)
+		status := "🔴 Stopped"
+		if running {
+			status = "🟢 Running"
+		}
+
+		botRef := strconv.FormatInt(b.ID, 10)
+		menu := &telebot.ReplyMarkup{}
+		btnManage := menu.Data("⚙️ Manage", CallbackBotSelect, botRef)
+		var btnToggle telebot.Btn
+		if running {
+			btnToggle = menu.Data("⏹ Stop", CallbackStopBot, botRef)
+		} else {
+			btnToggle = menu.Data("▶️ Start", CallbackStartBot, botRef)
+		}
+		menu.Inline(menu.Row(btnManage, btnToggle))
+
+		result := &telebot.ArticleResult{
+			Title:       "@" + username,
+			Description: status,
+			Text:        fmt.Sprintf("🤖 @%s\n%s", username, status),
+		}
+		result.SetResultID(botRef)
+		result.SetReplyMarkup(menu)
+		results = append(results, result)
+
+		if len(results) == 50 {
+			break
+		}
+	}
+
+	return results
+}
+
+// matchesInlineQuery reports whether a bot username matches the typed
+// inline query text; matching is case-insensitive and ignores a leading @
+func matchesInlineQuery(username, query string) bool {
+	query = strings.TrimSpace(strings.TrimPrefix(query, "@"))
+	if query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(username), strings.ToLower(query))
+}
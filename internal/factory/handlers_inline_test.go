@@ -0,0 +1,27 @@
+package factory
+
+import "testing"
+
+func TestMatchesInlineQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		query    string
+		want     bool
+	}{
+		{"empty query matches", "support_bot", "", true},
+		{"whitespace query matches", "support_bot", "   ", true},
+		{"substring match", "support_bot", "supp", true},
+		{"case insensitive", "SupportBot", "support", true},
+		{"leading @ ignored", "support_bot", "@support", true},
+		{"no match", "support_bot", "orders", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesInlineQuery(tt.username, tt.query); got != tt.want {
+				t.Errorf("matchesInlineQuery(%q, %q) = %v, want %v", tt.username, tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Support Inspection (Admin Only) ====================
+
+// handleInspectCommand opens a read-only view of any bot's admin panel
+// so the factory admin can assist owners with support requests:
+// /inspect <bot_id>
+//
+// Every use is written to the audit log. Message content is never shown
+// here — only settings, stats and list sizes.
+func (f *Factory) handleInspectCommand(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	botID, err := strconv.ParseInt(strings.TrimSpace(c.Message().Payload), 10, 64)
+	if err != nil {
+		return c.Reply("Usage: /inspect <bot_id>")
+	}
+
+	record, err := f.repo.GetBotByID(ctx, botID)
+	if err != nil || record == nil {
+		return c.Reply("❌ Bot not found.")
+	}
+
+	// The settings query coalesces every nullable column
+	targetBot, err := f.repo.GetBotSettingsByID(ctx, botID)
+	if err != nil || targetBot == nil {
+		return c.Reply("❌ Error loading bot settings.")
+	}
+
+	// Inspections are sensitive; leave a trace for the owner
+	if err := f.repo.RecordAudit(ctx, botID, c.Sender().ID, "admin_inspect", "panel"); err != nil {
+		log.Printf("Failed to record inspect audit for bot %d: %v", botID, err)
+	}
+
+	reachable, _ := f.repo.GetReachableUserCount(ctx, botID)
+	blocked, _ := f.repo.GetBlockedUserCount(ctx, botID)
+	keywords, _ := f.repo.GetAutoReplyCount(ctx, botID, "keyword")
+	commands, _ := f.repo.GetAutoReplyCount(ctx, botID, "command")
+	channels, _ := f.repo.GetForcedChannelCount(ctx, botID)
+	scheduled, _ := f.repo.GetScheduledMessagesCount(ctx, botID)
+
+	running := f.manager.IsRunning(record.Token)
+	status := "🔴 Stopped"
+	if running {
+		status = "🟢 Running"
+	}
+
+	msg := fmt.Sprintf(`🔍 <b>Support View (read-only)</b>
+
+<b>Bot:</b> @%s (ID %d)
+<b>Owner:</b> <code>%d</code>
+<b>Status:</b> %s
+
+📊 <b>Stats</b>
+• Reachable users: %d
+• Blocked users: %d
+• Auto-replies: %d keywords, %d commands
+• Forced channels: %d
+• Scheduled messages: %d
+
+⚙️ <b>Settings</b>
+• Forward auto-replies: %s
+• Forced subscription: %s
+• Digest interval: %s
+• Dedupe window: %s
+• SLA alert: %s
+• Survey: %s
+• Confirmation: %s
+
+This view is audited. Message content is not accessible without the owner's consent.`,
+		targetBot.Username, targetBot.ID,
+		targetBot.OwnerChatID,
+		status,
+		reachable, blocked,
+		keywords, commands,
+		channels,
+		scheduled,
+		onOff(targetBot.ForwardAutoReplies),
+		onOff(targetBot.ForcedSubEnabled),
+		minutesOrOff(targetBot.DigestIntervalMinutes),
+		minutesOrOff(targetBot.DedupeWindowMinutes),
+		minutesOrOff(targetBot.SLAMinutes),
+		onOff(targetBot.SurveyEnabled),
+		targetBot.ConfirmationMode,
+	)
+
+	return c.Reply(msg, telebot.ModeHTML)
+}
+
+// onOff renders a boolean setting for the support view
+func onOff(enabled bool) string {
+	if enabled {
+		return "✅ on"
+	}
+	return "❌ off"
+}
+
+// minutesOrOff renders a minutes-based setting where zero means disabled
+func minutesOrOff(minutes int) string {
+	if minutes <= 0 {
+		return "❌ off"
+	}
+	return fmt.Sprintf("%d min", minutes)
+}
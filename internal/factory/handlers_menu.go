@@ -6,8 +6,19 @@ import (
 
 // registerHandlers sets up all handlers for factory bot
 func (f *Factory) registerHandlers() {
+	// Text-only keyboards for owners who opted in
+	f.bot.Use(f.plainUIMiddleware)
+
 	// Only /start command - everything else is buttons
 	f.bot.Handle("/start", f.handleStart)
+	f.bot.Handle("/cancel", f.handleCancelCommand)
+
+	// Admin-only plan management
+	f.bot.Handle("/setplan", f.handleSetPlanCommand)
+	f.bot.Handle("/inspect", f.handleInspectCommand)
+	f.bot.Handle("/announce", f.handleAnnounceCommand)
+	f.bot.Handle("/flags", f.handleFlagsCommand)
+	f.bot.Handle("/flag", f.handleFlagCommand)
 
 	// Button callbacks (static)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackAddBot}, f.handleAddBotBtn)
@@ -15,6 +26,8 @@ func (f *Factory) registerHandlers() {
 	f.bot.Handle(&telebot.Btn{Unique: CallbackStats}, f.handleStatsBtn)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackMainMenu}, f.handleMainMenuBtn)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackCancelDel}, f.handleCancelDeleteBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackAcceptTOS}, f.handleAcceptTOSBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackPlainUI}, f.handleTogglePlainUIBtn)
 
 	// Button callbacks (with data)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackBotSelect}, f.handleBotSelectBtn)
@@ -22,29 +35,48 @@ func (f *Factory) registerHandlers() {
 	f.bot.Handle(&telebot.Btn{Unique: CallbackStopBot}, f.handleStopBotBtn)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackDeleteBot}, f.handleDeleteBotBtn)
 	f.bot.Handle(&telebot.Btn{Unique: CallbackConfirmDel}, f.handleConfirmDelBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackOnboard}, f.handleOnboardBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackReports}, f.handleReportsBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackReportBot}, f.handleReportBotBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackSuspendBot}, f.handleSuspendBotBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackDismissRep}, f.handleDismissReportsBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackColdBots}, f.handleColdBotsBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackAPIKeys}, f.handleAPIKeysBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackNewKey}, f.handleNewKeyBtn)
+	f.bot.Handle(&telebot.Btn{Unique: CallbackRevokeKey}, f.handleRevokeKeyBtn)
 
 	// Handle text messages (for token submission)
 	f.bot.Handle(telebot.OnText, f.handleText)
+
+	// Inline queries (@factorybot <search>) for quick bot lookup
+	f.bot.Handle(telebot.OnQuery, f.handleInlineQuery)
 }
 
 // getMainMenu returns the main menu inline keyboard
 func (f *Factory) getMainMenu(isAdmin bool) *telebot.ReplyMarkup {
 	menu := &telebot.ReplyMarkup{}
 
-	btnAddBot := menu.Data("➕ Add Bot", CallbackAddBot)
-	btnMyBots := menu.Data("🤖 My Bots", CallbackMyBots)
+	btnAddBot := menu.Data(f.branding.AddBotLabel, CallbackAddBot)
+	btnMyBots := menu.Data(f.branding.MyBotsLabel, CallbackMyBots)
+	btnPlainUI := menu.Data("🔤 Text-Only Menus", CallbackPlainUI)
 
 	if isAdmin {
-		btnStats := menu.Data("📊 Stats", CallbackStats)
+		btnStats := menu.Data(f.branding.StatsLabel, CallbackStats)
+		btnReports := menu.Data("🚨 Reports", CallbackReports)
+		btnColdBots := menu.Data("❄️ Cold Bots", CallbackColdBots)
 		menu.Inline(
 			menu.Row(btnAddBot),
 			menu.Row(btnMyBots),
 			menu.Row(btnStats),
+			menu.Row(btnReports),
+			menu.Row(btnColdBots),
+			menu.Row(btnPlainUI),
 		)
 	} else {
 		menu.Inline(
 			menu.Row(btnAddBot),
 			menu.Row(btnMyBots),
+			menu.Row(btnPlainUI),
 		)
 	}
 
@@ -54,7 +86,7 @@ func (f *Factory) getMainMenu(isAdmin bool) *telebot.ReplyMarkup {
 // getBackButton returns a back to menu button
 func (f *Factory) getBackButton() *telebot.ReplyMarkup {
 	menu := &telebot.ReplyMarkup{}
-	btnBack := menu.Data("« Back to Menu", CallbackMainMenu)
+	btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
 	menu.Inline(menu.Row(btnBack))
 	return menu
 }
@@ -63,28 +95,22 @@ func (f *Factory) getBackButton() *telebot.ReplyMarkup {
 func (f *Factory) handleStart(c telebot.Context) error {
 	isAdmin := c.Sender().ID == f.adminID
 
-	welcome := `🤖 <b>Welcome to Bot Factory!</b>
-
-I can help you create and manage your own Telegram contact bots.
-
-<b>How it works:</b>
-1. Create a bot with @BotFather
-2. Add it here using the button below
-3. Users message your bot, you receive the messages here
-4. Reply to forward your response back to them
+	return c.Send(f.branding.WelcomeMessage, f.getMainMenu(isAdmin), telebot.ModeHTML)
+}
 
-Choose an option below:`
+// handleCancelCommand mirrors the child bots' /cancel so owners can use
+// it anywhere. The factory never waits for input - token submission is a
+// single message - so there is nothing to clear, just reassure and show
+// the menu.
+func (f *Factory) handleCancelCommand(c telebot.Context) error {
+	isAdmin := c.Sender().ID == f.adminID
 
-	return c.Send(welcome, f.getMainMenu(isAdmin), telebot.ModeHTML)
+	return c.Send("Nothing to cancel — just send a bot token at any time.", f.getMainMenu(isAdmin), telebot.ModeHTML)
 }
 
 // handleMainMenuBtn returns to main menu
 func (f *Factory) handleMainMenuBtn(c telebot.Context) error {
 	isAdmin := c.Sender().ID == f.adminID
 
-	welcome := `🤖 <b>Bot Factory - Main Menu</b>
-
-Choose an option:`
-
-	return c.Edit(welcome, f.getMainMenu(isAdmin), telebot.ModeHTML)
+	return c.Edit(f.branding.MainMenuMessage, f.getMainMenu(isAdmin), telebot.ModeHTML)
 }
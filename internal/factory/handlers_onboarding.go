@@ -0,0 +1,137 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"gopkg.in/telebot.v3"
+)
+
+// Onboarding wizard step identifiers, carried as callback data
+const (
+	onboardStepCreate  = "create"
+	onboardStepConnect = "connect"
+)
+
+// hasAcceptedTOS reports whether a factory user accepted the terms of
+// service. Acceptance is never revoked, so positive answers are memoized
+// to keep the DB out of the hot path.
+func (f *Factory) hasAcceptedTOS(ctx context.Context, chatID int64) bool {
+	if _, ok := f.tosAccepted.Load(chatID); ok {
+		return true
+	}
+
+	acceptedAt, err := f.repo.GetTOSAcceptance(ctx, chatID)
+	if err != nil {
+		// Treat lookup failures as not accepted; the accept button retries
+		log.Printf("Failed to check ToS acceptance for %d: %v", chatID, err)
+		return false
+	}
+	if acceptedAt == nil {
+		return false
+	}
+
+	f.tosAccepted.Store(chatID, struct{}{})
+	return true
+}
+
+// showTOS presents the terms of service with an accept button. Owners must
+// accept before they can add bots.
+func (f *Factory) showTOS(c telebot.Context, edit bool) error {
+	msg := fmt.Sprintf(`📜 <b>Terms of Service</b>
+
+Before adding a bot to %s, please review and accept our terms:
+
+1. You are responsible for the content relayed through your bots.
+2. Spam, abuse and illegal content are not allowed and lead to removal.
+3. Bot tokens are stored encrypted; message metadata is stored to provide the service.
+4. The service is provided as-is, without uptime guarantees.
+
+By pressing Accept you agree to these terms and our privacy policy.`, f.branding.BrandName)
+
+	menu := &telebot.ReplyMarkup{}
+	btnAccept := menu.Data("✅ I Accept", CallbackAcceptTOS)
+	btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
+	menu.Inline(
+		menu.Row(btnAccept),
+		menu.Row(btnBack),
+	)
+
+	if edit {
+		return c.Edit(msg, menu, telebot.ModeHTML)
+	}
+	return c.Send(msg, menu, telebot.ModeHTML)
+}
+
+// handleAcceptTOSBtn records the acceptance with a timestamp and moves the
+// owner into the onboarding wizard
+func (f *Factory) handleAcceptTOSBtn(c telebot.Context) error {
+	ctx := context.Background()
+	senderID := c.Sender().ID
+
+	if err := f.repo.RecordTOSAcceptance(ctx, senderID); err != nil {
+		log.Printf("Failed to record ToS acceptance for %d: %v", senderID, err)
+		return c.Respond(&telebot.CallbackResponse{Text: "Failed to save. Please try again.", ShowAlert: true})
+	}
+	f.tosAccepted.Store(senderID, struct{}{})
+
+	c.Respond(&telebot.CallbackResponse{Text: "✅ Terms accepted!"})
+	return f.showOnboardStep(c, onboardStepCreate)
+}
+
+// handleOnboardBtn advances the onboarding wizard to the step carried in
+// the callback data
+func (f *Factory) handleOnboardBtn(c telebot.Context) error {
+	return f.showOnboardStep(c, c.Callback().Data)
+}
+
+// showOnboardStep renders one page of the onboarding wizard that walks a
+// new owner from BotFather to a configured bot
+func (f *Factory) showOnboardStep(c telebot.Context, step string) error {
+	menu := &telebot.ReplyMarkup{}
+
+	switch step {
+	case onboardStepCreate:
+		msg := `🧙 <b>Getting Started (1/2)</b>
+
+First, create your bot with Telegram's @BotFather:
+
+1. Open @BotFather
+2. Send /newbot
+3. Pick a display name and a username ending in <i>bot</i>
+4. BotFather replies with your bot token
+
+Keep that token handy for the next step.`
+
+		btnNext := menu.Data("Next »", CallbackOnboard, onboardStepConnect)
+		btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
+		menu.Inline(
+			menu.Row(btnNext),
+			menu.Row(btnBack),
+		)
+		return c.Edit(msg, menu, telebot.ModeHTML)
+
+	case onboardStepConnect:
+		msg := `🧙 <b>Getting Started (2/2)</b>
+
+Now connect the bot and configure the basics:
+
+1. Send me the token from BotFather and I'll take it from there
+2. Open your bot's admin panel (sent to you by your new bot) to set the welcome message, auto-replies and other settings
+3. Share your bot's @username — people who message it reach you here
+
+Ready? Press the button below and send your token.`
+
+		btnAdd := menu.Data(f.branding.AddBotLabel, CallbackAddBot)
+		btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
+		menu.Inline(
+			menu.Row(btnAdd),
+			menu.Row(btnBack),
+		)
+		return c.Edit(msg, menu, telebot.ModeHTML)
+
+	default:
+		return c.Respond(&telebot.CallbackResponse{Text: "Unknown step!", ShowAlert: true})
+	}
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Plan Management (Admin Only) ====================
+
+// handleSetPlanCommand assigns an owner's plan tier:
+// /setplan <owner_chat_id> <free|paid>
+func (f *Factory) handleSetPlanCommand(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return nil
+	}
+
+	parts := strings.Fields(c.Message().Payload)
+	if len(parts) != 2 {
+		return c.Reply("Usage: /setplan <owner_chat_id> <free|paid>")
+	}
+
+	ownerChatID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return c.Reply("❌ Invalid owner chat ID.")
+	}
+
+	plan := strings.ToLower(parts[1])
+	if plan != models.PlanFree && plan != models.PlanPaid {
+		return c.Reply("❌ Unknown plan. Use free or paid.")
+	}
+
+	if err := f.repo.SetOwnerPlan(context.Background(), ownerChatID, plan); err != nil {
+		log.Printf("Failed to set plan for owner %d: %v", ownerChatID, err)
+		return c.Reply("❌ Failed to update plan.")
+	}
+
+	return c.Reply(fmt.Sprintf("✅ Owner %d is now on the %s plan.", ownerChatID, plan))
+}
@@ -0,0 +1,226 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/models"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Abuse Report Handlers (Admin Only) ====================
+
+// handleReportsBtn lists bots with open abuse reports, most reported first
+func (f *Factory) handleReportsBtn(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return c.Respond(&telebot.CallbackResponse{Text: "Admin only!", ShowAlert: true})
+	}
+
+	ctx := context.Background()
+	summaries, err := f.repo.GetAbuseReportSummaries(ctx, 10)
+	if err != nil {
+		log.Printf("Failed to get abuse report summaries: %v", err)
+		return c.Edit("❌ Failed to load reports.", f.getBackButton())
+	}
+
+	if len(summaries) == 0 {
+		msg := `🚨 <b>Abuse Reports</b>
+
+No open reports. All clear!`
+		return c.Edit(msg, f.getBackButton(), telebot.ModeHTML)
+	}
+
+	msg := fmt.Sprintf("🚨 <b>Abuse Reports (%d bots)</b>\n\n", len(summaries))
+	msg += "Select a bot to review its reports:\n"
+
+	menu := &telebot.ReplyMarkup{}
+	var rows []telebot.Row
+
+	for _, summary := range summaries {
+		label := fmt.Sprintf("@%s — %d open", summary.BotUsername, summary.OpenCount)
+		// Flag owners with reports against more than one of their bots
+		if summary.OwnerReportCount > summary.OpenCount {
+			label += fmt.Sprintf(" (%d total by owner)", summary.OwnerReportCount)
+		}
+		btn := menu.Data(label, CallbackReportBot, strconv.FormatInt(summary.BotID, 10))
+		rows = append(rows, menu.Row(btn))
+	}
+
+	btnBack := menu.Data(f.branding.BackLabel, CallbackMainMenu)
+	rows = append(rows, menu.Row(btnBack))
+	menu.Inline(rows...)
+
+	return c.Edit(msg, menu, telebot.ModeHTML)
+}
+
+// handleReportBotBtn shows the open reports against one bot with the
+// moderation actions
+func (f *Factory) handleReportBotBtn(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return c.Respond(&telebot.CallbackResponse{Text: "Admin only!", ShowAlert: true})
+	}
+
+	botID, err := strconv.ParseInt(c.Callback().Data, 10, 64)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid bot!", ShowAlert: true})
+	}
+
+	ctx := context.Background()
+	targetBot, err := f.repo.GetBotByID(ctx, botID)
+	if err != nil || targetBot == nil {
+		return c.Edit("❌ Bot not found.", f.getBackButton())
+	}
+
+	reports, err := f.repo.GetOpenAbuseReports(ctx, botID, 5)
+	if err != nil {
+		log.Printf("Failed to get open abuse reports for bot %d: %v", botID, err)
+		return c.Edit("❌ Failed to load reports.", f.getBackButton())
+	}
+
+	msg := fmt.Sprintf(`🚨 <b>Reports for @%s</b>
+
+<b>Owner:</b> <code>%d</code>
+<b>Status:</b> %s
+
+<b>Latest reports:</b>`, targetBot.Username, targetBot.OwnerChatID, suspensionStatus(targetBot.IsSuspended))
+
+	for _, report := range reports {
+		reason := report.Reason
+		if reason == "" {
+			reason = "<i>no reason given</i>"
+		}
+		msg += fmt.Sprintf("\n• %s — %s", report.CreatedAt.Format("2006-01-02"), reason)
+	}
+
+	menu := &telebot.ReplyMarkup{}
+	botData := strconv.FormatInt(botID, 10)
+	btnSuspend := menu.Data("⛔ Suspend Bot", CallbackSuspendBot, botData)
+	btnDismiss := menu.Data("✅ Dismiss Reports", CallbackDismissRep, botData)
+	btnBack := menu.Data("« Back to Reports", CallbackReports)
+
+	menu.Inline(
+		menu.Row(btnSuspend),
+		menu.Row(btnDismiss),
+		menu.Row(btnBack),
+	)
+
+	return c.Edit(msg, menu, telebot.ModeHTML)
+}
+
+// handleSuspendBotBtn suspends a reported bot: the webhook is removed, the
+// owner is notified via the factory bot and the reports are resolved
+func (f *Factory) handleSuspendBotBtn(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return c.Respond(&telebot.CallbackResponse{Text: "Admin only!", ShowAlert: true})
+	}
+
+	botID, err := strconv.ParseInt(c.Callback().Data, 10, 64)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid bot!", ShowAlert: true})
+	}
+
+	ctx := context.Background()
+	targetBot, err := f.repo.GetBotByID(ctx, botID)
+	if err != nil || targetBot == nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Bot not found!", ShowAlert: true})
+	}
+
+	// Remove the webhook and deactivate so the bot stays down across restarts
+	f.manager.StopBot(targetBot.Token)
+	if err := f.repo.DeactivateBot(ctx, targetBot.Token); err != nil {
+		log.Printf("Failed to deactivate suspended bot %d: %v", botID, err)
+	}
+	if err := f.repo.SetBotSuspended(ctx, botID, true); err != nil {
+		log.Printf("Failed to mark bot %d suspended: %v", botID, err)
+		return c.Respond(&telebot.CallbackResponse{Text: "Failed to suspend!", ShowAlert: true})
+	}
+
+	if _, err := f.repo.CloseAbuseReports(ctx, botID, models.ReportStatusResolved); err != nil {
+		log.Printf("Failed to resolve reports for bot %d: %v", botID, err)
+	}
+
+	// Notify the owner through the factory bot
+	ownerMsg := fmt.Sprintf(`⛔ <b>Bot Suspended</b>
+
+Your bot @%s has been suspended by the administrator following abuse reports.
+
+It has been stopped and cannot be restarted. Contact the administrator if you believe this is a mistake.`, targetBot.Username)
+	if _, err := f.bot.Send(&telebot.Chat{ID: targetBot.OwnerChatID}, ownerMsg, telebot.ModeHTML); err != nil {
+		log.Printf("Failed to notify owner %d about suspension: %v", targetBot.OwnerChatID, err)
+	}
+
+	c.Respond(&telebot.CallbackResponse{Text: "⛔ Bot suspended!"})
+	return f.handleReportsBtn(c)
+}
+
+// handleDismissReportsBtn closes the open reports against a bot without
+// taking action
+func (f *Factory) handleDismissReportsBtn(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return c.Respond(&telebot.CallbackResponse{Text: "Admin only!", ShowAlert: true})
+	}
+
+	botID, err := strconv.ParseInt(c.Callback().Data, 10, 64)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Invalid bot!", ShowAlert: true})
+	}
+
+	ctx := context.Background()
+	dismissed, err := f.repo.CloseAbuseReports(ctx, botID, models.ReportStatusDismissed)
+	if err != nil {
+		log.Printf("Failed to dismiss reports for bot %d: %v", botID, err)
+		return c.Respond(&telebot.CallbackResponse{Text: "Failed to dismiss!", ShowAlert: true})
+	}
+
+	c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("✅ %d report(s) dismissed", dismissed)})
+	return f.handleReportsBtn(c)
+}
+
+// suspensionStatus renders a bot's suspension flag for the review screen
+func suspensionStatus(suspended bool) string {
+	if suspended {
+		return "⛔ Suspended"
+	}
+	return "🟢 Active"
+}
+
+// ==================== Cold Bot Report (Admin Only) ====================
+
+// handleColdBotsBtn lists the bots demoted to the cold tier, least
+// recently active first
+func (f *Factory) handleColdBotsBtn(c telebot.Context) error {
+	if c.Sender().ID != f.adminID {
+		return c.Respond(&telebot.CallbackResponse{Text: "Admin only!", ShowAlert: true})
+	}
+
+	coldBots := f.manager.ColdBots()
+	if len(coldBots) == 0 {
+		msg := fmt.Sprintf(`❄️ <b>Cold Bots</b>
+
+No cold bots. Every running bot received an update within the last %d days.`,
+			int(bot.ColdAfter.Hours()/24))
+		return c.Edit(msg, f.getBackButton(), telebot.ModeHTML)
+	}
+
+	ctx := context.Background()
+	msg := fmt.Sprintf(`❄️ <b>Cold Bots (%d)</b>
+
+Demoted after %d days without updates; each one is re-promoted automatically on its next incoming update.
+`, len(coldBots), int(bot.ColdAfter.Hours()/24))
+
+	for _, status := range coldBots {
+		name := fmt.Sprintf("bot %d", status.BotID)
+		if coldBot, err := f.repo.GetBotByID(ctx, status.BotID); err == nil && coldBot != nil && coldBot.Username != "" {
+			name = "@" + coldBot.Username
+		}
+		msg += fmt.Sprintf("\n• %s — last active %s, demoted %s",
+			name,
+			status.LastActive.Format("2006-01-02"),
+			status.DemotedAt.Format("2006-01-02"))
+	}
+
+	return c.Edit(msg, f.getBackButton(), telebot.ModeHTML)
+}
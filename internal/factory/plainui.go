@@ -0,0 +1,81 @@
+package factory
+
+import (
+	"context"
+	"log"
+
+	"github.com/Amr-9/botforge/internal/bot"
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Plain Text UI ====================
+
+// plainUIMiddleware renders the factory's keyboards with text-only
+// labels for owners who opted in. The toggle button itself is exempt so
+// its handler can re-render with the freshly flipped preference.
+func (f *Factory) plainUIMiddleware(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		sender := c.Sender()
+		if sender == nil {
+			return next(c)
+		}
+		if cb := c.Callback(); cb != nil && cb.Unique == CallbackPlainUI {
+			return next(c)
+		}
+		if f.plainUIEnabled(context.Background(), sender.ID) {
+			c = bot.PlainUI(c)
+		}
+		return next(c)
+	}
+}
+
+// plainUIEnabled reports whether an owner opted into text-only menus -
+// cache first, DB backfill on miss
+func (f *Factory) plainUIEnabled(ctx context.Context, ownerID int64) bool {
+	enabled, cacheHit, err := f.cache.GetPlainUI(ctx, ownerID)
+	if err != nil {
+		log.Printf("Cache error: %v", err)
+	}
+	if cacheHit {
+		return enabled
+	}
+
+	// Cache miss - load from DB and cache it
+	enabled, err = f.repo.GetOwnerPlainUI(ctx, ownerID)
+	if err != nil {
+		log.Printf("Failed to get plain UI preference: %v", err)
+		return false
+	}
+	f.cache.SetPlainUI(ctx, ownerID, enabled)
+	return enabled
+}
+
+// handleTogglePlainUIBtn flips the owner's text-only menu preference.
+// The preference covers the factory and every bot the owner runs.
+func (f *Factory) handleTogglePlainUIBtn(c telebot.Context) error {
+	ctx := context.Background()
+	ownerID := c.Sender().ID
+
+	enabled, err := f.repo.GetOwnerPlainUI(ctx, ownerID)
+	if err != nil {
+		log.Printf("Failed to get plain UI preference: %v", err)
+		return c.Respond(&telebot.CallbackResponse{Text: "❌ Error, try again"})
+	}
+
+	enabled = !enabled
+	if err := f.repo.SetOwnerPlainUI(ctx, ownerID, enabled); err != nil {
+		log.Printf("Failed to set plain UI preference: %v", err)
+		return c.Respond(&telebot.CallbackResponse{Text: "❌ Error, try again"})
+	}
+	f.cache.SetPlainUI(ctx, ownerID, enabled)
+
+	if enabled {
+		c.Respond(&telebot.CallbackResponse{Text: "Text-only menus enabled"})
+		c = bot.PlainUI(c)
+	} else {
+		c.Respond(&telebot.CallbackResponse{Text: "Text-only menus disabled"})
+	}
+
+	isAdmin := ownerID == f.adminID
+	return c.Edit(f.branding.MainMenuMessage, f.getMainMenu(isAdmin), telebot.ModeHTML)
+}
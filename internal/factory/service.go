@@ -1,28 +1,51 @@
 package factory
 
 import (
+	"context"
 	"log"
+	"sync"
 
 	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/cache"
 	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/features"
 	"gopkg.in/telebot.v3"
 )
 
 // Factory represents the main factory bot
 type Factory struct {
-	bot     *telebot.Bot
-	repo    *database.Repository
-	manager *bot.Manager
-	adminID int64
+	bot             *telebot.Bot
+	repo            *database.Repository
+	cache           cache.Cache
+	manager         *bot.Manager
+	adminID         int64
+	flags           *features.Flags    // runtime feature-flag resolver, shared cache-backed
+	factoryID       int64              // Telegram ID of this factory bot, recorded on bots it creates
+	branding        Branding           // user-facing strings, stock wording unless rebranded
+	usernameFetches sync.Map           // tokens with an in-flight username backfill
+	tosAccepted     sync.Map           // chat IDs with a confirmed ToS acceptance
+	abandonCancel   context.CancelFunc // stops the abandonment policy job, nil if not running
 }
 
-// NewFactory creates a new factory bot logic instance
-func NewFactory(botInstance *telebot.Bot, repo *database.Repository, manager *bot.Manager, adminID int64) (*Factory, error) {
+// NewFactory creates a new factory bot logic instance with the stock branding
+func NewFactory(botInstance *telebot.Bot, repo *database.Repository, redisCache cache.Cache, manager *bot.Manager, adminID int64) (*Factory, error) {
+	return NewFactoryWithBranding(botInstance, repo, redisCache, manager, adminID, Branding{})
+}
+
+// NewFactoryWithBranding creates a factory bot logic instance with custom
+// branding strings. Empty branding fields keep the stock wording.
+func NewFactoryWithBranding(botInstance *telebot.Bot, repo *database.Repository, redisCache cache.Cache, manager *bot.Manager, adminID int64, branding Branding) (*Factory, error) {
 	factory := &Factory{
-		bot:     botInstance,
-		repo:    repo,
-		manager: manager,
-		adminID: adminID,
+		bot:      botInstance,
+		repo:     repo,
+		cache:    redisCache,
+		manager:  manager,
+		adminID:  adminID,
+		flags:    features.New(redisCache),
+		branding: branding.withDefaults(),
+	}
+	if botInstance != nil && botInstance.Me != nil {
+		factory.factoryID = botInstance.Me.ID
 	}
 
 	factory.registerHandlers()
@@ -43,4 +66,8 @@ func (f *Factory) Start() {
 // Stop stops the factory bot
 func (f *Factory) Stop() {
 	log.Println("Stopping Factory Bot logic...")
+	if f.abandonCancel != nil {
+		f.abandonCancel()
+		f.abandonCancel = nil
+	}
 }
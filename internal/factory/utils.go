@@ -14,6 +14,17 @@ const (
 	CallbackDeleteBot  = "del_bot"
 	CallbackConfirmDel = "conf_del"
 	CallbackCancelDel  = "cancel_del"
+	CallbackAcceptTOS  = "accept_tos"
+	CallbackOnboard    = "onboard"
+	CallbackReports    = "reports"
+	CallbackReportBot  = "report_bot"
+	CallbackSuspendBot = "suspend_bot"
+	CallbackDismissRep = "dismiss_rep"
+	CallbackColdBots   = "cold_bots"
+	CallbackAPIKeys    = "api_keys"
+	CallbackNewKey     = "new_key"
+	CallbackRevokeKey  = "revoke_key"
+	CallbackPlainUI    = "plain_ui"
 )
 
 // isValidTokenFormat checks if a string looks like a bot token
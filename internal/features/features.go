@@ -0,0 +1,82 @@
+// Package features is a small feature-flag framework for rolling out
+// risky subsystems gradually. Every flag has a compiled-in default;
+// the factory admin can override it at runtime for the whole deployment
+// or for a single bot, with overrides stored in Redis so all instances
+// see them immediately.
+package features
+
+import (
+	"context"
+	"log"
+
+	"github.com/Amr-9/botforge/internal/cache"
+)
+
+// Flag names. Handlers check them by name; the admin toggles them by
+// name. Add new flags here and to Known below.
+const (
+	AIReplies    = "enable_ai_replies"
+	WebDashboard = "enable_web_dashboard"
+)
+
+// Flag describes one known feature flag
+type Flag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// Known lists every flag the deployment understands, in display order.
+// Overrides for unknown names are rejected by the admin commands.
+var Known = []Flag{
+	{AIReplies, "AI-generated reply suggestions", false},
+	{WebDashboard, "Web dashboard API", true},
+}
+
+// KnownFlag returns the definition for a flag name, or false if the
+// name is not a registered flag
+func KnownFlag(name string) (Flag, bool) {
+	for _, f := range Known {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Flag{}, false
+}
+
+// Flags resolves feature flags against the shared cache
+type Flags struct {
+	cache cache.Cache
+}
+
+// New creates a flag resolver backed by the given cache
+func New(c cache.Cache) *Flags {
+	return &Flags{cache: c}
+}
+
+// Enabled reports whether a flag is on for a bot. A per-bot override
+// wins over the deployment-wide one, which wins over the compiled-in
+// default; cache errors are logged and fall back to the default, so an
+// unavailable Redis never flips a flag.
+func (f *Flags) Enabled(ctx context.Context, name string, botID int64) bool {
+	enabled, set, err := f.cache.GetBotFeatureFlag(ctx, botID, name)
+	if err != nil {
+		log.Printf("Failed to get bot feature flag %s: %v", name, err)
+	} else if set {
+		return enabled
+	}
+	return f.EnabledGlobally(ctx, name)
+}
+
+// EnabledGlobally reports whether a flag is on deployment-wide,
+// ignoring per-bot overrides
+func (f *Flags) EnabledGlobally(ctx context.Context, name string) bool {
+	enabled, set, err := f.cache.GetFeatureFlag(ctx, name)
+	if err != nil {
+		log.Printf("Failed to get feature flag %s: %v", name, err)
+	} else if set {
+		return enabled
+	}
+	flag, ok := KnownFlag(name)
+	return ok && flag.Default
+}
@@ -0,0 +1,78 @@
+package features_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/features"
+)
+
+func setupFlags(t *testing.T) (*features.Flags, cache.Cache) {
+	t.Helper()
+
+	mem, err := cache.NewMemory(time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	t.Cleanup(func() { mem.Close() })
+
+	return features.New(mem), mem
+}
+
+func TestEnabled_CompiledInDefault(t *testing.T) {
+	flags, _ := setupFlags(t)
+	ctx := context.Background()
+
+	if flags.Enabled(ctx, features.AIReplies, 1) {
+		t.Error("Expected enable_ai_replies off by default")
+	}
+	if !flags.Enabled(ctx, features.WebDashboard, 1) {
+		t.Error("Expected enable_web_dashboard on by default")
+	}
+	if flags.Enabled(ctx, "no_such_flag", 1) {
+		t.Error("Expected unknown flags to resolve to off")
+	}
+}
+
+func TestEnabled_GlobalOverride(t *testing.T) {
+	flags, c := setupFlags(t)
+	ctx := context.Background()
+
+	if err := c.SetFeatureFlag(ctx, features.AIReplies, true); err != nil {
+		t.Fatalf("SetFeatureFlag failed: %v", err)
+	}
+	if !flags.Enabled(ctx, features.AIReplies, 1) {
+		t.Error("Expected global override to enable the flag")
+	}
+
+	if err := c.ClearFeatureFlag(ctx, features.AIReplies); err != nil {
+		t.Fatalf("ClearFeatureFlag failed: %v", err)
+	}
+	if flags.Enabled(ctx, features.AIReplies, 1) {
+		t.Error("Expected clearing the override to restore the default")
+	}
+}
+
+func TestEnabled_BotOverrideWinsOverGlobal(t *testing.T) {
+	flags, c := setupFlags(t)
+	ctx := context.Background()
+
+	if err := c.SetFeatureFlag(ctx, features.AIReplies, true); err != nil {
+		t.Fatalf("SetFeatureFlag failed: %v", err)
+	}
+	if err := c.SetBotFeatureFlag(ctx, 42, features.AIReplies, false); err != nil {
+		t.Fatalf("SetBotFeatureFlag failed: %v", err)
+	}
+
+	if flags.Enabled(ctx, features.AIReplies, 42) {
+		t.Error("Expected per-bot override to win over the global one")
+	}
+	if !flags.Enabled(ctx, features.AIReplies, 43) {
+		t.Error("Expected other bots to follow the global override")
+	}
+	if !flags.EnabledGlobally(ctx, features.AIReplies) {
+		t.Error("Expected EnabledGlobally to ignore per-bot overrides")
+	}
+}
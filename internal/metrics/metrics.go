@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Package metrics records per-handler latencies and exposes them in
+// Prometheus text format on /metrics, so slow menus can be found in
+// production. Each handler keeps a fixed-size window of recent samples,
+// which bounds memory while still giving useful p50/p95 estimates.
+
+// sampleWindow is how many recent samples are kept per handler for the
+// quantile estimates
+const sampleWindow = 512
+
+// handlerStats accumulates the latency observations of one handler
+type handlerStats struct {
+	count   uint64
+	sum     float64   // seconds
+	samples []float64 // ring buffer of recent samples, seconds
+	next    int       // ring buffer write position
+}
+
+// Registry collects latency observations keyed by handler name, plus
+// event counts fed from the event bus
+type Registry struct {
+	mu       sync.Mutex
+	handlers map[string]*handlerStats
+	events   map[string]uint64
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: make(map[string]*handlerStats),
+		events:   make(map[string]uint64),
+	}
+}
+
+// Default is the process-wide registry used by the package-level helpers
+var Default = NewRegistry()
+
+// ObserveHandler records one handler invocation in the default registry
+func ObserveHandler(handler string, d time.Duration) {
+	Default.Observe(handler, d)
+}
+
+// Handler returns the HTTP handler serving the default registry
+func Handler() http.Handler {
+	return Default
+}
+
+// CountEvent records one bus event in the default registry
+func CountEvent(name string) {
+	Default.CountEvent(name)
+}
+
+// CountEvent increments the counter for one bus event
+func (r *Registry) CountEvent(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[name]++
+}
+
+// Observe records one invocation of the named handler
+func (r *Registry) Observe(handler string, d time.Duration) {
+	seconds := d.Seconds()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.handlers[handler]
+	if !ok {
+		stats = &handlerStats{}
+		r.handlers[handler] = stats
+	}
+
+	stats.count++
+	stats.sum += seconds
+	if len(stats.samples) < sampleWindow {
+		stats.samples = append(stats.samples, seconds)
+	} else {
+		stats.samples[stats.next] = seconds
+	}
+	stats.next = (stats.next + 1) % sampleWindow
+}
+
+// ServeHTTP exports all handler latencies as a Prometheus summary metric
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.write(w)
+}
+
+// write renders the registry in Prometheus text exposition format, with
+// handlers sorted for deterministic output
+func (r *Registry) write(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP botforge_handler_latency_seconds Handler latency by callback unique or endpoint.")
+	fmt.Fprintln(w, "# TYPE botforge_handler_latency_seconds summary")
+
+	names := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		stats := r.handlers[name]
+		fmt.Fprintf(w, "botforge_handler_latency_seconds{handler=%q,quantile=\"0.5\"} %g\n", name, quantile(stats.samples, 0.5))
+		fmt.Fprintf(w, "botforge_handler_latency_seconds{handler=%q,quantile=\"0.95\"} %g\n", name, quantile(stats.samples, 0.95))
+		fmt.Fprintf(w, "botforge_handler_latency_seconds_sum{handler=%q} %g\n", name, stats.sum)
+		fmt.Fprintf(w, "botforge_handler_latency_seconds_count{handler=%q} %d\n", name, stats.count)
+	}
+
+	if len(r.events) > 0 {
+		fmt.Fprintln(w, "# HELP botforge_events_total Internal events by name.")
+		fmt.Fprintln(w, "# TYPE botforge_events_total counter")
+
+		eventNames := make([]string, 0, len(r.events))
+		for name := range r.events {
+			eventNames = append(eventNames, name)
+		}
+		sort.Strings(eventNames)
+
+		for _, name := range eventNames {
+			fmt.Fprintf(w, "botforge_events_total{event=%q} %d\n", name, r.events[name])
+		}
+	}
+}
+
+// quantile estimates the q-th quantile of the sample window
+func quantile(samples []float64, q float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
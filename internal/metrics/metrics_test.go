@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObserveAndExport(t *testing.T) {
+	r := NewRegistry()
+	for i := 1; i <= 100; i++ {
+		r.Observe("child_stats", time.Duration(i)*time.Millisecond)
+	}
+	r.Observe("schedule_menu", 5*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`# TYPE botforge_handler_latency_seconds summary`,
+		`botforge_handler_latency_seconds{handler="child_stats",quantile="0.5"} 0.05`,
+		`botforge_handler_latency_seconds{handler="child_stats",quantile="0.95"} 0.095`,
+		`botforge_handler_latency_seconds_count{handler="child_stats"} 100`,
+		`botforge_handler_latency_seconds_count{handler="schedule_menu"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("output missing %q\ngot:\n%s", want, body)
+		}
+	}
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+}
+
+func TestQuantileWindowRollsOver(t *testing.T) {
+	r := NewRegistry()
+	// Fill the window with slow samples, then overwrite it with fast ones;
+	// the quantiles should reflect only the recent window
+	for i := 0; i < sampleWindow; i++ {
+		r.Observe("h", time.Second)
+	}
+	for i := 0; i < sampleWindow; i++ {
+		r.Observe("h", time.Millisecond)
+	}
+
+	r.mu.Lock()
+	stats := r.handlers["h"]
+	p95 := quantile(stats.samples, 0.95)
+	count := stats.count
+	r.mu.Unlock()
+
+	if p95 > 0.01 {
+		t.Errorf("p95 = %g, want old samples evicted from window", p95)
+	}
+	if count != 2*sampleWindow {
+		t.Errorf("count = %d, want %d", count, 2*sampleWindow)
+	}
+}
+
+func TestQuantileEmpty(t *testing.T) {
+	if got := quantile(nil, 0.5); got != 0 {
+		t.Errorf("quantile(nil) = %g, want 0", got)
+	}
+}
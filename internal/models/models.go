@@ -1,20 +1,53 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Bot represents a child bot registered by a user
 type Bot struct {
-	ID                   int64     `db:"id"`
-	Token                string    `db:"token"`
-	Username             string    `db:"username"`
-	OwnerChatID          int64     `db:"owner_chat_id"`
-	IsActive             bool      `db:"is_active"`
-	StartMessage         string    `db:"start_message"`
-	ForwardAutoReplies   bool      `db:"forward_auto_replies"`   // Forward auto-replied messages to admin
-	ForcedSubEnabled     bool      `db:"forced_sub_enabled"`     // Enable forced channel subscription
-	ForcedSubMessage     string    `db:"forced_sub_message"`     // Custom message for non-subscribers
-	ShowSentConfirmation bool      `db:"show_sent_confirmation"` // Show "Message sent successfully" to admin
-	CreatedAt            time.Time `db:"created_at"`
+	ID                    int64     `db:"id"`
+	Token                 string    `db:"token"`
+	Username              string    `db:"username"`
+	OwnerChatID           int64     `db:"owner_chat_id"`
+	FactoryID             int64     `db:"factory_id"` // Telegram ID of the factory bot that registered this bot (0 = unknown/legacy)
+	IsActive              bool      `db:"is_active"`
+	IsSuspended           bool      `db:"is_suspended"` // Suspended by the factory admin after abuse reports
+	StartMessage          string    `db:"start_message"`
+	ForwardAutoReplies    bool      `db:"forward_auto_replies"`    // Forward auto-replied messages to admin
+	ForcedSubEnabled      bool      `db:"forced_sub_enabled"`      // Enable forced channel subscription
+	ForcedSubMessage      string    `db:"forced_sub_message"`      // Custom message for non-subscribers
+	ForcedSubGraceCount   int       `db:"forced_sub_grace_count"`  // Free messages before forced sub kicks in (0 = enforce immediately)
+	ShowSentConfirmation  bool      `db:"show_sent_confirmation"`  // Show "Message sent successfully" to admin
+	ConfirmationEmoji     string    `db:"confirmation_emoji"`      // Emoji used for the sent-confirmation reaction
+	ConfirmationMode      string    `db:"confirmation_mode"`       // "react" (emoji reaction) or "text" (reply message)
+	DigestIntervalMinutes int       `db:"digest_interval_minutes"` // Batch forwarded messages into digests (0 = off)
+	DigestUrgentKeywords  string    `db:"digest_urgent_keywords"`  // Comma-separated keywords that bypass digest mode
+	RelayChannelID        int64     `db:"relay_channel_id"`        // Channel whose posts are relayed to all users (0 = off)
+	ArchiveChatID         int64     `db:"archive_chat_id"`         // Chat receiving a copy of all traffic (0 = off)
+	BlockedMediaTypes     string    `db:"blocked_media_types"`     // Comma-separated media types users may not send ("" = none)
+	MaxFileSizeMB         int       `db:"max_file_size_mb"`        // Max accepted file size in MB (0 = unlimited)
+	FilterProfanityAction string    `db:"filter_profanity_action"` // Profanity filter action: "off", "drop", "warn" or "flag"
+	FilterLinksAction     string    `db:"filter_links_action"`     // Link/invite filter action: "off", "drop", "warn" or "flag"
+	DedupeWindowMinutes   int       `db:"dedupe_window_minutes"`   // Suppress identical consecutive messages within this window (0 = off)
+	SLAMinutes            int       `db:"sla_minutes"`             // Alert when a message is unanswered for this long (0 = off)
+	SurveyEnabled         bool      `db:"survey_enabled"`          // Ask users for a 1-5 star rating after the owner replies
+	MenuButtonEnabled     bool      `db:"menu_button_enabled"`     // Show the Telegram commands menu button to users
+	HeaderPolicy          string    `db:"header_policy"`           // How often the user info header is re-sent: "never", "gap" or "daily"
+	RichHeaderEnabled     bool      `db:"rich_header_enabled"`     // Include profile photo, counters and permalink in the header
+	PrivacyNotice         string    `db:"privacy_notice"`          // Appended to the welcome message and served by /privacy ("" = off)
+	ContentLoggingEnabled bool      `db:"content_logging_enabled"` // Store incoming message text, encrypted with a per-bot key (opt-in)
+	ContentRetentionDays  int       `db:"content_retention_days"`  // Days stored message content is kept before the retention job deletes it
+	SpamThreshold         int       `db:"spam_threshold"`          // Divert messages scoring at least this spam percent to review (0 = off)
+	MessageTTLHours       int       `db:"message_ttl_hours"`       // Reply-routing cache TTL override in hours (0 = global default)
+	Locale                string    `db:"locale"`                  // Owner display language for dates ("" = English)
+	Timezone              string    `db:"timezone"`                // Owner display timezone ("" = server time)
+	StatsDigest           string    `db:"stats_digest"`            // "", "daily" or "weekly" stats digest cadence
+	WhitelistEnabled      bool      `db:"whitelist_enabled"`       // Only pre-approved user IDs may interact with the bot
+	WhitelistMessage      string    `db:"whitelist_message"`       // Rejection message shown to non-whitelisted users ("" = stock text)
+	ReactionActions       string    `db:"reaction_actions"`        // Comma-separated emoji=action pairs for owner reaction workflows ("" = off)
+	CreatedAt             time.Time `db:"created_at"`
 }
 
 // MessageLog stores the mapping between admin message and user chat
@@ -26,6 +59,34 @@ type MessageLog struct {
 	CreatedAt  time.Time `db:"created_at"`
 }
 
+// MessageContent is one stored user message for a bot with content
+// logging enabled. Content is AES-GCM encrypted with a key derived per
+// bot; the repository decrypts it on read.
+type MessageContent struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	AdminMsgID int       `db:"admin_msg_id"`
+	Content    string    `db:"content"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// SpamFeedback is an owner's verdict on a spam-scored message, kept as
+// training data for the classifier
+type SpamFeedback struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	Verdict    string    `db:"verdict"` // "spam" or "ham"
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Spam feedback verdict constants
+const (
+	SpamVerdictSpam = "spam"
+	SpamVerdictHam  = "ham"
+)
+
 // BannedUser represents a banned user for a specific bot
 type BannedUser struct {
 	ID         int64     `db:"id"`
@@ -47,40 +108,321 @@ type ForcedChannel struct {
 	CreatedAt       time.Time `db:"created_at"`
 }
 
+// ForcedSubExemption marks a user who bypasses forced subscription
+// checks (VIPs, testers)
+type ForcedSubExemption struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ChannelMemberCount is one sample of a forced channel's member count,
+// recorded periodically so owners can track subscriber growth
+type ChannelMemberCount struct {
+	ID          int64     `db:"id"`
+	BotID       int64     `db:"bot_id"`
+	ChannelID   int64     `db:"channel_id"`
+	MemberCount int       `db:"member_count"`
+	RecordedAt  time.Time `db:"recorded_at"`
+}
+
 // AutoReply represents an auto-reply rule or custom command for a bot
 type AutoReply struct {
+	ID           int64     `db:"id"`
+	BotID        int64     `db:"bot_id"`
+	TriggerWord  string    `db:"trigger_word"` // Keyword or command name (without /)
+	Response     string    `db:"response"`     // Response text (supports Markdown) - used for text type
+	MessageType  string    `db:"message_type"` // "text", "photo", "video", "audio", "voice", "document", "animation", "video_note", "sticker"
+	FileID       string    `db:"file_id"`      // Telegram FileID for media
+	Caption      string    `db:"caption"`      // Caption for media (supports Markdown)
+	TriggerType  string    `db:"trigger_type"` // "keyword" or "command"
+	MatchType    string    `db:"match_type"`   // "exact" or "contains" (for keywords)
+	IsActive     bool      `db:"is_active"`
+	IsQuickReply bool      `db:"is_quick_reply"` // Shown as a persistent keyboard button for end users
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// ScheduledMessage represents a scheduled broadcast message
+type ScheduledMessage struct {
+	ID           int64  `db:"id"`
+	BotID        int64  `db:"bot_id"`
+	OwnerChatID  int64  `db:"owner_chat_id"`
+	MessageType  string `db:"message_type"`
+	MessageText  string `db:"message_text"`
+	FileID       string `db:"file_id"`
+	Caption      string `db:"caption"`
+	ScheduleType string `db:"schedule_type"`
+	// TargetChannelID posts to this channel instead of broadcasting to
+	// users (0 = broadcast to all users)
+	TargetChannelID int64      `db:"target_channel_id"`
+	ScheduledTime   time.Time  `db:"scheduled_time"`
+	TimeOfDay       string     `db:"time_of_day"`
+	DayOfWeek       *int       `db:"day_of_week"`
+	Status          string     `db:"status"`
+	LastSentAt      *time.Time `db:"last_sent_at"`
+	NextRunAt       *time.Time `db:"next_run_at"`
+	FailureReason   *string    `db:"failure_reason"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// Reply maps an owner's reply message to the copy delivered to the user,
+// so later edits can be propagated
+type Reply struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	AdminMsgID int       `db:"admin_msg_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	UserMsgID  int       `db:"user_msg_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// BotUser tracks a user's relationship with a bot, including whether the
+// bot can still reach them (false once they block it)
+type BotUser struct {
+	ID          int64      `db:"id"`
+	BotID       int64      `db:"bot_id"`
+	UserChatID  int64      `db:"user_chat_id"`
+	IsReachable bool       `db:"is_reachable"`
+	BlockedAt   *time.Time `db:"blocked_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+// RoutingRule forwards user messages containing a keyword to a specific
+// destination chat instead of the owner
+type RoutingRule struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	Keyword    string    `db:"keyword"`
+	DestChatID int64     `db:"dest_chat_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Broadcast is one completed broadcast, recorded for the history list.
+// MessageID points at the original message in the composer's chat, so
+// resending copies the same content Telegram still holds.
+type Broadcast struct {
+	ID             int64     `db:"id"`
+	BotID          int64     `db:"bot_id"`
+	ComposerChatID int64     `db:"composer_chat_id"`
+	MessageID      int       `db:"message_id"`
+	Audience       int       `db:"audience"`
+	SuccessCount   int       `db:"success_count"`
+	BlockedCount   int       `db:"blocked_count"`
+	FailedCount    int       `db:"failed_count"`
+	SentAt         time.Time `db:"sent_at"`
+}
+
+// ShortLink is an owner-created /l/{code} redirect with a click counter
+type ShortLink struct {
+	ID        int64     `db:"id"`
+	BotID     int64     `db:"bot_id"`
+	Code      string    `db:"code"`
+	TargetURL string    `db:"target_url"`
+	Clicks    int64     `db:"clicks"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Delivery records the outcome of sending an admin reply or broadcast
+// message to a user
+type Delivery struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	Source     string    `db:"source"` // "reply" or "broadcast"
+	Status     string    `db:"status"` // "delivered", "blocked" or "error"
+	ErrorCode  string    `db:"error_code"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Delivery source constants
+const (
+	DeliverySourceReply     = "reply"
+	DeliverySourceBroadcast = "broadcast"
+	DeliverySourceAPI       = "api"
+)
+
+// Delivery status constants
+const (
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusBlocked   = "blocked"
+	DeliveryStatusError     = "error"
+)
+
+// Permissions an API key can carry
+const (
+	APIPermReadStats     = "read_stats"
+	APIPermSendMessages  = "send_messages"
+	APIPermManageReplies = "manage_replies"
+)
+
+// APIKey is an owner-issued credential for the management API, scoped to
+// one bot and a set of permissions. Only the SHA-256 hash of the key
+// itself is ever stored.
+type APIKey struct {
+	ID          int64      `db:"id"`
+	OwnerChatID int64      `db:"owner_chat_id"`
+	BotID       int64      `db:"bot_id"`
+	Label       string     `db:"label"`
+	Permissions string     `db:"permissions"` // comma-separated permission names
+	LastUsedAt  *time.Time `db:"last_used_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// HasPermission reports whether the key carries the given permission
+func (k *APIKey) HasPermission(perm string) bool {
+	for _, p := range strings.Split(k.Permissions, ",") {
+		if strings.TrimSpace(p) == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Owner plan tiers controlling broadcast throughput
+const (
+	PlanFree = "free"
+	PlanPaid = "paid"
+)
+
+// BroadcastLimits describes the broadcast throughput of one plan tier
+type BroadcastLimits struct {
+	MessageInterval time.Duration // delay between two broadcast sends
+	MaxAudience     int           // largest allowed audience, 0 = unlimited
+}
+
+// BroadcastLimitsFor returns the limits of a plan tier, falling back to
+// the free tier for unknown plans
+func BroadcastLimitsFor(plan string) BroadcastLimits {
+	if plan == PlanPaid {
+		// 25 msg/s, unlimited audience
+		return BroadcastLimits{MessageInterval: 40 * time.Millisecond}
+	}
+	// 5 msg/s, capped audience
+	return BroadcastLimits{MessageInterval: 200 * time.Millisecond, MaxAudience: 500}
+}
+
+// BotRuntimeSettings holds the per-message settings for a running bot,
+// loaded in a single query and preloaded into cache at startup
+type BotRuntimeSettings struct {
+	BotID                 int64  `db:"id"`
+	StartMessage          string `db:"start_message"`
+	ForwardAutoReplies    bool   `db:"forward_auto_replies"`
+	ForcedSubEnabled      bool   `db:"forced_sub_enabled"`
+	ForcedSubMessage      string `db:"forced_sub_message"`
+	ForcedSubGraceCount   int    `db:"forced_sub_grace_count"`
+	ShowSentConfirmation  bool   `db:"show_sent_confirmation"`
+	ConfirmationEmoji     string `db:"confirmation_emoji"`
+	ConfirmationMode      string `db:"confirmation_mode"`
+	DigestIntervalMinutes int    `db:"digest_interval_minutes"`
+	DigestUrgentKeywords  string `db:"digest_urgent_keywords"`
+	RelayChannelID        int64  `db:"relay_channel_id"`
+	ArchiveChatID         int64  `db:"archive_chat_id"`
+	BlockedMediaTypes     string `db:"blocked_media_types"`
+	MaxFileSizeMB         int    `db:"max_file_size_mb"`
+	FilterProfanityAction string `db:"filter_profanity_action"`
+	FilterLinksAction     string `db:"filter_links_action"`
+	DedupeWindowMinutes   int    `db:"dedupe_window_minutes"`
+	SLAMinutes            int    `db:"sla_minutes"`
+	SurveyEnabled         bool   `db:"survey_enabled"`
+	MenuButtonEnabled     bool   `db:"menu_button_enabled"`
+	HeaderPolicy          string `db:"header_policy"`
+	RichHeaderEnabled     bool   `db:"rich_header_enabled"`
+	PrivacyNotice         string `db:"privacy_notice"`
+	ContentLoggingEnabled bool   `db:"content_logging_enabled"`
+	SpamThreshold         int    `db:"spam_threshold"`
+	MessageTTLHours       int    `db:"message_ttl_hours"`
+	Locale                string `db:"locale"`
+	Timezone              string `db:"timezone"`
+	StatsDigest           string `db:"stats_digest"`
+	WhitelistEnabled      bool   `db:"whitelist_enabled"`
+	WhitelistMessage      string `db:"whitelist_message"`
+	ReactionActions       string `db:"reaction_actions"`
+}
+
+// Confirmation mode constants
+const (
+	ConfirmationModeReact = "react"
+	ConfirmationModeText  = "text"
+)
+
+// Header policy constants control how often the user info header is re-sent
+const (
+	HeaderPolicyNever = "never" // once per user, ever
+	HeaderPolicyGap   = "gap"   // again after a conversation gap
+	HeaderPolicyDaily = "daily" // at most once per day
+)
+
+// AbuseReport is an end-user complaint about a hosted bot, filed via the
+// /report command in the child bot
+type AbuseReport struct {
+	ID             int64     `db:"id"`
+	BotID          int64     `db:"bot_id"`
+	ReporterChatID int64     `db:"reporter_chat_id"`
+	Reason         string    `db:"reason"`
+	Status         string    `db:"status"` // "open", "resolved" or "dismissed"
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// Abuse report status constants
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// AbuseReportSummary aggregates the open reports against one bot, with the
+// owner's all-time report count to surface repeat offenders
+type AbuseReportSummary struct {
+	BotID            int64  `db:"bot_id"`
+	BotUsername      string `db:"bot_username"`
+	OwnerChatID      int64  `db:"owner_chat_id"`
+	OpenCount        int64  `db:"open_count"`
+	OwnerReportCount int64  `db:"owner_report_count"`
+}
+
+// AuditLog records a destructive owner or admin action (user erasure,
+// statistics reset) for accountability
+type AuditLog struct {
 	ID          int64     `db:"id"`
 	BotID       int64     `db:"bot_id"`
-	TriggerWord string    `db:"trigger_word"` // Keyword or command name (without /)
-	Response    string    `db:"response"`     // Response text (supports Markdown) - used for text type
-	MessageType string    `db:"message_type"` // "text", "photo", "video", "audio", "voice", "document", "animation", "video_note", "sticker"
-	FileID      string    `db:"file_id"`      // Telegram FileID for media
-	Caption     string    `db:"caption"`      // Caption for media (supports Markdown)
-	TriggerType string    `db:"trigger_type"` // "keyword" or "command"
-	MatchType   string    `db:"match_type"`   // "exact" or "contains" (for keywords)
-	IsActive    bool      `db:"is_active"`
+	ActorChatID int64     `db:"actor_chat_id"`
+	Action      string    `db:"action"` // "erase_user" or "reset_stats"
+	Target      string    `db:"target"` // affected user ID, or "" for bot-wide actions
 	CreatedAt   time.Time `db:"created_at"`
 }
 
-// ScheduledMessage represents a scheduled broadcast message
-type ScheduledMessage struct {
-	ID            int64      `db:"id"`
-	BotID         int64      `db:"bot_id"`
-	OwnerChatID   int64      `db:"owner_chat_id"`
-	MessageType   string     `db:"message_type"`
-	MessageText   string     `db:"message_text"`
-	FileID        string     `db:"file_id"`
-	Caption       string     `db:"caption"`
-	ScheduleType  string     `db:"schedule_type"`
-	ScheduledTime time.Time  `db:"scheduled_time"`
-	TimeOfDay     string     `db:"time_of_day"`
-	DayOfWeek     *int       `db:"day_of_week"`
-	Status        string     `db:"status"`
-	LastSentAt    *time.Time `db:"last_sent_at"`
-	NextRunAt     *time.Time `db:"next_run_at"`
-	FailureReason *string    `db:"failure_reason"`
-	CreatedAt     time.Time  `db:"created_at"`
-	UpdatedAt     time.Time  `db:"updated_at"`
+// Audit action constants
+const (
+	AuditActionEraseUser    = "erase_user"
+	AuditActionResetStats   = "reset_stats"
+	AuditActionPurgeContent = "purge_content"
+)
+
+// Rating is a 1-5 star satisfaction score left by an end user
+type Rating struct {
+	ID         int64     `db:"id"`
+	BotID      int64     `db:"bot_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	Stars      int       `db:"stars"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// UnansweredMessage is a forwarded user message the owner has not replied
+// to yet, surfaced by the SLA alert loop
+type UnansweredMessage struct {
+	AdminMsgID int       `db:"admin_msg_id"`
+	UserChatID int64     `db:"user_chat_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// AudienceOverlap holds the number of users a pair of bots have in common
+type AudienceOverlap struct {
+	BotAUsername string `db:"bot_a_username"`
+	BotBUsername string `db:"bot_b_username"`
+	SharedUsers  int64  `db:"shared_users"`
 }
 
 // Schedule type constants
@@ -0,0 +1,53 @@
+// Package plugin lets operators extend child bots with bespoke features
+// (custom commands, integrations) without patching the core handler
+// files.
+//
+// Plugins are compiled in: a plugin package registers itself with
+// Register from an init function and is enabled by blank-importing it
+// from cmd/server/plugins_enabled.go. Loading shared objects via Go's
+// plugin package or interpreting sources with Yaegi can sit on top of
+// this registry later; the Plugin interface is the stable seam.
+package plugin
+
+import (
+	"github.com/Amr-9/botforge/internal/cache"
+	"github.com/Amr-9/botforge/internal/database"
+	"gopkg.in/telebot.v3"
+)
+
+// BotContext is what a plugin gets about the child bot it is extending
+type BotContext struct {
+	Token     string
+	BotID     int64
+	OwnerChat *telebot.Chat
+	Repo      *database.Repository
+	Cache     cache.Cache
+}
+
+// Plugin is one compile-time extension. RegisterHandlers runs once per
+// started child bot; handlers it registers coexist with the core ones,
+// so plugins should stick to their own commands and button uniques.
+type Plugin interface {
+	Name() string
+	RegisterHandlers(bot *telebot.Bot, botCtx *BotContext) error
+}
+
+// Migrator is implemented by plugins that need their own tables. The
+// statements run once at startup, before any bot starts.
+type Migrator interface {
+	Migrations() []string
+}
+
+// registry holds the plugins enabled in this build, in registration order
+var registry []Plugin
+
+// Register adds a plugin to the registry. Call it from the plugin
+// package's init function.
+func Register(p Plugin) {
+	registry = append(registry, p)
+}
+
+// All returns the registered plugins in registration order
+func All() []Plugin {
+	return registry
+}
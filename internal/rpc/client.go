@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Amr-9/botforge/internal/rpc/shardpb"
+)
+
+// Client calls the shard API of a sibling instance. Shards talk over the
+// private network, so connections are plaintext.
+type Client struct {
+	conn *grpc.ClientConn
+	svc  shardpb.ShardServiceClient
+}
+
+// Dial connects to the shard at addr
+func Dial(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial shard %s: %w", addr, err)
+	}
+	return &Client{
+		conn: conn,
+		svc:  shardpb.NewShardServiceClient(conn),
+	}, nil
+}
+
+// Close releases the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// SendMessage asks the shard to deliver a text message through one of its bots
+func (c *Client) SendMessage(ctx context.Context, botID, chatID int64, text string) error {
+	_, err := c.svc.SendMessage(ctx, &shardpb.SendMessageRequest{
+		BotId:  botID,
+		ChatId: chatID,
+		Text:   text,
+	})
+	return err
+}
+
+// GetBotStatus fetches the live state of a bot on the shard
+func (c *Client) GetBotStatus(ctx context.Context, botID int64) (*shardpb.GetBotStatusResponse, error) {
+	return c.svc.GetBotStatus(ctx, &shardpb.GetBotStatusRequest{BotId: botID})
+}
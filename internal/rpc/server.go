@@ -0,0 +1,99 @@
+// Package rpc implements the internal shard-to-shard gRPC API. Each shard
+// runs a Server so sibling instances can act on the bots it owns; the
+// Client side lives in client.go.
+package rpc
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/telebot.v3"
+
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/rpc/shardpb"
+)
+
+// Server answers shard API calls for the bots this instance owns
+type Server struct {
+	shardpb.UnimplementedShardServiceServer
+
+	repo    *database.Repository
+	manager *bot.Manager
+	grpcSrv *grpc.Server
+}
+
+// NewServer creates the shard API server
+func NewServer(repo *database.Repository, manager *bot.Manager) *Server {
+	return &Server{
+		repo:    repo,
+		manager: manager,
+	}
+}
+
+// Start listens on addr and serves in the background
+func (s *Server) Start(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.grpcSrv = grpc.NewServer()
+	shardpb.RegisterShardServiceServer(s.grpcSrv, s)
+
+	go func() {
+		log.Printf("Shard API listening on %s", addr)
+		if err := s.grpcSrv.Serve(lis); err != nil {
+			log.Printf("Shard API server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop shuts the server down gracefully
+func (s *Server) Stop() {
+	if s.grpcSrv != nil {
+		s.grpcSrv.GracefulStop()
+	}
+}
+
+// SendMessage delivers a text message through a bot owned by this shard
+func (s *Server) SendMessage(ctx context.Context, req *shardpb.SendMessageRequest) (*shardpb.SendMessageResponse, error) {
+	childBot, _, err := s.manager.GetBotByID(req.BotId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "bot %d is not running on this shard", req.BotId)
+	}
+
+	if _, err := childBot.Send(&telebot.Chat{ID: req.ChatId}, req.Text); err != nil {
+		log.Printf("Shard API send to %d via bot %d failed: %v", req.ChatId, req.BotId, err)
+		return nil, status.Errorf(codes.Unavailable, "failed to deliver message: %v", err)
+	}
+
+	return &shardpb.SendMessageResponse{Delivered: true}, nil
+}
+
+// GetBotStatus reports the live state of a bot on this shard
+func (s *Server) GetBotStatus(ctx context.Context, req *shardpb.GetBotStatusRequest) (*shardpb.GetBotStatusResponse, error) {
+	resp := &shardpb.GetBotStatusResponse{}
+
+	if _, _, err := s.manager.GetBotByID(req.BotId); err == nil {
+		resp.Running = true
+	} else {
+		for _, cold := range s.manager.ColdBots() {
+			if cold.BotID == req.BotId {
+				resp.Cold = true
+				break
+			}
+		}
+	}
+
+	if record, err := s.repo.GetBotByID(ctx, req.BotId); err == nil && record != nil {
+		resp.Username = record.Username
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,72 @@
+package rpc_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Amr-9/botforge/internal/bot"
+	"github.com/Amr-9/botforge/internal/database"
+	"github.com/Amr-9/botforge/internal/rpc"
+	"github.com/Amr-9/botforge/internal/rpc/shardpb"
+)
+
+// ==================== Shard API Tests ====================
+
+func newTestShardServer(t *testing.T) (*rpc.Server, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	mysql := database.NewMySQLFromDB(sqlxDB)
+	repo := database.NewRepository(mysql, "12345678901234567890123456789012")
+	manager := bot.NewManager(repo, nil, "")
+
+	return rpc.NewServer(repo, manager), mock
+}
+
+func TestShardSendMessage_UnknownBotIsNotFound(t *testing.T) {
+	server, _ := newTestShardServer(t)
+
+	_, err := server.SendMessage(context.Background(), &shardpb.SendMessageRequest{
+		BotId:  42,
+		ChatId: 555,
+		Text:   "hello",
+	})
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestShardGetBotStatus_UnknownBot(t *testing.T) {
+	server, mock := newTestShardServer(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM bots").
+		WillReturnError(sql.ErrNoRows)
+
+	resp, err := server.GetBotStatus(context.Background(), &shardpb.GetBotStatusRequest{BotId: 42})
+	if err != nil {
+		t.Fatalf("GetBotStatus failed: %v", err)
+	}
+
+	if resp.Running {
+		t.Error("Expected bot to be reported as not running")
+	}
+	if resp.Cold {
+		t.Error("Expected bot to be reported as not cold")
+	}
+	if resp.Username != "" {
+		t.Errorf("Expected empty username, got %q", resp.Username)
+	}
+}
@@ -0,0 +1,315 @@
+// Internal shard-to-shard API. In a multi-instance deployment each bot is
+// owned by exactly one shard (the one holding its webhook); other shards
+// forward actions for that bot here so the external REST API stays
+// instance-agnostic.
+//
+// Regenerate with: buf generate (see buf.gen.yaml)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: shard.proto
+
+package shardpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SendMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BotId         int64                  `protobuf:"varint,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	ChatId        int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_shard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_shard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendMessageRequest) GetBotId() int64 {
+	if x != nil {
+		return x.BotId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivered     bool                   `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_shard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_shard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendMessageResponse) GetDelivered() bool {
+	if x != nil {
+		return x.Delivered
+	}
+	return false
+}
+
+type GetBotStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BotId         int64                  `protobuf:"varint,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBotStatusRequest) Reset() {
+	*x = GetBotStatusRequest{}
+	mi := &file_shard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBotStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBotStatusRequest) ProtoMessage() {}
+
+func (x *GetBotStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBotStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBotStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBotStatusRequest) GetBotId() int64 {
+	if x != nil {
+		return x.BotId
+	}
+	return 0
+}
+
+type GetBotStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The bot is loaded and serving updates on this shard
+	Running bool `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	// The bot was demoted to the cold tier for inactivity
+	Cold          bool   `protobuf:"varint,2,opt,name=cold,proto3" json:"cold,omitempty"`
+	Username      string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBotStatusResponse) Reset() {
+	*x = GetBotStatusResponse{}
+	mi := &file_shard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBotStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBotStatusResponse) ProtoMessage() {}
+
+func (x *GetBotStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBotStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBotStatusResponse) Descriptor() ([]byte, []int) {
+	return file_shard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBotStatusResponse) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *GetBotStatusResponse) GetCold() bool {
+	if x != nil {
+		return x.Cold
+	}
+	return false
+}
+
+func (x *GetBotStatusResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+var File_shard_proto protoreflect.FileDescriptor
+
+const file_shard_proto_rawDesc = "" +
+	"\n" +
+	"\vshard.proto\x12\x11botforge.shard.v1\"X\n" +
+	"\x12SendMessageRequest\x12\x15\n" +
+	"\x06bot_id\x18\x01 \x01(\x03R\x05botId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"3\n" +
+	"\x13SendMessageResponse\x12\x1c\n" +
+	"\tdelivered\x18\x01 \x01(\bR\tdelivered\",\n" +
+	"\x13GetBotStatusRequest\x12\x15\n" +
+	"\x06bot_id\x18\x01 \x01(\x03R\x05botId\"`\n" +
+	"\x14GetBotStatusResponse\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12\x12\n" +
+	"\x04cold\x18\x02 \x01(\bR\x04cold\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername2\xcd\x01\n" +
+	"\fShardService\x12\\\n" +
+	"\vSendMessage\x12%.botforge.shard.v1.SendMessageRequest\x1a&.botforge.shard.v1.SendMessageResponse\x12_\n" +
+	"\fGetBotStatus\x12&.botforge.shard.v1.GetBotStatusRequest\x1a'.botforge.shard.v1.GetBotStatusResponseB0Z.github.com/Amr-9/botforge/internal/rpc/shardpbb\x06proto3"
+
+var (
+	file_shard_proto_rawDescOnce sync.Once
+	file_shard_proto_rawDescData []byte
+)
+
+func file_shard_proto_rawDescGZIP() []byte {
+	file_shard_proto_rawDescOnce.Do(func() {
+		file_shard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shard_proto_rawDesc), len(file_shard_proto_rawDesc)))
+	})
+	return file_shard_proto_rawDescData
+}
+
+var file_shard_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_shard_proto_goTypes = []any{
+	(*SendMessageRequest)(nil),   // 0: botforge.shard.v1.SendMessageRequest
+	(*SendMessageResponse)(nil),  // 1: botforge.shard.v1.SendMessageResponse
+	(*GetBotStatusRequest)(nil),  // 2: botforge.shard.v1.GetBotStatusRequest
+	(*GetBotStatusResponse)(nil), // 3: botforge.shard.v1.GetBotStatusResponse
+}
+var file_shard_proto_depIdxs = []int32{
+	0, // 0: botforge.shard.v1.ShardService.SendMessage:input_type -> botforge.shard.v1.SendMessageRequest
+	2, // 1: botforge.shard.v1.ShardService.GetBotStatus:input_type -> botforge.shard.v1.GetBotStatusRequest
+	1, // 2: botforge.shard.v1.ShardService.SendMessage:output_type -> botforge.shard.v1.SendMessageResponse
+	3, // 3: botforge.shard.v1.ShardService.GetBotStatus:output_type -> botforge.shard.v1.GetBotStatusResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_shard_proto_init() }
+func file_shard_proto_init() {
+	if File_shard_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shard_proto_rawDesc), len(file_shard_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shard_proto_goTypes,
+		DependencyIndexes: file_shard_proto_depIdxs,
+		MessageInfos:      file_shard_proto_msgTypes,
+	}.Build()
+	File_shard_proto = out.File
+	file_shard_proto_goTypes = nil
+	file_shard_proto_depIdxs = nil
+}
@@ -0,0 +1,170 @@
+// Internal shard-to-shard API. In a multi-instance deployment each bot is
+// owned by exactly one shard (the one holding its webhook); other shards
+// forward actions for that bot here so the external REST API stays
+// instance-agnostic.
+//
+// Regenerate with: buf generate (see buf.gen.yaml)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: shard.proto
+
+package shardpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ShardService_SendMessage_FullMethodName  = "/botforge.shard.v1.ShardService/SendMessage"
+	ShardService_GetBotStatus_FullMethodName = "/botforge.shard.v1.ShardService/GetBotStatus"
+)
+
+// ShardServiceClient is the client API for ShardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShardServiceClient interface {
+	// SendMessage delivers a text message through a bot owned by this shard
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
+	// GetBotStatus reports the live state of a bot on this shard
+	GetBotStatus(ctx context.Context, in *GetBotStatusRequest, opts ...grpc.CallOption) (*GetBotStatusResponse, error)
+}
+
+type shardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShardServiceClient(cc grpc.ClientConnInterface) ShardServiceClient {
+	return &shardServiceClient{cc}
+}
+
+func (c *shardServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMessageResponse)
+	err := c.cc.Invoke(ctx, ShardService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shardServiceClient) GetBotStatus(ctx context.Context, in *GetBotStatusRequest, opts ...grpc.CallOption) (*GetBotStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBotStatusResponse)
+	err := c.cc.Invoke(ctx, ShardService_GetBotStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShardServiceServer is the server API for ShardService service.
+// All implementations must embed UnimplementedShardServiceServer
+// for forward compatibility.
+type ShardServiceServer interface {
+	// SendMessage delivers a text message through a bot owned by this shard
+	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
+	// GetBotStatus reports the live state of a bot on this shard
+	GetBotStatus(context.Context, *GetBotStatusRequest) (*GetBotStatusResponse, error)
+	mustEmbedUnimplementedShardServiceServer()
+}
+
+// UnimplementedShardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShardServiceServer struct{}
+
+func (UnimplementedShardServiceServer) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedShardServiceServer) GetBotStatus(context.Context, *GetBotStatusRequest) (*GetBotStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBotStatus not implemented")
+}
+func (UnimplementedShardServiceServer) mustEmbedUnimplementedShardServiceServer() {}
+func (UnimplementedShardServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeShardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShardServiceServer will
+// result in compilation errors.
+type UnsafeShardServiceServer interface {
+	mustEmbedUnimplementedShardServiceServer()
+}
+
+func RegisterShardServiceServer(s grpc.ServiceRegistrar, srv ShardServiceServer) {
+	// If the following call panics, it indicates UnimplementedShardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShardService_ServiceDesc, srv)
+}
+
+func _ShardService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShardServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShardService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShardServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShardService_GetBotStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBotStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShardServiceServer).GetBotStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShardService_GetBotStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShardServiceServer).GetBotStatus(ctx, req.(*GetBotStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShardService_ServiceDesc is the grpc.ServiceDesc for ShardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "botforge.shard.v1.ShardService",
+	HandlerType: (*ShardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendMessage",
+			Handler:    _ShardService_SendMessage_Handler,
+		},
+		{
+			MethodName: "GetBotStatus",
+			Handler:    _ShardService_GetBotStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shard.proto",
+}
@@ -74,6 +74,7 @@ func (s *Scheduler) run() {
 		select {
 		case <-s.ticker.C:
 			s.processPendingMessages()
+			s.processStatsDigests()
 		case <-s.stopCh:
 			return
 		}
@@ -123,6 +124,12 @@ func (s *Scheduler) processMessage(ctx context.Context, msg models.ScheduledMess
 		return
 	}
 
+	// Channel posts are a single send - no per-user broadcast needed
+	if msg.TargetChannelID != 0 {
+		s.postToChannel(ctx, botInstance, msg)
+		return
+	}
+
 	// Get all user chat IDs
 	userIDs, err := s.repo.GetAllUserChatIDs(ctx, msg.BotID)
 	if err != nil {
@@ -138,8 +145,22 @@ func (s *Scheduler) processMessage(ctx context.Context, msg models.ScheduledMess
 		return
 	}
 
+	// Broadcast throughput and audience follow the owner's plan tier
+	plan, err := s.repo.GetOwnerPlan(ctx, msg.OwnerChatID)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get owner plan: %v", err)
+		plan = models.PlanFree
+	}
+	limits := models.BroadcastLimitsFor(plan)
+	if limits.MaxAudience > 0 && len(userIDs) > limits.MaxAudience {
+		reason := fmt.Sprintf("audience of %d exceeds the %s plan limit of %d users", len(userIDs), plan, limits.MaxAudience)
+		log.Printf("[Scheduler] Message ID=%d not sent: %s", msg.ID, reason)
+		s.repo.UpdateScheduledMessageStatus(ctx, msg.ID, models.ScheduleStatusFailed, reason)
+		return
+	}
+
 	// Broadcast the message
-	success, failed := s.broadcastMessage(botInstance, &msg, userIDs)
+	success, failed := s.broadcastMessage(botInstance, &msg, userIDs, limits.MessageInterval)
 	now := time.Now()
 
 	log.Printf("[Scheduler] Message ID=%d sent. Success=%d, Failed=%d", msg.ID, success, failed)
@@ -157,8 +178,73 @@ func (s *Scheduler) processMessage(ctx context.Context, msg models.ScheduledMess
 	s.notifyAdmin(botInstance, msg.OwnerChatID, &msg, success, failed)
 }
 
-// broadcastMessage sends the message to all users
-func (s *Scheduler) broadcastMessage(bot *telebot.Bot, msg *models.ScheduledMessage, userIDs []int64) (int, int) {
+// postToChannel sends the message to its target channel and updates the
+// schedule status
+func (s *Scheduler) postToChannel(ctx context.Context, bot *telebot.Bot, msg models.ScheduledMessage) {
+	err := s.sendScheduled(bot, &telebot.Chat{ID: msg.TargetChannelID}, &msg)
+	now := time.Now()
+
+	success, failed := 1, 0
+	if err != nil {
+		success, failed = 0, 1
+		log.Printf("[Scheduler] Failed to post message ID=%d to channel %d: %v", msg.ID, msg.TargetChannelID, err)
+	}
+
+	log.Printf("[Scheduler] Message ID=%d posted to channel %d. Success=%d, Failed=%d", msg.ID, msg.TargetChannelID, success, failed)
+
+	// Update status based on schedule type
+	if msg.ScheduleType == models.ScheduleTypeOnce {
+		if err != nil {
+			s.repo.UpdateScheduledMessageStatus(ctx, msg.ID, models.ScheduleStatusFailed, err.Error())
+		} else {
+			s.repo.UpdateScheduledMessageStatus(ctx, msg.ID, models.ScheduleStatusSent, "")
+		}
+	} else {
+		// Calculate next run time for recurring messages
+		nextRun := s.calculateNextRun(&msg, now)
+		s.repo.UpdateScheduledMessageAfterSend(ctx, msg.ID, now, nextRun)
+	}
+
+	// Notify admin
+	s.notifyAdmin(bot, msg.OwnerChatID, &msg, success, failed)
+}
+
+// sendScheduled delivers a scheduled message payload to a single recipient
+func (s *Scheduler) sendScheduled(bot *telebot.Bot, to telebot.Recipient, msg *models.ScheduledMessage) error {
+	var err error
+
+	switch msg.MessageType {
+	case models.MessageTypeText:
+		_, err = bot.Send(to, msg.MessageText, telebot.ModeMarkdown)
+
+	case models.MessageTypePhoto:
+		photo := &telebot.Photo{
+			File:    telebot.File{FileID: msg.FileID},
+			Caption: msg.Caption,
+		}
+		_, err = bot.Send(to, photo, telebot.ModeMarkdown)
+
+	case models.MessageTypeVideo:
+		video := &telebot.Video{
+			File:    telebot.File{FileID: msg.FileID},
+			Caption: msg.Caption,
+		}
+		_, err = bot.Send(to, video, telebot.ModeMarkdown)
+
+	case models.MessageTypeDocument:
+		doc := &telebot.Document{
+			File:    telebot.File{FileID: msg.FileID},
+			Caption: msg.Caption,
+		}
+		_, err = bot.Send(to, doc, telebot.ModeMarkdown)
+	}
+
+	return err
+}
+
+// broadcastMessage sends the message to all users, pacing sends by the
+// owner's plan interval
+func (s *Scheduler) broadcastMessage(bot *telebot.Bot, msg *models.ScheduledMessage, userIDs []int64, interval time.Duration) (int, int) {
 	success := 0
 	failed := 0
 
@@ -167,43 +253,14 @@ func (s *Scheduler) broadcastMessage(bot *telebot.Bot, msg *models.ScheduledMess
 			continue // Don't send to admin
 		}
 
-		userChat := &telebot.Chat{ID: userID}
-		var err error
-
-		switch msg.MessageType {
-		case models.MessageTypeText:
-			_, err = bot.Send(userChat, msg.MessageText, telebot.ModeMarkdown)
-
-		case models.MessageTypePhoto:
-			photo := &telebot.Photo{
-				File:    telebot.File{FileID: msg.FileID},
-				Caption: msg.Caption,
-			}
-			_, err = bot.Send(userChat, photo, telebot.ModeMarkdown)
-
-		case models.MessageTypeVideo:
-			video := &telebot.Video{
-				File:    telebot.File{FileID: msg.FileID},
-				Caption: msg.Caption,
-			}
-			_, err = bot.Send(userChat, video, telebot.ModeMarkdown)
-
-		case models.MessageTypeDocument:
-			doc := &telebot.Document{
-				File:    telebot.File{FileID: msg.FileID},
-				Caption: msg.Caption,
-			}
-			_, err = bot.Send(userChat, doc, telebot.ModeMarkdown)
-		}
-
-		if err != nil {
+		if err := s.sendScheduled(bot, &telebot.Chat{ID: userID}, msg); err != nil {
 			failed++
 		} else {
 			success++
 		}
 
-		// Rate limiting - 40ms between messages (25 msg/sec)
-		time.Sleep(40 * time.Millisecond)
+		// Rate limiting according to the owner's plan tier
+		time.Sleep(interval)
 	}
 
 	return success, failed
@@ -273,6 +330,9 @@ func (s *Scheduler) notifyAdmin(bot *telebot.Bot, adminID int64, msg *models.Sch
 	case models.ScheduleTypeWeekly:
 		scheduleInfo = "Weekly recurring"
 	}
+	if msg.TargetChannelID != 0 {
+		scheduleInfo += fmt.Sprintf(" → channel %d", msg.TargetChannelID)
+	}
 
 	report := fmt.Sprintf(`📢 <b>Scheduled Message Delivered</b>
 
@@ -368,7 +368,7 @@ func TestBroadcastMessage_ExcludesAdmin(t *testing.T) {
 	}
 
 	_ = sentTo
-	_ = sentMu
+	_ = &sentMu
 	_ = s
 
 	if count != 3 {
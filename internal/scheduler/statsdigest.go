@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Stats Digest ====================
+
+// Owners can opt into a daily or weekly statistics digest from the
+// settings menu; the scheduler pushes it from the aggregates the bot
+// already records (bot_users, message_logs, message_contents).
+
+// statsDigestTextLimit caps how many stored messages the top-keyword
+// aggregation decrypts per digest
+const statsDigestTextLimit = 500
+
+// processStatsDigests sends due stats digests to opted-in owners
+func (s *Scheduler) processStatsDigests() {
+	ctx := context.Background()
+	now := time.Now()
+
+	bots, err := s.repo.GetStatsDigestBots(ctx, now)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get stats digest bots: %v", err)
+		return
+	}
+
+	for _, b := range bots {
+		botInstance, _, err := s.manager.GetBotByID(b.ID)
+		if err != nil {
+			// Bot not running on this instance; another shard owns it
+			continue
+		}
+
+		period := 24 * time.Hour
+		label := "Last 24 hours"
+		if b.StatsDigest == "weekly" {
+			period = 7 * 24 * time.Hour
+			label = "This week"
+		}
+
+		report := s.buildStatsDigest(ctx, b.ID, now.Add(-period), label)
+		if _, err := botInstance.Send(&telebot.Chat{ID: b.OwnerChatID}, report, telebot.ModeHTML); err != nil {
+			log.Printf("[Scheduler] Failed to send stats digest for bot %d: %v", b.ID, err)
+			continue
+		}
+
+		if err := s.repo.MarkStatsDigestSent(ctx, b.ID, now); err != nil {
+			log.Printf("[Scheduler] Failed to mark stats digest sent for bot %d: %v", b.ID, err)
+		}
+	}
+}
+
+// buildStatsDigest renders one bot's digest for the period since the
+// cutoff. Counts that fail to load show as 0 rather than dropping the
+// whole digest.
+func (s *Scheduler) buildStatsDigest(ctx context.Context, botID int64, since time.Time, label string) string {
+	newUsers, err := s.repo.GetNewUserCount(ctx, botID, since)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get new user count for bot %d: %v", botID, err)
+	}
+	messages, err := s.repo.GetMessageCountSince(ctx, botID, since)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get message count for bot %d: %v", botID, err)
+	}
+	activeUsers, err := s.repo.GetActiveUserCount(ctx, botID, since)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get active user count for bot %d: %v", botID, err)
+	}
+
+	report := fmt.Sprintf(`📈 <b>Stats Digest</b>
+
+<b>%s:</b>
+👥 New users: %d
+💬 Messages: %d
+🟢 Active users: %d`,
+		label, newUsers, messages, activeUsers)
+
+	// Top keyword only exists for bots with content logging enabled
+	texts, err := s.repo.GetMessageTextsSince(ctx, botID, since, statsDigestTextLimit)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to get message texts for bot %d: %v", botID, err)
+	}
+	// The keyword comes from user-written text, so escape it: a keyword
+	// like <b> would make Telegram reject the HTML message and the
+	// digest would retry forever, since it is only marked sent on success
+	if keyword := topKeyword(texts); keyword != "" {
+		report += fmt.Sprintf("\n🔑 Top keyword: %s", html.EscapeString(keyword))
+	}
+
+	return report
+}
+
+// digestStopwords are common words excluded from the top-keyword count
+var digestStopwords = map[string]bool{
+	"this": true, "that": true, "with": true, "have": true, "what": true,
+	"when": true, "your": true, "from": true, "please": true, "hello": true,
+	"thanks": true, "thank": true, "there": true, "about": true, "will": true,
+	"would": true, "could": true, "want": true, "need": true, "just": true,
+}
+
+// topKeyword returns the most frequent interesting word across the
+// texts, or "" when nothing occurs more than once. Short words and
+// stopwords are skipped; ties break alphabetically so the result is
+// deterministic.
+func topKeyword(texts []string) string {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			word = strings.Trim(word, ".,!?:;\"'()[]")
+			if len([]rune(word)) < 4 || digestStopwords[word] {
+				continue
+			}
+			counts[word]++
+		}
+	}
+
+	best, bestCount := "", 1
+	for word, count := range counts {
+		if count > bestCount || (count == bestCount && best != "" && word < best) {
+			best, bestCount = word, count
+		}
+	}
+	return best
+}
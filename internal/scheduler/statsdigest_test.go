@@ -0,0 +1,45 @@
+package scheduler
+
+import "testing"
+
+func TestTopKeyword(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  string
+	}{
+		{
+			name:  "most frequent word wins",
+			texts: []string{"what is the price", "price list please", "your price?"},
+			want:  "price",
+		},
+		{
+			name:  "stopwords and short words are skipped",
+			texts: []string{"please please please", "can you do it", "refund refund"},
+			want:  "refund",
+		},
+		{
+			name:  "no repeated word means no keyword",
+			texts: []string{"shipping question", "refund request"},
+			want:  "",
+		},
+		{
+			name:  "empty input",
+			texts: nil,
+			want:  "",
+		},
+		{
+			name:  "ties break alphabetically",
+			texts: []string{"delivery delivery", "billing billing"},
+			want:  "billing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topKeyword(tt.texts); got != tt.want {
+				t.Errorf("topKeyword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
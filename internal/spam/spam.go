@@ -0,0 +1,151 @@
+// Package spam scores incoming messages for spam likelihood. A
+// classifier returns a score between 0 and 1; the bot layer compares it
+// against the per-bot threshold and diverts suspected spam into a review
+// queue instead of the owner's main inbox.
+//
+// Two classifiers ship in-tree: an embedded heuristic that needs no
+// infrastructure, and an HTTP client for operators who run a real model
+// behind a scoring endpoint.
+package spam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Classifier scores a message text for spam likelihood (0 = clean,
+// 1 = certain spam)
+type Classifier interface {
+	Score(ctx context.Context, text string) (float64, error)
+}
+
+// ==================== Embedded Heuristic Classifier ====================
+
+// spamPhrases are strong spam markers, matched after lowercasing
+var spamPhrases = []string{
+	"free money", "earn money", "make money fast", "work from home",
+	"crypto investment", "guaranteed profit", "double your", "click here",
+	"limited offer", "act now", "winner", "you have won", "claim your prize",
+	"casino", "viagra", "loan approval",
+}
+
+// linkRx matches URLs and Telegram invite links, the same shapes the
+// content filter pack recognizes
+var linkRx = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|telegram\.me/|joinchat)`)
+
+// HeuristicClassifier is the embedded fallback model: a weighted sum of
+// simple spam signals. It will never beat a trained model but catches
+// the bulk spam waves hosted bots actually see.
+type HeuristicClassifier struct{}
+
+// NewHeuristicClassifier creates the embedded classifier
+func NewHeuristicClassifier() *HeuristicClassifier {
+	return &HeuristicClassifier{}
+}
+
+// Score rates the text by its spam signals. Never returns an error.
+func (h *HeuristicClassifier) Score(ctx context.Context, text string) (float64, error) {
+	if text == "" {
+		return 0, nil
+	}
+
+	lower := strings.ToLower(text)
+	score := 0.0
+
+	for _, phrase := range spamPhrases {
+		if strings.Contains(lower, phrase) {
+			score += 0.4
+		}
+	}
+
+	// Multiple links are a stronger signal than one
+	links := len(linkRx.FindAllStringIndex(text, -1))
+	if links == 1 {
+		score += 0.2
+	} else if links > 1 {
+		score += 0.5
+	}
+
+	// Shouting: mostly-uppercase messages of meaningful length
+	if upperRatio(text) > 0.7 && len([]rune(text)) > 20 {
+		score += 0.3
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, nil
+}
+
+// upperRatio returns the share of letters that are uppercase
+func upperRatio(text string) float64 {
+	letters, uppers := 0, 0
+	for _, r := range text {
+		if strings.ContainsRune("abcdefghijklmnopqrstuvwxyz", r) {
+			letters++
+		} else if strings.ContainsRune("ABCDEFGHIJKLMNOPQRSTUVWXYZ", r) {
+			letters++
+			uppers++
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+	return float64(uppers) / float64(letters)
+}
+
+// ==================== HTTP Classifier ====================
+
+// HTTPClassifier scores messages via an external endpoint. The endpoint
+// receives {"text": "..."} and must answer {"score": 0.93}.
+type HTTPClassifier struct {
+	url   string
+	httpc *http.Client
+}
+
+// NewHTTPClassifier creates a classifier backed by a scoring endpoint
+func NewHTTPClassifier(url string) *HTTPClassifier {
+	return &HTTPClassifier{
+		url:   url,
+		httpc: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Score posts the text to the scoring endpoint
+func (h *HTTPClassifier) Score(ctx context.Context, text string) (float64, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpc.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach spam classifier: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("spam classifier returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Score float64 `json:"score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode spam classifier response: %w", err)
+	}
+
+	return result.Score, nil
+}
@@ -0,0 +1,79 @@
+package spam_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/spam"
+)
+
+func TestHeuristicClassifier_CleanText(t *testing.T) {
+	c := spam.NewHeuristicClassifier()
+
+	score, err := c.Score(context.Background(), "Hi, when will my order arrive?")
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if score >= 0.5 {
+		t.Errorf("Expected low score for clean text, got %v", score)
+	}
+}
+
+func TestHeuristicClassifier_ObviousSpam(t *testing.T) {
+	c := spam.NewHeuristicClassifier()
+
+	score, err := c.Score(context.Background(),
+		"FREE MONEY!!! GUARANTEED PROFIT, CLICK HERE https://spam.example t.me/scamchannel")
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if score < 0.7 {
+		t.Errorf("Expected high score for obvious spam, got %v", score)
+	}
+}
+
+func TestHeuristicClassifier_EmptyText(t *testing.T) {
+	c := spam.NewHeuristicClassifier()
+
+	score, err := c.Score(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if score != 0 {
+		t.Errorf("Expected zero score for empty text, got %v", score)
+	}
+}
+
+func TestHTTPClassifier_Score(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"score": 0.93}`))
+	}))
+	defer srv.Close()
+
+	c := spam.NewHTTPClassifier(srv.URL)
+	score, err := c.Score(context.Background(), "some message")
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if score != 0.93 {
+		t.Errorf("Expected score 0.93, got %v", score)
+	}
+}
+
+func TestHTTPClassifier_ServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := spam.NewHTTPClassifier(srv.URL)
+	if _, err := c.Score(context.Background(), "some message"); err == nil {
+		t.Error("Expected error for server failure")
+	}
+}
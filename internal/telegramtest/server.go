@@ -0,0 +1,321 @@
+// Package telegramtest provides a fake Telegram Bot API server for
+// integration tests. It speaks just enough of the Bot API for the flows
+// BotForge exercises (getMe, webhooks, sending, copying and forwarding
+// messages), records every call it receives and lets tests inject API
+// errors, so Manager and Factory flows can be tested without real tokens
+// or network access.
+package telegramtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// Call is a single Bot API request received by the fake server
+type Call struct {
+	Token   string
+	Method  string
+	Payload map[string]interface{} // decoded JSON request body
+}
+
+// ChatID returns the chat_id field of the payload as an int64 (0 if
+// absent or not numeric). Telebot sends chat IDs as strings.
+func (c Call) ChatID() int64 {
+	s, _ := c.Payload["chat_id"].(string)
+	id, _ := strconv.ParseInt(s, 10, 64)
+	return id
+}
+
+// Text returns the text field of the payload, if any
+func (c Call) Text() string {
+	s, _ := c.Payload["text"].(string)
+	return s
+}
+
+// apiError is a canned Bot API error response for a method
+type apiError struct {
+	code        int
+	description string
+}
+
+// Server is a fake Telegram Bot API server backed by httptest
+type Server struct {
+	srv *httptest.Server
+
+	mu            sync.Mutex
+	calls         []Call
+	failures      map[string]apiError     // method -> error to return
+	chats         map[int64]*telebot.Chat // chat_id -> chat known to getChat
+	webhookURLs   map[string]string       // token -> URL from the last setWebhook
+	webhookErrors map[string]string       // token -> last_error_message for getWebhookInfo
+	nextMsgID     int
+}
+
+// NewServer starts a fake Bot API server. It is closed automatically
+// when the test or benchmark finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		failures:      make(map[string]apiError),
+		chats:         make(map[int64]*telebot.Chat),
+		webhookURLs:   make(map[string]string),
+		webhookErrors: make(map[string]string),
+		nextMsgID:     1000,
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL returns the base URL to use as telebot.Settings.URL
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// NewBot creates a telebot instance connected to the fake server. The
+// bot ID is derived from the numeric prefix of the token, matching
+// Telegram's token format ("12345:abc...").
+func (s *Server) NewBot(t testing.TB, token string) *telebot.Bot {
+	t.Helper()
+
+	bot, err := telebot.NewBot(telebot.Settings{
+		Token:       token,
+		URL:         s.srv.URL,
+		Synchronous: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test bot: %v", err)
+	}
+	return bot
+}
+
+// AddChat registers a chat so getChat can resolve it. Useful for flows
+// that validate channels or groups by ID.
+func (s *Server) AddChat(chat *telebot.Chat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chats[chat.ID] = chat
+}
+
+// FailWith makes every subsequent call to method return a Bot API error.
+// Use the exact descriptions from telebot's errors (e.g. "Forbidden: bot
+// was blocked by the user") to get the matching typed error back.
+func (s *Server) FailWith(method string, code int, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = apiError{code: code, description: description}
+}
+
+// Succeed removes a failure previously installed with FailWith
+func (s *Server) Succeed(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, method)
+}
+
+// SetWebhookError makes getWebhookInfo report a delivery error for the
+// bot, like Telegram does when it cannot reach a registered webhook.
+// An empty message clears the error.
+func (s *Server) SetWebhookError(token, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if message == "" {
+		delete(s.webhookErrors, token)
+		return
+	}
+	s.webhookErrors[token] = message
+}
+
+// Calls returns all recorded calls for a method, in order
+func (s *Server) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Call
+	for _, c := range s.calls {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// LastCall returns the most recent call for a method
+func (s *Server) LastCall(method string) (Call, bool) {
+	calls := s.Calls(method)
+	if len(calls) == 0 {
+		return Call{}, false
+	}
+	return calls[len(calls)-1], true
+}
+
+// CallCount returns how many times a method was called
+func (s *Server) CallCount(method string) int {
+	return len(s.Calls(method))
+}
+
+// Reset clears all recorded calls and installed failures
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+	s.failures = make(map[string]apiError)
+}
+
+// handle dispatches a Bot API request: /bot{token}/{method}
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/bot")
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	token, method := parts[0], parts[1]
+
+	var payload map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Token: token, Method: method, Payload: payload})
+	failure, failed := s.failures[method]
+	s.mu.Unlock()
+
+	if failed {
+		writeError(w, failure.code, failure.description)
+		return
+	}
+
+	switch method {
+	case "getMe":
+		writeResult(w, botUser(token))
+	case "setWebhook":
+		s.mu.Lock()
+		url, _ := payload["url"].(string)
+		s.webhookURLs[token] = url
+		s.mu.Unlock()
+		writeResult(w, true)
+	case "deleteWebhook":
+		s.mu.Lock()
+		delete(s.webhookURLs, token)
+		delete(s.webhookErrors, token)
+		s.mu.Unlock()
+		writeResult(w, true)
+	case "getWebhookInfo":
+		s.handleGetWebhookInfo(w, token)
+	case "sendMessage", "copyMessage", "forwardMessage", "editMessageText", "editMessageCaption", "editMessageReplyMarkup":
+		writeResult(w, s.message(token, payload))
+	case "getChat":
+		s.handleGetChat(w, payload)
+	default:
+		// sendChatAction, setMessageReaction,
+		// answerCallbackQuery, deleteMessage and friends all return true
+		writeResult(w, true)
+	}
+}
+
+// handleGetWebhookInfo reports the URL from the last setWebhook plus
+// any error installed with SetWebhookError
+func (s *Server) handleGetWebhookInfo(w http.ResponseWriter, token string) {
+	s.mu.Lock()
+	info := map[string]interface{}{
+		"url":                  s.webhookURLs[token],
+		"pending_update_count": 0,
+	}
+	if msg, ok := s.webhookErrors[token]; ok {
+		info["last_error_message"] = msg
+		info["last_error_date"] = time.Now().Unix()
+	}
+	s.mu.Unlock()
+	writeResult(w, info)
+}
+
+// handleGetChat resolves a chat registered via AddChat
+func (s *Server) handleGetChat(w http.ResponseWriter, payload map[string]interface{}) {
+	var id int64
+	if str, ok := payload["chat_id"].(string); ok {
+		id, _ = strconv.ParseInt(str, 10, 64)
+	}
+
+	s.mu.Lock()
+	chat, ok := s.chats[id]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat not found")
+		return
+	}
+	writeResult(w, chat)
+}
+
+// message builds a Bot API Message result for a send/copy/forward call
+func (s *Server) message(token string, payload map[string]interface{}) map[string]interface{} {
+	s.mu.Lock()
+	s.nextMsgID++
+	id := s.nextMsgID
+	s.mu.Unlock()
+
+	var chatID int64
+	if str, ok := payload["chat_id"].(string); ok {
+		chatID, _ = strconv.ParseInt(str, 10, 64)
+	}
+
+	msg := map[string]interface{}{
+		"message_id": id,
+		"date":       time.Now().Unix(),
+		"from":       botUser(token),
+		"chat": map[string]interface{}{
+			"id":   chatID,
+			"type": "private",
+		},
+	}
+	if text, ok := payload["text"].(string); ok {
+		msg["text"] = text
+	}
+	return msg
+}
+
+// botUser builds the bot's own Telegram user from its token
+func botUser(token string) map[string]interface{} {
+	id := int64(42)
+	if idx := strings.Index(token, ":"); idx > 0 {
+		if parsed, err := strconv.ParseInt(token[:idx], 10, 64); err == nil {
+			id = parsed
+		}
+	}
+	return map[string]interface{}{
+		"id":         id,
+		"is_bot":     true,
+		"first_name": "Test Bot",
+		"username":   fmt.Sprintf("testbot%d", id),
+	}
+}
+
+// writeResult writes a successful Bot API envelope
+func writeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":     true,
+		"result": result,
+	})
+}
+
+// writeError writes a Bot API error envelope
+func writeError(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":          false,
+		"error_code":  code,
+		"description": description,
+	})
+}
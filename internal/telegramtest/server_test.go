@@ -0,0 +1,113 @@
+package telegramtest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Amr-9/botforge/internal/telegramtest"
+	"gopkg.in/telebot.v3"
+)
+
+const testToken = "123456:TEST_TOKEN"
+
+// ==================== Server Tests ====================
+
+func TestNewBot_ResolvesIdentityFromToken(t *testing.T) {
+	s := telegramtest.NewServer(t)
+	bot := s.NewBot(t, testToken)
+
+	if bot.Me == nil {
+		t.Fatal("Expected bot.Me to be populated from getMe")
+	}
+	if bot.Me.ID != 123456 {
+		t.Errorf("Expected bot ID 123456 from token prefix, got %d", bot.Me.ID)
+	}
+	if s.CallCount("getMe") != 1 {
+		t.Errorf("Expected 1 getMe call, got %d", s.CallCount("getMe"))
+	}
+}
+
+func TestSend_RecordsCall(t *testing.T) {
+	s := telegramtest.NewServer(t)
+	bot := s.NewBot(t, testToken)
+
+	msg, err := bot.Send(&telebot.Chat{ID: 777}, "hello")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if msg.ID == 0 {
+		t.Error("Expected a message ID in the response")
+	}
+
+	call, ok := s.LastCall("sendMessage")
+	if !ok {
+		t.Fatal("Expected a sendMessage call to be recorded")
+	}
+	if call.ChatID() != 777 {
+		t.Errorf("Expected chat_id 777, got %d", call.ChatID())
+	}
+	if call.Text() != "hello" {
+		t.Errorf("Expected text 'hello', got '%s'", call.Text())
+	}
+}
+
+func TestFailWith_ReturnsTypedTelebotError(t *testing.T) {
+	s := telegramtest.NewServer(t)
+	bot := s.NewBot(t, testToken)
+
+	s.FailWith("copyMessage", 403, "Forbidden: bot was blocked by the user")
+
+	_, err := bot.Copy(&telebot.Chat{ID: 777}, &telebot.Message{ID: 1, Chat: &telebot.Chat{ID: 888}})
+	if !errors.Is(err, telebot.ErrBlockedByUser) {
+		t.Errorf("Expected ErrBlockedByUser, got %v", err)
+	}
+
+	s.Succeed("copyMessage")
+	if _, err := bot.Copy(&telebot.Chat{ID: 777}, &telebot.Message{ID: 1, Chat: &telebot.Chat{ID: 888}}); err != nil {
+		t.Errorf("Expected copy to succeed after Succeed, got %v", err)
+	}
+}
+
+func TestGetChat_ResolvesRegisteredChats(t *testing.T) {
+	s := telegramtest.NewServer(t)
+	bot := s.NewBot(t, testToken)
+
+	// A username is required, or telebot reclassifies the chat as a
+	// private channel on the way back
+	s.AddChat(&telebot.Chat{ID: -100123, Type: telebot.ChatChannel, Title: "News", Username: "newschannel"})
+
+	chat, err := bot.ChatByID(-100123)
+	if err != nil {
+		t.Fatalf("ChatByID failed: %v", err)
+	}
+	if chat.Type != telebot.ChatChannel {
+		t.Errorf("Expected channel type, got %s", chat.Type)
+	}
+
+	if _, err := bot.ChatByID(-999); err == nil {
+		t.Error("Expected an error for an unknown chat")
+	}
+}
+
+func TestProcessUpdate_DispatchesToHandlers(t *testing.T) {
+	s := telegramtest.NewServer(t)
+	bot := s.NewBot(t, testToken)
+
+	bot.Handle(telebot.OnText, func(c telebot.Context) error {
+		return c.Send("pong")
+	})
+
+	user := telegramtest.User(555, "alice")
+	bot.ProcessUpdate(telegramtest.MessageUpdate(user, "ping"))
+
+	call, ok := s.LastCall("sendMessage")
+	if !ok {
+		t.Fatal("Expected the handler to send a message")
+	}
+	if call.ChatID() != 555 {
+		t.Errorf("Expected reply in chat 555, got %d", call.ChatID())
+	}
+	if call.Text() != "pong" {
+		t.Errorf("Expected text 'pong', got '%s'", call.Text())
+	}
+}
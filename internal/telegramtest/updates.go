@@ -0,0 +1,79 @@
+package telegramtest
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ==================== Update Fixtures ====================
+
+var updateSeq int64
+
+func nextUpdateID() int {
+	return int(atomic.AddInt64(&updateSeq, 1))
+}
+
+// User builds a Telegram user fixture
+func User(id int64, username string) *telebot.User {
+	return &telebot.User{
+		ID:        id,
+		FirstName: "Test",
+		Username:  username,
+	}
+}
+
+// PrivateChat builds a private chat fixture for a user
+func PrivateChat(userID int64) *telebot.Chat {
+	return &telebot.Chat{
+		ID:   userID,
+		Type: telebot.ChatPrivate,
+	}
+}
+
+// MessageUpdate builds an update carrying a text message from a user in
+// their private chat, ready to feed into Bot.ProcessUpdate or a webhook.
+func MessageUpdate(from *telebot.User, text string) telebot.Update {
+	return telebot.Update{
+		ID: nextUpdateID(),
+		Message: &telebot.Message{
+			ID:       nextUpdateID(),
+			Sender:   from,
+			Chat:     PrivateChat(from.ID),
+			Text:     text,
+			Unixtime: time.Now().Unix(),
+		},
+	}
+}
+
+// ReplyUpdate builds an update where a user replies to an earlier
+// message, as the owner does when answering a forwarded message.
+func ReplyUpdate(from *telebot.User, text string, replyTo *telebot.Message) telebot.Update {
+	update := MessageUpdate(from, text)
+	update.Message.ReplyTo = replyTo
+	return update
+}
+
+// CallbackUpdate builds an update for an inline button press. The unique
+// and data parts mirror telebot's "\f<unique>|<data>" callback format.
+func CallbackUpdate(from *telebot.User, unique, data string) telebot.Update {
+	payload := "\f" + unique
+	if data != "" {
+		payload += "|" + data
+	}
+	return telebot.Update{
+		ID: nextUpdateID(),
+		Callback: &telebot.Callback{
+			ID:     "cb" + strconv.Itoa(nextUpdateID()),
+			Sender: from,
+			Data:   payload,
+			Message: &telebot.Message{
+				ID:       nextUpdateID(),
+				Chat:     PrivateChat(from.ID),
+				Unixtime: time.Now().Unix(),
+			},
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package tgapi
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChaosTransport is a fault-injecting http.RoundTripper for validating
+// retry, rate limiting and queueing logic in staging. It randomly turns
+// outgoing Bot API requests into 429s, 5xx errors or timeouts at the
+// configured rates; everything else (and all non-Telegram traffic)
+// passes through untouched. Never enable it in production.
+type ChaosTransport struct {
+	base        http.RoundTripper
+	rate429     float64
+	rate5xx     float64
+	rateTimeout float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewChaosTransport wraps base with fault injection. Each rate is the
+// probability (0..1) that a Bot API request draws that fault; a nil
+// base falls back to http.DefaultTransport.
+func NewChaosTransport(base http.RoundTripper, rate429, rate5xx, rateTimeout float64) *ChaosTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &ChaosTransport{
+		base:        base,
+		rate429:     rate429,
+		rate5xx:     rate5xx,
+		rateTimeout: rateTimeout,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// RoundTrip injects at most one fault per request, chosen by a single
+// roll so the rates are independent probabilities that simply add up
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only Bot API calls are candidates; the classifier, NATS and other
+	// outbound traffic share the default transport and must stay clean
+	if !strings.HasPrefix(req.URL.Path, "/bot") {
+		return t.base.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	roll := t.rng.Float64()
+	t.mu.Unlock()
+
+	switch {
+	case roll < t.rate429:
+		return chaosResponse(req, http.StatusTooManyRequests, "Too Many Requests: chaos injected"), nil
+	case roll < t.rate429+t.rate5xx:
+		return chaosResponse(req, http.StatusBadGateway, "Bad Gateway: chaos injected"), nil
+	case roll < t.rate429+t.rate5xx+t.rateTimeout:
+		return nil, &chaosTimeoutError{}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// chaosResponse fabricates a Bot API error response so callers decode
+// the same envelope a real failure would carry
+func chaosResponse(req *http.Request, code int, description string) *http.Response {
+	body := fmt.Sprintf(`{"ok":false,"error_code":%d,"description":%q}`, code, description)
+	if code == http.StatusTooManyRequests {
+		body = fmt.Sprintf(`{"ok":false,"error_code":%d,"description":%q,"parameters":{"retry_after":1}}`, code, description)
+	}
+
+	return &http.Response{
+		StatusCode: code,
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// chaosTimeoutError mimics a client timeout so net.Error checks in
+// callers treat it like the real thing
+type chaosTimeoutError struct{}
+
+func (e *chaosTimeoutError) Error() string   { return "chaos injected: request timed out" }
+func (e *chaosTimeoutError) Timeout() bool   { return true }
+func (e *chaosTimeoutError) Temporary() bool { return true }
@@ -0,0 +1,104 @@
+package tgapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/tgapi"
+)
+
+// ==================== Chaos Transport Tests ====================
+
+// chaosClient builds a tgapi client whose requests go through a
+// ChaosTransport with the given rates, against a healthy fake server
+func chaosClient(t *testing.T, rate429, rate5xx, rateTimeout float64) (*tgapi.Client, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":{"id":123,"is_bot":true,"first_name":"Test","username":"test_bot"}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 0)
+	client.SetTransport(tgapi.NewChaosTransport(nil, rate429, rate5xx, rateTimeout))
+
+	return client, server
+}
+
+func TestChaosTransport_Inject429(t *testing.T) {
+	client, _ := chaosClient(t, 1, 0, 0)
+
+	_, err := client.GetMe(context.Background(), "test-token")
+	var apiErr *tgapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected injected 429 API error, got %v", err)
+	}
+}
+
+func TestChaosTransport_Inject5xx(t *testing.T) {
+	client, _ := chaosClient(t, 0, 1, 0)
+
+	_, err := client.GetMe(context.Background(), "test-token")
+	var apiErr *tgapi.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code < http.StatusInternalServerError {
+		t.Fatalf("Expected injected 5xx API error, got %v", err)
+	}
+}
+
+func TestChaosTransport_InjectTimeout(t *testing.T) {
+	client, _ := chaosClient(t, 0, 0, 1)
+
+	_, err := client.GetMe(context.Background(), "test-token")
+	if err == nil {
+		t.Fatal("Expected injected timeout error, got nil")
+	}
+}
+
+func TestChaosTransport_ZeroRatesPassThrough(t *testing.T) {
+	client, _ := chaosClient(t, 0, 0, 0)
+
+	me, err := client.GetMe(context.Background(), "test-token")
+	if err != nil {
+		t.Fatalf("GetMe failed: %v", err)
+	}
+	if me.ID != 123 {
+		t.Errorf("Expected bot ID 123, got %d", me.ID)
+	}
+}
+
+func TestChaosTransport_IgnoresNonBotAPITraffic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "clean")
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: tgapi.NewChaosTransport(nil, 1, 0, 0)}
+	resp, err := httpClient.Get(server.URL + "/classify")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected non-Telegram traffic to pass through, got status %d", resp.StatusCode)
+	}
+}
+
+func TestChaosTimeoutError_IsNetError(t *testing.T) {
+	httpClient := &http.Client{Transport: tgapi.NewChaosTransport(nil, 0, 0, 1)}
+	_, err := httpClient.Get("http://example.invalid/bot123/getMe")
+	if err == nil {
+		t.Fatal("Expected timeout error, got nil")
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected a net.Error with Timeout()=true, got %v", err)
+	}
+}
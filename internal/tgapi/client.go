@@ -0,0 +1,178 @@
+package tgapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default client settings
+const (
+	DefaultBaseURL    = "https://api.telegram.org"
+	DefaultTimeout    = 10 * time.Second
+	DefaultMaxRetries = 2
+)
+
+// Client is a small HTTP client for direct Telegram Bot API calls that are
+// not routed through a telebot instance (e.g. getMe during bot registration)
+type Client struct {
+	baseURL    string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewClient creates a Telegram API client. Zero values fall back to the
+// package defaults; baseURL may point at a self-hosted Bot API server.
+// Proxy settings are picked up from the environment via the default transport.
+func NewClient(baseURL string, timeout time.Duration, maxRetries int) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if maxRetries < 0 {
+		maxRetries = DefaultMaxRetries
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// NewDefaultClient creates a client with the package defaults
+func NewDefaultClient() *Client {
+	return NewClient("", 0, DefaultMaxRetries)
+}
+
+// SetTransport overrides the underlying HTTP transport, e.g. to route
+// requests through a ChaosTransport
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// User is the Telegram API representation of a bot or user account
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username"`
+}
+
+// APIError is a non-OK response returned by the Telegram Bot API
+type APIError struct {
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram API error %d: %s", e.Code, e.Description)
+}
+
+// apiResponse is the common envelope of all Bot API responses
+type apiResponse struct {
+	Ok          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// GetMe returns basic information about the bot identified by token
+func (c *Client) GetMe(ctx context.Context, token string) (*User, error) {
+	var user User
+	if err := c.call(ctx, token, "getMe", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// call performs a Bot API method call with retries on transient failures
+func (c *Client) call(ctx context.Context, token, method string, result interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			// Linear backoff between attempts
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+			}
+		}
+
+		lastErr = c.doCall(ctx, token, method, result)
+		if lastErr == nil {
+			return nil
+		}
+		if !isRetryable(lastErr) {
+			return lastErr
+		}
+	}
+
+	return fmt.Errorf("telegram API %s failed after %d attempts: %w", method, c.maxRetries+1, lastErr)
+}
+
+// doCall performs a single Bot API request and decodes the response envelope
+func (c *Client) doCall(ctx context.Context, token, method string, result interface{}) error {
+	url := fmt.Sprintf("%s/bot%s/%s", c.baseURL, token, method)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build telegram API request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Strip the token from transport errors so it never reaches the logs
+		return &transportError{msg: fmt.Sprintf("telegram API request failed: %s", strings.ReplaceAll(err.Error(), token, "***"))}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return &APIError{Code: resp.StatusCode, Description: "server error"}
+	}
+
+	var envelope apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode telegram API response: %w", err)
+	}
+
+	if !envelope.Ok {
+		return &APIError{Code: envelope.ErrorCode, Description: envelope.Description}
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(envelope.Result, result); err != nil {
+			return fmt.Errorf("failed to decode telegram API result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// transportError is an HTTP-level failure (timeout, connection reset)
+type transportError struct {
+	msg string
+}
+
+func (e *transportError) Error() string {
+	return e.msg
+}
+
+// isRetryable reports whether an error is worth retrying: transport
+// failures, server errors, and rate limiting
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError
+	}
+
+	var tErr *transportError
+	return errors.As(err, &tErr)
+}
@@ -0,0 +1,124 @@
+package tgapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Amr-9/botforge/internal/tgapi"
+)
+
+// ==================== GetMe Tests ====================
+
+func TestGetMe_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bottest-token/getMe" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"id":123,"is_bot":true,"first_name":"Test","username":"test_bot"}}`)
+	}))
+	defer server.Close()
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 0)
+
+	me, err := client.GetMe(context.Background(), "test-token")
+	if err != nil {
+		t.Fatalf("GetMe failed: %v", err)
+	}
+
+	if me.ID != 123 {
+		t.Errorf("Expected ID 123, got %d", me.ID)
+	}
+	if me.Username != "test_bot" {
+		t.Errorf("Expected test_bot, got %s", me.Username)
+	}
+}
+
+func TestGetMe_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":false,"error_code":401,"description":"Unauthorized"}`)
+	}))
+	defer server.Close()
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 2)
+
+	_, err := client.GetMe(context.Background(), "bad-token")
+	if err == nil {
+		t.Fatal("Expected error for unauthorized token")
+	}
+
+	apiErr, ok := err.(*tgapi.APIError)
+	if !ok {
+		t.Fatalf("Expected APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != 401 {
+		t.Errorf("Expected code 401, got %d", apiErr.Code)
+	}
+}
+
+func TestGetMe_RetriesOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"Test","username":"retry_bot"}}`)
+	}))
+	defer server.Close()
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 2)
+
+	me, err := client.GetMe(context.Background(), "test-token")
+	if err != nil {
+		t.Fatalf("GetMe failed after retry: %v", err)
+	}
+
+	if me.Username != "retry_bot" {
+		t.Errorf("Expected retry_bot, got %s", me.Username)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestGetMe_NoRetryOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"ok":false,"error_code":404,"description":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 2)
+
+	_, err := client.GetMe(context.Background(), "test-token")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected 1 call (no retries), got %d", calls)
+	}
+}
+
+func TestGetMe_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := tgapi.NewClient(server.URL, 5*time.Second, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetMe(ctx, "test-token")
+	if err == nil {
+		t.Fatal("Expected error for cancelled context")
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
 )
 
 // EncryptDeterministic encrypts text using AES-GCM with a deterministic nonce derived from the plaintext.
@@ -76,6 +79,54 @@ func DecryptDeterministic(cryptoText, key string) (string, error) {
 	return string(plaintext), nil
 }
 
+// Encrypt encrypts text using AES-GCM with a random nonce. Unlike
+// EncryptDeterministic the same plaintext produces a different ciphertext
+// every time, so it is safe for message content where equality of
+// ciphertexts would leak which users say the same thing.
+// Format: Base64(Nonce + Ciphertext + Tag), same as EncryptDeterministic.
+func Encrypt(plaintext, key string) (string, error) {
+	if len(key) != 32 {
+		return "", fmt.Errorf("key must be exactly 32 bytes (got %d)", len(key))
+	}
+
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, []byte(plaintext), nil)
+	finalPayload := append(nonce, ciphertext...)
+
+	return base64.StdEncoding.EncodeToString(finalPayload), nil
+}
+
+// Decrypt decrypts a base64 encoded string encrypted with Encrypt. Since
+// the nonce is stored in the payload this also decrypts deterministic
+// ciphertexts, but DecryptDeterministic is kept as the pair of its encryptor.
+func Decrypt(cryptoText, key string) (string, error) {
+	return DecryptDeterministic(cryptoText, key)
+}
+
+// DeriveKey derives a 32-byte subkey from a master key and a context
+// label via HMAC-SHA256, so different data classes (e.g. one bot's
+// message content vs. another's) are encrypted under different keys and
+// a single leaked subkey does not expose the master key or its siblings.
+func DeriveKey(masterKey, context string) string {
+	h := hmac.New(sha256.New, []byte(masterKey))
+	h.Write([]byte(context))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
 // deriveNonce generates a deterministic 12-byte nonce
 func deriveNonce(plaintext, key string) []byte {
 	h := hmac.New(sha256.New, []byte(key))
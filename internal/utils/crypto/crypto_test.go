@@ -250,3 +250,91 @@ func TestDifferentKeys_DifferentCiphertexts(t *testing.T) {
 		t.Error("Same plaintext with different keys should produce different ciphertexts")
 	}
 }
+
+// ==================== Encrypt / Decrypt (Random Nonce) Tests ====================
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	plaintext := "a user message worth protecting"
+
+	ciphertext, err := crypto.Encrypt(plaintext, validKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	decrypted, err := crypto.Decrypt(ciphertext, validKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected '%s', got '%s'", plaintext, decrypted)
+	}
+}
+
+func TestEncrypt_NotDeterministic(t *testing.T) {
+	plaintext := "same message twice"
+
+	ciphertext1, _ := crypto.Encrypt(plaintext, validKey)
+	ciphertext2, _ := crypto.Encrypt(plaintext, validKey)
+
+	if ciphertext1 == ciphertext2 {
+		t.Error("Encrypt should produce different ciphertexts for the same plaintext")
+	}
+}
+
+func TestEncrypt_InvalidKeyLength(t *testing.T) {
+	_, err := crypto.Encrypt("text", "short")
+	if err == nil {
+		t.Error("Expected error for invalid key length")
+	}
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	ciphertext, _ := crypto.Encrypt("secret", validKey)
+
+	_, err := crypto.Decrypt(ciphertext, "abcdefghijklmnopqrstuvwxyz123456")
+	if err == nil {
+		t.Error("Expected error when decrypting with wrong key")
+	}
+}
+
+// ==================== DeriveKey Tests ====================
+
+func TestDeriveKey_Length(t *testing.T) {
+	key := crypto.DeriveKey(validKey, "content:1")
+	if len(key) != 32 {
+		t.Errorf("Expected 32-byte derived key, got %d bytes", len(key))
+	}
+}
+
+func TestDeriveKey_DifferentContexts(t *testing.T) {
+	key1 := crypto.DeriveKey(validKey, "content:1")
+	key2 := crypto.DeriveKey(validKey, "content:2")
+
+	if key1 == key2 {
+		t.Error("Different contexts should derive different keys")
+	}
+}
+
+func TestDeriveKey_Stable(t *testing.T) {
+	key1 := crypto.DeriveKey(validKey, "content:1")
+	key2 := crypto.DeriveKey(validKey, "content:1")
+
+	if key1 != key2 {
+		t.Error("Same master key and context should always derive the same key")
+	}
+}
+
+func TestDeriveKey_UsableForEncryption(t *testing.T) {
+	derived := crypto.DeriveKey(validKey, "content:42")
+
+	ciphertext, err := crypto.Encrypt("hello", derived)
+	if err != nil {
+		t.Fatalf("Derived key should be a valid encryption key: %v", err)
+	}
+
+	decrypted, err := crypto.Decrypt(ciphertext, derived)
+	if err != nil || decrypted != "hello" {
+		t.Errorf("Expected round-trip with derived key, got '%s' (err: %v)", decrypted, err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package client is a Go client for the BotForge management API. It is
+// generated from api/openapi.yaml; regenerate it when the spec changes
+// rather than editing request or response types by hand.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Client calls the BotForge management API with a scoped API key
+type Client struct {
+	baseURL string
+	apiKey  string
+	httpc   *http.Client
+}
+
+// New creates a client for the given server base URL (without the /api/v1
+// prefix) and API key (bfk_...).
+func New(baseURL, apiKey string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		httpc:   http.DefaultClient,
+	}
+}
+
+// SetHTTPClient overrides the underlying HTTP client, e.g. to set timeouts
+func (c *Client) SetHTTPClient(httpc *http.Client) {
+	c.httpc = httpc
+}
+
+// BotStats mirrors the BotStats schema
+type BotStats struct {
+	BotID          int64 `json:"bot_id"`
+	Running        bool  `json:"running"`
+	ReachableUsers int64 `json:"reachable_users"`
+	BlockedUsers   int64 `json:"blocked_users"`
+}
+
+// SendMessageRequest mirrors the SendMessageRequest schema
+type SendMessageRequest struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// AutoReply mirrors the AutoReply schema
+type AutoReply struct {
+	ID          int64  `json:"id"`
+	Trigger     string `json:"trigger"`
+	Response    string `json:"response"`
+	TriggerType string `json:"trigger_type"`
+	MatchType   string `json:"match_type,omitempty"`
+}
+
+// CreateAutoReplyRequest mirrors the CreateAutoReplyRequest schema
+type CreateAutoReplyRequest struct {
+	Trigger     string `json:"trigger"`
+	Response    string `json:"response"`
+	TriggerType string `json:"trigger_type,omitempty"`
+	MatchType   string `json:"match_type,omitempty"`
+}
+
+// APIError is a non-2xx response from the server
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// GetBotStats returns audience and runtime stats for a bot.
+// Requires the read_stats permission.
+func (c *Client) GetBotStats(ctx context.Context, botID int64) (*BotStats, error) {
+	var stats BotStats
+	path := fmt.Sprintf("/api/v1/bots/%d/stats", botID)
+	if err := c.do(ctx, http.MethodGet, path, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// SendMessage sends a text message to one user of the bot.
+// Requires the send_messages permission.
+func (c *Client) SendMessage(ctx context.Context, botID, chatID int64, text string) error {
+	path := fmt.Sprintf("/api/v1/bots/%d/messages", botID)
+	return c.do(ctx, http.MethodPost, path, SendMessageRequest{ChatID: chatID, Text: text}, nil)
+}
+
+// ListAutoReplies lists the bot's auto-replies of one trigger type
+// ("keyword" or "command"; empty means keyword).
+// Requires the manage_replies permission.
+func (c *Client) ListAutoReplies(ctx context.Context, botID int64, triggerType string) ([]AutoReply, error) {
+	path := fmt.Sprintf("/api/v1/bots/%d/replies", botID)
+	if triggerType != "" {
+		path += "?type=" + url.QueryEscape(triggerType)
+	}
+	var replies []AutoReply
+	if err := c.do(ctx, http.MethodGet, path, nil, &replies); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
+
+// CreateAutoReply creates a text auto-reply.
+// Requires the manage_replies permission.
+func (c *Client) CreateAutoReply(ctx context.Context, botID int64, req CreateAutoReplyRequest) error {
+	path := fmt.Sprintf("/api/v1/bots/%d/replies", botID)
+	return c.do(ctx, http.MethodPost, path, req, nil)
+}
+
+// DeleteAutoReply deletes an auto-reply.
+// Requires the manage_replies permission.
+func (c *Client) DeleteAutoReply(ctx context.Context, botID, replyID int64) error {
+	path := fmt.Sprintf("/api/v1/bots/%d/replies/", botID) + strconv.FormatInt(replyID, 10)
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// do issues one request and decodes the response into out when non-nil
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	reqBody := &bytes.Buffer{}
+	if body != nil {
+		if err := json.NewEncoder(reqBody).Encode(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}